@@ -14,10 +14,50 @@ echo "Running backup"
 # config.xml in child directores is state that should. For example-
 # branches/myorg/branches/myrepo/branches/master/config.xml should be retained while
 # branches/myorg/config.xml should not
-tar -C ${JENKINS_HOME} -czf "${BACKUP_TMP_DIR}/${backup_number}.tar.gz" --exclude jobs/*/workspace* --no-wildcards-match-slash --anchored --exclude jobs/*/config.xml -c jobs && \
-mv ${BACKUP_TMP_DIR}/${backup_number}.tar.gz ${BACKUP_DIR}/${backup_number}.tar.gz
+#
+# BACKUP_INCLUDE and BACKUP_EXCLUDE contain glob patterns relative to JENKINS_HOME, one pattern per line
+include=(jobs)
+if [[ ! -z "${BACKUP_INCLUDE}" ]]; then
+    include=()
+    while IFS= read -r pattern; do
+        [[ -z "${pattern}" ]] && continue
+        for path in "${JENKINS_HOME}"/${pattern}; do
+            [[ -e "${path}" ]] && include+=("${path#${JENKINS_HOME}/}")
+        done
+    done <<< "${BACKUP_INCLUDE}"
+    [[ ${#include[@]} -eq 0 ]] && echo "Nothing matches BACKUP_INCLUDE patterns in '${JENKINS_HOME}'" && exit 1;
+fi
+exclude=()
+if [[ ! -z "${BACKUP_EXCLUDE}" ]]; then
+    while IFS= read -r pattern; do
+        [[ ! -z "${pattern}" ]] && exclude+=(--exclude "${pattern}")
+    done <<< "${BACKUP_EXCLUDE}"
+fi
 
-[[ ! -s ${BACKUP_DIR}/${backup_number}.tar.gz ]] && echo "backup file '${BACKUP_DIR}/${backup_number}.tar.gz' is empty" && exit 1;
+# BACKUP_COMPRESSION selects the archive compression: gzip (default), zstd or none,
+# BACKUP_COMPRESSION_LEVEL optionally sets the compression level
+case "${BACKUP_COMPRESSION:-gzip}" in
+    gzip) extension=tar.gz; compress=(gzip) ;;
+    zstd) extension=tar.zst; compress=(zstd -q -T0) ;;
+    none) extension=tar; compress=(cat) ;;
+    *) echo "Unsupported BACKUP_COMPRESSION '${BACKUP_COMPRESSION}'" && exit 1 ;;
+esac
+if [[ ! -z "${BACKUP_COMPRESSION_LEVEL}" && "${BACKUP_COMPRESSION}" != "none" ]]; then
+    compress+=("-${BACKUP_COMPRESSION_LEVEL}")
+fi
+backup_file="${BACKUP_DIR}/${backup_number}.${extension}"
+
+tar -C ${JENKINS_HOME} -cf - --exclude jobs/*/workspace* --no-wildcards-match-slash --anchored "${exclude[@]}" --exclude jobs/*/config.xml "${include[@]}" | \
+"${compress[@]}" > "${BACKUP_TMP_DIR}/${backup_number}.${extension}" && \
+mv ${BACKUP_TMP_DIR}/${backup_number}.${extension} ${backup_file}
+
+[[ ! -s ${backup_file} ]] && echo "backup file '${backup_file}' is empty" && exit 1;
+echo "BACKUP_SIZE=$(stat -c %s ${backup_file})"
+
+if [[ ! -z "${BACKUP_COUNT}" ]]; then
+    echo "Trimming to only ${BACKUP_COUNT} recent backups"
+    find ${BACKUP_DIR} \( -name '*.tar.gz' -o -name '*.tar.zst' -o -name '*.tar' \) -exec basename {} \; | sort -gr | tail -n +$((BACKUP_COUNT +1)) | xargs -I '{}' rm ${BACKUP_DIR}/'{}'
+fi
 
 echo Done
 exit 0
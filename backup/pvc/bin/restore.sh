@@ -7,9 +7,47 @@ set -eo pipefail
 [[ -z "${JENKINS_HOME}" ]] && echo "Required 'JENKINS_HOME' env not set" && exit 1;
 
 backup_number=$1
+
+# the archive format is detected by the file extension, see BACKUP_COMPRESSION in backup.sh
+backup_file=""
+for extension in tar.gz tar.zst tar; do
+    if [[ -f "${BACKUP_DIR}/${backup_number}.${extension}" ]]; then
+        backup_file="${BACKUP_DIR}/${backup_number}.${extension}"
+        break
+    fi
+done
+[[ -z "${backup_file}" ]] && echo "Backup '${backup_number}' not found in '${BACKUP_DIR}'" && exit 1;
+
+extract() {
+    case "${backup_file}" in
+        *.tar.gz) tar -C "$1" -zxf "${backup_file}" ;;
+        *.tar.zst) zstd -q -dc "${backup_file}" | tar -C "$1" -xf - ;;
+        *.tar) tar -C "$1" -xf "${backup_file}" ;;
+    esac
+}
+
+# with RESTORE_DRY_RUN=true the backup is only compared with JENKINS_HOME, every changed file is printed
+# as 'added: <path>' or 'modified: <path>' and nothing is restored
+if [[ "${RESTORE_DRY_RUN}" == "true" ]]; then
+    echo "Running restore dry run"
+    RESTORE_TMP_DIR=$(mktemp -d)
+    trap 'rm -rf "${RESTORE_TMP_DIR}"' EXIT
+    extract ${RESTORE_TMP_DIR}
+    (cd ${RESTORE_TMP_DIR} && find . -type f | sort) | while IFS= read -r path; do
+        path="${path#./}"
+        if [[ ! -e "${JENKINS_HOME}/${path}" ]]; then
+            echo "added: ${path}"
+        elif ! cmp -s "${RESTORE_TMP_DIR}/${path}" "${JENKINS_HOME}/${path}"; then
+            echo "modified: ${path}"
+        fi
+    done
+    echo Done
+    exit 0
+fi
+
 echo "Running restore backup"
 
-tar -C ${JENKINS_HOME} -zxf "${BACKUP_DIR}/${backup_number}.tar.gz"
+extract ${JENKINS_HOME}
 
 echo Done
 exit 0
\ No newline at end of file
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/controller/jenkinsimage"
 
@@ -13,19 +15,22 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
 	"github.com/jenkinsci/kubernetes-operator/pkg/controller/jenkins"
 	"github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/health"
+	"github.com/jenkinsci/kubernetes-operator/pkg/leaderelection"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications"
 	e "github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/webhook"
 	"github.com/jenkinsci/kubernetes-operator/version"
 
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	kubemetrics "github.com/operator-framework/operator-sdk/pkg/kube-metrics"
-	"github.com/operator-framework/operator-sdk/pkg/leader"
 	"github.com/operator-framework/operator-sdk/pkg/log/zap"
 	"github.com/operator-framework/operator-sdk/pkg/metrics"
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
@@ -37,6 +42,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
@@ -71,10 +77,45 @@ func main() {
 	hostname := pflag.String("jenkins-api-hostname", "", "Hostname or IP of Jenkins API. It can be service name, node IP or localhost.")
 	port := pflag.Int("jenkins-api-port", 0, "The port on which Jenkins API is running. Note: If you want to use nodePort don't set this setting and --jenkins-api-use-nodeport must be true.")
 	useNodePort := pflag.Bool("jenkins-api-use-nodeport", false, "Connect to Jenkins API using the service nodePort instead of service port. If you want to set this as true - don't set --jenkins-api-port.")
+	retries := pflag.Int("jenkins-api-retries", client.DefaultRetryPolicy.Retries, "The number of retries of idempotent Jenkins API requests after 502, 503 and 504 responses, timeouts and refused connections, 0 disables retries.")
+	retryBackoff := pflag.Duration("jenkins-api-retry-backoff", client.DefaultRetryPolicy.InitialBackoff, "The maximal random delay before the first retry of Jenkins API request, it doubles with every retry.")
+	circuitBreakerThreshold := pflag.Int("jenkins-api-circuit-breaker-threshold", client.DefaultRetryPolicy.FailureThreshold, "The number of consecutive failed Jenkins API requests after which requests to Jenkins aren't sent for --jenkins-api-circuit-breaker-duration, 0 disables the circuit breaker.")
+	scheme := pflag.String("jenkins-api-scheme", client.HTTPScheme, "The scheme of Jenkins API, http or https.")
+	caSecret := pflag.String("jenkins-api-ca-secret", "", "The Secret with the PEM encoded CA bundle under the ca.crt key which signed the certificate of Jenkins API, in the namespace/name format, the namespace defaults to the namespace of the operator.")
+	clientCertificateSecret := pflag.String("jenkins-api-client-cert-secret", "", "The kubernetes.io/tls Secret with the client certificate of the operator for Jenkins API, in the namespace/name format, the namespace defaults to the namespace of the operator.")
+	insecureSkipVerify := pflag.Bool("jenkins-api-insecure-skip-verify", false, "Don't verify the certificate of Jenkins API, use it only for testing.")
+	environmentProxy := client.ProxySettingsFromEnvironment()
+	httpProxy := pflag.String("http-proxy", environmentProxy.HTTPProxy, "The proxy URL of HTTP requests to Jenkins and the update center, defaults to the HTTP_PROXY environment variable. It can be overridden by spec.proxy of Jenkins CR.")
+	httpsProxy := pflag.String("https-proxy", environmentProxy.HTTPSProxy, "The proxy URL of HTTPS requests to Jenkins and the update center, defaults to the HTTPS_PROXY environment variable. It can be overridden by spec.proxy of Jenkins CR.")
+	noProxy := pflag.String("no-proxy", environmentProxy.NoProxy, "Comma separated list of hosts, domains, IP addresses and CIDRs reached directly, defaults to the NO_PROXY environment variable. In-cluster addresses are always reached directly.")
+	circuitBreakerDuration := pflag.Duration("jenkins-api-circuit-breaker-duration", client.DefaultRetryPolicy.OpenDuration, "The duration after which the open circuit breaker lets a trial Jenkins API request through.")
+	qps := pflag.Float32("jenkins-api-qps", client.DefaultRateLimit.QPS, "The average number of Jenkins API requests per second sent to single Jenkins, 0 disables rate limiting. It can be overridden by spec.jenkinsAPISettings.rateLimit of Jenkins CR.")
+	burst := pflag.Int("jenkins-api-burst", client.DefaultRateLimit.Burst, "The maximal number of Jenkins API requests sent to single Jenkins at once.")
+	slowCallThreshold := pflag.Duration("jenkins-api-slow-call-threshold", client.DefaultSlowCallThreshold, "The duration of Jenkins API requests which are logged as slow, 0 disables logging.")
 	debug := pflag.Bool("debug", false, "Set log level to debug")
+	logEncoding := pflag.String("log-encoding", log.EncodingConsole, "The encoding of logs, 'console' or 'json'. Debug logs of single Jenkins CR can be enabled by the jenkins.io/log-level: debug annotation.")
+	syncPeriod := pflag.Duration("sync-period", 10*time.Hour, "The period of the resync of watched resources which triggers reconciliation of all Jenkins CRs.")
+	reconcileInterval := pflag.Duration("reconcile-interval", jenkins.DefaultReconcileIntervals.Reconcile, "The interval of periodic reconciliation of every Jenkins CR which detects configuration drift, 0 disables it. It can be overridden by spec.reconcileInterval of Jenkins CR.")
+	excludeNamespaces := pflag.String("exclude-namespaces", "", "Comma separated list of namespaces where Jenkins CRs are ignored by the operator.")
+	namespaceSelector := pflag.String("namespace-selector", "", "Label selector of namespaces where Jenkins CRs are reconciled by the operator, e.g. jenkins=enabled. It requires get, list and watch permissions for namespaces.")
+	shardName := pflag.String("shard-name", "", "Reconcile only Jenkins CRs with the jenkins.io/shard label set to this value, Jenkins CRs without the label are reconciled when it's empty.")
+	shardCount := pflag.Uint32("shard-count", 0, "Number of operator instances which split Jenkins CRs by the hash of their namespace and name, 0 disables it.")
+	shardIndex := pflag.Uint32("shard-index", 0, "Index of the operator instance when --shard-count is set, from 0 to shard-count - 1.")
+	maxRequeueDelay := pflag.Duration("max-requeue-delay", jenkins.DefaultReconcileIntervals.MaxRequeueDelay, "The maximal random delay of the next reconcile loop when the operator waits for a change, e.g. Jenkins master pod start.")
+	leaderElect := pflag.Bool("leader-elect", leaderelection.DefaultConfig.Enabled, "Enable leader election, it ensures that only one replica of the operator reconciles Jenkins CRs. Disable it only when a single replica is running.")
+	leaderElectionNamespace := pflag.String("leader-election-namespace", "", "Namespace of the Lease object used for leader election, defaults to the namespace of the operator.")
+	leaseDuration := pflag.Duration("leader-election-lease-duration", leaderelection.DefaultConfig.LeaseDuration, "The duration that non-leader replicas wait before they take over the leadership of a leader which stopped renewing it.")
+	renewDeadline := pflag.Duration("leader-election-renew-deadline", leaderelection.DefaultConfig.RenewDeadline, "The duration that the leader retries renewing the leadership before it gives up.")
+	retryPeriod := pflag.Duration("leader-election-retry-period", leaderelection.DefaultConfig.RetryPeriod, "The duration between tries of acquiring and renewing the leadership.")
+	webhookPort := pflag.Int("webhook-port", 0, "The port of the webhook server which converts API versions of Jenkins CRs and serves the admission webhooks, 0 disables it.")
+	healthProbeBindAddress := pflag.String("health-probe-bind-address", ":8081", "The address of the liveness (/healthz) and readiness (/readyz) probes and of the health of Jenkins CRs (/healthz/instances), empty disables it.")
+	webhookCertDir := pflag.String("webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs", "The directory with the tls.crt and tls.key files of the webhook server.")
 	pflag.Parse()
 
-	log.SetupLogger(*debug)
+	if err := log.SetupLoggerWithEncoding(*debug, *logEncoding); err != nil {
+		log.SetupLogger(*debug)
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
 	printInfo()
 
 	namespace, err := k8sutil.GetWatchNamespace()
@@ -82,6 +123,25 @@ func main() {
 		fatal(errors.Wrap(err, "failed to get watch namespace"), *debug)
 	}
 	logger.Info(fmt.Sprintf("Watch namespace: %v", namespace))
+	namespaceFilter, err := jenkins.NewNamespaceFilter(*excludeNamespaces, *namespaceSelector)
+	if err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
+	shard := jenkins.Shard{Name: *shardName, Count: *shardCount, Index: *shardIndex}
+	if err := shard.Validate(); err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
+	leaderElectionConfig := leaderelection.Config{
+		Enabled:       *leaderElect,
+		LockName:      shard.LeaderLockName("jenkins-operator-lock"),
+		Namespace:     *leaderElectionNamespace,
+		LeaseDuration: *leaseDuration,
+		RenewDeadline: *renewDeadline,
+		RetryPeriod:   *retryPeriod,
+	}
+	if err := leaderElectionConfig.Validate(); err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
 
 	// get a config to talk to the apiserver
 	cfg, err := config.GetConfig()
@@ -91,17 +151,20 @@ func main() {
 
 	ctx := context.TODO()
 
-	// Become the leader before proceeding
-	err = leader.Become(ctx, "jenkins-operator-lock")
-	if err != nil {
-		fatal(errors.Wrap(err, "failed to become leader"), *debug)
-	}
-
 	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{
+	options := manager.Options{
 		Namespace:          namespace,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
-	})
+		SyncPeriod:         syncPeriod,
+		Port:               *webhookPort,
+		CertDir:            *webhookCertDir,
+	}
+	// WATCH_NAMESPACE may contain comma separated list of namespaces
+	if namespaces := strings.Split(namespace, ","); len(namespaces) > 1 {
+		options.Namespace = ""
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		fatal(errors.Wrap(err, "failed to create manager"), *debug)
 	}
@@ -113,6 +176,14 @@ func main() {
 		fatal(errors.Wrap(err, "failed to setup scheme"), *debug)
 	}
 
+	// setup webhooks, they're called only when the Jenkins CRD refers to the conversion webhook and the admission
+	// webhook configurations are created
+	if *webhookPort > 0 {
+		mgr.GetWebhookServer().Register(webhook.ConversionPath, webhook.NewConversionWebhook())
+		mgr.GetWebhookServer().Register(webhook.MutatingPath, webhook.NewMutatingWebhook(*useNodePort))
+		mgr.GetWebhookServer().Register(webhook.ValidatingPath, webhook.NewValidatingWebhook())
+	}
+
 	// setup events
 	events, err := event.New(cfg, constants.OperatorName)
 	if err != nil {
@@ -131,13 +202,42 @@ func main() {
 	go notifications.Listen(c, events, mgr.GetClient())
 
 	// validate jenkins API connection
-	jenkinsAPIConnectionSettings := client.JenkinsAPIConnectionSettings{Hostname: *hostname, Port: *port, UseNodePort: *useNodePort}
+	retryPolicy := client.RetryPolicy{
+		Retries:          *retries,
+		InitialBackoff:   *retryBackoff,
+		MaxBackoff:       client.DefaultRetryPolicy.MaxBackoff,
+		FailureThreshold: *circuitBreakerThreshold,
+		OpenDuration:     *circuitBreakerDuration,
+	}
+	tlsSettings, err := newJenkinsAPITLSSettings(*caSecret, *clientCertificateSecret, *insecureSkipVerify)
+	if err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
+	jenkinsAPIConnectionSettings := client.JenkinsAPIConnectionSettings{
+		Hostname:          *hostname,
+		Port:              *port,
+		UseNodePort:       *useNodePort,
+		Scheme:            *scheme,
+		TLS:               tlsSettings,
+		Proxy:             client.ProxySettings{HTTPProxy: *httpProxy, HTTPSProxy: *httpsProxy, NoProxy: *noProxy},
+		Retry:             retryPolicy,
+		RateLimit:         client.RateLimit{QPS: *qps, Burst: *burst},
+		SlowCallThreshold: *slowCallThreshold,
+	}
 	if err := jenkinsAPIConnectionSettings.Validate(); err != nil {
 		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
 	}
 
 	// setup Jenkins controller
-	if err := jenkins.Add(mgr, jenkinsAPIConnectionSettings, *clientSet, *cfg, &c); err != nil {
+	instances := health.NewInstances()
+	controllerOptions := jenkins.Options{
+		Intervals:       jenkins.ReconcileIntervals{Reconcile: *reconcileInterval, MaxRequeueDelay: *maxRequeueDelay},
+		NamespaceFilter: namespaceFilter,
+		Shard:           shard,
+		Instances:       instances,
+		Events:          events,
+	}
+	if err := jenkins.Add(mgr, jenkinsAPIConnectionSettings, *clientSet, *cfg, &c, controllerOptions); err != nil {
 		fatal(errors.Wrap(err, "failed to setup controllers"), *debug)
 	}
 	// setup JenkinsImage controller
@@ -173,16 +273,60 @@ func main() {
 		}
 	}
 
-	logger.Info("Starting the Cmd.")
+	stopCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-signals.SetupSignalHandler()
+		cancel()
+	}()
+
+	if len(*healthProbeBindAddress) > 0 {
+		go func() {
+			if err := health.Serve(stopCtx, *healthProbeBindAddress, instances); err != nil {
+				fatal(err, *debug)
+			}
+		}()
+	}
 
-	// start the Cmd
-	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
-		fatal(errors.Wrap(err, "failed to start cmd"), *debug)
+	// start the Cmd when the operator becomes the leader
+	err = leaderelection.Run(stopCtx, cfg, leaderElectionConfig, func(leaderCtx context.Context) {
+		logger.Info("Starting the Cmd.")
+		if err := mgr.Start(leaderCtx.Done()); err != nil {
+			fatal(errors.Wrap(err, "failed to start cmd"), *debug)
+		}
+	})
+	if err != nil {
+		fatal(errors.Wrap(err, "failed to run leader election"), *debug)
 	}
 }
 
 // serveCRMetrics gets the Operator/CustomResource GVKs and generates metrics based on those types.
 // It serves those metrics on "http://metricsHost:operatorMetricsPort".
+// newJenkinsAPITLSSettings returns TLS settings of Jenkins API, Secrets without the namespace are looked up
+// in the namespace of the operator
+func newJenkinsAPITLSSettings(caSecret, clientCertificateSecret string, insecureSkipVerify bool) (client.TLSSettings, error) {
+	settings := client.TLSSettings{InsecureSkipVerify: insecureSkipVerify}
+	if len(caSecret) == 0 && len(clientCertificateSecret) == 0 {
+		return settings, nil
+	}
+
+	var operatorNamespace string
+	if (len(caSecret) > 0 && !strings.Contains(caSecret, "/")) || (len(clientCertificateSecret) > 0 && !strings.Contains(clientCertificateSecret, "/")) {
+		namespace, err := k8sutil.GetOperatorNamespace()
+		if err != nil {
+			return settings, errors.Wrap(err, "namespace of Jenkins API Secrets isn't set")
+		}
+		operatorNamespace = namespace
+	}
+
+	var err error
+	if settings.CASecret, err = client.ParseSecretReference(caSecret, operatorNamespace); err != nil {
+		return settings, err
+	}
+	settings.ClientCertificateSecret, err = client.ParseSecretReference(clientCertificateSecret, operatorNamespace)
+	return settings, err
+}
+
 func serveCRMetrics(cfg *rest.Config) error {
 	// Below function returns filtered operator/CustomResource specific GVKs.
 	// For more control override the below GVK list with your own custom logic.
@@ -230,7 +374,7 @@ func filterGKVsFromAddToScheme(gvks []schema.GroupVersionKind) []schema.GroupVer
 		{Group: "route.openshift.io", Kind: "Route", Version: matchAnyValue},
 		{Group: "image.openshift.io", Kind: "ImageStream", Version: matchAnyValue},
 		// Custom Resources
-		{Group: "jenkins.io", Kind: "Jenkins", Version: matchAnyValue},
+		{Group: "jenkins.io", Kind: "Jenkins", Version: v1alpha2.SchemeGroupVersion.Version},
 		{Group: "jenkins.io", Kind: "JenkinsImage", Version: matchAnyValue},
 	}
 
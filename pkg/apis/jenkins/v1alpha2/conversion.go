@@ -0,0 +1,4 @@
+package v1alpha2
+
+// Hub marks v1alpha2 as the version which other versions of Jenkins are converted to and from
+func (*Jenkins) Hub() {}
@@ -0,0 +1,173 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	crdFile      = "../../../../deploy/crds/jenkins_v1alpha2_jenkins_crd.yaml"
+	chartCRDFile = "../../../../chart/jenkins-operator/crds/jenkins-crd.yaml"
+
+	webhookCRDPatchFile = "../../../../deploy/webhook_crd_patch.yaml"
+)
+
+func readJenkinsCRD(t *testing.T, path string) map[string]interface{} {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	decoder := yaml.NewYAMLOrJSONDecoder(file, 4096)
+	for {
+		crd := map[string]interface{}{}
+		err := decoder.Decode(&crd)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if crd["metadata"].(map[string]interface{})["name"] == "jenkins.jenkins.io" {
+			return crd
+		}
+	}
+	t.Fatalf("the Jenkins CRD not found in %s", path)
+	return nil
+}
+
+func readJenkinsCRDSchema(t *testing.T, path string) map[string]interface{} {
+	crd := readJenkinsCRD(t, path)
+	for _, version := range crd["spec"].(map[string]interface{})["versions"].([]interface{}) {
+		version := version.(map[string]interface{})
+		if version["name"] == SchemeGroupVersion.Version {
+			return version["schema"].(map[string]interface{})["openAPIV3Schema"].(map[string]interface{})
+		}
+	}
+	t.Fatalf("schema of the Jenkins CRD %s version not found in %s", SchemeGroupVersion.Version, path)
+	return nil
+}
+
+// property returns the schema of the property under the path, "[]" selects items of the array.
+func property(t *testing.T, schema map[string]interface{}, path ...string) map[string]interface{} {
+	for _, name := range path {
+		var next interface{}
+		if name == "[]" {
+			next = schema["items"]
+		} else if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			next = properties[name]
+		}
+		require.NotNil(t, next, "property %v not found", path)
+		schema = next.(map[string]interface{})
+	}
+
+	return schema
+}
+
+func pattern(t *testing.T, schema map[string]interface{}, path ...string) *regexp.Regexp {
+	return regexp.MustCompile(property(t, schema, path...)["pattern"].(string))
+}
+
+func TestJenkinsCRDSchema(t *testing.T) {
+	schema := readJenkinsCRDSchema(t, crdFile)
+
+	t.Run("chart CRD has the same schema", func(t *testing.T) {
+		assert.Equal(t, schema, readJenkinsCRDSchema(t, chartCRDFile))
+	})
+	t.Run("seed job schedules", func(t *testing.T) {
+		for _, field := range []string{"pollSCM", "buildPeriodically"} {
+			cron := pattern(t, schema, "spec", "seedJobs", "[]", field)
+			for _, valid := range []string{"", "H/5 * * * *", "0 0 * * 1-5", "H H(0-7) * * *", "0 30 * * * *", "@daily", "0 0 1 JAN *"} {
+				assert.True(t, cron.MatchString(valid), "%s '%s' should be valid", field, valid)
+			}
+			for _, invalid := range []string{"H/5 * * *", "every day", "@sometimes", "* * * * * * *", " "} {
+				assert.False(t, cron.MatchString(invalid), "%s '%s' should be invalid", field, invalid)
+			}
+		}
+	})
+	t.Run("plugins", func(t *testing.T) {
+		for _, field := range []string{"plugins", "basePlugins"} {
+			name := pattern(t, schema, "spec", "master", field, "[]", "name")
+			assert.True(t, name.MatchString("workflow-job"))
+			assert.False(t, name.MatchString("workflow job"))
+
+			version := pattern(t, schema, "spec", "master", field, "[]", "version")
+			assert.True(t, version.MatchString("2.39"))
+			assert.True(t, version.MatchString("latest"))
+			assert.True(t, version.MatchString("1.0.0-beta+1"))
+			assert.False(t, version.MatchString("2.39 "))
+			assert.False(t, version.MatchString(""))
+		}
+	})
+	t.Run("enums", func(t *testing.T) {
+		credentialTypes := property(t, schema, "spec", "seedJobs", "[]", "credentialType")["enum"].([]interface{})
+		assert.Len(t, credentialTypes, len(AllowedJenkinsCredentialMap))
+		for _, credentialType := range credentialTypes {
+			assert.Contains(t, AllowedJenkinsCredentialMap, credentialType)
+		}
+
+		assert.ElementsMatch(t, []interface{}{string(BackupCompressionGzip), string(BackupCompressionZstd), string(BackupCompressionNone)},
+			property(t, schema, "spec", "backup", "compression")["enum"])
+		assert.ElementsMatch(t, []interface{}{string(NotificationLevelWarning), string(NotificationLevelInfo)},
+			property(t, schema, "spec", "notifications", "[]", "level")["enum"])
+	})
+	t.Run("defaults", func(t *testing.T) {
+		assert.Equal(t, string(CreateUserAuthorizationStrategy), property(t, schema, "spec", "jenkinsAPISettings", "authorizationStrategy")["default"])
+		assert.Equal(t, map[string]interface{}{"authorizationStrategy": string(CreateUserAuthorizationStrategy)},
+			property(t, schema, "spec", "jenkinsAPISettings")["default"])
+		assert.Equal(t, string(corev1.PullAlways), property(t, schema, "spec", "master", "containers", "[]", "imagePullPolicy")["default"])
+		assert.Equal(t, map[string]interface{}{"port": float64(8080)}, property(t, schema, "spec", "service")["default"])
+		assert.Equal(t, map[string]interface{}{"type": string(corev1.ServiceTypeClusterIP), "port": float64(50000)},
+			property(t, schema, "spec", "slaveService")["default"])
+	})
+}
+
+func TestJenkinsCRDVersions(t *testing.T) {
+	for _, path := range []string{crdFile, chartCRDFile} {
+		spec := readJenkinsCRD(t, path)["spec"].(map[string]interface{})
+
+		versions := map[string][]interface{}{}
+		for _, version := range spec["versions"].([]interface{}) {
+			version := version.(map[string]interface{})
+			versions[version["name"].(string)] = []interface{}{version["served"], version["storage"]}
+		}
+		assert.Equal(t, map[string][]interface{}{
+			"v1alpha1":                 {true, false},
+			SchemeGroupVersion.Version: {true, true},
+			"v1beta1":                  {false, false},
+		}, versions, path)
+		assert.Equal(t, map[string]interface{}{"strategy": "None"}, spec["conversion"], path)
+	}
+}
+
+func TestJenkinsCRDWebhookPatch(t *testing.T) {
+	file, err := os.Open(webhookCRDPatchFile)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+	var patch []map[string]interface{}
+	require.NoError(t, yaml.NewYAMLOrJSONDecoder(file, 4096).Decode(&patch))
+
+	for _, path := range []string{crdFile, chartCRDFile} {
+		versions := readJenkinsCRD(t, path)["spec"].(map[string]interface{})["versions"].([]interface{})
+		for _, operation := range patch {
+			if operation["op"] != "test" {
+				continue
+			}
+			// the patch refers to the version by its index
+			var index int
+			_, err := fmt.Sscanf(operation["path"].(string), "/spec/versions/%d/name", &index)
+			require.NoError(t, err)
+			require.True(t, index < len(versions), path)
+			assert.Equal(t, operation["value"], versions[index].(map[string]interface{})["name"], path)
+		}
+	}
+	conversion := patch[1]["value"].(map[string]interface{})
+	assert.Equal(t, "Webhook", conversion["strategy"])
+	assert.Equal(t, map[string]interface{}{"name": "jenkins-operator-webhook", "namespace": "default", "path": "/convert"},
+		conversion["webhookClientConfig"].(map[string]interface{})["service"])
+}
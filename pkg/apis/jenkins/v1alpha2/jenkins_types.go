@@ -3,6 +3,7 @@ package v1alpha2
 import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -27,6 +28,7 @@ type JenkinsSpec struct {
 	// Defaults to :
 	// port: 8080
 	// type: ClusterIP
+	// +kubebuilder:default={"port":8080}
 	// +optional
 	Service Service `json:"service,omitempty"`
 
@@ -34,6 +36,7 @@ type JenkinsSpec struct {
 	// Defaults to :
 	// port: 50000
 	// type: ClusterIP
+	// +kubebuilder:default={"type":"ClusterIP","port":50000}
 	// +optional
 	SlaveService Service `json:"slaveService,omitempty"`
 
@@ -64,10 +67,345 @@ type JenkinsSpec struct {
 	ServiceAccount ServiceAccount `json:"serviceAccount,omitempty"`
 
 	// JenkinsAPISettings defines configuration used by the operator to gain admin access to the Jenkins API
+	// +kubebuilder:default={"authorizationStrategy":"createUser"}
 	JenkinsAPISettings JenkinsAPISettings `json:"jenkinsAPISettings"`
 
 	// SeedAgent defines agent node configurations
 	SeedAgent SeedAgent `json:"seedAgent,omitempty"`
+
+	// Vault defines HashiCorp Vault used to resolve ${vault:path#key} placeholders in Groovy scripts
+	// and Configuration as Code ConfigMaps
+	// +optional
+	Vault *Vault `json:"vault,omitempty"`
+
+	// AWS defines AWS Secrets Manager and SSM Parameter Store access used to resolve ${aws-secretsmanager:name#key}
+	// and ${aws-ssm:name} placeholders in Groovy scripts and Configuration as Code ConfigMaps
+	// +optional
+	AWS *AWS `json:"aws,omitempty"`
+
+	// ReconcileInterval is the interval of periodic reconciliation of Jenkins which detects drift of its
+	// configuration, it overrides the --reconcile-interval flag of the operator
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// Proxy overrides the proxy settings of the operator for requests to Jenkins and the update center
+	// +optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+
+	// Monitoring defines Prometheus monitoring of Jenkins
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// ScriptLogs defines the ConfigMap with logs of groovy scripts and Configuration as Code executed by the operator
+	// +optional
+	ScriptLogs *ScriptLogs `json:"scriptLogs,omitempty"`
+
+	// Security defines security settings of Jenkins managed by the operator
+	// +optional
+	Security *Security `json:"security,omitempty"`
+
+	// Agents defines Jenkins agents started by the operator and by the kubernetes-plugin cloud
+	// +optional
+	Agents *Agents `json:"agents,omitempty"`
+}
+
+// Agents defines Jenkins agents started by the operator and by the kubernetes-plugin cloud
+type Agents struct {
+	// Namespace is the dedicated namespace of agent pods, the operator creates there the ServiceAccount of agent pods,
+	// the Role and RoleBinding which allow Jenkins to manage agent pods and the NetworkPolicy which isolates them
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// KubernetesCloud overrides settings of the kubernetes-plugin cloud which the operator configures
+	// +optional
+	KubernetesCloud *KubernetesCloud `json:"kubernetesCloud,omitempty"`
+
+	// Static are permanent inbound agents which the operator registers in Jenkins and runs as Deployments
+	// +optional
+	Static []StaticAgent `json:"static,omitempty"`
+}
+
+// StaticAgent defines the permanent inbound agent
+type StaticAgent struct {
+	// Name is the name of the Jenkins node specified as a DNS_LABEL
+	Name string `json:"name"`
+
+	// Labels are Jenkins labels which select the agent in jobs, e.g. agent { label 'docker' }
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// Executors is the number of concurrent builds on the agent, defaults to 1
+	// +optional
+	Executors int `json:"executors,omitempty"`
+
+	// Image is the inbound agent Docker image, defaults to the seed agent image
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources are CPU and memory requests and limits of the agent container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// KubernetesCloud overrides settings of the kubernetes-plugin cloud named kubernetes, settings which aren't set are
+// derived from the Jenkins CR and the cluster
+type KubernetesCloud struct {
+	// JenkinsURL is the URL of Jenkins used by agents, defaults to the Jenkins HTTP Service
+	// +optional
+	JenkinsURL string `json:"jenkinsURL,omitempty"`
+
+	// JenkinsTunnel is host:port of the Jenkins slave endpoint used by agents, defaults to the Jenkins slave Service
+	// +optional
+	JenkinsTunnel string `json:"jenkinsTunnel,omitempty"`
+
+	// Namespace is the namespace where agent pods are started, defaults to the namespace of the Jenkins CR,
+	// the ServiceAccount of Jenkins must be allowed to manage pods in it
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ServiceAccountName is the ServiceAccount of agent pods started from JenkinsAgentPodTemplates which don't
+	// set their own
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// ContainerCap is the maximum number of concurrently running agent pods, unlimited when not set
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ContainerCap int `json:"containerCap,omitempty"`
+
+	// MaxRequestsPerHost is the maximum number of concurrent requests from Jenkins to Kubernetes API, defaults to 32
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRequestsPerHost int `json:"maxRequestsPerHost,omitempty"`
+}
+
+// Security defines security settings of Jenkins managed by the operator
+type Security struct {
+	// AdminCredentialRotation defines periodic rotation of the password and the API token of the operator user
+	// created by the createUser authorization strategy
+	// +optional
+	AdminCredentialRotation *AdminCredentialRotation `json:"adminCredentialRotation,omitempty"`
+
+	// Authorization defines the role-based authorization strategy of Jenkins which the operator applies by
+	// Configuration as Code
+	// +optional
+	Authorization *Authorization `json:"authorization,omitempty"`
+
+	// CredentialsSync defines synchronization of Secrets labeled with jenkins.io/operator-credentials-type to Jenkins
+	// credentials
+	// +optional
+	CredentialsSync *CredentialsSync `json:"credentialsSync,omitempty"`
+
+	// FIPS selects FIPS capable default images, enables FIPS 140 compliance mode of Jenkins and refuses plugins and
+	// images known to be non-compliant
+	// +optional
+	FIPS bool `json:"fips,omitempty"`
+
+	// KubernetesCredentialsProvider defines the setup of https://jenkinsci.github.io/kubernetes-credentials-provider-plugin/
+	// +optional
+	KubernetesCredentialsProvider *KubernetesCredentialsProvider `json:"kubernetesCredentialsProvider,omitempty"`
+
+	// Realm defines the security realm of Jenkins which the operator applies by Configuration as Code
+	// +optional
+	Realm *SecurityRealm `json:"realm,omitempty"`
+}
+
+// AdminCredentialRotation defines how often the operator regenerates the credentials of the operator user
+type AdminCredentialRotation struct {
+	// Interval is the maximal age of the credentials of the operator user, at least 1h
+	Interval metav1.Duration `json:"interval"`
+}
+
+// Authorization defines global and project roles of the role-strategy plugin
+type Authorization struct {
+	// GlobalRoles grant permissions in the whole Jenkins
+	// +optional
+	GlobalRoles []AuthorizationRole `json:"globalRoles,omitempty"`
+
+	// ProjectRoles grant permissions on items which full names match the pattern of the role
+	// +optional
+	ProjectRoles []AuthorizationRole `json:"projectRoles,omitempty"`
+}
+
+// AuthorizationRole defines permissions of the role and users and groups which the role is assigned to
+type AuthorizationRole struct {
+	// Name is the unique name of the role
+	Name string `json:"name"`
+
+	// Pattern is the regular expression of full names of items, e.g. team-a/.*, it's required by project roles
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// Permissions granted by the role in the <group>/<name> format, e.g. Overall/Read or Job/Build
+	Permissions []string `json:"permissions"`
+
+	// Users which the role is assigned to
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Groups of the security realm which the role is assigned to
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// CredentialsSync defines how the operator creates Jenkins credentials from Kubernetes Secrets
+type CredentialsSync struct {
+	// Enabled creates, updates and deletes Jenkins credentials of the global domain according to Secrets labeled with
+	// jenkins.io/operator-credentials-type in the Jenkins CR namespace
+	Enabled bool `json:"enabled"`
+}
+
+// KubernetesCredentialsProvider defines how kubernetes-credentials-provider plugin reads Secrets in the Jenkins CR namespace
+type KubernetesCredentialsProvider struct {
+	// Enabled grants the Jenkins master service account access to Secrets only with a dedicated Role and RoleBinding
+	Enabled bool `json:"enabled"`
+
+	// LabelSelector narrows Secrets converted by the plugin to Jenkins credentials, e.g. team=backend
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// SecurityRealm defines how users are authenticated in Jenkins
+type SecurityRealm struct {
+	// LDAP authenticates users in LDAP or Active Directory with the ldap plugin
+	// +optional
+	LDAP *LDAPSecurityRealm `json:"ldap,omitempty"`
+
+	// OIDC authenticates users in OpenID Connect identity provider with the oic-auth plugin
+	// +optional
+	OIDC *OIDCSecurityRealm `json:"oidc,omitempty"`
+}
+
+// LDAPGroupMembershipStrategy defines how the ldap plugin resolves groups of users
+type LDAPGroupMembershipStrategy string
+
+const (
+	// FromGroupSearchLDAPGroupMembershipStrategy searches groups which contain the user
+	FromGroupSearchLDAPGroupMembershipStrategy LDAPGroupMembershipStrategy = "fromGroupSearch"
+	// FromUserRecordLDAPGroupMembershipStrategy reads groups from the attribute of the user record
+	FromUserRecordLDAPGroupMembershipStrategy LDAPGroupMembershipStrategy = "fromUserRecord"
+)
+
+// LDAPSecurityRealm defines the LDAP server and how users and groups are searched in it
+type LDAPSecurityRealm struct {
+	// Server is the LDAP server, e.g. ldaps://ldap.example.com:636, more servers can be separated by spaces
+	Server string `json:"server"`
+
+	// RootDN is the DN of the root of the LDAP tree, e.g. dc=example,dc=com, it's inferred by the ldap plugin when not set
+	// +optional
+	RootDN string `json:"rootDN,omitempty"`
+
+	// BindSecret is the Secret with bindDN and bindPassword keys of the user which searches in LDAP, the anonymous
+	// bind is used when it's not set
+	// +optional
+	BindSecret SecretRef `json:"bindSecret,omitempty"`
+
+	// UserSearchBase is the DN relative to the root DN where users are searched, e.g. ou=people
+	// +optional
+	UserSearchBase string `json:"userSearchBase,omitempty"`
+
+	// UserSearch is the filter of users, defaults to uid={0}, use sAMAccountName={0} for Active Directory
+	// +optional
+	UserSearch string `json:"userSearch,omitempty"`
+
+	// GroupSearchBase is the DN relative to the root DN where groups are searched, e.g. ou=groups
+	// +optional
+	GroupSearchBase string `json:"groupSearchBase,omitempty"`
+
+	// GroupSearchFilter is the filter of groups
+	// +optional
+	GroupSearchFilter string `json:"groupSearchFilter,omitempty"`
+
+	// GroupMembershipStrategy is fromGroupSearch (default) or fromUserRecord
+	// +optional
+	GroupMembershipStrategy LDAPGroupMembershipStrategy `json:"groupMembershipStrategy,omitempty"`
+
+	// GroupMembershipFilter is the filter of groups which contain the user used by fromGroupSearch strategy,
+	// e.g. (| (member={0}) (uniqueMember={0}))
+	// +optional
+	GroupMembershipFilter string `json:"groupMembershipFilter,omitempty"`
+
+	// GroupMembershipAttribute is the attribute of the user record with groups used by fromUserRecord strategy,
+	// defaults to memberOf
+	// +optional
+	GroupMembershipAttribute string `json:"groupMembershipAttribute,omitempty"`
+}
+
+// OIDCSecurityRealm defines the OpenID Connect identity provider and the local break-glass admin account
+type OIDCSecurityRealm struct {
+	// Issuer is the URL of the identity provider, its configuration is discovered from
+	// <issuer>/.well-known/openid-configuration
+	Issuer string `json:"issuer"`
+
+	// ClientID is the id of Jenkins client registered in the identity provider
+	ClientID string `json:"clientID"`
+
+	// ClientSecret is the Secret with clientSecret key of Jenkins client registered in the identity provider
+	ClientSecret SecretRef `json:"clientSecret"`
+
+	// Scopes requested from the identity provider, defaults to openid, email and profile
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// UserNameClaim is the claim with the user name, defaults to sub
+	// +optional
+	UserNameClaim string `json:"userNameClaim,omitempty"`
+
+	// GroupsClaim is the claim with groups of the user, e.g. groups
+	// +optional
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// BreakGlassSecret is the Secret with username and password keys of the local admin account which can log in when
+	// the identity provider doesn't work, the operator generates the jenkins-operator-break-glass-<cr_name> Secret
+	// when it's not set
+	// +optional
+	BreakGlassSecret SecretRef `json:"breakGlassSecret,omitempty"`
+}
+
+// ScriptLogs defines the ConfigMap which keeps logs of the latest groovy scripts and Configuration as Code executed by
+// the operator, e.g. to debug failed groovy scripts without the operator logs
+type ScriptLogs struct {
+	// Limit is the number of the latest script executions kept in the ConfigMap, zero disables it
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Limit int32 `json:"limit"`
+}
+
+// Monitoring defines Prometheus monitoring of Jenkins with the Jenkins Prometheus plugin
+type Monitoring struct {
+	// Enabled installs and configures the Jenkins Prometheus plugin, exposes its metrics on the Jenkins HTTP Service
+	// and creates the ServiceMonitor of Prometheus Operator when its API is available
+	Enabled bool `json:"enabled"`
+
+	// Path is the path of the Prometheus metrics endpoint in Jenkins, defaults to prometheus
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Interval is the scrape interval of Jenkins metrics, e.g. 30s, the default of Prometheus is used when it's empty
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// Labels are added to the ServiceMonitor, e.g. to match the serviceMonitorSelector of Prometheus
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Proxy defines the HTTP/S proxy of requests sent by the operator to Jenkins and the update center, in-cluster
+// addresses are always reached directly
+type Proxy struct {
+	// HTTPProxy is the proxy URL of HTTP requests, e.g. http://proxy.example.com:3128
+	// +kubebuilder:validation:Pattern=`^(|https?://.+)$`
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the proxy URL of HTTPS requests, e.g. http://proxy.example.com:3128
+	// +kubebuilder:validation:Pattern=`^(|https?://.+)$`
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is the comma separated list of hosts, domains, IP addresses and CIDRs reached directly
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
 }
 
 // AuthorizationStrategy defines authorization strategy of the operator for the Jenkins API
@@ -82,7 +420,132 @@ const (
 
 // JenkinsAPISettings defines configuration used by the operator to gain admin access to the Jenkins API
 type JenkinsAPISettings struct {
+	// AuthorizationStrategy is the way the operator authenticates to the Jenkins API
+	// Defaults to createUser
+	// +kubebuilder:validation:Enum=createUser;serviceAccount
+	// +kubebuilder:default=createUser
 	AuthorizationStrategy AuthorizationStrategy `json:"authorizationStrategy"`
+
+	// TokenRotationInterval is the interval of rotation of the API token of the operator user used by the createUser
+	// authorization strategy, the old token is revoked after the new one is stored in the operator credentials Secret,
+	// the token is rotated only when the Jenkins master Pod is recreated when it's not set
+	// +optional
+	TokenRotationInterval *metav1.Duration `json:"tokenRotationInterval,omitempty"`
+
+	// PortForwardFallback enables the port-forward to the Jenkins master Pod when the operator can't connect to
+	// the Jenkins API, e.g. because of network policies
+	// +optional
+	PortForwardFallback bool `json:"portForwardFallback,omitempty"`
+
+	// AuthProxy defines authentication of the operator to the auth proxy in front of Jenkins, e.g. oauth2-proxy
+	// +optional
+	AuthProxy *AuthProxy `json:"authProxy,omitempty"`
+
+	// RateLimit overrides the rate limit of the operator for Jenkins API requests sent to this Jenkins
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// SSHCLI enables execution of Groovy scripts by the Jenkins CLI over SSH instead of the script console HTTP
+	// endpoint, e.g. when the script console is blocked by policy, it requires the createUser authorization strategy
+	// +optional
+	SSHCLI *SSHCLI `json:"sshCLI,omitempty"`
+}
+
+// SSHCLI defines the Jenkins CLI over SSH used by the operator to execute Groovy scripts
+type SSHCLI struct {
+	// Port is the port of the Jenkins SSH server, it's configured by the operator on Jenkins start
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// RateLimit defines the token bucket rate limiter of Jenkins API requests
+type RateLimit struct {
+	// RequestsPerSecond is the average number of requests per second, 0 disables rate limiting
+	// +kubebuilder:validation:Minimum=0
+	RequestsPerSecond int32 `json:"requestsPerSecond"`
+
+	// Burst is the maximal number of requests sent at once, defaults to requestsPerSecond
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// AuthProxy defines HTTP headers which authenticate the operator to the auth proxy (SSO gateway) in front of Jenkins
+type AuthProxy struct {
+	// Headers are set on every Jenkins API request, the Authorization header replaces the authentication of
+	// the operator user, e.g. Jenkins trusts the user forwarded by the proxy
+	Headers []AuthProxyHeader `json:"headers"`
+}
+
+// AuthProxyHeader defines the HTTP header with the value read from the Secret
+type AuthProxyHeader struct {
+	// Name is the name of the header, e.g. Authorization or X-Forwarded-Access-Token
+	Name string `json:"name"`
+
+	// Prefix is prepended to the value of the header, e.g. "Bearer "
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// ValueSecretKeySelector selects the value of the header from the Secret in the Jenkins CR namespace
+	ValueSecretKeySelector SecretKeySelector `json:"valueSecretKeySelector"`
+}
+
+// AWS defines access to AWS Secrets Manager and SSM Parameter Store, the operator authenticates with
+// IAM roles for service accounts (IRSA) or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables
+type AWS struct {
+	// Region is the AWS region of secrets and parameters, for example eu-west-1
+	Region string `json:"region"`
+
+	// RoleARN is the IAM role assumed with the operator service account web identity token
+	// Defaults to AWS_ROLE_ARN environment variable of the operator pod.
+	// +optional
+	RoleARN string `json:"roleArn,omitempty"`
+}
+
+// VaultAuthMethod defines the method of the operator authentication in HashiCorp Vault
+type VaultAuthMethod string
+
+const (
+	// VaultTokenAuthMethod uses token from the token key of the Kubernetes secret
+	VaultTokenAuthMethod VaultAuthMethod = "token"
+	// VaultKubernetesAuthMethod uses the operator service account token
+	VaultKubernetesAuthMethod VaultAuthMethod = "kubernetes"
+	// VaultAppRoleAuthMethod uses roleId and secretId keys of the Kubernetes secret
+	VaultAppRoleAuthMethod VaultAuthMethod = "approle"
+)
+
+// Vault defines HashiCorp Vault connection
+type Vault struct {
+	// Address is the Vault server address, for example https://vault.example.com:8200
+	Address string `json:"address"`
+
+	// Namespace is the Vault Enterprise namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Auth defines the operator authentication in Vault
+	Auth VaultAuth `json:"auth"`
+}
+
+// VaultAuth defines the operator authentication in HashiCorp Vault
+type VaultAuth struct {
+	// Method is the authentication method: token, kubernetes or approle
+	Method VaultAuthMethod `json:"method"`
+
+	// MountPath is the path where the authentication method is enabled
+	// Defaults to the method name.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// Role is the Vault role used by the kubernetes authentication method
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// Secret is the Kubernetes secret with the token key for the token authentication method
+	// or roleId and secretId keys for the approle authentication method
+	// +optional
+	Secret SecretRef `json:"secret,omitempty"`
 }
 
 // ServiceAccount defines Kubernetes service account attributes
@@ -108,13 +571,106 @@ const (
 
 // Notification is a service configuration used to send notifications about Jenkins status.
 type Notification struct {
+	// +kubebuilder:validation:Enum=warning;info
 	LoggingLevel NotificationLevel `json:"level"`
-	Verbose      bool              `json:"verbose"`
-	Name         string            `json:"name"`
-	Slack        *Slack            `json:"slack,omitempty"`
-	Teams        *MicrosoftTeams   `json:"teams,omitempty"`
-	Mailgun      *Mailgun          `json:"mailgun,omitempty"`
-	SMTP         *SMTP             `json:"smtp,omitempty"`
+	// +optional
+	Verbose bool `json:"verbose"`
+	// +kubebuilder:validation:MinLength=1
+	Name        string          `json:"name"`
+	Slack       *Slack          `json:"slack,omitempty"`
+	Teams       *MicrosoftTeams `json:"teams,omitempty"`
+	Mailgun     *Mailgun        `json:"mailgun,omitempty"`
+	SMTP        *SMTP           `json:"smtp,omitempty"`
+	Webhook     *Webhook        `json:"webhook,omitempty"`
+	PagerDuty   *PagerDuty      `json:"pagerDuty,omitempty"`
+	Opsgenie    *Opsgenie       `json:"opsgenie,omitempty"`
+	Discord     *Discord        `json:"discord,omitempty"`
+	Matrix      *Matrix         `json:"matrix,omitempty"`
+	CloudEvents *CloudEvents    `json:"cloudEvents,omitempty"`
+	// Template is the go-template of the message which replaces the short or verbose message of events,
+	// see provider.TemplateData for available fields
+	// +optional
+	Template string `json:"template,omitempty"`
+	// Filter selects events sent by the service, level is the minimal level of events
+	// +optional
+	Filter *NotificationFilter `json:"filter,omitempty"`
+}
+
+// NotificationFilter selects events sent by the notification service, empty fields match all events.
+type NotificationFilter struct {
+	// Phases are phases of events: base or user
+	// +optional
+	Phases []string `json:"phases,omitempty"`
+	// Reasons are reason types of events, e.g. PodRestart or BaseConfigurationFailed
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+	// Selector is the label selector of Jenkins CRs
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// CloudEvents is handler which sends events as CloudEvents 1.0 in HTTP structured content mode.
+type CloudEvents struct {
+	// SinkURL is the endpoint where events are sent, e.g. a Knative broker or an Argo Events webhook
+	// +kubebuilder:validation:Pattern=`^https?://`
+	SinkURL string `json:"sinkURL"`
+	// Source is the source attribute of events
+	// Defaults to /apis/jenkins.io/v1alpha2/namespaces/<namespace>/jenkins/<name>
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// Matrix is handler for Matrix room notification channel.
+type Matrix struct {
+	// HomeserverURL is the URL of the Matrix homeserver, e.g. https://matrix.example.com
+	// +kubebuilder:validation:Pattern=`^https?://`
+	HomeserverURL string `json:"homeserverURL"`
+	// RoomID is the ID of the room where messages are sent, e.g. !abcdefgh:example.com
+	RoomID string `json:"roomID"`
+	// AccessTokenSecretKeySelector selects the access token of the user which sends messages
+	AccessTokenSecretKeySelector SecretKeySelector `json:"accessTokenSecretKeySelector"`
+}
+
+// Discord is handler for Discord notification channel.
+type Discord struct {
+	// The web hook URL to Discord channel
+	WebHookURLSecretKeySelector SecretKeySelector `json:"webHookURLSecretKeySelector"`
+}
+
+// Opsgenie is handler for Opsgenie alerts notification channel.
+type Opsgenie struct {
+	// APIKeySecretKeySelector selects the API key of the Opsgenie API integration
+	APIKeySecretKeySelector SecretKeySelector `json:"apiKeySecretKeySelector"`
+	// URL is the Opsgenie API endpoint, use https://api.eu.opsgenie.com for the EU instance
+	// Defaults to https://api.opsgenie.com
+	// +optional
+	URL string `json:"url,omitempty"`
+	// Tags are additional tags of alerts
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// PagerDuty is handler for PagerDuty Events API v2 notification channel.
+type PagerDuty struct {
+	// RoutingKeySecretKeySelector selects the integration key of the PagerDuty service
+	RoutingKeySecretKeySelector SecretKeySelector `json:"routingKeySecretKeySelector"`
+	// URL is the Events API v2 endpoint
+	// Defaults to https://events.pagerduty.com/v2/enqueue
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// Webhook is handler for generic outgoing webhook notification channel, events are POSTed as JSON.
+type Webhook struct {
+	// URL is the endpoint where events are sent
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// Headers are additional HTTP headers sent with every request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+	// TokenSecretKeySelector selects the token sent as a bearer token in the Authorization header
+	// +optional
+	TokenSecretKeySelector *SecretKeySelector `json:"tokenSecretKeySelector,omitempty"`
 }
 
 // Slack is handler for Slack notification channel.
@@ -125,13 +681,27 @@ type Slack struct {
 
 // SMTP is handler for sending emails via this protocol.
 type SMTP struct {
+	// UsernameSecretKeySelector and PasswordSecretKeySelector select credentials of the SMTP server,
+	// authentication is skipped when the secret name of the username is empty
 	UsernameSecretKeySelector SecretKeySelector `json:"usernameSecretKeySelector"`
 	PasswordSecretKeySelector SecretKeySelector `json:"passwordSecretKeySelector"`
-	Port                      int               `json:"port"`
-	Server                    string            `json:"server"`
-	TLSInsecureSkipVerify     bool              `json:"tlsInsecureSkipVerify,omitempty"`
-	From                      string            `json:"from"`
-	To                        string            `json:"to"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port                  int    `json:"port"`
+	Server                string `json:"server"`
+	TLSInsecureSkipVerify bool   `json:"tlsInsecureSkipVerify,omitempty"`
+	// SSL enables implicit TLS (usually port 465), STARTTLS is used when the server supports it otherwise
+	// +optional
+	SSL  bool   `json:"ssl,omitempty"`
+	From string `json:"from"`
+	// To is the comma separated list of recipients
+	To string `json:"to"`
+	// InfoTo is the comma separated list of recipients of info events, overrides To
+	// +optional
+	InfoTo string `json:"infoTo,omitempty"`
+	// WarningTo is the comma separated list of recipients of warning events, overrides To
+	// +optional
+	WarningTo string `json:"warningTo,omitempty"`
 }
 
 // MicrosoftTeams is handler for Microsoft MicrosoftTeams notification channel.
@@ -169,6 +739,8 @@ type Container struct {
 	// Image pull policy.
 	// One of Always, Never, IfNotPresent.
 	// Defaults to Always.
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +kubebuilder:default=Always
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy"`
 
 	// Compute Resources required by this container.
@@ -250,11 +822,93 @@ type Container struct {
 // Plugin defines Jenkins plugin.
 type Plugin struct {
 	// Name is the name of Jenkins plugin
+	// +kubebuilder:validation:Pattern=`^[0-9a-zA-Z-_]+$`
 	Name string `json:"name"`
-	// Version is the version of Jenkins plugin
+	// Version is the version of Jenkins plugin, the latest version is resolved and pinned by the operator
+	// when it's set to latest
+	// +kubebuilder:validation:Pattern=`^[0-9a-zA-Z+.-]+$`
 	Version string `json:"version"`
 	// DownloadURL is the custom url from where plugin has to be downloaded.
+	// +kubebuilder:validation:Pattern=`^https?://`
 	DownloadURL string `json:"downloadURL,omitempty"`
+	// SHA256 is the hex or base64 encoded SHA-256 checksum of the plugin file verified when
+	// PluginChecksumVerification is enabled, it overrides the checksum published in the update center
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{64}|[A-Za-z0-9+/]{43}=)$`
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// PluginsConfigMap references ConfigMap which contains plugins.txt file.
+type PluginsConfigMap struct {
+	// Name is the name of ConfigMap
+	Name string `json:"name"`
+
+	// Key is the key of plugins.txt file in the ConfigMap
+	// Defaults to plugins.txt
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// PluginBundle defines the source of plugin files installed offline, exactly one of
+// PersistentVolumeClaimName or Image has to be set.
+type PluginBundle struct {
+	// PersistentVolumeClaimName is the name of PersistentVolumeClaim which contains plugin files
+	// +optional
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+
+	// Image is the OCI image which contains plugin files, they're copied to the Jenkins master pod by an init container
+	// The image must provide the sh and cp commands
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy is the pull policy of Image
+	// Defaults to IfNotPresent
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Path is the directory which contains <plugin-name>.hpi or <plugin-name>.jpi files,
+	// relative to the root of the PersistentVolumeClaim or absolute in the Image
+	// Defaults to the root of the PersistentVolumeClaim or /plugins in the Image
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// PluginCache defines the PersistentVolumeClaim used as the plugin download cache.
+type PluginCache struct {
+	// PersistentVolumeClaimName is the name of an existing PersistentVolumeClaim used as the cache,
+	// the operator creates and manages the PersistentVolumeClaim when it's empty
+	// +optional
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+
+	// StorageClassName is the storage class of the PersistentVolumeClaim created by the operator
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Size is the size of the PersistentVolumeClaim created by the operator
+	// Defaults to 1Gi
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
+// PluginSecurityWarnings defines how often the operator checks installed plugins against security warnings.
+type PluginSecurityWarnings struct {
+	// Interval is the interval between checks
+	// Defaults to 24h
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// UpdateCenterJSONURL is the URL of the update center metadata which contains security warnings
+	// Defaults to <spec.master.updateCenterURL>/update-center.actual.json
+	// +optional
+	UpdateCenterJSONURL string `json:"updateCenterJSONURL,omitempty"`
+}
+
+// PluginDependencyResolution defines how the operator resolves transitive dependencies of plugins.
+type PluginDependencyResolution struct {
+	// PluginVersionsURL is the URL of the update center metadata which contains dependencies of all plugin versions
+	// Defaults to <spec.master.updateCenterURL>/current/plugin-versions.json
+	// +optional
+	PluginVersionsURL string `json:"pluginVersionsURL,omitempty"`
 }
 
 // JenkinsMaster defines the Jenkins master pod attributes and plugins,
@@ -293,10 +947,23 @@ type JenkinsMaster struct {
 	// for each container individually.
 	// +optional
 	// Defaults to:
+	// runAsNonRoot: true
 	// runAsUser: 1000
+	// runAsGroup: 1000
 	// fsGroup: 1000
 	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
 
+	// DisableRestrictedSecurityContext disables the defaults of security contexts and the seccomp profile which make
+	// the Jenkins master pod compliant with the restricted Pod Security Standard. Security contexts set in the CR are
+	// always used as they are.
+	// +optional
+	DisableRestrictedSecurityContext bool `json:"disableRestrictedSecurityContext,omitempty"`
+
+	// ReadOnlyRootFilesystem mounts the root filesystem of the Jenkins master container as read-only. The temporary
+	// directory, the cache of the extracted WAR file and the plugins staging directory are mounted as emptyDir volumes.
+	// +optional
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty"`
+
 	// List of containers belonging to the pod.
 	// Containers cannot currently be added or removed.
 	// There must be at least one container in a Pod.
@@ -373,10 +1040,57 @@ type JenkinsMaster struct {
 	// +optional
 	Plugins []Plugin `json:"plugins,omitempty"`
 
-	// DisableCSRFProtection allows you to toggle CSRF Protection on Jenkins
-	DisableCSRFProtection bool `json:"disableCSRFProtection"`
+	// PluginsConfigMap references ConfigMap with plugins.txt file in the jenkins-plugin-cli format,
+	// its plugins are installed together with Plugins which take precedence
+	// +optional
+	PluginsConfigMap *PluginsConfigMap `json:"pluginsConfigMap,omitempty"`
 
-	// PriorityClassName for Jenkins master pod
+	// UpdateCenterURL is the URL of the update center or its mirror used by install scripts to download plugins
+	// Defaults to https://updates.jenkins.io
+	// +optional
+	UpdateCenterURL string `json:"updateCenterURL,omitempty"`
+
+	// UpdateCenterDownloadURL is the URL of the mirror from where plugin files are downloaded,
+	// plugins are downloaded from <UpdateCenterDownloadURL>/plugins/<name>/<version>/<name>.hpi
+	// Defaults to <UpdateCenterURL>/download
+	// +optional
+	UpdateCenterDownloadURL string `json:"updateCenterDownloadURL,omitempty"`
+
+	// PluginBundle installs plugins offline from .hpi files mounted from a PersistentVolumeClaim or copied from an image,
+	// the update center isn't used at all
+	// +optional
+	PluginBundle *PluginBundle `json:"pluginBundle,omitempty"`
+
+	// PluginCache keeps downloaded plugin files on a PersistentVolumeClaim, so they aren't downloaded
+	// from the update center again when Jenkins master pod is recreated
+	// +optional
+	PluginCache *PluginCache `json:"pluginCache,omitempty"`
+
+	// PluginSecurityWarnings enables periodic checks of installed plugins against security warnings published
+	// in the update center, affected plugins are reported in the PluginSecurityWarnings condition
+	// +optional
+	PluginSecurityWarnings *PluginSecurityWarnings `json:"pluginSecurityWarnings,omitempty"`
+
+	// PluginDependencyResolution enables resolution of transitive plugin dependencies by the operator,
+	// dependencies are pinned to the lowest versions required by plugins
+	// +optional
+	PluginDependencyResolution *PluginDependencyResolution `json:"pluginDependencyResolution,omitempty"`
+
+	// PluginChecksumVerification verifies SHA-256 checksums of all plugin files before they're installed,
+	// Jenkins master doesn't start when any checksum doesn't match
+	// +optional
+	PluginChecksumVerification bool `json:"pluginChecksumVerification,omitempty"`
+
+	// DynamicPluginInstallation installs new user plugins through the Jenkins plugin manager and loads them
+	// without restarting Jenkins master, the master pod is restarted only when a plugin can't be loaded dynamically
+	// or a version of an already installed plugin has changed
+	// +optional
+	DynamicPluginInstallation bool `json:"dynamicPluginInstallation,omitempty"`
+
+	// DisableCSRFProtection allows you to toggle CSRF Protection on Jenkins
+	DisableCSRFProtection bool `json:"disableCSRFProtection"`
+
+	// PriorityClassName for Jenkins master pod
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
@@ -479,17 +1193,184 @@ type JenkinsStatus struct {
 	// +optional
 	BackupDoneBeforePodDeletion bool `json:"backupDoneBeforePodDeletion,omitempty"`
 
+	// BackupDestinations contains results of the latest backup for every configured backup destination
+	// +optional
+	BackupDestinations []BackupDestinationStatus `json:"backupDestinations,omitempty"`
+
 	// UserAndPasswordHash is a SHA256 hash made from user and password
 	// +optional
 	UserAndPasswordHash string `json:"userAndPasswordHash,omitempty"`
 
+	// EnvSecretsHash is a SHA256 hash made from resource versions of Secrets referenced by environment variables of
+	// Jenkins master containers, the Jenkins master pod is restarted when it changes
+	// +optional
+	EnvSecretsHash string `json:"envSecretsHash,omitempty"`
+
+	// SSHCLIHash is a SHA256 hash made from the port of the Jenkins SSH server and the SSH public key of the operator
+	// user, the Jenkins master pod is restarted when it changes because they're applied on Jenkins start
+	// +optional
+	SSHCLIHash string `json:"sshCLIHash,omitempty"`
+
 	// CreatedSeedJobs contains list of seed job id already created in Jenkins
 	// +optional
 	CreatedSeedJobs []string `json:"createdSeedJobs,omitempty"`
 
+	// SyncedCredentials contains list of Jenkins credential ids created by the operator from Kubernetes Secrets
+	// +optional
+	SyncedCredentials []string `json:"syncedCredentials,omitempty"`
+
+	// CreatedStaticAgents contains list of static agent names registered in Jenkins by the operator
+	// +optional
+	CreatedStaticAgents []string `json:"createdStaticAgents,omitempty"`
+
+	// SeedJobs contains the latest build results of seed jobs
+	// +optional
+	SeedJobs []SeedJobStatus `json:"seedJobs,omitempty"`
+
 	// AppliedGroovyScripts is a list with all applied groovy scripts in Jenkins by the operator
 	// +optional
 	AppliedGroovyScripts []AppliedGroovyScript `json:"appliedGroovyScripts,omitempty"`
+
+	// ConfigurationAsCodeGitRepositories contains commits of Configuration as Code Git repositories applied in Jenkins
+	// +optional
+	ConfigurationAsCodeGitRepositories []ConfigurationAsCodeGitRepositoryStatus `json:"configurationAsCodeGitRepositories,omitempty"`
+
+	// ConfigurationAsCodeRemoteURLs contains hashes of Configuration as Code YAML files from remote URLs applied in Jenkins
+	// +optional
+	ConfigurationAsCodeRemoteURLs []ConfigurationAsCodeRemoteURLStatus `json:"configurationAsCodeRemoteURLs,omitempty"`
+
+	// PinnedPlugins contains versions of plugins with the latest version resolved by the operator
+	// +optional
+	PinnedPlugins []PinnedPlugin `json:"pinnedPlugins,omitempty"`
+
+	// Plugins contains plugins installed in Jenkins, it's updated after the base configuration is applied
+	// +optional
+	Plugins []InstalledPlugin `json:"plugins,omitempty"`
+
+	// Conditions contains the latest observations of Jenkins state
+	// +optional
+	Conditions []JenkinsCondition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of Jenkins CR observed by the operator, the Ready condition
+	// reflects the latest spec only when it matches metadata.generation
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is the high-level summary of Jenkins state driven by its conditions, tools should use it instead of
+	// the completion times of the base and the user configuration
+	// +optional
+	Phase JenkinsPhase `json:"phase,omitempty"`
+
+	// URL is the URL of Jenkins HTTP service inside the cluster
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Version is the version of Jenkins core running in the Jenkins master pod
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// JenkinsPhase is the high-level summary of Jenkins state.
+type JenkinsPhase string
+
+const (
+	// JenkinsPhaseProvisioning - resources of Jenkins master are being created and its pod isn't ready yet
+	JenkinsPhaseProvisioning JenkinsPhase = "Provisioning"
+
+	// JenkinsPhaseConfiguringBase - the base configuration of Jenkins is being reconciled
+	JenkinsPhaseConfiguringBase JenkinsPhase = "ConfiguringBase"
+
+	// JenkinsPhaseRestoringBackup - the latest backup is being restored
+	JenkinsPhaseRestoringBackup JenkinsPhase = "RestoringBackup"
+
+	// JenkinsPhaseConfiguringUser - the user configuration of Jenkins is being reconciled
+	JenkinsPhaseConfiguringUser JenkinsPhase = "ConfiguringUser"
+
+	// JenkinsPhaseReady - the base and the user configuration of Jenkins are reconciled
+	JenkinsPhaseReady JenkinsPhase = "Ready"
+
+	// JenkinsPhaseDegraded - Jenkins has been configured but the latest reconcile loop or backup has failed
+	JenkinsPhaseDegraded JenkinsPhase = "Degraded"
+
+	// JenkinsPhaseFailed - the configuration of Jenkins has failed before it became ready
+	JenkinsPhaseFailed JenkinsPhase = "Failed"
+)
+
+// InstalledPlugin is the plugin installed in Jenkins.
+type InstalledPlugin struct {
+	// Name is the name of Jenkins plugin
+	Name string `json:"name"`
+
+	// Version is the installed version of Jenkins plugin
+	Version string `json:"version"`
+
+	// Enabled tells whether the plugin is enabled
+	Enabled bool `json:"enabled"`
+}
+
+// PinnedPlugin is the plugin version resolved by the operator for a plugin with the latest version.
+type PinnedPlugin struct {
+	// Name is the name of Jenkins plugin
+	Name string `json:"name"`
+
+	// Version is the resolved version of Jenkins plugin
+	Version string `json:"version"`
+
+	// PinTime is the time when the version has been resolved
+	PinTime metav1.Time `json:"pinTime"`
+}
+
+// JenkinsConditionType is the type of Jenkins condition.
+type JenkinsConditionType string
+
+const (
+	// PluginSecurityWarningsCondition is true when installed plugins are affected by security warnings
+	// published in the update center
+	PluginSecurityWarningsCondition JenkinsConditionType = "PluginSecurityWarnings"
+
+	// ReadyCondition is true when the base and the user configuration of Jenkins are reconciled
+	ReadyCondition JenkinsConditionType = "Ready"
+
+	// BaseConfigurationReconciledCondition is true when the base configuration of Jenkins is reconciled
+	BaseConfigurationReconciledCondition JenkinsConditionType = "BaseConfigurationReconciled"
+
+	// UserConfigurationReconciledCondition is true when the user configuration of Jenkins is reconciled
+	UserConfigurationReconciledCondition JenkinsConditionType = "UserConfigurationReconciled"
+
+	// BackupHealthyCondition is true when the latest backup has been stored in all backup destinations
+	BackupHealthyCondition JenkinsConditionType = "BackupHealthy"
+
+	// UpgradeAvailableCondition is true when a newer version of Jenkins core is released in the same release line
+	// (LTS or weekly) as the running version
+	UpgradeAvailableCondition JenkinsConditionType = "UpgradeAvailable"
+
+	// PausedCondition is true when the reconciliation of Jenkins is paused by the jenkins.io/paused annotation
+	PausedCondition JenkinsConditionType = "Paused"
+)
+
+// JenkinsCondition describes the state of Jenkins at a certain point.
+type JenkinsCondition struct {
+	// Type is the type of condition
+	Type JenkinsConditionType `json:"type"`
+
+	// Status is the status of condition, one of True, False, Unknown
+	Status corev1.ConditionStatus `json:"status"`
+
+	// LastProbeTime is the last time the condition was probed
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// LastTransitionTime is the last time the condition transitioned from one status to another
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is the unique, one-word, CamelCase reason for the condition's last transition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the human-readable message indicating details about last transition
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
@@ -498,6 +1379,12 @@ type JenkinsStatus struct {
 // Jenkins is the Schema for the jenkins API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:path=jenkins,scope=Namespaced,shortName=jk;jks,categories=all
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Jenkins struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -530,26 +1417,79 @@ const (
 	UsernamePasswordCredentialType JenkinsCredentialType = "usernamePassword"
 	// ExternalCredentialType defines other credential type
 	ExternalCredentialType JenkinsCredentialType = "external"
+	// GitLabAPITokenCredentialType define GitLab personal access token credential type, it's used to clone
+	// the repository over HTTPS and to access GitLab API
+	GitLabAPITokenCredentialType JenkinsCredentialType = "gitlabApiToken"
+	// BitbucketAppPasswordCredentialType define Bitbucket Cloud app password credential type
+	BitbucketAppPasswordCredentialType JenkinsCredentialType = "bitbucketAppPassword"
+	// BitbucketAPITokenCredentialType define Bitbucket Server HTTP access token or Bitbucket Cloud access token
+	// credential type
+	BitbucketAPITokenCredentialType JenkinsCredentialType = "bitbucketApiToken"
+	// GitHubAppCredentialType define GitHub App credential type
+	GitHubAppCredentialType JenkinsCredentialType = "githubApp"
 )
 
 // AllowedJenkinsCredentialMap contains all allowed Jenkins credentials types.
 var AllowedJenkinsCredentialMap = map[string]string{
-	string(NoJenkinsCredentialCredentialType): "",
-	string(BasicSSHCredentialType):            "",
-	string(UsernamePasswordCredentialType):    "",
-	string(ExternalCredentialType):            "",
+	string(NoJenkinsCredentialCredentialType):  "",
+	string(BasicSSHCredentialType):             "",
+	string(UsernamePasswordCredentialType):     "",
+	string(ExternalCredentialType):             "",
+	string(GitLabAPITokenCredentialType):       "",
+	string(BitbucketAppPasswordCredentialType): "",
+	string(BitbucketAPITokenCredentialType):    "",
+	string(GitHubAppCredentialType):            "",
 }
 
 // SeedAgent defines configuration for seed job agent configuration
 type SeedAgent struct {
 	// More info: https://kubernetes.io/docs/concepts/containers/images
 	Image string `json:"image"`
+
+	// Compute Resources required by the seed job agent container.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector is a selector which must match a node's labels for the seed job agent pod to be scheduled on that node.
+	// Defaults to spec.master.nodeSelector.
+	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// If specified, the seed job agent pod's tolerations.
+	// Defaults to spec.master.tolerations.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// ServiceAccountName is the name of the ServiceAccount used to run the seed job agent pod.
+	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling
+	// the seed job agent image.
+	// Defaults to spec.master.imagePullSecrets.
+	// More info: https://kubernetes.io/docs/concepts/containers/images#specifying-imagepullsecrets-on-a-pod
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Volumes is the list of additional volumes of the seed job agent pod.
+	// More info: https://kubernetes.io/docs/concepts/storage/volumes
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts is the list of additional volumes mounted into the seed job agent container.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
 }
 
 // SeedJob defines configuration for seed job
 // More info: https://github.com/jenkinsci/kubernetes-operator/blob/master/docs/getting-started.md#configure-seed-jobs-and-pipelines.
 type SeedJob struct {
 	// ID is the unique seed job name
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	ID string `json:"id,omitempty"`
 
 	// CredentialID is the Kubernetes secret name which stores repository access credentials
@@ -563,12 +1503,17 @@ type SeedJob struct {
 	Targets string `json:"targets,omitempty"`
 
 	// RepositoryBranch is the repository branch where are seed job definitions
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	RepositoryBranch string `json:"repositoryBranch,omitempty"`
 
 	// RepositoryURL is the repository access URL. Can be SSH or HTTPS.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	RepositoryURL string `json:"repositoryUrl,omitempty"`
 
 	// JenkinsCredentialType is the https://jenkinsci.github.io/kubernetes-credentials-provider-plugin/ credential type
+	// +kubebuilder:validation:Enum="";basicSSHUserPrivateKey;usernamePassword;external;gitlabApiToken;bitbucketAppPassword;bitbucketApiToken;githubApp
 	// +optional
 	JenkinsCredentialType JenkinsCredentialType `json:"credentialType,omitempty"`
 
@@ -584,14 +1529,45 @@ type SeedJob struct {
 	// +optional
 	GitHubPushTrigger bool `json:"githubPushTrigger"`
 
+	// BitbucketServerURL is the Bitbucket Server URL, for example https://bitbucket.example.com, Bitbucket Cloud
+	// is used when it's not set, it's used only with Bitbucket credential types
+	// +optional
+	BitbucketServerURL string `json:"bitbucketServerUrl,omitempty"`
+
+	// BitbucketManageHooks lets Bitbucket Branch Source plugin register web hooks of jobs created by the seed job,
+	// it's used only with Bitbucket credential types
+	// +optional
+	BitbucketManageHooks bool `json:"bitbucketManageHooks,omitempty"`
+
+	// GitLabPushTrigger is used for GitLab web hooks, it requires GitLabServerURL
+	// +optional
+	GitLabPushTrigger bool `json:"gitlabPushTrigger,omitempty"`
+
+	// GitLabServerURL is the GitLab server URL, for example https://gitlab.com, the seed job uses it as GitLab
+	// connection authenticated with the gitlabApiToken credential
+	// +optional
+	GitLabServerURL string `json:"gitlabServerUrl,omitempty"`
+
+	// GitLabRegisterWebhook registers the push web hook of the seed job in the GitLab project, it requires
+	// GitLabPushTrigger
+	// +optional
+	GitLabRegisterWebhook bool `json:"gitlabRegisterWebhook,omitempty"`
+
 	// BuildPeriodically is setting for scheduled trigger
+	// +kubebuilder:validation:Pattern=`^(|@(yearly|annually|monthly|weekly|daily|midnight|hourly)|[0-9A-Za-z*?/,()-]+(\s+[0-9A-Za-z*?/,()-]+){4,5})$`
 	// +optional
 	BuildPeriodically string `json:"buildPeriodically"`
 
 	// PollSCM is setting for polling changes in SCM
+	// +kubebuilder:validation:Pattern=`^(|@(yearly|annually|monthly|weekly|daily|midnight|hourly)|[0-9A-Za-z*?/,()-]+(\s+[0-9A-Za-z*?/,()-]+){4,5})$`
 	// +optional
 	PollSCM string `json:"pollSCM"`
 
+	// WebhookOnly disables building the seed job by the operator after its configuration change, the seed job is
+	// built only by the web hook triggers
+	// +optional
+	WebhookOnly bool `json:"webhookOnly,omitempty"`
+
 	// IgnoreMissingFiles is setting for Job DSL API plugin to ignore files that miss
 	// +optional
 	IgnoreMissingFiles bool `json:"ignoreMissingFiles"`
@@ -607,6 +1583,49 @@ type SeedJob struct {
 	// UnstableOnDeprecation is setting for Job DSL API plugin that sets build status as unstable if build using deprecated features
 	// +optional
 	UnstableOnDeprecation bool `json:"unstableOnDeprecation"`
+
+	// Multibranch creates multibranch pipeline pointed at the repository instead of running Job DSL scripts from Targets
+	// +optional
+	Multibranch *MultibranchPipeline `json:"multibranch,omitempty"`
+}
+
+// MultibranchPipeline defines multibranch pipeline created by the seed job.
+type MultibranchPipeline struct {
+	// ScriptPath is the path of the pipeline script in the repository
+	// Defaults to Jenkinsfile.
+	// +optional
+	ScriptPath string `json:"scriptPath,omitempty"`
+
+	// DiscoverTags enables discovery of tags
+	// +optional
+	DiscoverTags bool `json:"discoverTags,omitempty"`
+
+	// DiscoverPullRequests enables discovery of pull requests from the origin repository, it's supported only for
+	// GitHub repositories and requires github-branch-source plugin
+	// +optional
+	DiscoverPullRequests bool `json:"discoverPullRequests,omitempty"`
+
+	// IncludeBranches is the space separated list of wildcards of branch names which are built
+	// Defaults to *.
+	// +optional
+	IncludeBranches string `json:"includeBranches,omitempty"`
+
+	// ExcludeBranches is the space separated list of wildcards of branch names which are not built
+	// +optional
+	ExcludeBranches string `json:"excludeBranches,omitempty"`
+
+	// ScanInterval is the interval of periodic repository scan, for example 1h or 1d
+	// +kubebuilder:validation:Pattern=`^([1-9][0-9]*[mhd])?$`
+	// +optional
+	ScanInterval string `json:"scanInterval,omitempty"`
+
+	// OrphanedItemDaysToKeep is the number of days to keep jobs of removed branches
+	// +optional
+	OrphanedItemDaysToKeep int32 `json:"orphanedItemDaysToKeep,omitempty"`
+
+	// OrphanedItemNumToKeep is the number of jobs of removed branches to keep
+	// +optional
+	OrphanedItemNumToKeep int32 `json:"orphanedItemNumToKeep,omitempty"`
 }
 
 // Handler defines a specific action that should be taken.
@@ -618,17 +1637,113 @@ type Handler struct {
 // Backup defines configuration of Jenkins backup.
 type Backup struct {
 	// ContainerName is the container name responsible for backup operation
+	// +optional
 	ContainerName string `json:"containerName"`
 
 	// Action defines action which performs backup in backup container sidecar
+	// +optional
 	Action Handler `json:"action"`
 
+	// Destinations defines list of places where every backup is stored, each scheduled backup is made in all of them,
+	// can't be used together with containerName and action
+	// +optional
+	Destinations []BackupDestination `json:"destinations,omitempty"`
+
 	// Interval tells how often make backup in seconds
 	// Defaults to 30.
+	// +optional
 	Interval uint64 `json:"interval"`
 
 	// MakeBackupBeforePodDeletion tells operator to make backup before Jenkins master pod deletion
 	MakeBackupBeforePodDeletion bool `json:"makeBackupBeforePodDeletion"`
+
+	// Consistent tells operator to put Jenkins into the quiet mode for the duration of the backup action,
+	// so no new builds are started while JENKINS_HOME is archived
+	// +optional
+	Consistent bool `json:"consistent,omitempty"`
+
+	// VeleroHooks tells operator to add Velero pre and post backup hook annotations to the Jenkins master pod,
+	// Jenkins is put into the quiet mode for the duration of the Velero backup
+	// +optional
+	VeleroHooks bool `json:"veleroHooks,omitempty"`
+
+	// Hooks defines Groovy scripts executed in Jenkins before and after the backup
+	// +optional
+	Hooks BackupHooks `json:"hooks,omitempty"`
+
+	// Include is the list of glob patterns relative to JENKINS_HOME which are archived,
+	// it's passed to the backup action as BACKUP_INCLUDE environment variable (one pattern per line)
+	// Defaults to jobs directory.
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude is the list of glob patterns relative to JENKINS_HOME which are skipped when archiving, for example
+	// workspaces, fingerprints or war cache, it's passed to the backup action as BACKUP_EXCLUDE environment variable
+	// (one pattern per line)
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Compression is the compression algorithm of the backup archive: gzip, zstd or none,
+	// it's passed to the backup action as BACKUP_COMPRESSION environment variable
+	// Defaults to gzip.
+	// +kubebuilder:validation:Enum=gzip;zstd;none
+	// +optional
+	Compression BackupCompression `json:"compression,omitempty"`
+
+	// CompressionLevel is the compression level of the selected algorithm, 1-9 for gzip and 1-19 for zstd,
+	// it's passed to the backup action as BACKUP_COMPRESSION_LEVEL environment variable
+	// Defaults to the algorithm default level.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=19
+	// +optional
+	CompressionLevel int32 `json:"compressionLevel,omitempty"`
+}
+
+// BackupCompression defines the compression algorithm of the backup archive.
+type BackupCompression string
+
+const (
+	// BackupCompressionGzip - archive compressed with gzip, the default
+	BackupCompressionGzip BackupCompression = "gzip"
+
+	// BackupCompressionZstd - archive compressed with zstd
+	BackupCompressionZstd BackupCompression = "zstd"
+
+	// BackupCompressionNone - archive not compressed
+	BackupCompressionNone BackupCompression = "none"
+)
+
+// BackupHooks defines Groovy scripts executed in Jenkins before and after the backup, scripts are executed in
+// alphabetical order of ConfigMap keys with .groovy extension and have access to backupNumber variable,
+// post backup scripts have also access to backupSucceeded variable.
+type BackupHooks struct {
+	// PreBackup is the list of ConfigMaps with Groovy scripts executed before the backup, the backup is not made
+	// when any of them fails
+	// +optional
+	PreBackup []ConfigMapRef `json:"preBackup,omitempty"`
+
+	// PostBackup is the list of ConfigMaps with Groovy scripts executed after the backup
+	// +optional
+	PostBackup []ConfigMapRef `json:"postBackup,omitempty"`
+}
+
+// BackupDestination defines a single place where Jenkins backup is stored.
+type BackupDestination struct {
+	// Name is the unique name of the backup destination, it's used to report backup results in the status
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ContainerName is the container name responsible for backup operation for this destination
+	// +kubebuilder:validation:MinLength=1
+	ContainerName string `json:"containerName"`
+
+	// Action defines action which performs backup in backup container sidecar
+	Action Handler `json:"action"`
+
+	// BackupCount tells how many recent backups should be kept in this destination,
+	// it's passed to the backup action as BACKUP_COUNT environment variable
+	// +optional
+	BackupCount uint64 `json:"backupCount,omitempty"`
 }
 
 // Restore defines configuration of Jenkins backup restore operation.
@@ -642,6 +1757,88 @@ type Restore struct {
 	// RecoveryOnce if want to restore specific backup set this field and then Jenkins will be restarted and desired backup will be restored
 	// +optional
 	RecoveryOnce uint64 `json:"recoveryOnce,omitempty"`
+
+	// DryRunOnce if want to review changes of a specific backup before restoring it set this field and then the list
+	// of files, jobs and plugins which would be changed will be stored in the restore dry run ConfigMap
+	// +optional
+	DryRunOnce uint64 `json:"dryRunOnce,omitempty"`
+}
+
+// BackupDestinationStatus defines the observed state of a single backup destination.
+type BackupDestinationStatus struct {
+	// Name is the name of the backup destination
+	Name string `json:"name"`
+
+	// LastBackup is the latest backup number successfully stored in this destination
+	// +optional
+	LastBackup uint64 `json:"lastBackup,omitempty"`
+
+	// LastBackupTime is a time when the latest backup has been successfully stored in this destination
+	// +optional
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+
+	// Error is the error message of the latest failed backup in this destination
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// SeedJobStatus defines the latest build result of the seed job.
+type SeedJobStatus struct {
+	// ID is the seed job id
+	ID string `json:"id"`
+
+	// LastBuildNumber is the number of the latest seed job build
+	// +optional
+	LastBuildNumber int64 `json:"lastBuildNumber,omitempty"`
+
+	// LastBuildResult is the result of the latest seed job build, for example SUCCESS or FAILURE,
+	// it's empty while the build is running
+	// +optional
+	LastBuildResult string `json:"lastBuildResult,omitempty"`
+
+	// LastBuildTime is a time when the latest seed job build has been started
+	// +optional
+	LastBuildTime *metav1.Time `json:"lastBuildTime,omitempty"`
+
+	// LastBuildConsoleURL is the link to the console output of the latest seed job build
+	// +optional
+	LastBuildConsoleURL string `json:"lastBuildConsoleUrl,omitempty"`
+}
+
+// ConfigurationAsCodeRemoteURLStatus defines the state of Configuration as Code YAML file from remote URL applied in Jenkins.
+type ConfigurationAsCodeRemoteURLStatus struct {
+	// URL is the address of the YAML file
+	URL string `json:"url"`
+
+	// ContentHash is the SHA-256 hash of the YAML file applied in Jenkins
+	// +optional
+	ContentHash string `json:"contentHash,omitempty"`
+
+	// Hash is the hash of the remote URL configuration and credentials
+	// +optional
+	Hash string `json:"hash,omitempty"`
+
+	// LastPollTime is a time when the URL has been checked for the changed content
+	// +optional
+	LastPollTime *metav1.Time `json:"lastPollTime,omitempty"`
+}
+
+// ConfigurationAsCodeGitRepositoryStatus defines the state of Configuration as Code Git repository applied in Jenkins.
+type ConfigurationAsCodeGitRepositoryStatus struct {
+	// Name is the name of the repository
+	Name string `json:"name"`
+
+	// Commit is the resolved commit SHA applied in Jenkins
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// Hash is the hash of the repository configuration and credentials
+	// +optional
+	Hash string `json:"hash,omitempty"`
+
+	// LastPollTime is a time when the repository has been checked for new commits
+	// +optional
+	LastPollTime *metav1.Time `json:"lastPollTime,omitempty"`
 }
 
 // AppliedGroovyScript is the applied groovy script in Jenkins by the operator.
@@ -664,12 +1861,74 @@ type SecretRef struct {
 // ConfigMapRef is reference to Kubernetes ConfigMap.
 type ConfigMapRef struct {
 	Name string `json:"name"`
+
+	// Priority defines the order in which ConfigMaps are applied, ConfigMaps with lower priority are applied first
+	// ConfigMaps with the same priority are applied in the order of declaration.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// DependsOn is the list of ConfigMap names from the same configurations list which must be applied
+	// before this ConfigMap
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Execution overrides the timeout and retry policy of the customization for scripts from this ConfigMap
+	// +optional
+	Execution *ScriptExecution `json:"execution,omitempty"`
 }
 
 // Customization defines configuration of Jenkins customization.
 type Customization struct {
 	Secret         SecretRef      `json:"secret"`
 	Configurations []ConfigMapRef `json:"configurations"`
+
+	// Execution defines the timeout and retry policy of script execution
+	// +optional
+	Execution *ScriptExecution `json:"execution,omitempty"`
+
+	// Template enables rendering of ConfigMaps as Go templates before they are applied
+	// +optional
+	Template *CustomizationTemplate `json:"template,omitempty"`
+}
+
+// CustomizationTemplate defines values available in ConfigMaps rendered as Go templates.
+type CustomizationTemplate struct {
+	// ValuesFrom is the list of Secrets and ConfigMaps which keys are available as .Values in templates,
+	// later sources override keys from earlier ones
+	// +optional
+	ValuesFrom []TemplateValuesSource `json:"valuesFrom,omitempty"`
+
+	// Values are available as .Values in templates, they override keys from ValuesFrom
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// TemplateValuesSource defines Secret or ConfigMap with template values.
+type TemplateValuesSource struct {
+	// SecretName is the name of the Secret with template values
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// ConfigMapName is the name of the ConfigMap with template values
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// ScriptExecution defines the timeout and retry policy of groovy script execution through the Jenkins API
+type ScriptExecution struct {
+	// Timeout is the maximum duration of a single script execution request
+	// Defaults to no timeout.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries is the number of retries after a transient Jenkins API error, like 503 Service Unavailable
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// RetryInterval is the duration between retries
+	// Defaults to 5s.
+	// +optional
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
 }
 
 // GroovyScripts defines configuration of Jenkins customization via groovy scripts.
@@ -680,4 +1939,77 @@ type GroovyScripts struct {
 // ConfigurationAsCode defines configuration of Jenkins customization via Configuration as Code Jenkins plugin.
 type ConfigurationAsCode struct {
 	Customization `json:",inline"`
+
+	// ReloadStrategy defines how the changed configuration is applied: reload or restart
+	// Defaults to reload.
+	// +optional
+	ReloadStrategy ConfigurationAsCodeReloadStrategy `json:"reloadStrategy,omitempty"`
+
+	// GitRepositories is the list of Git repositories with Configuration as Code YAML files, they are applied after
+	// the configurations from ConfigMaps
+	// +optional
+	GitRepositories []ConfigurationAsCodeGitRepository `json:"gitRepositories,omitempty"`
+
+	// RemoteURLs is the list of Configuration as Code YAML files served over HTTP(S), they are applied after
+	// the configurations from ConfigMaps and Git repositories
+	// +optional
+	RemoteURLs []ConfigurationAsCodeRemoteURL `json:"remoteURLs,omitempty"`
+}
+
+// ConfigurationAsCodeRemoteURL defines Configuration as Code YAML file served over HTTP(S).
+type ConfigurationAsCodeRemoteURL struct {
+	// URL is the address of the YAML file, for example https://config.example.com/jenkins/jenkins.yaml
+	URL string `json:"url"`
+
+	// Credentials is the Kubernetes secret with username and password keys for basic authentication
+	// or token key for bearer token authentication
+	// +optional
+	Credentials SecretRef `json:"credentials,omitempty"`
+
+	// PollInterval is the interval of checking the URL for the changed content
+	// Defaults to 5m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// ConfigurationAsCodeReloadStrategy defines how the changed Configuration as Code is applied
+type ConfigurationAsCodeReloadStrategy string
+
+const (
+	// ReloadConfigurationAsCodeReloadStrategy applies changed YAML files in place with the Configuration as Code plugin
+	ReloadConfigurationAsCodeReloadStrategy ConfigurationAsCodeReloadStrategy = "reload"
+	// RestartConfigurationAsCodeReloadStrategy restarts the Jenkins master pod when already applied YAML files change,
+	// so the whole configuration is applied to Jenkins from scratch
+	RestartConfigurationAsCodeReloadStrategy ConfigurationAsCodeReloadStrategy = "restart"
+)
+
+// ConfigurationAsCodeGitRepository defines Git repository with Configuration as Code YAML files.
+type ConfigurationAsCodeGitRepository struct {
+	// Name is the unique name of the repository
+	Name string `json:"name"`
+
+	// URL is the repository URL, for example https://github.com/jenkinsci/kubernetes-operator.git
+	// or git@github.com:jenkinsci/kubernetes-operator.git
+	URL string `json:"url"`
+
+	// Ref is the branch, tag or commit to check out
+	// Defaults to master.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Path is the directory in the repository with YAML files, all *.yaml and *.yml files from this directory
+	// and its subdirectories are applied in alphabetical order
+	// Defaults to the repository root directory.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Credentials is the Kubernetes secret with repository access credentials, it contains username and password
+	// keys for HTTPS repositories or privateKey key for SSH repositories
+	// +optional
+	Credentials SecretRef `json:"credentials,omitempty"`
+
+	// PollInterval is the interval of checking the repository for new commits
+	// Defaults to 5m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
 }
@@ -0,0 +1,192 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsAgentPodTemplateSpec defines the desired state of JenkinsAgentPodTemplate
+type JenkinsAgentPodTemplateSpec struct {
+	// JenkinsRef is the reference to the Jenkins CR in the same namespace which the pod template is applied to
+	JenkinsRef JenkinsRef `json:"jenkinsRef"`
+
+	// Labels are Jenkins labels which select the pod template in jobs, e.g. agent { label 'maven' }
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// Containers are containers of the agent pod, the container named jnlp replaces the default inbound agent container
+	// +optional
+	Containers []AgentContainer `json:"containers,omitempty"`
+
+	// Volumes are volumes mounted in all containers of the agent pod
+	// +optional
+	Volumes []AgentVolume `json:"volumes,omitempty"`
+
+	// ServiceAccountName is the name of the ServiceAccount used to run the agent pod
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// OS is the operating system of nodes which run the agent pod, windows agent pods get the Windows inbound agent
+	// container and their containers sleep in PowerShell by default
+	// +optional
+	OS AgentOS `json:"os,omitempty"`
+
+	// Arch is the CPU architecture of nodes which run the agent pod, e.g. amd64 or arm64
+	// +optional
+	Arch string `json:"arch,omitempty"`
+
+	// NodeSelector must match a node's labels for the agent pod to be scheduled on that node, the OS and Arch
+	// are added as kubernetes.io/os and kubernetes.io/arch
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// IdleMinutes is the number of minutes the agent pod is kept after the build to be reused by next builds
+	// +optional
+	IdleMinutes int `json:"idleMinutes,omitempty"`
+
+	// InstanceCap is the maximum number of agent pods created from the pod template, unlimited when not set
+	// +optional
+	InstanceCap int `json:"instanceCap,omitempty"`
+
+	// Yaml is the raw agent pod definition in YAML, it's combined with the other fields of the pod template
+	// according to the YamlMergeStrategy
+	// +optional
+	Yaml string `json:"yaml,omitempty"`
+
+	// YamlMergeStrategy defines how the Yaml is combined with the pod template inherited by the agent pod
+	// +optional
+	YamlMergeStrategy YamlMergeStrategy `json:"yamlMergeStrategy,omitempty"`
+}
+
+// JenkinsRef is the reference to the Jenkins CR
+type JenkinsRef struct {
+	Name string `json:"name"`
+}
+
+// YamlMergeStrategy defines how the raw YAML of the pod template is combined with the inherited pod template
+type YamlMergeStrategy string
+
+const (
+	// YamlMergeStrategyOverride replaces the inherited YAML with the YAML of the pod template
+	YamlMergeStrategyOverride YamlMergeStrategy = "override"
+	// YamlMergeStrategyMerge merges the YAML of the pod template into the inherited YAML
+	YamlMergeStrategyMerge YamlMergeStrategy = "merge"
+)
+
+// AgentOS is the operating system of nodes which run the agent pod
+type AgentOS string
+
+const (
+	// AgentOSLinux runs the agent pod on Linux nodes
+	AgentOSLinux AgentOS = "linux"
+	// AgentOSWindows runs the agent pod on Windows nodes
+	AgentOSWindows AgentOS = "windows"
+)
+
+// AgentContainer defines the container of the agent pod
+type AgentContainer struct {
+	// Name of the container specified as a DNS_LABEL
+	Name string `json:"name"`
+
+	// Image is the Docker image name
+	Image string `json:"image"`
+
+	// AlwaysPullImage pulls the image before every start of the container
+	// +optional
+	AlwaysPullImage bool `json:"alwaysPullImage,omitempty"`
+
+	// Command is the shell command which overrides the entrypoint of the image, e.g. sleep
+	// +optional
+	Command string `json:"command,omitempty"`
+
+	// Args are the shell arguments of the command, e.g. 99999
+	// +optional
+	Args string `json:"args,omitempty"`
+
+	// WorkingDir is the container's working directory
+	// +optional
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// TTYEnabled allocates a TTY for the container, it's required to keep the container with the cat command running
+	// +optional
+	TTYEnabled bool `json:"ttyEnabled,omitempty"`
+
+	// Env is the list of environment variables set in the container, only value and valueFrom.secretKeyRef are supported
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Resources are CPU and memory requests and limits of the container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// AgentVolume defines the volume mounted in the containers of the agent pod, exactly one source has to be set
+type AgentVolume struct {
+	// MountPath is the path within the containers at which the volume should be mounted
+	MountPath string `json:"mountPath"`
+
+	// EmptyDir is the empty directory which shares the lifetime of the agent pod
+	// +optional
+	EmptyDir *AgentEmptyDirVolume `json:"emptyDir,omitempty"`
+
+	// Secret is the Secret in the namespace of the agent pod
+	// +optional
+	Secret *AgentSecretVolume `json:"secret,omitempty"`
+
+	// ConfigMap is the ConfigMap in the namespace of the agent pod
+	// +optional
+	ConfigMap *AgentConfigMapVolume `json:"configMap,omitempty"`
+
+	// PersistentVolumeClaim is the PersistentVolumeClaim in the namespace of the agent pod
+	// +optional
+	PersistentVolumeClaim *AgentPersistentVolumeClaimVolume `json:"persistentVolumeClaim,omitempty"`
+}
+
+// AgentEmptyDirVolume defines the empty directory volume of the agent pod
+type AgentEmptyDirVolume struct {
+	// Memory uses tmpfs instead of the node's disk
+	// +optional
+	Memory bool `json:"memory,omitempty"`
+}
+
+// AgentSecretVolume defines the Secret volume of the agent pod
+type AgentSecretVolume struct {
+	SecretName string `json:"secretName"`
+}
+
+// AgentConfigMapVolume defines the ConfigMap volume of the agent pod
+type AgentConfigMapVolume struct {
+	ConfigMapName string `json:"configMapName"`
+}
+
+// AgentPersistentVolumeClaimVolume defines the PersistentVolumeClaim volume of the agent pod
+type AgentPersistentVolumeClaimVolume struct {
+	ClaimName string `json:"claimName"`
+
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsAgentPodTemplate is the Schema for the jenkinsagentpodtemplates API, it describes the kubernetes-plugin pod
+// template which the operator applies to the bound Jenkins
+// +kubebuilder:resource:path=jenkinsagentpodtemplates,scope=Namespaced,shortName=jkapt,categories=all
+type JenkinsAgentPodTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              JenkinsAgentPodTemplateSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsAgentPodTemplateList contains a list of JenkinsAgentPodTemplate
+type JenkinsAgentPodTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JenkinsAgentPodTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JenkinsAgentPodTemplate{}, &JenkinsAgentPodTemplateList{})
+}
@@ -36,7 +36,7 @@ type JenkinsImageStatus struct {
 
 // JenkinsImage is the Schema for the jenkinsimages API
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:path=jenkinsimages,scope=Namespaced
+// +kubebuilder:resource:path=jenkinsimages,scope=Namespaced,shortName=jki,categories=all
 type JenkinsImage struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
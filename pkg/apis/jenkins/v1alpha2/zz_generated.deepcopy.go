@@ -5,306 +5,264 @@
 package v1alpha2
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AppliedGroovyScript) DeepCopyInto(out *AppliedGroovyScript) {
+func (in *AWS) DeepCopyInto(out *AWS) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedGroovyScript.
-func (in *AppliedGroovyScript) DeepCopy() *AppliedGroovyScript {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWS.
+func (in *AWS) DeepCopy() *AWS {
 	if in == nil {
 		return nil
 	}
-	out := new(AppliedGroovyScript)
+	out := new(AWS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Backup) DeepCopyInto(out *Backup) {
+func (in *AdminCredentialRotation) DeepCopyInto(out *AdminCredentialRotation) {
 	*out = *in
-	in.Action.DeepCopyInto(&out.Action)
+	out.Interval = in.Interval
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backup.
-func (in *Backup) DeepCopy() *Backup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminCredentialRotation.
+func (in *AdminCredentialRotation) DeepCopy() *AdminCredentialRotation {
 	if in == nil {
 		return nil
 	}
-	out := new(Backup)
+	out := new(AdminCredentialRotation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
+func (in *AgentConfigMapVolume) DeepCopyInto(out *AgentConfigMapVolume) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapRef.
-func (in *ConfigMapRef) DeepCopy() *ConfigMapRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentConfigMapVolume.
+func (in *AgentConfigMapVolume) DeepCopy() *AgentConfigMapVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(ConfigMapRef)
+	out := new(AgentConfigMapVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConfigurationAsCode) DeepCopyInto(out *ConfigurationAsCode) {
+func (in *AgentContainer) DeepCopyInto(out *AgentContainer) {
 	*out = *in
-	in.Customization.DeepCopyInto(&out.Customization)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationAsCode.
-func (in *ConfigurationAsCode) DeepCopy() *ConfigurationAsCode {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentContainer.
+func (in *AgentContainer) DeepCopy() *AgentContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(ConfigurationAsCode)
+	out := new(AgentContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Container) DeepCopyInto(out *Container) {
+func (in *AgentEmptyDirVolume) DeepCopyInto(out *AgentEmptyDirVolume) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Command != nil {
-		in, out := &in.Command, &out.Command
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]v1.ContainerPort, len(*in))
-		copy(*out, *in)
-	}
-	if in.EnvFrom != nil {
-		in, out := &in.EnvFrom, &out.EnvFrom
-		*out = make([]v1.EnvFromSource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LivenessProbe != nil {
-		in, out := &in.LivenessProbe, &out.LivenessProbe
-		*out = new(v1.Probe)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ReadinessProbe != nil {
-		in, out := &in.ReadinessProbe, &out.ReadinessProbe
-		*out = new(v1.Probe)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Lifecycle != nil {
-		in, out := &in.Lifecycle, &out.Lifecycle
-		*out = new(v1.Lifecycle)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.SecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Container.
-func (in *Container) DeepCopy() *Container {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEmptyDirVolume.
+func (in *AgentEmptyDirVolume) DeepCopy() *AgentEmptyDirVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(Container)
+	out := new(AgentEmptyDirVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Customization) DeepCopyInto(out *Customization) {
+func (in *AgentPersistentVolumeClaimVolume) DeepCopyInto(out *AgentPersistentVolumeClaimVolume) {
 	*out = *in
-	out.Secret = in.Secret
-	if in.Configurations != nil {
-		in, out := &in.Configurations, &out.Configurations
-		*out = make([]ConfigMapRef, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Customization.
-func (in *Customization) DeepCopy() *Customization {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPersistentVolumeClaimVolume.
+func (in *AgentPersistentVolumeClaimVolume) DeepCopy() *AgentPersistentVolumeClaimVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(Customization)
+	out := new(AgentPersistentVolumeClaimVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroovyScripts) DeepCopyInto(out *GroovyScripts) {
+func (in *AgentSecretVolume) DeepCopyInto(out *AgentSecretVolume) {
 	*out = *in
-	in.Customization.DeepCopyInto(&out.Customization)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroovyScripts.
-func (in *GroovyScripts) DeepCopy() *GroovyScripts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSecretVolume.
+func (in *AgentSecretVolume) DeepCopy() *AgentSecretVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(GroovyScripts)
+	out := new(AgentSecretVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Handler) DeepCopyInto(out *Handler) {
+func (in *AgentVolume) DeepCopyInto(out *AgentVolume) {
 	*out = *in
-	if in.Exec != nil {
-		in, out := &in.Exec, &out.Exec
-		*out = new(v1.ExecAction)
-		(*in).DeepCopyInto(*out)
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(AgentEmptyDirVolume)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(AgentSecretVolume)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(AgentConfigMapVolume)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(AgentPersistentVolumeClaimVolume)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Handler.
-func (in *Handler) DeepCopy() *Handler {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentVolume.
+func (in *AgentVolume) DeepCopy() *AgentVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(Handler)
+	out := new(AgentVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Image) DeepCopyInto(out *Image) {
+func (in *Agents) DeepCopyInto(out *Agents) {
 	*out = *in
+	if in.KubernetesCloud != nil {
+		in, out := &in.KubernetesCloud, &out.KubernetesCloud
+		*out = new(KubernetesCloud)
+		**out = **in
+	}
+	if in.Static != nil {
+		in, out := &in.Static, &out.Static
+		*out = make([]StaticAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Image.
-func (in *Image) DeepCopy() *Image {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Agents.
+func (in *Agents) DeepCopy() *Agents {
 	if in == nil {
 		return nil
 	}
-	out := new(Image)
+	out := new(Agents)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Jenkins) DeepCopyInto(out *Jenkins) {
+func (in *AppliedGroovyScript) DeepCopyInto(out *AppliedGroovyScript) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Jenkins.
-func (in *Jenkins) DeepCopy() *Jenkins {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedGroovyScript.
+func (in *AppliedGroovyScript) DeepCopy() *AppliedGroovyScript {
 	if in == nil {
 		return nil
 	}
-	out := new(Jenkins)
+	out := new(AppliedGroovyScript)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Jenkins) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsAPISettings) DeepCopyInto(out *JenkinsAPISettings) {
+func (in *AuthProxy) DeepCopyInto(out *AuthProxy) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]AuthProxyHeader, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAPISettings.
-func (in *JenkinsAPISettings) DeepCopy() *JenkinsAPISettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthProxy.
+func (in *AuthProxy) DeepCopy() *AuthProxy {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsAPISettings)
+	out := new(AuthProxy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsImage) DeepCopyInto(out *JenkinsImage) {
+func (in *AuthProxyHeader) DeepCopyInto(out *AuthProxyHeader) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.ValueSecretKeySelector = in.ValueSecretKeySelector
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsImage.
-func (in *JenkinsImage) DeepCopy() *JenkinsImage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthProxyHeader.
+func (in *AuthProxyHeader) DeepCopy() *AuthProxyHeader {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsImage)
+	out := new(AuthProxyHeader)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JenkinsImage) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsImageList) DeepCopyInto(out *JenkinsImageList) {
+func (in *Authorization) DeepCopyInto(out *Authorization) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]JenkinsImage, len(*in))
+	if in.GlobalRoles != nil {
+		in, out := &in.GlobalRoles, &out.GlobalRoles
+		*out = make([]AuthorizationRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProjectRoles != nil {
+		in, out := &in.ProjectRoles, &out.ProjectRoles
+		*out = make([]AuthorizationRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -312,364 +270,1647 @@ func (in *JenkinsImageList) DeepCopyInto(out *JenkinsImageList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsImageList.
-func (in *JenkinsImageList) DeepCopy() *JenkinsImageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authorization.
+func (in *Authorization) DeepCopy() *Authorization {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsImageList)
+	out := new(Authorization)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JenkinsImageList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsImageSpec) DeepCopyInto(out *JenkinsImageSpec) {
+func (in *AuthorizationRole) DeepCopyInto(out *AuthorizationRole) {
 	*out = *in
-	out.BaseImage = in.BaseImage
-	if in.Plugins != nil {
-		in, out := &in.Plugins, &out.Plugins
-		*out = make([]JenkinsPlugin, len(*in))
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsImageSpec.
-func (in *JenkinsImageSpec) DeepCopy() *JenkinsImageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationRole.
+func (in *AuthorizationRole) DeepCopy() *AuthorizationRole {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsImageSpec)
+	out := new(AuthorizationRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsImageStatus) DeepCopyInto(out *JenkinsImageStatus) {
+func (in *Backup) DeepCopyInto(out *Backup) {
 	*out = *in
-	if in.InstalledPlugins != nil {
-		in, out := &in.InstalledPlugins, &out.InstalledPlugins
-		*out = make([]JenkinsPlugin, len(*in))
+	in.Action.DeepCopyInto(&out.Action)
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]BackupDestination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Hooks.DeepCopyInto(&out.Hooks)
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsImageStatus.
-func (in *JenkinsImageStatus) DeepCopy() *JenkinsImageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backup.
+func (in *Backup) DeepCopy() *Backup {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsImageStatus)
+	out := new(Backup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsList) DeepCopyInto(out *JenkinsList) {
+func (in *BackupDestination) DeepCopyInto(out *BackupDestination) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Jenkins, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.Action.DeepCopyInto(&out.Action)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsList.
-func (in *JenkinsList) DeepCopy() *JenkinsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestination.
+func (in *BackupDestination) DeepCopy() *BackupDestination {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsList)
+	out := new(BackupDestination)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JenkinsList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupDestinationStatus) DeepCopyInto(out *BackupDestinationStatus) {
+	*out = *in
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
 	}
-	return nil
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestinationStatus.
+func (in *BackupDestinationStatus) DeepCopy() *BackupDestinationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupDestinationStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsMaster) DeepCopyInto(out *JenkinsMaster) {
+func (in *BackupHooks) DeepCopyInto(out *BackupHooks) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.PreBackup != nil {
+		in, out := &in.PreBackup, &out.PreBackup
+		*out = make([]ConfigMapRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.AnnotationsDeprecated != nil {
-		in, out := &in.AnnotationsDeprecated, &out.AnnotationsDeprecated
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.PostBackup != nil {
+		in, out := &in.PostBackup, &out.PostBackup
+		*out = make([]ConfigMapRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHooks.
+func (in *BackupHooks) DeepCopy() *BackupHooks {
+	if in == nil {
+		return nil
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	out := new(BackupHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEvents) DeepCopyInto(out *CloudEvents) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEvents.
+func (in *CloudEvents) DeepCopy() *CloudEvents {
+	if in == nil {
+		return nil
 	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.PodSecurityContext)
+	out := new(CloudEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Execution != nil {
+		in, out := &in.Execution, &out.Execution
+		*out = new(ScriptExecution)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]Container, len(*in))
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapRef.
+func (in *ConfigMapRef) DeepCopy() *ConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationAsCode) DeepCopyInto(out *ConfigurationAsCode) {
+	*out = *in
+	in.Customization.DeepCopyInto(&out.Customization)
+	if in.GitRepositories != nil {
+		in, out := &in.GitRepositories, &out.GitRepositories
+		*out = make([]ConfigurationAsCodeGitRepository, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+	if in.RemoteURLs != nil {
+		in, out := &in.RemoteURLs, &out.RemoteURLs
+		*out = make([]ConfigurationAsCodeRemoteURL, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationAsCode.
+func (in *ConfigurationAsCode) DeepCopy() *ConfigurationAsCode {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationAsCode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationAsCodeGitRepository) DeepCopyInto(out *ConfigurationAsCodeGitRepository) {
+	*out = *in
+	out.Credentials = in.Credentials
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationAsCodeGitRepository.
+func (in *ConfigurationAsCodeGitRepository) DeepCopy() *ConfigurationAsCodeGitRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationAsCodeGitRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationAsCodeGitRepositoryStatus) DeepCopyInto(out *ConfigurationAsCodeGitRepositoryStatus) {
+	*out = *in
+	if in.LastPollTime != nil {
+		in, out := &in.LastPollTime, &out.LastPollTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationAsCodeGitRepositoryStatus.
+func (in *ConfigurationAsCodeGitRepositoryStatus) DeepCopy() *ConfigurationAsCodeGitRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationAsCodeGitRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationAsCodeRemoteURL) DeepCopyInto(out *ConfigurationAsCodeRemoteURL) {
+	*out = *in
+	out.Credentials = in.Credentials
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationAsCodeRemoteURL.
+func (in *ConfigurationAsCodeRemoteURL) DeepCopy() *ConfigurationAsCodeRemoteURL {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationAsCodeRemoteURL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationAsCodeRemoteURLStatus) DeepCopyInto(out *ConfigurationAsCodeRemoteURLStatus) {
+	*out = *in
+	if in.LastPollTime != nil {
+		in, out := &in.LastPollTime, &out.LastPollTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationAsCodeRemoteURLStatus.
+func (in *ConfigurationAsCodeRemoteURLStatus) DeepCopy() *ConfigurationAsCodeRemoteURLStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationAsCodeRemoteURLStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Container) DeepCopyInto(out *Container) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]corev1.ContainerPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.BasePlugins != nil {
-		in, out := &in.BasePlugins, &out.BasePlugins
-		*out = make([]Plugin, len(*in))
-		copy(*out, *in)
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Plugins != nil {
-		in, out := &in.Plugins, &out.Plugins
-		*out = make([]Plugin, len(*in))
-		copy(*out, *in)
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(corev1.Lifecycle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsMaster.
-func (in *JenkinsMaster) DeepCopy() *JenkinsMaster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Container.
+func (in *Container) DeepCopy() *Container {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsMaster)
+	out := new(Container)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsPlugin) DeepCopyInto(out *JenkinsPlugin) {
+func (in *CredentialsSync) DeepCopyInto(out *CredentialsSync) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsPlugin.
-func (in *JenkinsPlugin) DeepCopy() *JenkinsPlugin {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSync.
+func (in *CredentialsSync) DeepCopy() *CredentialsSync {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsPlugin)
+	out := new(CredentialsSync)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
+func (in *Customization) DeepCopyInto(out *Customization) {
 	*out = *in
-	in.Master.DeepCopyInto(&out.Master)
-	if in.SeedJobs != nil {
-		in, out := &in.SeedJobs, &out.SeedJobs
-		*out = make([]SeedJob, len(*in))
-		copy(*out, *in)
-	}
-	if in.Notifications != nil {
-		in, out := &in.Notifications, &out.Notifications
-		*out = make([]Notification, len(*in))
+	out.Secret = in.Secret
+	if in.Configurations != nil {
+		in, out := &in.Configurations, &out.Configurations
+		*out = make([]ConfigMapRef, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Service.DeepCopyInto(&out.Service)
-	in.SlaveService.DeepCopyInto(&out.SlaveService)
-	in.Backup.DeepCopyInto(&out.Backup)
-	in.Restore.DeepCopyInto(&out.Restore)
-	in.GroovyScripts.DeepCopyInto(&out.GroovyScripts)
-	in.ConfigurationAsCode.DeepCopyInto(&out.ConfigurationAsCode)
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]rbacv1.RoleRef, len(*in))
-		copy(*out, *in)
+	if in.Execution != nil {
+		in, out := &in.Execution, &out.Execution
+		*out = new(ScriptExecution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(CustomizationTemplate)
+		(*in).DeepCopyInto(*out)
 	}
-	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
-	out.JenkinsAPISettings = in.JenkinsAPISettings
-	out.SeedAgent = in.SeedAgent
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsSpec.
-func (in *JenkinsSpec) DeepCopy() *JenkinsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Customization.
+func (in *Customization) DeepCopy() *Customization {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsSpec)
+	out := new(Customization)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsStatus) DeepCopyInto(out *JenkinsStatus) {
+func (in *CustomizationTemplate) DeepCopyInto(out *CustomizationTemplate) {
 	*out = *in
-	if in.ProvisionStartTime != nil {
-		in, out := &in.ProvisionStartTime, &out.ProvisionStartTime
-		*out = (*in).DeepCopy()
-	}
-	if in.BaseConfigurationCompletedTime != nil {
-		in, out := &in.BaseConfigurationCompletedTime, &out.BaseConfigurationCompletedTime
-		*out = (*in).DeepCopy()
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]TemplateValuesSource, len(*in))
+		copy(*out, *in)
 	}
-	if in.UserConfigurationCompletedTime != nil {
-		in, out := &in.UserConfigurationCompletedTime, &out.UserConfigurationCompletedTime
-		*out = (*in).DeepCopy()
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.CreatedSeedJobs != nil {
-		in, out := &in.CreatedSeedJobs, &out.CreatedSeedJobs
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomizationTemplate.
+func (in *CustomizationTemplate) DeepCopy() *CustomizationTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomizationTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Discord) DeepCopyInto(out *Discord) {
+	*out = *in
+	out.WebHookURLSecretKeySelector = in.WebHookURLSecretKeySelector
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Discord.
+func (in *Discord) DeepCopy() *Discord {
+	if in == nil {
+		return nil
+	}
+	out := new(Discord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroovyScripts) DeepCopyInto(out *GroovyScripts) {
+	*out = *in
+	in.Customization.DeepCopyInto(&out.Customization)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroovyScripts.
+func (in *GroovyScripts) DeepCopy() *GroovyScripts {
+	if in == nil {
+		return nil
+	}
+	out := new(GroovyScripts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Handler) DeepCopyInto(out *Handler) {
+	*out = *in
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(corev1.ExecAction)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Handler.
+func (in *Handler) DeepCopy() *Handler {
+	if in == nil {
+		return nil
+	}
+	out := new(Handler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Image) DeepCopyInto(out *Image) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Image.
+func (in *Image) DeepCopy() *Image {
+	if in == nil {
+		return nil
+	}
+	out := new(Image)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstalledPlugin) DeepCopyInto(out *InstalledPlugin) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstalledPlugin.
+func (in *InstalledPlugin) DeepCopy() *InstalledPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(InstalledPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Jenkins) DeepCopyInto(out *Jenkins) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Jenkins.
+func (in *Jenkins) DeepCopy() *Jenkins {
+	if in == nil {
+		return nil
+	}
+	out := new(Jenkins)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Jenkins) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAPISettings) DeepCopyInto(out *JenkinsAPISettings) {
+	*out = *in
+	if in.TokenRotationInterval != nil {
+		in, out := &in.TokenRotationInterval, &out.TokenRotationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AuthProxy != nil {
+		in, out := &in.AuthProxy, &out.AuthProxy
+		*out = new(AuthProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.SSHCLI != nil {
+		in, out := &in.SSHCLI, &out.SSHCLI
+		*out = new(SSHCLI)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAPISettings.
+func (in *JenkinsAPISettings) DeepCopy() *JenkinsAPISettings {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAPISettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAgentPodTemplate) DeepCopyInto(out *JenkinsAgentPodTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAgentPodTemplate.
+func (in *JenkinsAgentPodTemplate) DeepCopy() *JenkinsAgentPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAgentPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsAgentPodTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAgentPodTemplateList) DeepCopyInto(out *JenkinsAgentPodTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JenkinsAgentPodTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAgentPodTemplateList.
+func (in *JenkinsAgentPodTemplateList) DeepCopy() *JenkinsAgentPodTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAgentPodTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsAgentPodTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAgentPodTemplateSpec) DeepCopyInto(out *JenkinsAgentPodTemplateSpec) {
+	*out = *in
+	out.JenkinsRef = in.JenkinsRef
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]AgentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]AgentVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAgentPodTemplateSpec.
+func (in *JenkinsAgentPodTemplateSpec) DeepCopy() *JenkinsAgentPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAgentPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsCondition) DeepCopyInto(out *JenkinsCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsCondition.
+func (in *JenkinsCondition) DeepCopy() *JenkinsCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsImage) DeepCopyInto(out *JenkinsImage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsImage.
+func (in *JenkinsImage) DeepCopy() *JenkinsImage {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsImage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsImageList) DeepCopyInto(out *JenkinsImageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JenkinsImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsImageList.
+func (in *JenkinsImageList) DeepCopy() *JenkinsImageList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsImageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsImageSpec) DeepCopyInto(out *JenkinsImageSpec) {
+	*out = *in
+	out.BaseImage = in.BaseImage
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]JenkinsPlugin, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsImageSpec.
+func (in *JenkinsImageSpec) DeepCopy() *JenkinsImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsImageStatus) DeepCopyInto(out *JenkinsImageStatus) {
+	*out = *in
+	if in.InstalledPlugins != nil {
+		in, out := &in.InstalledPlugins, &out.InstalledPlugins
+		*out = make([]JenkinsPlugin, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsImageStatus.
+func (in *JenkinsImageStatus) DeepCopy() *JenkinsImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsList) DeepCopyInto(out *JenkinsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Jenkins, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsList.
+func (in *JenkinsList) DeepCopy() *JenkinsList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsMaster) DeepCopyInto(out *JenkinsMaster) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AnnotationsDeprecated != nil {
+		in, out := &in.AnnotationsDeprecated, &out.AnnotationsDeprecated
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BasePlugins != nil {
+		in, out := &in.BasePlugins, &out.BasePlugins
+		*out = make([]Plugin, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]Plugin, len(*in))
+		copy(*out, *in)
+	}
+	if in.PluginsConfigMap != nil {
+		in, out := &in.PluginsConfigMap, &out.PluginsConfigMap
+		*out = new(PluginsConfigMap)
+		**out = **in
+	}
+	if in.PluginBundle != nil {
+		in, out := &in.PluginBundle, &out.PluginBundle
+		*out = new(PluginBundle)
+		**out = **in
+	}
+	if in.PluginCache != nil {
+		in, out := &in.PluginCache, &out.PluginCache
+		*out = new(PluginCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginSecurityWarnings != nil {
+		in, out := &in.PluginSecurityWarnings, &out.PluginSecurityWarnings
+		*out = new(PluginSecurityWarnings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginDependencyResolution != nil {
+		in, out := &in.PluginDependencyResolution, &out.PluginDependencyResolution
+		*out = new(PluginDependencyResolution)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsMaster.
+func (in *JenkinsMaster) DeepCopy() *JenkinsMaster {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsMaster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsPlugin) DeepCopyInto(out *JenkinsPlugin) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsPlugin.
+func (in *JenkinsPlugin) DeepCopy() *JenkinsPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsRef) DeepCopyInto(out *JenkinsRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsRef.
+func (in *JenkinsRef) DeepCopy() *JenkinsRef {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
+	*out = *in
+	in.Master.DeepCopyInto(&out.Master)
+	if in.SeedJobs != nil {
+		in, out := &in.SeedJobs, &out.SeedJobs
+		*out = make([]SeedJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]Notification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Service.DeepCopyInto(&out.Service)
+	in.SlaveService.DeepCopyInto(&out.SlaveService)
+	in.Backup.DeepCopyInto(&out.Backup)
+	in.Restore.DeepCopyInto(&out.Restore)
+	in.GroovyScripts.DeepCopyInto(&out.GroovyScripts)
+	in.ConfigurationAsCode.DeepCopyInto(&out.ConfigurationAsCode)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]rbacv1.RoleRef, len(*in))
+		copy(*out, *in)
+	}
+	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
+	in.JenkinsAPISettings.DeepCopyInto(&out.JenkinsAPISettings)
+	in.SeedAgent.DeepCopyInto(&out.SeedAgent)
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(Vault)
+		**out = **in
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWS)
+		**out = **in
+	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(Proxy)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScriptLogs != nil {
+		in, out := &in.ScriptLogs, &out.ScriptLogs
+		*out = new(ScriptLogs)
+		**out = **in
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(Security)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Agents != nil {
+		in, out := &in.Agents, &out.Agents
+		*out = new(Agents)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsSpec.
+func (in *JenkinsSpec) DeepCopy() *JenkinsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsStatus) DeepCopyInto(out *JenkinsStatus) {
+	*out = *in
+	if in.ProvisionStartTime != nil {
+		in, out := &in.ProvisionStartTime, &out.ProvisionStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BaseConfigurationCompletedTime != nil {
+		in, out := &in.BaseConfigurationCompletedTime, &out.BaseConfigurationCompletedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UserConfigurationCompletedTime != nil {
+		in, out := &in.UserConfigurationCompletedTime, &out.UserConfigurationCompletedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BackupDestinations != nil {
+		in, out := &in.BackupDestinations, &out.BackupDestinations
+		*out = make([]BackupDestinationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CreatedSeedJobs != nil {
+		in, out := &in.CreatedSeedJobs, &out.CreatedSeedJobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncedCredentials != nil {
+		in, out := &in.SyncedCredentials, &out.SyncedCredentials
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreatedStaticAgents != nil {
+		in, out := &in.CreatedStaticAgents, &out.CreatedStaticAgents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SeedJobs != nil {
+		in, out := &in.SeedJobs, &out.SeedJobs
+		*out = make([]SeedJobStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AppliedGroovyScripts != nil {
+		in, out := &in.AppliedGroovyScripts, &out.AppliedGroovyScripts
+		*out = make([]AppliedGroovyScript, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigurationAsCodeGitRepositories != nil {
+		in, out := &in.ConfigurationAsCodeGitRepositories, &out.ConfigurationAsCodeGitRepositories
+		*out = make([]ConfigurationAsCodeGitRepositoryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigurationAsCodeRemoteURLs != nil {
+		in, out := &in.ConfigurationAsCodeRemoteURLs, &out.ConfigurationAsCodeRemoteURLs
+		*out = make([]ConfigurationAsCodeRemoteURLStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PinnedPlugins != nil {
+		in, out := &in.PinnedPlugins, &out.PinnedPlugins
+		*out = make([]PinnedPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]InstalledPlugin, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]JenkinsCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsStatus.
+func (in *JenkinsStatus) DeepCopy() *JenkinsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesCloud) DeepCopyInto(out *KubernetesCloud) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesCloud.
+func (in *KubernetesCloud) DeepCopy() *KubernetesCloud {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesCloud)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesCredentialsProvider) DeepCopyInto(out *KubernetesCredentialsProvider) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesCredentialsProvider.
+func (in *KubernetesCredentialsProvider) DeepCopy() *KubernetesCredentialsProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesCredentialsProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPSecurityRealm) DeepCopyInto(out *LDAPSecurityRealm) {
+	*out = *in
+	out.BindSecret = in.BindSecret
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPSecurityRealm.
+func (in *LDAPSecurityRealm) DeepCopy() *LDAPSecurityRealm {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPSecurityRealm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mailgun) DeepCopyInto(out *Mailgun) {
+	*out = *in
+	out.APIKeySecretKeySelector = in.APIKeySecretKeySelector
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mailgun.
+func (in *Mailgun) DeepCopy() *Mailgun {
+	if in == nil {
+		return nil
+	}
+	out := new(Mailgun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Matrix) DeepCopyInto(out *Matrix) {
+	*out = *in
+	out.AccessTokenSecretKeySelector = in.AccessTokenSecretKeySelector
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Matrix.
+func (in *Matrix) DeepCopy() *Matrix {
+	if in == nil {
+		return nil
+	}
+	out := new(Matrix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MicrosoftTeams) DeepCopyInto(out *MicrosoftTeams) {
+	*out = *in
+	out.WebHookURLSecretKeySelector = in.WebHookURLSecretKeySelector
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MicrosoftTeams.
+func (in *MicrosoftTeams) DeepCopy() *MicrosoftTeams {
+	if in == nil {
+		return nil
+	}
+	out := new(MicrosoftTeams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultibranchPipeline) DeepCopyInto(out *MultibranchPipeline) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultibranchPipeline.
+func (in *MultibranchPipeline) DeepCopy() *MultibranchPipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(MultibranchPipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Notification) DeepCopyInto(out *Notification) {
+	*out = *in
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(Slack)
+		**out = **in
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(MicrosoftTeams)
+		**out = **in
+	}
+	if in.Mailgun != nil {
+		in, out := &in.Mailgun, &out.Mailgun
+		*out = new(Mailgun)
+		**out = **in
+	}
+	if in.SMTP != nil {
+		in, out := &in.SMTP, &out.SMTP
+		*out = new(SMTP)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(Webhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDuty)
+		**out = **in
+	}
+	if in.Opsgenie != nil {
+		in, out := &in.Opsgenie, &out.Opsgenie
+		*out = new(Opsgenie)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Discord != nil {
+		in, out := &in.Discord, &out.Discord
+		*out = new(Discord)
+		**out = **in
+	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = new(Matrix)
+		**out = **in
+	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = new(CloudEvents)
+		**out = **in
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(NotificationFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
+func (in *Notification) DeepCopy() *Notification {
+	if in == nil {
+		return nil
+	}
+	out := new(Notification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationFilter) DeepCopyInto(out *NotificationFilter) {
+	*out = *in
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilter.
+func (in *NotificationFilter) DeepCopy() *NotificationFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCSecurityRealm) DeepCopyInto(out *OIDCSecurityRealm) {
+	*out = *in
+	out.ClientSecret = in.ClientSecret
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.BreakGlassSecret = in.BreakGlassSecret
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSecurityRealm.
+func (in *OIDCSecurityRealm) DeepCopy() *OIDCSecurityRealm {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCSecurityRealm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Opsgenie) DeepCopyInto(out *Opsgenie) {
+	*out = *in
+	out.APIKeySecretKeySelector = in.APIKeySecretKeySelector
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.AppliedGroovyScripts != nil {
-		in, out := &in.AppliedGroovyScripts, &out.AppliedGroovyScripts
-		*out = make([]AppliedGroovyScript, len(*in))
-		copy(*out, *in)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Opsgenie.
+func (in *Opsgenie) DeepCopy() *Opsgenie {
+	if in == nil {
+		return nil
+	}
+	out := new(Opsgenie)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDuty) DeepCopyInto(out *PagerDuty) {
+	*out = *in
+	out.RoutingKeySecretKeySelector = in.RoutingKeySecretKeySelector
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDuty.
+func (in *PagerDuty) DeepCopy() *PagerDuty {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDuty)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PinnedPlugin) DeepCopyInto(out *PinnedPlugin) {
+	*out = *in
+	in.PinTime.DeepCopyInto(&out.PinTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PinnedPlugin.
+func (in *PinnedPlugin) DeepCopy() *PinnedPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(PinnedPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Plugin) DeepCopyInto(out *Plugin) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plugin.
+func (in *Plugin) DeepCopy() *Plugin {
+	if in == nil {
+		return nil
+	}
+	out := new(Plugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginBundle) DeepCopyInto(out *PluginBundle) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginBundle.
+func (in *PluginBundle) DeepCopy() *PluginBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginCache) DeepCopyInto(out *PluginCache) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsStatus.
-func (in *JenkinsStatus) DeepCopy() *JenkinsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginCache.
+func (in *PluginCache) DeepCopy() *PluginCache {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsStatus)
+	out := new(PluginCache)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Mailgun) DeepCopyInto(out *Mailgun) {
+func (in *PluginDependencyResolution) DeepCopyInto(out *PluginDependencyResolution) {
 	*out = *in
-	out.APIKeySecretKeySelector = in.APIKeySecretKeySelector
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mailgun.
-func (in *Mailgun) DeepCopy() *Mailgun {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginDependencyResolution.
+func (in *PluginDependencyResolution) DeepCopy() *PluginDependencyResolution {
 	if in == nil {
 		return nil
 	}
-	out := new(Mailgun)
+	out := new(PluginDependencyResolution)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MicrosoftTeams) DeepCopyInto(out *MicrosoftTeams) {
+func (in *PluginSecurityWarnings) DeepCopyInto(out *PluginSecurityWarnings) {
 	*out = *in
-	out.WebHookURLSecretKeySelector = in.WebHookURLSecretKeySelector
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MicrosoftTeams.
-func (in *MicrosoftTeams) DeepCopy() *MicrosoftTeams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSecurityWarnings.
+func (in *PluginSecurityWarnings) DeepCopy() *PluginSecurityWarnings {
 	if in == nil {
 		return nil
 	}
-	out := new(MicrosoftTeams)
+	out := new(PluginSecurityWarnings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Notification) DeepCopyInto(out *Notification) {
+func (in *PluginsConfigMap) DeepCopyInto(out *PluginsConfigMap) {
 	*out = *in
-	if in.Slack != nil {
-		in, out := &in.Slack, &out.Slack
-		*out = new(Slack)
-		**out = **in
-	}
-	if in.Teams != nil {
-		in, out := &in.Teams, &out.Teams
-		*out = new(MicrosoftTeams)
-		**out = **in
-	}
-	if in.Mailgun != nil {
-		in, out := &in.Mailgun, &out.Mailgun
-		*out = new(Mailgun)
-		**out = **in
-	}
-	if in.SMTP != nil {
-		in, out := &in.SMTP, &out.SMTP
-		*out = new(SMTP)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginsConfigMap.
+func (in *PluginsConfigMap) DeepCopy() *PluginsConfigMap {
+	if in == nil {
+		return nil
 	}
+	out := new(PluginsConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Proxy) DeepCopyInto(out *Proxy) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
-func (in *Notification) DeepCopy() *Notification {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Proxy.
+func (in *Proxy) DeepCopy() *Proxy {
 	if in == nil {
 		return nil
 	}
-	out := new(Notification)
+	out := new(Proxy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Plugin) DeepCopyInto(out *Plugin) {
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plugin.
-func (in *Plugin) DeepCopy() *Plugin {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
 	if in == nil {
 		return nil
 	}
-	out := new(Plugin)
+	out := new(RateLimit)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -709,6 +1950,48 @@ func (in *SMTP) DeepCopy() *SMTP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHCLI) DeepCopyInto(out *SSHCLI) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHCLI.
+func (in *SSHCLI) DeepCopy() *SSHCLI {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHCLI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptExecution) DeepCopyInto(out *ScriptExecution) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryInterval != nil {
+		in, out := &in.RetryInterval, &out.RetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptExecution.
+func (in *ScriptExecution) DeepCopy() *ScriptExecution {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptExecution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
 	*out = *in
@@ -742,9 +2025,126 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Security) DeepCopyInto(out *Security) {
+	*out = *in
+	if in.AdminCredentialRotation != nil {
+		in, out := &in.AdminCredentialRotation, &out.AdminCredentialRotation
+		*out = new(AdminCredentialRotation)
+		**out = **in
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(Authorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsSync != nil {
+		in, out := &in.CredentialsSync, &out.CredentialsSync
+		*out = new(CredentialsSync)
+		**out = **in
+	}
+	if in.KubernetesCredentialsProvider != nil {
+		in, out := &in.KubernetesCredentialsProvider, &out.KubernetesCredentialsProvider
+		*out = new(KubernetesCredentialsProvider)
+		**out = **in
+	}
+	if in.Realm != nil {
+		in, out := &in.Realm, &out.Realm
+		*out = new(SecurityRealm)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Security.
+func (in *Security) DeepCopy() *Security {
+	if in == nil {
+		return nil
+	}
+	out := new(Security)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityRealm) DeepCopyInto(out *SecurityRealm) {
+	*out = *in
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAPSecurityRealm)
+		**out = **in
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCSecurityRealm)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityRealm.
+func (in *SecurityRealm) DeepCopy() *SecurityRealm {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityRealm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptLogs) DeepCopyInto(out *ScriptLogs) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptLogs.
+func (in *ScriptLogs) DeepCopy() *ScriptLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeedAgent) DeepCopyInto(out *SeedAgent) {
 	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -761,6 +2161,11 @@ func (in *SeedAgent) DeepCopy() *SeedAgent {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeedJob) DeepCopyInto(out *SeedJob) {
 	*out = *in
+	if in.Multibranch != nil {
+		in, out := &in.Multibranch, &out.Multibranch
+		*out = new(MultibranchPipeline)
+		**out = **in
+	}
 	return
 }
 
@@ -774,6 +2179,26 @@ func (in *SeedJob) DeepCopy() *SeedJob {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedJobStatus) DeepCopyInto(out *SeedJobStatus) {
+	*out = *in
+	if in.LastBuildTime != nil {
+		in, out := &in.LastBuildTime, &out.LastBuildTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedJobStatus.
+func (in *SeedJobStatus) DeepCopy() *SeedJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Service) DeepCopyInto(out *Service) {
 	*out = *in
@@ -848,3 +2273,103 @@ func (in *Slack) DeepCopy() *Slack {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticAgent) DeepCopyInto(out *StaticAgent) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticAgent.
+func (in *StaticAgent) DeepCopy() *StaticAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateValuesSource) DeepCopyInto(out *TemplateValuesSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateValuesSource.
+func (in *TemplateValuesSource) DeepCopy() *TemplateValuesSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateValuesSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vault) DeepCopyInto(out *Vault) {
+	*out = *in
+	out.Auth = in.Auth
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vault.
+func (in *Vault) DeepCopy() *Vault {
+	if in == nil {
+		return nil
+	}
+	out := new(Vault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuth) DeepCopyInto(out *VaultAuth) {
+	*out = *in
+	out.Secret = in.Secret
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuth.
+func (in *VaultAuth) DeepCopy() *VaultAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Webhook) DeepCopyInto(out *Webhook) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TokenSecretKeySelector != nil {
+		in, out := &in.TokenSecretKeySelector, &out.TokenSecretKeySelector
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Webhook.
+func (in *Webhook) DeepCopy() *Webhook {
+	if in == nil {
+		return nil
+	}
+	out := new(Webhook)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,94 @@
+package v1beta1
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &Jenkins{}
+
+// ConvertTo converts Jenkins to the v1alpha2 hub version.
+func (in *Jenkins) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1alpha2.Jenkins)
+	src := in.DeepCopy()
+
+	dst.TypeMeta = v1alpha2.JenkinsTypeMeta()
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+	dst.Spec = v1alpha2.JenkinsSpec{
+		Master:              src.Spec.Master,
+		SeedJobs:            src.Spec.SeedJobs,
+		SeedAgent:           src.Spec.SeedAgent,
+		Notifications:       src.Spec.Notifications,
+		Service:             src.Spec.Ingress.Service,
+		SlaveService:        src.Spec.Ingress.AgentService,
+		Backup:              src.Spec.Persistence.Backup,
+		Restore:             src.Spec.Persistence.Restore,
+		JenkinsAPISettings:  v1alpha2.JenkinsAPISettings{AuthorizationStrategy: src.Spec.Authorization.Strategy, TokenRotationInterval: src.Spec.Authorization.TokenRotationInterval, PortForwardFallback: src.Spec.Authorization.PortForwardFallback, AuthProxy: src.Spec.Authorization.AuthProxy, RateLimit: src.Spec.Authorization.RateLimit, SSHCLI: src.Spec.Authorization.SSHCLI},
+		Roles:               src.Spec.Authorization.Roles,
+		ServiceAccount:      src.Spec.Authorization.ServiceAccount,
+		GroovyScripts:       src.Spec.Configuration.GroovyScripts,
+		ConfigurationAsCode: src.Spec.Configuration.ConfigurationAsCode,
+		Vault:               src.Spec.Secrets.Vault,
+		AWS:                 src.Spec.Secrets.AWS,
+		ReconcileInterval:   src.Spec.ReconcileInterval,
+		Proxy:               src.Spec.Proxy,
+		Monitoring:          src.Spec.Monitoring,
+		ScriptLogs:          src.Spec.ScriptLogs,
+		Security:            src.Spec.Security,
+		Agents:              src.Spec.Agents,
+	}
+
+	return nil
+}
+
+// ConvertFrom converts Jenkins from the v1alpha2 hub version.
+func (in *Jenkins) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha2.Jenkins).DeepCopy()
+
+	in.TypeMeta.APIVersion = SchemeGroupVersion.String()
+	in.TypeMeta.Kind = Kind
+	in.ObjectMeta = src.ObjectMeta
+	in.Status = src.Status
+	in.Spec = JenkinsSpec{
+		Master:        src.Spec.Master,
+		SeedJobs:      src.Spec.SeedJobs,
+		SeedAgent:     src.Spec.SeedAgent,
+		Notifications: src.Spec.Notifications,
+		Ingress: Ingress{
+			Service:      src.Spec.Service,
+			AgentService: src.Spec.SlaveService,
+		},
+		Persistence: Persistence{
+			Backup:  src.Spec.Backup,
+			Restore: src.Spec.Restore,
+		},
+		Authorization: Authorization{
+			Strategy:              src.Spec.JenkinsAPISettings.AuthorizationStrategy,
+			TokenRotationInterval: src.Spec.JenkinsAPISettings.TokenRotationInterval,
+			PortForwardFallback:   src.Spec.JenkinsAPISettings.PortForwardFallback,
+			AuthProxy:             src.Spec.JenkinsAPISettings.AuthProxy,
+			RateLimit:             src.Spec.JenkinsAPISettings.RateLimit,
+			SSHCLI:                src.Spec.JenkinsAPISettings.SSHCLI,
+			Roles:                 src.Spec.Roles,
+			ServiceAccount:        src.Spec.ServiceAccount,
+		},
+		Configuration: Configuration{
+			GroovyScripts:       src.Spec.GroovyScripts,
+			ConfigurationAsCode: src.Spec.ConfigurationAsCode,
+		},
+		Secrets: Secrets{
+			Vault: src.Spec.Vault,
+			AWS:   src.Spec.AWS,
+		},
+		ReconcileInterval: src.Spec.ReconcileInterval,
+		Proxy:             src.Spec.Proxy,
+		Monitoring:        src.Spec.Monitoring,
+		ScriptLogs:        src.Spec.ScriptLogs,
+		Security:          src.Spec.Security,
+		Agents:            src.Spec.Agents,
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConversion(t *testing.T) {
+	hub := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master:             v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: "jenkins-master"}}},
+			SeedJobs:           []v1alpha2.SeedJob{{ID: "jenkins-operator"}},
+			Notifications:      []v1alpha2.Notification{{Name: "slack"}},
+			Service:            v1alpha2.Service{Type: corev1.ServiceTypeNodePort, Port: 8080},
+			SlaveService:       v1alpha2.Service{Type: corev1.ServiceTypeClusterIP, Port: 50000},
+			Backup:             v1alpha2.Backup{ContainerName: "backup", Interval: 30},
+			Restore:            v1alpha2.Restore{ContainerName: "backup"},
+			JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.ServiceAccountAuthorizationStrategy, TokenRotationInterval: &metav1.Duration{Duration: 24 * time.Hour}, PortForwardFallback: true, AuthProxy: &v1alpha2.AuthProxy{Headers: []v1alpha2.AuthProxyHeader{{Name: "Authorization", Prefix: "Bearer "}}}, RateLimit: &v1alpha2.RateLimit{RequestsPerSecond: 5}, SSHCLI: &v1alpha2.SSHCLI{Port: 2222}},
+			Roles:              []rbacv1.RoleRef{{Kind: "ClusterRole", Name: "view"}},
+			Vault:              &v1alpha2.Vault{Address: "https://vault:8200"},
+			Proxy:              &v1alpha2.Proxy{HTTPSProxy: "http://proxy:3128"},
+			Monitoring:         &v1alpha2.Monitoring{Enabled: true, Interval: "30s"},
+			ScriptLogs:         &v1alpha2.ScriptLogs{Limit: 5},
+			Security:           &v1alpha2.Security{AdminCredentialRotation: &v1alpha2.AdminCredentialRotation{Interval: metav1.Duration{Duration: time.Hour}}},
+			Agents:             &v1alpha2.Agents{KubernetesCloud: &v1alpha2.KubernetesCloud{ContainerCap: 10}},
+		},
+		Status: v1alpha2.JenkinsStatus{OperatorVersion: "v0.4.0"},
+	}
+
+	jenkins := &Jenkins{}
+	require.NoError(t, jenkins.ConvertFrom(hub))
+
+	assert.Equal(t, SchemeGroupVersion.String(), jenkins.APIVersion)
+	assert.Equal(t, "example", jenkins.Name)
+	assert.Equal(t, hub.Spec.Service, jenkins.Spec.Ingress.Service)
+	assert.Equal(t, hub.Spec.SlaveService, jenkins.Spec.Ingress.AgentService)
+	assert.Equal(t, hub.Spec.Backup, jenkins.Spec.Persistence.Backup)
+	assert.Equal(t, v1alpha2.ServiceAccountAuthorizationStrategy, jenkins.Spec.Authorization.Strategy)
+	assert.Equal(t, hub.Spec.Vault, jenkins.Spec.Secrets.Vault)
+	assert.Equal(t, hub.Status, jenkins.Status)
+
+	converted := &v1alpha2.Jenkins{}
+	require.NoError(t, jenkins.ConvertTo(converted))
+
+	assert.Equal(t, v1alpha2.JenkinsTypeMeta(), converted.TypeMeta)
+	converted.TypeMeta = hub.TypeMeta
+	assert.Equal(t, hub, converted)
+}
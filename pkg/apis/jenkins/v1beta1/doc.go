@@ -0,0 +1,4 @@
+// Package v1beta1 contains API Schema definitions for the jenkins.io v1beta1 API group
+// +k8s:deepcopy-gen=package,register
+// +groupName=jenkins.io
+package v1beta1
@@ -0,0 +1,185 @@
+package v1beta1
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsSpec defines the desired state of the Jenkins.
+// +k8s:openapi-gen=true
+type JenkinsSpec struct {
+	// Master represents Jenkins master pod properties and Jenkins plugins.
+	// Every single change here requires a pod restart.
+	Master v1alpha2.JenkinsMaster `json:"master"`
+
+	// SeedJobs defines list of Jenkins Seed Job configurations
+	// +optional
+	SeedJobs []v1alpha2.SeedJob `json:"seedJobs,omitempty"`
+
+	// SeedAgent defines the agent which runs seed jobs
+	// +optional
+	SeedAgent v1alpha2.SeedAgent `json:"seedAgent,omitempty"`
+
+	// Notifications defines list of a services which are used to inform about Jenkins status
+	// +optional
+	Notifications []v1alpha2.Notification `json:"notifications,omitempty"`
+
+	// Ingress defines how Jenkins master is exposed
+	// +optional
+	Ingress Ingress `json:"ingress,omitempty"`
+
+	// Persistence defines backup and restore of Jenkins jobs history
+	// +optional
+	Persistence Persistence `json:"persistence,omitempty"`
+
+	// Authorization defines how the operator authorizes in Jenkins API and permissions of Jenkins master
+	// +optional
+	Authorization Authorization `json:"authorization,omitempty"`
+
+	// Configuration defines Groovy scripts and Configuration as Code applied to Jenkins
+	// +optional
+	Configuration Configuration `json:"configuration,omitempty"`
+
+	// Secrets defines external stores of secrets used by Jenkins configuration
+	// +optional
+	Secrets Secrets `json:"secrets,omitempty"`
+
+	// ReconcileInterval is the interval of periodic reconciliation of the CR which detects configuration drift,
+	// it overrides --reconcile-interval of the operator, 0 disables it
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// Proxy overrides the proxy settings of the operator for requests to Jenkins and the update center
+	// +optional
+	Proxy *v1alpha2.Proxy `json:"proxy,omitempty"`
+
+	// Monitoring defines Prometheus monitoring of Jenkins
+	// +optional
+	Monitoring *v1alpha2.Monitoring `json:"monitoring,omitempty"`
+
+	// ScriptLogs defines the ConfigMap with logs of groovy scripts and Configuration as Code executed by the operator
+	// +optional
+	ScriptLogs *v1alpha2.ScriptLogs `json:"scriptLogs,omitempty"`
+
+	// Security defines security settings of Jenkins managed by the operator
+	// +optional
+	Security *v1alpha2.Security `json:"security,omitempty"`
+
+	// Agents defines Jenkins agents started by the operator and by the kubernetes-plugin cloud
+	// +optional
+	Agents *v1alpha2.Agents `json:"agents,omitempty"`
+}
+
+// Ingress defines Kubernetes services of Jenkins master.
+type Ingress struct {
+	// Service is the Kubernetes service of Jenkins master HTTP pod
+	// Defaults to :
+	// port: 8080
+	// type: ClusterIP
+	// +optional
+	Service v1alpha2.Service `json:"service,omitempty"`
+
+	// AgentService is the Kubernetes service of Jenkins master agents (JNLP) pod
+	// Defaults to :
+	// port: 50000
+	// type: ClusterIP
+	// +optional
+	AgentService v1alpha2.Service `json:"agentService,omitempty"`
+}
+
+// Persistence defines backup and restore of Jenkins jobs history.
+type Persistence struct {
+	// Backup defines configuration of Jenkins backup
+	// +optional
+	Backup v1alpha2.Backup `json:"backup,omitempty"`
+
+	// Restore defines configuration of Jenkins backup restore
+	// +optional
+	Restore v1alpha2.Restore `json:"restore,omitempty"`
+}
+
+// Authorization defines how the operator authorizes in Jenkins API and permissions of Jenkins master.
+type Authorization struct {
+	// Strategy defines how the operator authorizes in Jenkins API, defaults to createUser
+	// +optional
+	Strategy v1alpha2.AuthorizationStrategy `json:"strategy,omitempty"`
+
+	// TokenRotationInterval is the interval of rotation of the API token of the operator user
+	// +optional
+	TokenRotationInterval *metav1.Duration `json:"tokenRotationInterval,omitempty"`
+
+	// PortForwardFallback enables the port-forward to the Jenkins master Pod when the Jenkins API isn't reachable
+	// +optional
+	PortForwardFallback bool `json:"portForwardFallback,omitempty"`
+
+	// AuthProxy defines authentication of the operator to the auth proxy in front of Jenkins
+	// +optional
+	AuthProxy *v1alpha2.AuthProxy `json:"authProxy,omitempty"`
+
+	// RateLimit overrides the rate limit of the operator for Jenkins API requests
+	// +optional
+	RateLimit *v1alpha2.RateLimit `json:"rateLimit,omitempty"`
+
+	// SSHCLI enables execution of Groovy scripts by the Jenkins CLI over SSH
+	// +optional
+	SSHCLI *v1alpha2.SSHCLI `json:"sshCLI,omitempty"`
+
+	// Roles defines list of extra RBAC roles for the Jenkins Master pod service account
+	// +optional
+	Roles []rbacv1.RoleRef `json:"roles,omitempty"`
+
+	// ServiceAccount defines Jenkins master service account attributes
+	// +optional
+	ServiceAccount v1alpha2.ServiceAccount `json:"serviceAccount,omitempty"`
+}
+
+// Configuration defines Groovy scripts and Configuration as Code applied to Jenkins.
+type Configuration struct {
+	// GroovyScripts defines configuration of Jenkins customization via groovy scripts
+	// +optional
+	GroovyScripts v1alpha2.GroovyScripts `json:"groovyScripts,omitempty"`
+
+	// ConfigurationAsCode defines configuration of Jenkins customization via Configuration as Code Jenkins plugin
+	// +optional
+	ConfigurationAsCode v1alpha2.ConfigurationAsCode `json:"configurationAsCode,omitempty"`
+}
+
+// Secrets defines external stores of secrets used by Jenkins configuration.
+type Secrets struct {
+	// Vault defines HashiCorp Vault used to resolve secret references in Jenkins configuration
+	// +optional
+	Vault *v1alpha2.Vault `json:"vault,omitempty"`
+
+	// AWS defines AWS Secrets Manager used to resolve secret references in Jenkins configuration
+	// +optional
+	AWS *v1alpha2.AWS `json:"aws,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Jenkins is the Schema for the jenkins API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=jenkins,scope=Namespaced,shortName=jk;jks,categories=all
+type Jenkins struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the Jenkins
+	Spec JenkinsSpec `json:"spec,omitempty"`
+
+	// Status defines the observed state of Jenkins
+	Status v1alpha2.JenkinsStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsList contains a list of Jenkins.
+type JenkinsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Jenkins `json:"items"`
+}
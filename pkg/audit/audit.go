@@ -0,0 +1,154 @@
+// Package audit records mutations performed by the operator on Jenkins, e.g. recreation of the Jenkins master pod,
+// executed groovy scripts and rotated secrets. Every record is logged and appended to the ConfigMap of the Jenkins CR
+// which keeps the latest records.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConfigMapKey is the key of the ConfigMap data which holds records, one JSON record per line
+	ConfigMapKey = "audit.log"
+	// MaxRecords is the number of the latest records kept in the ConfigMap
+	MaxRecords = 100
+)
+
+// Action is the type of mutation performed by the operator
+type Action string
+
+const (
+	// ActionPodRecreated is recorded when the operator deletes the Jenkins master pod to recreate it
+	ActionPodRecreated Action = "PodRecreated"
+	// ActionGroovyScriptExecuted is recorded when the operator executes a groovy script in Jenkins
+	ActionGroovyScriptExecuted Action = "GroovyScriptExecuted"
+	// ActionSecretRotated is recorded when the operator replaces a generated secret
+	ActionSecretRotated Action = "SecretRotated"
+)
+
+// Record is a mutation performed by the operator
+type Record struct {
+	Time       metav1.Time `json:"time"`
+	Action     Action      `json:"action"`
+	Generation int64       `json:"generation"`
+	Message    string      `json:"message"`
+	Hash       string      `json:"hash,omitempty"`
+}
+
+var logger = log.Log.WithName("audit")
+
+// GetConfigMapName returns name of the ConfigMap with audit records of the Jenkins CR
+func GetConfigMapName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-audit-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// Add records the mutation of Jenkins triggered by the current generation of the Jenkins CR, the failure of storing
+// the record is logged only, the mutation has already been performed
+func Add(k8sClient k8s.Client, jenkins *v1alpha2.Jenkins, action Action, message, hash string) {
+	record := Record{
+		Time:       metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
+		Action:     action,
+		Generation: jenkins.Generation,
+		Message:    message,
+		Hash:       hash,
+	}
+	logger.Info(message, "cr", jenkins.Name, "action", action, "generation", record.Generation, "hash", hash)
+
+	if err := store(k8sClient, jenkins, record); err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't store audit record: %s", err), "cr", jenkins.Name)
+	}
+}
+
+// Hash returns the hash of the content applied to Jenkins, e.g. the groovy script, which is recorded instead of the
+// content
+func Hash(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+func store(k8sClient k8s.Client, jenkins *v1alpha2.Jenkins, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: GetConfigMapName(jenkins)}, configMap)
+		if apierrors.IsNotFound(err) {
+			return k8sClient.Create(context.TODO(), newConfigMap(jenkins, string(line)))
+		} else if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[ConfigMapKey] = appendLine(configMap.Data[ConfigMapKey], string(line))
+		return k8sClient.Update(context.TODO(), configMap)
+	})
+}
+
+// appendLine appends the record to records and drops the oldest records above MaxRecords
+func appendLine(records, line string) string {
+	lines := strings.Split(strings.TrimSpace(records), "\n")
+	if len(lines) == 1 && len(lines[0]) == 0 {
+		lines = nil
+	}
+	lines = append(lines, line)
+	if len(lines) > MaxRecords {
+		lines = lines[len(lines)-MaxRecords:]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func newConfigMap(jenkins *v1alpha2.Jenkins, line string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetConfigMapName(jenkins),
+			Namespace: jenkins.Namespace,
+			Labels: map[string]string{
+				constants.LabelAppKey:       constants.LabelAppValue,
+				constants.LabelJenkinsCRKey: jenkins.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(jenkins, v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.Kind)),
+			},
+		},
+		Data: map[string]string{ConfigMapKey: line + "\n"},
+	}
+}
+
+// Records returns audit records stored in the ConfigMap, the oldest first
+func Records(configMap *corev1.ConfigMap) ([]Record, error) {
+	var records []Record
+	for _, line := range strings.Split(configMap.Data[ConfigMapKey], "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return nil, errors.Wrapf(err, "invalid audit record '%s'", line)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAdd(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example", Generation: 3}}
+	fakeClient := fake.NewFakeClient()
+
+	Add(fakeClient, jenkins, ActionPodRecreated, "Jenkins master pod has been recreated", "")
+	Add(fakeClient, jenkins, ActionGroovyScriptExecuted, "groovy script has been executed", Hash("println 'hello'"))
+
+	configMap := &corev1.ConfigMap{}
+	err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "jenkins-operator-audit-example"}, configMap)
+	require.NoError(t, err)
+	assert.Equal(t, "example", configMap.OwnerReferences[0].Name)
+	records, err := Records(configMap)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, ActionPodRecreated, records[0].Action)
+	assert.Equal(t, int64(3), records[0].Generation)
+	assert.Empty(t, records[0].Hash)
+	assert.Equal(t, ActionGroovyScriptExecuted, records[1].Action)
+	assert.Equal(t, Hash("println 'hello'"), records[1].Hash)
+	assert.False(t, records[1].Time.IsZero())
+}
+
+func TestAppendLine(t *testing.T) {
+	assert.Equal(t, "a\n", appendLine("", "a"))
+	assert.Equal(t, "a\nb\n", appendLine("a\n", "b"))
+
+	var records string
+	for i := 0; i < MaxRecords+5; i++ {
+		records = appendLine(records, fmt.Sprint(i))
+	}
+	lines := strings.Split(strings.TrimSpace(records), "\n")
+	assert.Len(t, lines, MaxRecords)
+	assert.Equal(t, "5", lines[0])
+	assert.Equal(t, fmt.Sprint(MaxRecords+4), lines[MaxRecords-1])
+}
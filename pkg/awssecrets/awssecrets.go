@@ -0,0 +1,297 @@
+package awssecrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	secretsManagerService = "secretsmanager"
+	ssmService            = "ssm"
+	stsService            = "sts"
+
+	roleSessionName = "jenkins-operator"
+	requestTimeout  = 10 * time.Second
+)
+
+// placeholderRegex matches ${aws-secretsmanager:name#key} and ${aws-ssm:name#key} placeholders, key is optional
+var placeholderRegex = regexp.MustCompile(`\$\{aws-(secretsmanager|ssm):([^#}]+)(?:#([^}]+))?\}`)
+
+var regionRegex = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+// Resolver resolves AWS Secrets Manager and SSM Parameter Store placeholders
+type Resolver interface {
+	Resolve(content string) (string, error)
+}
+
+type resolver struct {
+	jenkins     *v1alpha2.Jenkins
+	httpClient  *http.Client
+	endpoint    func(service, region string) string
+	getenv      func(key string) string
+	now         func() time.Time
+	credentials *credentials
+	values      map[string]string
+}
+
+// New creates new instance of Resolver
+func New(jenkins *v1alpha2.Jenkins) Resolver {
+	return &resolver{
+		jenkins:    jenkins,
+		httpClient: &http.Client{Timeout: requestTimeout},
+		endpoint:   endpoint,
+		getenv:     os.Getenv,
+		now:        time.Now,
+		values:     map[string]string{},
+	}
+}
+
+func endpoint(service, region string) string {
+	domain := "amazonaws.com"
+	if strings.HasPrefix(region, "cn-") {
+		domain = "amazonaws.com.cn"
+	}
+	return fmt.Sprintf("https://%s.%s.%s", service, region, domain)
+}
+
+// HasPlaceholders returns true if content contains AWS placeholders
+func HasPlaceholders(content string) bool {
+	return placeholderRegex.MatchString(content)
+}
+
+// Resolve replaces all ${aws-secretsmanager:name#key} and ${aws-ssm:name#key} placeholders in content
+// with values read from AWS, every secret and parameter is read only once by the Resolver instance
+func (r *resolver) Resolve(content string) (string, error) {
+	if !HasPlaceholders(content) {
+		return content, nil
+	}
+	if r.jenkins.Spec.AWS == nil {
+		return "", errors.New("found AWS placeholders but spec.aws is not configured")
+	}
+
+	var resolveErr error
+	resolved := placeholderRegex.ReplaceAllStringFunc(content, func(placeholder string) string {
+		if resolveErr != nil {
+			return placeholder
+		}
+		matches := placeholderRegex.FindStringSubmatch(placeholder)
+		value, err := r.read(matches[1], matches[2], matches[3])
+		if err != nil {
+			resolveErr = err
+			return placeholder
+		}
+		return value
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	return resolved, nil
+}
+
+func (r *resolver) read(service, name, key string) (string, error) {
+	cacheKey := service + ":" + name
+	value, found := r.values[cacheKey]
+	if !found {
+		var err error
+		switch service {
+		case secretsManagerService:
+			value, err = r.getSecretValue(name)
+		case ssmService:
+			value, err = r.getParameter(name)
+		}
+		if err != nil {
+			return "", err
+		}
+		r.values[cacheKey] = value
+	}
+
+	if len(key) == 0 {
+		return value, nil
+	}
+
+	data := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return "", errors.Wrapf(err, "AWS %s value '%s' is not a JSON object", service, name)
+	}
+	keyValue, found := data[key]
+	if !found {
+		return "", errors.Errorf("key '%s' not found in AWS %s value '%s'", key, service, name)
+	}
+	if text, ok := keyValue.(string); ok {
+		return text, nil
+	}
+	encoded, err := json.Marshal(keyValue)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(encoded), nil
+}
+
+func (r *resolver) getSecretValue(secretID string) (string, error) {
+	response := struct {
+		SecretString string `json:"SecretString"`
+		SecretBinary []byte `json:"SecretBinary"`
+	}{}
+	err := r.call(secretsManagerService, "secretsmanager.GetSecretValue", map[string]interface{}{"SecretId": secretID}, &response)
+	if err != nil {
+		return "", errors.Wrapf(err, "couldn't get AWS Secrets Manager secret '%s'", secretID)
+	}
+	if len(response.SecretString) == 0 && len(response.SecretBinary) > 0 {
+		return string(response.SecretBinary), nil
+	}
+	return response.SecretString, nil
+}
+
+func (r *resolver) getParameter(name string) (string, error) {
+	response := struct {
+		Parameter struct {
+			Value string `json:"Value"`
+		} `json:"Parameter"`
+	}{}
+	err := r.call(ssmService, "AmazonSSM.GetParameter", map[string]interface{}{"Name": name, "WithDecryption": true}, &response)
+	if err != nil {
+		return "", errors.Wrapf(err, "couldn't get AWS SSM parameter '%s'", name)
+	}
+	return response.Parameter.Value, nil
+}
+
+// call calls AWS JSON protocol API operation
+func (r *resolver) call(service, target string, input interface{}, output interface{}) error {
+	creds, err := r.getCredentials()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	region := r.jenkins.Spec.AWS.Region
+	request, err := http.NewRequest(http.MethodPost, r.endpoint(service, region)+"/", bytes.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	request.Header.Set("X-Amz-Target", target)
+	sign(request, body, *creds, region, service, r.now())
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		awsError := struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}{}
+		_ = json.NewDecoder(response.Body).Decode(&awsError)
+		return errors.Errorf("invalid response status code '%d': %s %s", response.StatusCode, awsError.Type, awsError.Message)
+	}
+
+	return errors.WithStack(json.NewDecoder(response.Body).Decode(output))
+}
+
+// getCredentials returns credentials of the role assumed with the web identity token (IRSA)
+// or static credentials from environment variables
+func (r *resolver) getCredentials() (*credentials, error) {
+	if r.credentials != nil {
+		return r.credentials, nil
+	}
+
+	roleARN := r.jenkins.Spec.AWS.RoleARN
+	if len(roleARN) == 0 {
+		roleARN = r.getenv("AWS_ROLE_ARN")
+	}
+	tokenFile := r.getenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+	if len(roleARN) > 0 && len(tokenFile) > 0 {
+		token, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't read AWS web identity token")
+		}
+		creds, err := r.assumeRoleWithWebIdentity(roleARN, strings.TrimSpace(string(token)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't assume AWS role '%s'", roleARN)
+		}
+		r.credentials = creds
+		return r.credentials, nil
+	}
+
+	accessKeyID, secretAccessKey := r.getenv("AWS_ACCESS_KEY_ID"), r.getenv("AWS_SECRET_ACCESS_KEY")
+	if len(accessKeyID) > 0 && len(secretAccessKey) > 0 {
+		r.credentials = &credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey, SessionToken: r.getenv("AWS_SESSION_TOKEN")}
+		return r.credentials, nil
+	}
+
+	return nil, errors.New("AWS credentials not found, configure IAM roles for service accounts for the operator " +
+		"or set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables")
+}
+
+func (r *resolver) assumeRoleWithWebIdentity(roleARN, token string) (*credentials, error) {
+	query := url.Values{}
+	query.Set("Action", "AssumeRoleWithWebIdentity")
+	query.Set("Version", "2011-06-15")
+	query.Set("RoleArn", roleARN)
+	query.Set("RoleSessionName", roleSessionName)
+	query.Set("WebIdentityToken", token)
+
+	response, err := r.httpClient.Get(r.endpoint(stsService, r.jenkins.Spec.AWS.Region) + "/?" + query.Encode())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("invalid response status code '%d'", response.StatusCode)
+	}
+
+	result := struct {
+		Credentials struct {
+			AccessKeyID     string `xml:"AccessKeyId"`
+			SecretAccessKey string `xml:"SecretAccessKey"`
+			SessionToken    string `xml:"SessionToken"`
+		} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+	}{}
+	if err := xml.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &credentials{
+		AccessKeyID:     result.Credentials.AccessKeyID,
+		SecretAccessKey: result.Credentials.SecretAccessKey,
+		SessionToken:    result.Credentials.SessionToken,
+	}, nil
+}
+
+// Validate verifies AWS configuration
+func Validate(jenkins v1alpha2.Jenkins) []string {
+	var messages []string
+	aws := jenkins.Spec.AWS
+	if aws == nil {
+		return messages
+	}
+
+	if !regionRegex.MatchString(aws.Region) {
+		messages = append(messages, fmt.Sprintf("spec.aws.region '%s' is invalid", aws.Region))
+	}
+	if len(aws.RoleARN) > 0 && !strings.HasPrefix(aws.RoleARN, "arn:aws") {
+		messages = append(messages, fmt.Sprintf("spec.aws.roleArn '%s' must be an IAM role ARN", aws.RoleARN))
+	}
+
+	return messages
+}
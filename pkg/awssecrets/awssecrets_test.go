@@ -0,0 +1,162 @@
+package awssecrets
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func awsServer(t *testing.T, calls map[string]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Action") == "AssumeRoleWithWebIdentity" {
+			calls["sts"]++
+			assert.Equal(t, "arn:aws:iam::123456789012:role/jenkins-operator", r.URL.Query().Get("RoleArn"))
+			assert.Equal(t, "web-identity-token", r.URL.Query().Get("WebIdentityToken"))
+			_, _ = w.Write([]byte(`<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><Credentials>
+<AccessKeyId>ASIAEXAMPLE</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>session</SessionToken>
+</Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`))
+			return
+		}
+
+		calls[r.Header.Get("X-Amz-Target")]++
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=ASIAEXAMPLE/"))
+		assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		input := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		switch r.Header.Get("X-Amz-Target") {
+		case "secretsmanager.GetSecretValue":
+			if input["SecretId"] != "jenkins/admin" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type": "ResourceNotFoundException", "message": "Secrets Manager can't find the specified secret."}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"SecretString": "{\"username\": \"admin\", \"password\": \"p4ssw0rd\"}"}`))
+		case "AmazonSSM.GetParameter":
+			assert.Equal(t, true, input["WithDecryption"])
+			_, _ = w.Write([]byte(`{"Parameter": {"Name": "/jenkins/system-message", "Value": "Hello world"}}`))
+		}
+	}))
+}
+
+func newTestResolver(t *testing.T, serverURL string, env map[string]string) *resolver {
+	r := New(&v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{AWS: &v1alpha2.AWS{Region: "eu-west-1"}}}).(*resolver)
+	r.endpoint = func(service, region string) string {
+		assert.Equal(t, "eu-west-1", region)
+		return serverURL
+	}
+	r.getenv = func(key string) string { return env[key] }
+	r.now = func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }
+	return r
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("IAM roles for service accounts", func(t *testing.T) {
+		calls := map[string]int{}
+		server := awsServer(t, calls)
+		defer server.Close()
+		tokenFile, err := ioutil.TempFile("", "token")
+		require.NoError(t, err)
+		defer func() { _ = os.Remove(tokenFile.Name()) }()
+		_, err = tokenFile.WriteString("web-identity-token\n")
+		require.NoError(t, err)
+		r := newTestResolver(t, server.URL, map[string]string{
+			"AWS_ROLE_ARN":                "arn:aws:iam::123456789012:role/jenkins-operator",
+			"AWS_WEB_IDENTITY_TOKEN_FILE": tokenFile.Name(),
+		})
+
+		resolved, err := r.Resolve("user: ${aws-secretsmanager:jenkins/admin#username}\n" +
+			"password: ${aws-secretsmanager:jenkins/admin#password}\n" +
+			"message: ${aws-ssm:/jenkins/system-message}")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user: admin\npassword: p4ssw0rd\nmessage: Hello world", resolved)
+		assert.Equal(t, map[string]int{"sts": 1, "secretsmanager.GetSecretValue": 1, "AmazonSSM.GetParameter": 1}, calls)
+	})
+	t.Run("static credentials", func(t *testing.T) {
+		calls := map[string]int{}
+		server := awsServer(t, calls)
+		defer server.Close()
+		r := newTestResolver(t, server.URL, map[string]string{
+			"AWS_ACCESS_KEY_ID":     "ASIAEXAMPLE",
+			"AWS_SECRET_ACCESS_KEY": "secret",
+			"AWS_SESSION_TOKEN":     "session",
+		})
+
+		resolved, err := r.Resolve("${aws-secretsmanager:jenkins/admin}")
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"username": "admin", "password": "p4ssw0rd"}`, resolved)
+		assert.Equal(t, 0, calls["sts"])
+	})
+	t.Run("secret not found", func(t *testing.T) {
+		calls := map[string]int{}
+		server := awsServer(t, calls)
+		defer server.Close()
+		r := newTestResolver(t, server.URL, map[string]string{"AWS_ACCESS_KEY_ID": "ASIAEXAMPLE", "AWS_SECRET_ACCESS_KEY": "secret", "AWS_SESSION_TOKEN": "session"})
+
+		_, err := r.Resolve("${aws-secretsmanager:jenkins/missing#password}")
+
+		assert.EqualError(t, err, "couldn't get AWS Secrets Manager secret 'jenkins/missing': "+
+			"invalid response status code '400': ResourceNotFoundException Secrets Manager can't find the specified secret.")
+	})
+	t.Run("key not found", func(t *testing.T) {
+		calls := map[string]int{}
+		server := awsServer(t, calls)
+		defer server.Close()
+		r := newTestResolver(t, server.URL, map[string]string{"AWS_ACCESS_KEY_ID": "ASIAEXAMPLE", "AWS_SECRET_ACCESS_KEY": "secret", "AWS_SESSION_TOKEN": "session"})
+
+		_, err := r.Resolve("${aws-secretsmanager:jenkins/admin#token}")
+
+		assert.EqualError(t, err, "key 'token' not found in AWS secretsmanager value 'jenkins/admin'")
+	})
+	t.Run("credentials not found", func(t *testing.T) {
+		r := newTestResolver(t, "http://localhost", map[string]string{})
+
+		_, err := r.Resolve("${aws-ssm:/jenkins/system-message}")
+
+		assert.Error(t, err)
+	})
+	t.Run("without placeholders", func(t *testing.T) {
+		resolved, err := New(&v1alpha2.Jenkins{}).Resolve("jenkins:\n  systemMessage: ${SYSTEM_MESSAGE}")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jenkins:\n  systemMessage: ${SYSTEM_MESSAGE}", resolved)
+	})
+	t.Run("AWS not configured", func(t *testing.T) {
+		_, err := New(&v1alpha2.Jenkins{}).Resolve("${aws-ssm:/jenkins/system-message}")
+
+		assert.EqualError(t, err, "found AWS placeholders but spec.aws is not configured")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		assert.Nil(t, Validate(v1alpha2.Jenkins{}))
+	})
+	t.Run("happy", func(t *testing.T) {
+		jenkins := v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{AWS: &v1alpha2.AWS{
+			Region:  "us-gov-west-1",
+			RoleARN: "arn:aws-us-gov:iam::123456789012:role/jenkins-operator",
+		}}}
+
+		assert.Nil(t, Validate(jenkins))
+	})
+	t.Run("invalid region and role ARN", func(t *testing.T) {
+		jenkins := v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{AWS: &v1alpha2.AWS{Region: "Ireland", RoleARN: "jenkins-operator"}}}
+
+		assert.Equal(t, []string{
+			"spec.aws.region 'Ireland' is invalid",
+			"spec.aws.roleArn 'jenkins-operator' must be an IAM role ARN",
+		}, Validate(jenkins))
+	})
+}
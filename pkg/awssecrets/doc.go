@@ -0,0 +1,3 @@
+// Package awssecrets resolves AWS Secrets Manager and SSM Parameter Store placeholders in Groovy scripts
+// and Configuration as Code
+package awssecrets
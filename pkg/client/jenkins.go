@@ -3,9 +3,9 @@ package client
 import (
 	"fmt"
 	"net/http"
-	"net/http/cookiejar"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/bndr/gojenkins"
 	"github.com/pkg/errors"
@@ -19,6 +19,8 @@ var (
 // Jenkins defines Jenkins API.
 type Jenkins interface {
 	GenerateToken(userName, tokenName string) (*UserToken, error)
+	RevokeToken(userName, tokenUUID string) error
+	GetInstanceIdentity() (string, error)
 	Info() (*gojenkins.ExecutorResponse, error)
 	SafeRestart() error
 	CreateNode(name string, numExecutors int, description string, remoteFS string, label string, options ...interface{}) (*gojenkins.Node, error)
@@ -55,7 +57,9 @@ type Jenkins interface {
 	CreateView(name string, viewType string) (*gojenkins.View, error)
 	Poll() (int, error)
 	ExecuteScript(groovyScript string) (logs string, err error)
+	ExecuteScriptWithOptions(groovyScript string, options ScriptExecutionOptions) (logs string, err error)
 	GetNodeSecret(name string) (string, error)
+	GetVersion() string
 }
 
 type jenkins struct {
@@ -67,6 +71,22 @@ type JenkinsAPIConnectionSettings struct {
 	Hostname    string
 	Port        int
 	UseNodePort bool
+	// Scheme is the scheme of Jenkins API URL, http or https, defaults to http
+	Scheme string
+	// TLS defines verification of the Jenkins API certificate and the client certificate of the operator
+	TLS TLSSettings
+	// Proxy is the proxy of Jenkins API requests when Jenkins is reached by the hostname, the in-cluster service
+	// of Jenkins is always reached directly
+	Proxy ProxySettings
+	// Retry is the retry policy of Jenkins API requests
+	Retry RetryPolicy
+	// SlowCallThreshold is the duration of Jenkins API requests which are logged as slow, zero disables logging
+	SlowCallThreshold time.Duration
+	// RateLimit is the rate limit of Jenkins API requests sent to single Jenkins
+	RateLimit RateLimit
+	// Headers are set on every Jenkins API request, e.g. credentials of the auth proxy in front of Jenkins, they
+	// override the authentication of the client
+	Headers http.Header
 }
 
 type setBearerToken struct {
@@ -86,11 +106,32 @@ func (t *setBearerToken) RoundTrip(r *http.Request) (*http.Response, error) {
 	return t.transport().RoundTrip(r)
 }
 
+// setHeaders sets headers of every request, it runs after the authentication of the client so the headers override it
+type setHeaders struct {
+	rt      http.RoundTripper
+	headers http.Header
+}
+
+func (t *setHeaders) transport() http.RoundTripper {
+	if t.rt != nil {
+		return t.rt
+	}
+	return http.DefaultTransport
+}
+
+func (t *setHeaders) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	for name, values := range t.headers {
+		r.Header[name] = values
+	}
+	return t.transport().RoundTrip(r)
+}
+
 // CreateOrUpdateJob creates or updates a job from config.
 func (jenkins *jenkins) CreateOrUpdateJob(config, jobName string) (job *gojenkins.Job, created bool, err error) {
 	// create or update
 	job, err = jenkins.GetJob(jobName)
-	if isNotFoundError(err) {
+	if IsNotFoundError(err) {
 		job, err = jenkins.CreateJob(config, jobName)
 		created = true
 		return job, true, errors.WithStack(err)
@@ -105,14 +146,21 @@ func (jenkins *jenkins) CreateOrUpdateJob(config, jobName string) (job *gojenkin
 // BuildJenkinsAPIUrl returns Jenkins API URL.
 func (j JenkinsAPIConnectionSettings) BuildJenkinsAPIUrl(serviceName string, serviceNamespace string, servicePort int32, serviceNodePort int32) string {
 	if j.Hostname == "" && j.Port == 0 {
-		return fmt.Sprintf("http://%s.%s:%d", serviceName, serviceNamespace, servicePort)
+		return fmt.Sprintf("%s://%s.%s:%d", j.scheme(), serviceName, serviceNamespace, servicePort)
 	}
 
 	if j.Hostname != "" && j.UseNodePort {
-		return fmt.Sprintf("http://%s:%d", j.Hostname, serviceNodePort)
+		return fmt.Sprintf("%s://%s:%d", j.scheme(), j.Hostname, serviceNodePort)
 	}
 
-	return fmt.Sprintf("http://%s:%d", j.Hostname, j.Port)
+	return fmt.Sprintf("%s://%s:%d", j.scheme(), j.Hostname, j.Port)
+}
+
+func (j JenkinsAPIConnectionSettings) scheme() string {
+	if len(j.Scheme) == 0 {
+		return HTTPScheme
+	}
+	return j.Scheme
 }
 
 // Validate validates jenkins API connection settings.
@@ -129,20 +177,28 @@ func (j JenkinsAPIConnectionSettings) Validate() error {
 		return errors.New("empty hostname is now allowed. Please provide hostname")
 	}
 
+	if j.scheme() != HTTPScheme && j.scheme() != HTTPSScheme {
+		return errors.Errorf("unsupported scheme '%s' of Jenkins API, use %s or %s", j.Scheme, HTTPScheme, HTTPSScheme)
+	}
+
+	if j.TLS.IsSet() && j.scheme() != HTTPSScheme {
+		return errors.Errorf("TLS settings of Jenkins API require the %s scheme", HTTPSScheme)
+	}
+
 	return nil
 }
 
 // NewUserAndPasswordAuthorization creates Jenkins API client with user and password authorization.
-func NewUserAndPasswordAuthorization(url, userName, passwordOrToken string) (Jenkins, error) {
-	return newClient(url, userName, passwordOrToken)
+func NewUserAndPasswordAuthorization(url, userName, passwordOrToken string, settings JenkinsAPIConnectionSettings) (Jenkins, error) {
+	return newClient(url, userName, passwordOrToken, settings)
 }
 
 // NewBearerTokenAuthorization creates Jenkins API client with bearer token authorization.
-func NewBearerTokenAuthorization(url, token string) (Jenkins, error) {
-	return newClient(url, "", token)
+func NewBearerTokenAuthorization(url, token string, settings JenkinsAPIConnectionSettings) (Jenkins, error) {
+	return newClient(url, "", token, settings)
 }
 
-func newClient(url, userName, passwordOrToken string) (Jenkins, error) {
+func newClient(url, userName, passwordOrToken string, settings JenkinsAPIConnectionSettings) (Jenkins, error) {
 	if strings.HasSuffix(url, "/") {
 		url = url[:len(url)-1]
 	}
@@ -151,18 +207,25 @@ func newClient(url, userName, passwordOrToken string) (Jenkins, error) {
 	jenkinsClient.Server = url
 
 	var basicAuth *gojenkins.BasicAuth
-	jar, err := cookiejar.New(nil)
+	transport, err := settings.newHTTPTransport()
 	if err != nil {
-		return nil, errors.Wrap(err, "couldn't create a cookie jar")
+		return nil, err
+	}
+	// the session and its crumb are reused by clients created by next reconcile loops
+	jenkinsSession := getSession(url, userName, passwordOrToken, settings.Headers)
+	httpClient := &http.Client{Jar: jenkinsSession, Transport: &metricsTransport{rt: transport, slowCallThreshold: settings.SlowCallThreshold}}
+	if len(settings.Headers) > 0 {
+		httpClient.Transport = &setHeaders{headers: settings.Headers, rt: httpClient.Transport}
 	}
-
-	httpClient := &http.Client{Jar: jar}
 
 	if len(userName) > 0 && len(passwordOrToken) > 0 {
 		basicAuth = &gojenkins.BasicAuth{Username: userName, Password: passwordOrToken}
 	} else {
 		httpClient.Transport = &setBearerToken{token: passwordOrToken, rt: httpClient.Transport}
 	}
+	httpClient.Transport = newRateLimitTransport(httpClient.Transport, jenkinsServer(url), settings.RateLimit)
+	httpClient.Transport = &sessionTransport{rt: httpClient.Transport, session: jenkinsSession}
+	httpClient.Transport = newRetryTransport(httpClient.Transport, jenkinsServer(url), settings.Retry)
 
 	jenkinsClient.Requester = &gojenkins.Requester{
 		Base:      url,
@@ -185,7 +248,8 @@ func newClient(url, userName, passwordOrToken string) (Jenkins, error) {
 	return jenkinsClient, nil
 }
 
-func isNotFoundError(err error) bool {
+// IsNotFoundError returns true if the error is returned by Jenkins API for not existing resource.
+func IsNotFoundError(err error) bool {
 	if err != nil {
 		return err.Error() == errorNotFound.Error()
 	}
@@ -216,6 +280,11 @@ func (jenkins *jenkins) GetNodeSecret(name string) (string, error) {
 	return result["secret"], nil
 }
 
+// GetVersion returns the version of Jenkins core reported by the X-Jenkins header when the client was created.
+func (jenkins *jenkins) GetVersion() string {
+	return jenkins.Version
+}
+
 // Returns the list of all plugins installed on the Jenkins server.
 // You can supply depth parameter, to limit how much data is returned.
 func (jenkins *jenkins) GetPlugins(depth int) (*gojenkins.Plugins, error) {
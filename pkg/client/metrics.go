@@ -0,0 +1,111 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "jenkins_operator"
+	metricsSubsystem = "jenkins_api"
+
+	// DefaultSlowCallThreshold is the default duration of Jenkins API requests which are logged as slow
+	DefaultSlowCallThreshold = 10 * time.Second
+
+	endpointGroovyScript  = "groovy_script"
+	endpointCreateJob     = "create_job"
+	endpointJob           = "job"
+	endpointPluginManager = "plugin_manager"
+	endpointNode          = "node"
+	endpointQueue         = "queue"
+	endpointView          = "view"
+	endpointUserToken     = "user_token"
+	endpointCrumb         = "crumb"
+	endpointRoot          = "root"
+	endpointOther         = "other"
+
+	// statusCodeError is the status code label of requests without the response
+	statusCodeError = "error"
+)
+
+var requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metricsNamespace,
+	Subsystem: metricsSubsystem,
+	Name:      "request_duration_seconds",
+	Help:      "Duration of Jenkins API requests in seconds partitioned by endpoint, method and status code.",
+	Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+}, []string{"server", "endpoint", "method", "code"})
+
+func init() {
+	metrics.Registry.MustRegister(requestDuration)
+}
+
+// endpointRules map path segments of Jenkins API to endpoints, the first matching rule wins
+var endpointRules = []struct {
+	segment  string
+	endpoint string
+}{
+	{"/scriptText", endpointGroovyScript},
+	{"/createItem", endpointCreateJob},
+	{"/pluginManager/", endpointPluginManager},
+	{"/updateCenter/", endpointPluginManager},
+	{"/crumbIssuer/", endpointCrumb},
+	{"/jenkins.security.ApiTokenProperty/", endpointUserToken},
+	{"/job/", endpointJob},
+	{"/computer/", endpointNode},
+	{"/queue/", endpointQueue},
+	{"/view/", endpointView},
+}
+
+// getEndpoint returns the endpoint of the request path, it keeps the cardinality of metrics low
+func getEndpoint(path string) string {
+	for _, rule := range endpointRules {
+		if strings.Contains(path, rule.segment) {
+			return rule.endpoint
+		}
+	}
+	if path == "" || path == "/" || (strings.HasSuffix(path, "/api/json") && strings.Count(path, "/") <= 3) {
+		return endpointRoot
+	}
+	return endpointOther
+}
+
+// metricsTransport records the duration of every Jenkins API request and logs requests slower than the threshold
+type metricsTransport struct {
+	rt                http.RoundTripper
+	slowCallThreshold time.Duration
+}
+
+func (t *metricsTransport) transport() http.RoundTripper {
+	if t.rt != nil {
+		return t.rt
+	}
+	return http.DefaultTransport
+}
+
+func (t *metricsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	started := time.Now()
+	response, err := t.transport().RoundTrip(r)
+	duration := time.Since(started)
+
+	endpoint := getEndpoint(r.URL.Path)
+	code := statusCodeError
+	if response != nil {
+		code = fmt.Sprint(response.StatusCode)
+	}
+	server := fmt.Sprintf("%s://%s", r.URL.Scheme, r.URL.Host)
+	requestDuration.WithLabelValues(server, endpoint, r.Method, code).Observe(duration.Seconds())
+
+	if t.slowCallThreshold > 0 && duration >= t.slowCallThreshold {
+		log.Log.V(log.VWarn).Info(fmt.Sprintf("Slow Jenkins API request %s %s took %s", r.Method, r.URL.Path, duration.Round(time.Millisecond)),
+			"server", server, "endpoint", endpoint, "code", code)
+	}
+	return response, err
+}
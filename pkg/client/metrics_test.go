@@ -0,0 +1,44 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEndpoint(t *testing.T) {
+	for path, endpoint := range map[string]string{
+		"/api/json":                    endpointRoot,
+		"/jenkins/api/json":            endpointRoot,
+		"/jenkins/scriptText":          endpointGroovyScript,
+		"/createItem":                  endpointCreateJob,
+		"/job/seed/job/build/api/json": endpointJob,
+		"/pluginManager/api/json":      endpointPluginManager,
+		"/crumbIssuer/api/json":        endpointCrumb,
+		"/user/jenkins-operator/descriptorByName/jenkins.security.ApiTokenProperty/generateNewToken": endpointUserToken,
+		"/computer/agent/slave-agent.jnlp": endpointNode,
+		"/whoAmI/api/json/extra":           endpointOther,
+	} {
+		assert.Equal(t, endpoint, getEndpoint(path), path)
+	}
+}
+
+func TestMetricsTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	httpClient := &http.Client{Transport: &metricsTransport{slowCallThreshold: DefaultSlowCallThreshold}}
+	series := testutil.CollectAndCount(requestDuration)
+
+	response, err := httpClient.Post(server.URL+"/scriptText", "text/plain", nil)
+	require.NoError(t, err)
+	_ = response.Body.Close()
+
+	// the series of the request is created by the transport
+	assert.Equal(t, series+1, testutil.CollectAndCount(requestDuration))
+}
@@ -62,6 +62,35 @@ func (mr *MockJenkinsMockRecorder) GenerateToken(userName, tokenName interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockJenkins)(nil).GenerateToken), userName, tokenName)
 }
 
+// RevokeToken mocks base method
+func (m *MockJenkins) RevokeToken(userName, tokenUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", userName, tokenUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken
+func (mr *MockJenkinsMockRecorder) RevokeToken(userName, tokenUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockJenkins)(nil).RevokeToken), userName, tokenUUID)
+}
+
+// GetInstanceIdentity mocks base method
+func (m *MockJenkins) GetInstanceIdentity() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceIdentity")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceIdentity indicates an expected call of GetInstanceIdentity
+func (mr *MockJenkinsMockRecorder) GetInstanceIdentity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceIdentity", reflect.TypeOf((*MockJenkins)(nil).GetInstanceIdentity))
+}
+
 // Info mocks base method
 func (m *MockJenkins) Info() (*gojenkins.ExecutorResponse, error) {
 	m.ctrl.T.Helper()
@@ -632,3 +661,32 @@ func (mr *MockJenkinsMockRecorder) ExecuteScript(groovyScript interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteScript", reflect.TypeOf((*MockJenkins)(nil).ExecuteScript), groovyScript)
 }
+
+// ExecuteScriptWithOptions mocks base method
+func (m *MockJenkins) ExecuteScriptWithOptions(groovyScript string, options ScriptExecutionOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteScriptWithOptions", groovyScript, options)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteScriptWithOptions indicates an expected call of ExecuteScriptWithOptions
+func (mr *MockJenkinsMockRecorder) ExecuteScriptWithOptions(groovyScript, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteScriptWithOptions", reflect.TypeOf((*MockJenkins)(nil).ExecuteScriptWithOptions), groovyScript, options)
+}
+
+// GetVersion mocks base method
+func (m *MockJenkins) GetVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetVersion indicates an expected call of GetVersion
+func (mr *MockJenkinsMockRecorder) GetVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockJenkins)(nil).GetVersion))
+}
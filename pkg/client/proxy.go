@@ -0,0 +1,57 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// InClusterNoProxy are addresses which are always reached directly, they are appended to NoProxy
+const InClusterNoProxy = "localhost,127.0.0.1,.svc,.cluster.local"
+
+// ProxySettings defines the HTTP/S proxy of requests sent by the operator to Jenkins and the update center
+type ProxySettings struct {
+	// HTTPProxy is the proxy URL of HTTP requests
+	HTTPProxy string
+	// HTTPSProxy is the proxy URL of HTTPS requests
+	HTTPSProxy string
+	// NoProxy is the comma separated list of hosts, domains, IP addresses and CIDRs reached directly
+	NoProxy string
+}
+
+// ProxySettingsFromEnvironment returns proxy settings from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+// variables (or their lowercase versions)
+func ProxySettingsFromEnvironment() ProxySettings {
+	config := httpproxy.FromEnvironment()
+	return ProxySettings{HTTPProxy: config.HTTPProxy, HTTPSProxy: config.HTTPSProxy, NoProxy: config.NoProxy}
+}
+
+// IsSet returns true if any proxy is set
+func (p ProxySettings) IsSet() bool {
+	return len(p.HTTPProxy) > 0 || len(p.HTTPSProxy) > 0
+}
+
+// ProxyFunc returns the proxy function of http.Transport, in-cluster addresses are always reached directly
+func (p ProxySettings) ProxyFunc() func(*http.Request) (*url.URL, error) {
+	if !p.IsSet() {
+		return nil
+	}
+
+	noProxy := InClusterNoProxy
+	if len(strings.TrimSpace(p.NoProxy)) > 0 {
+		noProxy = p.NoProxy + "," + noProxy
+	}
+	proxyFunc := (&httpproxy.Config{HTTPProxy: p.HTTPProxy, HTTPSProxy: p.HTTPSProxy, NoProxy: noProxy}).ProxyFunc()
+	return func(r *http.Request) (*url.URL, error) {
+		return proxyFunc(r.URL)
+	}
+}
+
+// NewHTTPTransport returns the copy of the default transport which sends requests through the proxy
+func (p ProxySettings) NewHTTPTransport() http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = p.ProxyFunc()
+	return transport
+}
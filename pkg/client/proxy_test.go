@@ -0,0 +1,60 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxySettings_ProxyFunc(t *testing.T) {
+	proxyURL := func(settings ProxySettings, url string) string {
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		proxyFunc := settings.ProxyFunc()
+		if proxyFunc == nil {
+			return ""
+		}
+		proxy, err := proxyFunc(request)
+		require.NoError(t, err)
+		if proxy == nil {
+			return ""
+		}
+		return proxy.String()
+	}
+	settings := ProxySettings{HTTPProxy: "http://proxy:3128", HTTPSProxy: "http://secure-proxy:3128", NoProxy: "example.com"}
+
+	assert.Equal(t, "", proxyURL(ProxySettings{}, "https://updates.jenkins.io"))
+	assert.Equal(t, "http://secure-proxy:3128", proxyURL(settings, "https://updates.jenkins.io"))
+	assert.Equal(t, "http://proxy:3128", proxyURL(settings, "http://jenkins.company.com:8080"))
+	assert.Equal(t, "", proxyURL(settings, "https://jenkins.example.com"))
+	assert.Equal(t, "", proxyURL(settings, "http://jenkins-operator-http-example.default.svc:8080"))
+	assert.Equal(t, "", proxyURL(settings, "http://jenkins-operator-http-example.default.svc.cluster.local:8080"))
+	assert.Equal(t, "", proxyURL(settings, "http://localhost:8080"))
+}
+
+func TestJenkinsAPIConnectionSettings_newHTTPTransport(t *testing.T) {
+	proxy := ProxySettings{HTTPProxy: "http://proxy:3128"}
+	request, err := http.NewRequest(http.MethodGet, "http://jenkins.company.com:8080", nil)
+	require.NoError(t, err)
+
+	t.Run("in-cluster service", func(t *testing.T) {
+		transport, err := JenkinsAPIConnectionSettings{Proxy: proxy}.newHTTPTransport()
+
+		require.NoError(t, err)
+		assert.Nil(t, transport.(*http.Transport).Proxy)
+	})
+	t.Run("hostname", func(t *testing.T) {
+		transport, err := JenkinsAPIConnectionSettings{Hostname: "jenkins.company.com", Port: 8080, Proxy: proxy}.newHTTPTransport()
+
+		require.NoError(t, err)
+		proxyURL, err := transport.(*http.Transport).Proxy(request)
+		require.NoError(t, err)
+		assert.Equal(t, "http://proxy:3128", proxyURL.String())
+
+		sameTransport, err := JenkinsAPIConnectionSettings{Hostname: "other.company.com", Proxy: proxy}.newHTTPTransport()
+		require.NoError(t, err)
+		assert.True(t, transport == sameTransport)
+	})
+}
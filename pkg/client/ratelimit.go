@@ -0,0 +1,76 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// RateLimit defines the token bucket rate limiter of Jenkins API requests sent to single Jenkins
+type RateLimit struct {
+	// QPS is the average number of requests per second, zero disables rate limiting
+	QPS float32
+	// Burst is the maximal number of requests sent at once
+	Burst int
+}
+
+// DefaultRateLimit is the rate limit of the operator
+var DefaultRateLimit = RateLimit{QPS: 10, Burst: 20}
+
+// rateLimiter is the rate limiter of single Jenkins with its settings
+type rateLimiter struct {
+	limiter   flowcontrol.RateLimiter
+	rateLimit RateLimit
+}
+
+var (
+	rateLimitersMutex sync.Mutex
+	// rateLimiters are shared by clients of the same Jenkins, so concurrent reconcile loops don't exceed the limit
+	rateLimiters = map[string]*rateLimiter{}
+)
+
+// getRateLimiter returns the rate limiter of Jenkins, the new one is created when the rate limit changes
+func getRateLimiter(jenkinsURL string, rateLimit RateLimit) flowcontrol.RateLimiter {
+	rateLimitersMutex.Lock()
+	defer rateLimitersMutex.Unlock()
+
+	limiter, found := rateLimiters[jenkinsURL]
+	if !found || limiter.rateLimit != rateLimit {
+		burst := rateLimit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = &rateLimiter{limiter: flowcontrol.NewTokenBucketRateLimiter(rateLimit.QPS, burst), rateLimit: rateLimit}
+		rateLimiters[jenkinsURL] = limiter
+	}
+	return limiter.limiter
+}
+
+// rateLimitTransport delays requests which exceed the rate limit of Jenkins
+type rateLimitTransport struct {
+	rt      http.RoundTripper
+	limiter flowcontrol.RateLimiter
+}
+
+func newRateLimitTransport(rt http.RoundTripper, jenkinsURL string, rateLimit RateLimit) http.RoundTripper {
+	if rateLimit.QPS <= 0 {
+		return rt
+	}
+	return &rateLimitTransport{rt: rt, limiter: getRateLimiter(jenkinsURL, rateLimit)}
+}
+
+func (t *rateLimitTransport) transport() http.RoundTripper {
+	if t.rt != nil {
+		return t.rt
+	}
+	return http.DefaultTransport
+}
+
+func (t *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(r.Context()); err != nil {
+		return nil, errors.Wrap(err, "rate limit of Jenkins API exceeded")
+	}
+	return t.transport().RoundTrip(r)
+}
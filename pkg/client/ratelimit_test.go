@@ -0,0 +1,40 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, http.DefaultTransport, newRateLimitTransport(http.DefaultTransport, server.URL, RateLimit{}))
+	})
+	t.Run("requests over the burst are delayed", func(t *testing.T) {
+		rateLimit := RateLimit{QPS: 20, Burst: 1}
+		httpClient := &http.Client{Transport: newRateLimitTransport(nil, server.URL, rateLimit)}
+
+		started := time.Now()
+		for i := 0; i < 3; i++ {
+			response, err := httpClient.Get(server.URL)
+			require.NoError(t, err)
+			_ = response.Body.Close()
+		}
+
+		assert.True(t, time.Since(started) >= 90*time.Millisecond)
+	})
+	t.Run("limiter is shared by clients of the same Jenkins", func(t *testing.T) {
+		rateLimit := RateLimit{QPS: 5, Burst: 5}
+		limiter := getRateLimiter(server.URL, rateLimit)
+
+		assert.Same(t, limiter, getRateLimiter(server.URL, rateLimit))
+		assert.NotSame(t, limiter, getRateLimiter(server.URL, RateLimit{QPS: 10, Burst: 5}))
+	})
+}
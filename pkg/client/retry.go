@@ -0,0 +1,274 @@
+package client
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy defines retries of failed Jenkins API requests and the circuit breaker which stops sending requests
+// to Jenkins which keeps failing
+type RetryPolicy struct {
+	// Retries is the number of retries of an idempotent request after a transient error, zero disables retries
+	Retries int
+	// InitialBackoff is the maximal delay before the first retry, the delay doubles with every retry
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximal delay between retries
+	MaxBackoff time.Duration
+	// FailureThreshold is the number of consecutive failed requests which opens the circuit breaker, zero disables it
+	FailureThreshold int
+	// OpenDuration is the duration after which the open circuit breaker lets a trial request through
+	OpenDuration time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of the operator
+var DefaultRetryPolicy = RetryPolicy{
+	Retries:          3,
+	InitialBackoff:   500 * time.Millisecond,
+	MaxBackoff:       5 * time.Second,
+	FailureThreshold: 5,
+	OpenDuration:     30 * time.Second,
+}
+
+// CircuitOpenError is returned without sending the request when the circuit breaker of Jenkins is open
+type CircuitOpenError struct {
+	URL string
+	// RetryAfter is the duration after which the circuit breaker lets a trial request through
+	RetryAfter time.Duration
+}
+
+func (e CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker of Jenkins API %s is open, retry after %s", e.URL, e.RetryAfter.Round(time.Second))
+}
+
+// IsTransientError returns true if the error is caused by Jenkins which is temporarily unavailable, e.g. busy or
+// restarting, the request may succeed later
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var circuitOpenErr CircuitOpenError
+	if errors.As(err, &circuitOpenErr) {
+		return true
+	}
+	var scriptErr transientError
+	if errors.As(err, &scriptErr) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	// gojenkins returns the status code as the error message
+	switch errors.Cause(err).Error() {
+	case fmt.Sprint(http.StatusBadGateway), fmt.Sprint(http.StatusServiceUnavailable), fmt.Sprint(http.StatusGatewayTimeout):
+		return true
+	}
+	return false
+}
+
+// GetRetryAfter returns the duration after which the request failed with the error should be retried, zero when
+// it's unknown
+func GetRetryAfter(err error) time.Duration {
+	var circuitOpenErr CircuitOpenError
+	if errors.As(err, &circuitOpenErr) {
+		return circuitOpenErr.RetryAfter
+	}
+	return 0
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker is thread-safe state of requests to single Jenkins, it opens after the number of consecutive
+// failures and lets a single trial request through after the open duration
+type circuitBreaker struct {
+	mutex        sync.Mutex
+	state        circuitState
+	failures     int
+	openedAt     time.Time
+	halfOpenedAt time.Time
+}
+
+var (
+	circuitBreakersMutex sync.Mutex
+	// circuitBreakers are shared by clients of the same Jenkins, clients are created by every reconcile loop
+	circuitBreakers = map[string]*circuitBreaker{}
+)
+
+func getCircuitBreaker(jenkinsURL string) *circuitBreaker {
+	circuitBreakersMutex.Lock()
+	defer circuitBreakersMutex.Unlock()
+
+	breaker, found := circuitBreakers[jenkinsURL]
+	if !found {
+		breaker = &circuitBreaker{}
+		circuitBreakers[jenkinsURL] = breaker
+	}
+	return breaker
+}
+
+// allow returns zero if the request can be sent, otherwise the duration after which the trial request is let through
+func (b *circuitBreaker) allow(policy RetryPolicy) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if sinceOpened := time.Since(b.openedAt); sinceOpened < policy.OpenDuration {
+			return policy.OpenDuration - sinceOpened
+		}
+		b.state = circuitHalfOpen
+		b.halfOpenedAt = time.Now()
+		return 0
+	case circuitHalfOpen:
+		// the trial request which hasn't finished within the open duration is given up, the next one is let
+		// through after the open duration again
+		if time.Since(b.halfOpenedAt) >= policy.OpenDuration {
+			b.state = circuitOpen
+			b.openedAt = time.Now()
+		}
+		// only one trial request is in flight
+		return policy.OpenDuration
+	default:
+		return 0
+	}
+}
+
+func (b *circuitBreaker) record(policy RetryPolicy, failed bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !failed {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= policy.FailureThreshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// retryTransport retries idempotent requests after transient errors with jittered exponential backoff and records
+// results of requests in the circuit breaker of Jenkins
+type retryTransport struct {
+	rt      http.RoundTripper
+	policy  RetryPolicy
+	breaker *circuitBreaker
+}
+
+func newRetryTransport(rt http.RoundTripper, jenkinsURL string, policy RetryPolicy) http.RoundTripper {
+	transport := &retryTransport{rt: rt, policy: policy}
+	if policy.FailureThreshold > 0 {
+		transport.breaker = getCircuitBreaker(jenkinsURL)
+	}
+	return transport
+}
+
+func (t *retryTransport) transport() http.RoundTripper {
+	if t.rt != nil {
+		return t.rt
+	}
+	return http.DefaultTransport
+}
+
+func (t *retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	// retryable is the result of the last attempt, it's recorded on every return of the sent request
+	retryable := false
+	if t.breaker != nil {
+		if retryAfter := t.breaker.allow(t.policy); retryAfter > 0 {
+			return nil, CircuitOpenError{URL: fmt.Sprintf("%s://%s", r.URL.Scheme, r.URL.Host), RetryAfter: retryAfter}
+		}
+		defer func() {
+			t.breaker.record(t.policy, retryable)
+		}()
+	}
+
+	for attempt := 0; ; attempt++ {
+		response, err := t.transport().RoundTrip(r)
+		retryable = isRetryable(response, err)
+		if !retryable || attempt >= t.policy.Retries || !isIdempotent(r) {
+			return response, err
+		}
+
+		if r.Body != nil && r.Body != http.NoBody {
+			if r.GetBody == nil {
+				return response, err
+			}
+			body, bodyErr := r.GetBody()
+			if bodyErr != nil {
+				return response, err
+			}
+			r.Body = body
+		}
+		if response != nil {
+			_ = response.Body.Close()
+		}
+		time.Sleep(t.backoff(attempt))
+	}
+}
+
+// backoff returns random delay up to the exponential backoff of the attempt
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	backoff := t.policy.InitialBackoff
+	for i := 0; i < attempt && backoff < t.policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > t.policy.MaxBackoff {
+		backoff = t.policy.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(backoff)))
+}
+
+// isRetryable returns true for responses of Jenkins which is busy or restarting behind a proxy, timeouts
+// and refused connections
+func isRetryable(response *http.Response, err error) bool {
+	if err != nil {
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			return true
+		}
+		var opErr *net.OpError
+		return errors.As(err, &opErr)
+	}
+	switch response.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent returns true for requests which don't change Jenkins, e.g. groovy scripts aren't retried because
+// they may still be running, they are retried by ExecuteScriptWithOptions
+func isIdempotent(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// jenkinsServer returns the scheme and the host of the Jenkins URL which identifies the circuit breaker
+func jenkinsServer(jenkinsURL string) string {
+	parsed, err := url.Parse(jenkinsURL)
+	if err != nil {
+		return jenkinsURL
+	}
+	return fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host)
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	newServer := func(statusCodes ...int) (*httptest.Server, *int) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			statusCode := http.StatusOK
+			if requests < len(statusCodes) {
+				statusCode = statusCodes[requests]
+			}
+			requests++
+			w.WriteHeader(statusCode)
+		}))
+		return server, &requests
+	}
+
+	t.Run("retries idempotent request after transient errors", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable, http.StatusBadGateway)
+		defer server.Close()
+		httpClient := &http.Client{Transport: newRetryTransport(nil, server.URL, RetryPolicy{Retries: 3})}
+
+		response, err := httpClient.Get(server.URL)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 3, *requests)
+	})
+	t.Run("gives up after retries", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		defer server.Close()
+		httpClient := &http.Client{Transport: newRetryTransport(nil, server.URL, RetryPolicy{Retries: 1})}
+
+		response, err := httpClient.Get(server.URL)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		assert.Equal(t, 2, *requests)
+	})
+	t.Run("doesn't retry other errors", func(t *testing.T) {
+		server, requests := newServer(http.StatusInternalServerError)
+		defer server.Close()
+		httpClient := &http.Client{Transport: newRetryTransport(nil, server.URL, RetryPolicy{Retries: 3})}
+
+		response, err := httpClient.Get(server.URL)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+		assert.Equal(t, 1, *requests)
+	})
+	t.Run("doesn't retry non-idempotent request", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable)
+		defer server.Close()
+		httpClient := &http.Client{Transport: newRetryTransport(nil, server.URL, RetryPolicy{Retries: 3})}
+
+		response, err := httpClient.Post(server.URL, "text/plain", strings.NewReader("script"))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		assert.Equal(t, 1, *requests)
+	})
+	t.Run("circuit breaker", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		defer server.Close()
+		policy := RetryPolicy{FailureThreshold: 2, OpenDuration: 100 * time.Millisecond}
+		httpClient := &http.Client{Transport: newRetryTransport(nil, jenkinsServer(server.URL), policy)}
+
+		for i := 0; i < 2; i++ {
+			_, err := httpClient.Get(server.URL)
+			require.NoError(t, err)
+		}
+		_, err := httpClient.Get(server.URL)
+
+		assert.True(t, IsTransientError(err))
+		assert.True(t, GetRetryAfter(err) > 0)
+		assert.Equal(t, 2, *requests)
+
+		// the trial request fails and opens the circuit breaker again
+		time.Sleep(policy.OpenDuration)
+		_, err = httpClient.Get(server.URL)
+		require.NoError(t, err)
+		_, err = httpClient.Get(server.URL)
+		assert.True(t, IsTransientError(err))
+		assert.Equal(t, 3, *requests)
+
+		// the successful trial request closes the circuit breaker
+		time.Sleep(policy.OpenDuration)
+		for i := 0; i < 2; i++ {
+			response, err := httpClient.Get(server.URL)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+		}
+		assert.Equal(t, 5, *requests)
+	})
+	t.Run("circuit breaker records request which body can't be sent again", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		defer server.Close()
+		policy := RetryPolicy{Retries: 3, FailureThreshold: 1, OpenDuration: time.Minute}
+		httpClient := &http.Client{Transport: newRetryTransport(nil, jenkinsServer(server.URL), policy)}
+		request, err := http.NewRequest(http.MethodGet, server.URL, strings.NewReader("body"))
+		require.NoError(t, err)
+		request.GetBody = nil
+
+		response, err := httpClient.Do(request)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		_, err = httpClient.Get(server.URL)
+		assert.True(t, IsTransientError(err))
+		assert.Equal(t, 1, *requests)
+	})
+}
+
+func TestCircuitBreaker_HalfOpenTimeout(t *testing.T) {
+	policy := RetryPolicy{FailureThreshold: 1, OpenDuration: 50 * time.Millisecond}
+	breaker := &circuitBreaker{}
+	breaker.record(policy, true)
+	time.Sleep(policy.OpenDuration)
+
+	// the trial request is let through and never finishes
+	assert.Zero(t, breaker.allow(policy))
+	assert.Equal(t, policy.OpenDuration, breaker.allow(policy))
+
+	time.Sleep(policy.OpenDuration)
+	assert.Equal(t, policy.OpenDuration, breaker.allow(policy))
+	assert.Equal(t, circuitOpen, breaker.state)
+
+	time.Sleep(policy.OpenDuration)
+	assert.Zero(t, breaker.allow(policy))
+}
+
+func TestIsTransientError(t *testing.T) {
+	assert.False(t, IsTransientError(nil))
+	assert.False(t, IsTransientError(errors.New("404")))
+	assert.False(t, IsTransientError(errors.New("couldn't generate API token")))
+	assert.True(t, IsTransientError(errors.WithStack(errors.New("503"))))
+	assert.True(t, IsTransientError(errors.Wrap(transientError{errors.New("invalid status code '502'")}, "couldn't execute groovy script")))
+	assert.True(t, IsTransientError(errors.Wrap(CircuitOpenError{URL: "http://jenkins:8080", RetryAfter: time.Second}, "couldn't poll data from Jenkins API")))
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -24,13 +25,51 @@ func (e GroovyScriptExecutionFailed) Error() string {
 	return "script execution failed"
 }
 
+// ScriptExecutionOptions defines the timeout and retry policy of groovy script execution
+type ScriptExecutionOptions struct {
+	// Timeout is the maximum duration of a single script execution request, zero means no timeout
+	Timeout time.Duration
+	// Retries is the number of retries after a transient error
+	Retries int
+	// RetryInterval is the duration between retries
+	RetryInterval time.Duration
+}
+
+// transientError indicates the script wasn't executed because Jenkins is temporarily unavailable
+type transientError struct {
+	error
+}
+
 func (jenkins *jenkins) ExecuteScript(script string) (string, error) {
-	now := time.Now().Unix()
-	verifier := fmt.Sprintf("verifier-%d", now)
-	return jenkins.executeScript(script, verifier)
+	return jenkins.ExecuteScriptWithOptions(script, ScriptExecutionOptions{})
+}
+
+// ExecuteScriptWithOptions executes groovy script and retries it after transient errors
+func (jenkins *jenkins) ExecuteScriptWithOptions(script string, options ScriptExecutionOptions) (string, error) {
+	requester := jenkins.Requester
+	if options.Timeout > 0 {
+		httpClient := *jenkins.Requester.Client
+		httpClient.Timeout = options.Timeout
+		requesterWithTimeout := *jenkins.Requester
+		requesterWithTimeout.Client = &httpClient
+		requester = &requesterWithTimeout
+	}
+
+	for attempt := 0; ; attempt++ {
+		verifier := fmt.Sprintf("verifier-%d", time.Now().Unix())
+		logs, err := executeScript(requester, script, verifier)
+		if _, ok := errors.Cause(err).(transientError); !ok || attempt >= options.Retries {
+			return logs, err
+		}
+		time.Sleep(options.RetryInterval)
+	}
 }
 
 func (jenkins *jenkins) executeScript(script string, verifier string) (string, error) {
+	return executeScript(jenkins.Requester, script, verifier)
+}
+
+func executeScript(requester *gojenkins.Requester, script string, verifier string) (string, error) {
 	output := ""
 	fullScript := fmt.Sprintf("%s\nprint println('%s')", script, verifier)
 
@@ -38,20 +77,29 @@ func (jenkins *jenkins) executeScript(script string, verifier string) (string, e
 	data.Set("script", fullScript)
 
 	ar := gojenkins.NewAPIRequest("POST", "/scriptText", bytes.NewBufferString(data.Encode()))
-	if err := jenkins.Requester.SetCrumb(ar); err != nil {
-		return output, err
+	if err := setCrumb(requester, ar); err != nil {
+		return output, errors.Wrap(err, "couldn't get crumb")
 	}
 	ar.SetHeader("Content-Type", "application/x-www-form-urlencoded")
 	ar.Suffix = ""
 
-	r, err := jenkins.Requester.Do(ar, &output, nil)
+	r, err := requester.Do(ar, &output, nil)
 	if err != nil {
+		// the script may still be running when the request timed out so it's not retried
+		if netErr, ok := err.(net.Error); !ok || !netErr.Timeout() {
+			err = transientError{err}
+		}
 		return "", errors.Wrapf(err, "couldn't execute groovy script, logs '%s'", output)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		return output, errors.Errorf("invalid status code '%d', logs '%s'", r.StatusCode, output)
+		err = errors.Errorf("invalid status code '%d', logs '%s'", r.StatusCode, output)
+		switch r.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			err = transientError{err}
+		}
+		return output, err
 	}
 
 	if !strings.Contains(output, verifier) {
@@ -60,3 +108,18 @@ func (jenkins *jenkins) executeScript(script string, verifier string) (string, e
 
 	return output, nil
 }
+
+// setCrumb sets the CSRF protection header of the request, unlike Requester.SetCrumb it returns the error when
+// Jenkins is unavailable
+func setCrumb(requester *gojenkins.Requester, ar *gojenkins.APIRequest) error {
+	crumbData := map[string]string{}
+	response, err := requester.GetJSON("/crumbIssuer/api/json", &crumbData, nil)
+	if response == nil {
+		return err
+	}
+
+	if response.StatusCode == http.StatusOK && crumbData["crumbRequestField"] != "" {
+		ar.SetHeader(crumbData["crumbRequestField"], crumbData["crumb"])
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bndr/gojenkins"
 	"github.com/stretchr/testify/assert"
@@ -86,3 +87,89 @@ func Test_ExecuteScript(t *testing.T) {
 		assert.EqualError(t, err, "invalid status code '500', logs ''", logs)
 	})
 }
+
+func Test_ExecuteScriptWithOptions(t *testing.T) {
+	newJenkinsClient := func(ts *httptest.Server) *jenkins {
+		jenkinsClient := &jenkins{}
+		jenkinsClient.Server = ts.URL
+		jenkinsClient.Requester = &gojenkins.Requester{
+			Base:      ts.URL,
+			SslVerify: true,
+			Client:    ts.Client(),
+			BasicAuth: &gojenkins.BasicAuth{Username: "unused", Password: "unused"},
+		}
+		return jenkinsClient
+	}
+
+	t.Run("retry after service unavailable", func(t *testing.T) {
+		calls := 0
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+			if !strings.Contains(request.URL.Path, "/scriptText") {
+				responseWriter.WriteHeader(http.StatusNotFound)
+				return
+			}
+			calls++
+			if calls < 3 {
+				responseWriter.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = fmt.Fprint(responseWriter, request.FormValue("script"))
+		}))
+		defer ts.Close()
+
+		_, err := newJenkinsClient(ts).ExecuteScriptWithOptions(script, ScriptExecutionOptions{Retries: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+	t.Run("retries exceeded", func(t *testing.T) {
+		calls := 0
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+			if strings.Contains(request.URL.Path, "/scriptText") {
+				calls++
+			}
+			responseWriter.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+
+		_, err := newJenkinsClient(ts).ExecuteScriptWithOptions(script, ScriptExecutionOptions{Retries: 1})
+
+		assert.EqualError(t, err, "invalid status code '503', logs ''")
+		assert.Equal(t, 2, calls)
+	})
+	t.Run("script execution failure is not retried", func(t *testing.T) {
+		calls := 0
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+			if strings.Contains(request.URL.Path, "/scriptText") {
+				calls++
+				_, _ = fmt.Fprint(responseWriter, "some exception stack trace without verifier")
+				return
+			}
+			responseWriter.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+
+		_, err := newJenkinsClient(ts).ExecuteScriptWithOptions(script, ScriptExecutionOptions{Retries: 3})
+
+		assert.EqualError(t, err, "script execution failed")
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		calls := 0
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+			if strings.Contains(request.URL.Path, "/scriptText") {
+				calls++
+				time.Sleep(200 * time.Millisecond)
+			}
+			responseWriter.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+		jenkinsClient := newJenkinsClient(ts)
+
+		_, err := jenkinsClient.ExecuteScriptWithOptions(script, ScriptExecutionOptions{Timeout: 50 * time.Millisecond, Retries: 3})
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Zero(t, jenkinsClient.Requester.Client.Timeout)
+	})
+}
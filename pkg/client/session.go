@@ -0,0 +1,145 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// crumbIssuerPath is the path of the Jenkins API endpoint which issues CSRF protection crumbs
+const crumbIssuerPath = "/crumbIssuer/api/json"
+
+// session is the authenticated web session of the Jenkins user with its CSRF protection crumb, Jenkins binds crumbs
+// to the session so they are reused together
+type session struct {
+	mutex           sync.Mutex
+	credentialsHash [sha256.Size]byte
+	jar             *cookiejar.Jar
+	crumb           []byte
+}
+
+// sessionKey identifies the session of the user in Jenkins
+type sessionKey struct {
+	server   string
+	userName string
+}
+
+var (
+	sessionsMutex sync.Mutex
+	// sessions are shared by clients of the same Jenkins and user, clients are created by every reconcile loop
+	sessions = map[sessionKey]*session{}
+)
+
+// getSession returns the session of the user in Jenkins, the new session is created when the credentials change
+func getSession(jenkinsURL, userName, passwordOrToken string, headers http.Header) *session {
+	sessionsMutex.Lock()
+	defer sessionsMutex.Unlock()
+
+	key := sessionKey{server: jenkinsServer(jenkinsURL), userName: userName}
+	hash := sha256.New()
+	hash.Write([]byte(passwordOrToken))
+	_ = headers.Write(hash)
+	var credentialsHash [sha256.Size]byte
+	copy(credentialsHash[:], hash.Sum(nil))
+	s, found := sessions[key]
+	if !found || s.credentialsHash != credentialsHash {
+		s = &session{credentialsHash: credentialsHash}
+		s.invalidate()
+		sessions[key] = s
+	}
+	return s
+}
+
+// invalidate forgets the session cookies and the crumb, e.g. after Jenkins has been restarted
+func (s *session) invalidate() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.jar, _ = cookiejar.New(nil)
+	s.crumb = nil
+}
+
+// SetCookies implements http.CookieJar
+func (s *session) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.jar.SetCookies(u, cookies)
+}
+
+// Cookies implements http.CookieJar
+func (s *session) Cookies(u *url.URL) []*http.Cookie {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jar.Cookies(u)
+}
+
+func (s *session) getCrumb() []byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.crumb
+}
+
+func (s *session) setCrumb(crumb []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.crumb = crumb
+}
+
+// sessionTransport serves the crumb of the session without requesting Jenkins and invalidates the session when
+// Jenkins rejects the request
+type sessionTransport struct {
+	rt      http.RoundTripper
+	session *session
+}
+
+func (t *sessionTransport) transport() http.RoundTripper {
+	if t.rt != nil {
+		return t.rt
+	}
+	return http.DefaultTransport
+}
+
+func (t *sessionTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	isCrumbRequest := r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, crumbIssuerPath)
+	if crumb := t.session.getCrumb(); isCrumbRequest && crumb != nil {
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          ioutil.NopCloser(bytes.NewReader(crumb)),
+			ContentLength: int64(len(crumb)),
+			Request:       r,
+		}, nil
+	}
+
+	response, err := t.transport().RoundTrip(r)
+	if err != nil {
+		return response, err
+	}
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden:
+		t.session.invalidate()
+	case isCrumbRequest && response.StatusCode == http.StatusOK:
+		crumb, err := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		t.session.setCrumb(crumb)
+		response.Body = ioutil.NopCloser(bytes.NewReader(crumb))
+	}
+	return response, nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionTransport(t *testing.T) {
+	crumbRequests, rejectScript := 0, false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jenkins" + crumbIssuerPath:
+			crumbRequests++
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "session", Path: "/jenkins"})
+			_, _ = w.Write([]byte(`{"crumbRequestField":"Jenkins-Crumb","crumb":"crumb"}`))
+		case "/jenkins/scriptText":
+			if cookie, err := r.Cookie("JSESSIONID"); rejectScript || err != nil || cookie.Value != "session" {
+				w.WriteHeader(http.StatusForbidden)
+			}
+		}
+	}))
+	defer server.Close()
+	jenkinsSession := getSession(server.URL, "jenkins-operator", "token", nil)
+	httpClient := &http.Client{Jar: jenkinsSession, Transport: &sessionTransport{session: jenkinsSession}}
+	getCrumb := func() string {
+		response, err := httpClient.Get(server.URL + "/jenkins" + crumbIssuerPath)
+		require.NoError(t, err)
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+	postScript := func() int {
+		response, err := httpClient.Post(server.URL+"/jenkins/scriptText", "text/plain", nil)
+		require.NoError(t, err)
+		_ = response.Body.Close()
+		return response.StatusCode
+	}
+
+	t.Run("crumb and cookies are reused", func(t *testing.T) {
+		assert.Contains(t, getCrumb(), `"crumb":"crumb"`)
+		assert.Contains(t, getCrumb(), `"crumb":"crumb"`)
+		assert.Equal(t, 1, crumbRequests)
+		assert.Equal(t, http.StatusOK, postScript())
+		assert.Same(t, jenkinsSession, getSession(server.URL, "jenkins-operator", "token", nil))
+	})
+	t.Run("rejected request invalidates session", func(t *testing.T) {
+		rejectScript = true
+		assert.Equal(t, http.StatusForbidden, postScript())
+		rejectScript = false
+
+		getCrumb()
+		assert.Equal(t, 2, crumbRequests)
+		assert.Equal(t, http.StatusOK, postScript())
+	})
+	t.Run("new credentials create new session", func(t *testing.T) {
+		assert.NotSame(t, jenkinsSession, getSession(server.URL, "jenkins-operator", "rotated-token", nil))
+	})
+}
+
+func TestSetHeaders(t *testing.T) {
+	var authorization, token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization, token = r.Header.Get("Authorization"), r.Header.Get("X-Forwarded-Access-Token")
+	}))
+	defer server.Close()
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer sso")
+	headers.Set("X-Forwarded-Access-Token", "access")
+	httpClient := &http.Client{Transport: &setHeaders{headers: headers}}
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	request.SetBasicAuth("jenkins-operator", "token")
+
+	response, err := httpClient.Do(request)
+	require.NoError(t, err)
+	_ = response.Body.Close()
+
+	assert.Equal(t, "Bearer sso", authorization)
+	assert.Equal(t, "access", token)
+	assert.NotSame(t, getSession(server.URL, "jenkins-operator", "token", nil), getSession(server.URL, "jenkins-operator", "token", headers))
+}
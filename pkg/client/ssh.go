@@ -0,0 +1,159 @@
+package client
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// instanceIdentityHeader is the HTTP header with the public key of the Jenkins instance identity, Jenkins SSH
+	// server uses the same key as its host key
+	instanceIdentityHeader = "X-Instance-Identity"
+
+	// groovyCLICommand executes the Groovy script read from stdin
+	groovyCLICommand = "groovy ="
+
+	sshDialTimeout = 10 * time.Second
+)
+
+// SSHSettings defines the Jenkins CLI over SSH which executes Groovy scripts instead of the script console
+type SSHSettings struct {
+	// Address is the host and the port of the Jenkins SSH server
+	Address string
+	// UserName is the Jenkins user authenticated by PrivateKey
+	UserName string
+	// PrivateKey is the PEM encoded private key of the user
+	PrivateKey []byte
+	// InstanceIdentity is the base64 encoded public key of the Jenkins instance identity, it verifies the host key
+	InstanceIdentity string
+}
+
+// sshJenkins executes Groovy scripts by the Jenkins CLI over SSH, other calls are sent to the Jenkins API
+type sshJenkins struct {
+	Jenkins
+	address string
+	config  *ssh.ClientConfig
+}
+
+// NewSSHScriptClient returns the Jenkins API client which executes Groovy scripts by the Jenkins CLI over SSH
+func NewSSHScriptClient(jenkins Jenkins, settings SSHSettings) (Jenkins, error) {
+	signer, err := ssh.ParsePrivateKey(settings.PrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse SSH private key of Jenkins CLI")
+	}
+	hostKey, err := parseInstanceIdentity(settings.InstanceIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sshJenkins{
+		Jenkins: jenkins,
+		address: settings.Address,
+		config: &ssh.ClientConfig{
+			User:            settings.UserName,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+			Timeout:         sshDialTimeout,
+		},
+	}, nil
+}
+
+// parseInstanceIdentity parses the value of the X-Instance-Identity header to the SSH public key
+func parseInstanceIdentity(instanceIdentity string) (ssh.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(instanceIdentity)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't decode Jenkins instance identity")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse Jenkins instance identity")
+	}
+	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("unsupported type of Jenkins instance identity '%T'", publicKey)
+	}
+	hostKey, err := ssh.NewPublicKey(rsaPublicKey)
+	return hostKey, errors.WithStack(err)
+}
+
+// GetInstanceIdentity returns the base64 encoded public key of the Jenkins instance identity
+func (jenkins *jenkins) GetInstanceIdentity() (string, error) {
+	body := ""
+	r, err := jenkins.Requester.Get("/", &body, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "couldn't get Jenkins instance identity")
+	}
+	defer r.Body.Close()
+
+	instanceIdentity := r.Header.Get(instanceIdentityHeader)
+	if len(instanceIdentity) == 0 {
+		return "", errors.Errorf("Jenkins didn't send %s header: %d", instanceIdentityHeader, r.StatusCode)
+	}
+	return instanceIdentity, nil
+}
+
+func (jenkins *sshJenkins) ExecuteScript(script string) (string, error) {
+	return jenkins.ExecuteScriptWithOptions(script, ScriptExecutionOptions{})
+}
+
+// ExecuteScriptWithOptions executes groovy script by the Jenkins CLI and retries it after transient errors
+func (jenkins *sshJenkins) ExecuteScriptWithOptions(script string, options ScriptExecutionOptions) (string, error) {
+	for attempt := 0; ; attempt++ {
+		verifier := fmt.Sprintf("verifier-%d", time.Now().Unix())
+		logs, err := jenkins.executeScript(script, verifier, options.Timeout)
+		if _, ok := errors.Cause(err).(transientError); !ok || attempt >= options.Retries {
+			return logs, err
+		}
+		time.Sleep(options.RetryInterval)
+	}
+}
+
+func (jenkins *sshJenkins) executeScript(script string, verifier string, timeout time.Duration) (string, error) {
+	client, err := ssh.Dial("tcp", jenkins.address, jenkins.config)
+	if err != nil {
+		if _, ok := err.(net.Error); ok {
+			err = transientError{err}
+		}
+		return "", errors.Wrapf(err, "couldn't connect to Jenkins CLI over SSH '%s'", jenkins.address)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", errors.Wrap(transientError{err}, "couldn't open Jenkins CLI session")
+	}
+	defer session.Close()
+
+	output := &bytes.Buffer{}
+	session.Stdout = output
+	session.Stderr = output
+	session.Stdin = strings.NewReader(fmt.Sprintf("%s\nprint println('%s')", script, verifier))
+	if timeout > 0 {
+		// the script may still be running when the connection is closed so it's not retried
+		timer := time.AfterFunc(timeout, func() { _ = client.Close() })
+		defer timer.Stop()
+	}
+
+	err = session.Run(groovyCLICommand)
+	if _, ok := err.(*ssh.ExitError); ok {
+		return output.String(), &GroovyScriptExecutionFailed{}
+	}
+	if err != nil {
+		return output.String(), errors.Wrapf(err, "couldn't execute groovy script, logs '%s'", output)
+	}
+
+	if !strings.Contains(output.String(), verifier) {
+		return output.String(), &GroovyScriptExecutionFailed{}
+	}
+
+	return output.String(), nil
+}
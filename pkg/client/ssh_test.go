@@ -0,0 +1,158 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// startSSHServer starts the SSH server which runs the handler on every exec request and returns its address
+func startSSHServer(t *testing.T, hostKey *rsa.PrivateKey, userKey ssh.PublicKey, handler func(command, stdin string) (string, uint32)) string {
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == "operator" && string(key.Marshal()) == string(userKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unknown public key of %s", conn.User())
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSHConnection(conn, config, handler)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveSSHConnection(conn net.Conn, config *ssh.ServerConfig, handler func(command, stdin string) (string, uint32)) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		for request := range channelRequests {
+			if request.Type != "exec" {
+				_ = request.Reply(false, nil)
+				continue
+			}
+			_ = request.Reply(true, nil)
+			command := string(request.Payload[4:])
+			stdin, _ := ioutil.ReadAll(channel)
+			output, exitStatus := handler(command, string(stdin))
+			_, _ = channel.Write([]byte(output))
+			status := make([]byte, 4)
+			binary.BigEndian.PutUint32(status, exitStatus)
+			_, _ = channel.SendRequest("exit-status", false, status)
+			_ = channel.Close()
+		}
+	}
+}
+
+func TestSSHJenkins_ExecuteScript(t *testing.T) {
+	hostKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&hostKey.PublicKey)
+	require.NoError(t, err)
+	instanceIdentity := base64.StdEncoding.EncodeToString(der)
+
+	userPublicKey, userPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(userPrivateKey)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})
+	sshPublicKey, err := ssh.NewPublicKey(userPublicKey)
+	require.NoError(t, err)
+
+	address := startSSHServer(t, hostKey, sshPublicKey, func(command, stdin string) (string, uint32) {
+		if command != groovyCLICommand {
+			return "unknown command", 1
+		}
+		if strings.HasPrefix(stdin, "throw") {
+			return "groovy.lang.MissingPropertyException", 1
+		}
+		lines := strings.Split(stdin, "\n")
+		verifier := strings.TrimSuffix(strings.TrimPrefix(lines[len(lines)-1], "print println('"), "')")
+		return strings.Join(lines[:len(lines)-1], "\n") + "\n" + verifier + "\n", 0
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client, err := NewSSHScriptClient(nil, SSHSettings{Address: address, UserName: "operator", PrivateKey: privateKey, InstanceIdentity: instanceIdentity})
+		require.NoError(t, err)
+
+		logs, err := client.ExecuteScript("println 'hello'")
+
+		require.NoError(t, err)
+		assert.Contains(t, logs, "println 'hello'")
+	})
+	t.Run("script failed", func(t *testing.T) {
+		client, err := NewSSHScriptClient(nil, SSHSettings{Address: address, UserName: "operator", PrivateKey: privateKey, InstanceIdentity: instanceIdentity})
+		require.NoError(t, err)
+
+		logs, err := client.ExecuteScript("throw new Exception()")
+
+		assert.IsType(t, &GroovyScriptExecutionFailed{}, err)
+		assert.Equal(t, "groovy.lang.MissingPropertyException", logs)
+	})
+	t.Run("unknown host key", func(t *testing.T) {
+		otherHostKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&otherHostKey.PublicKey)
+		require.NoError(t, err)
+		client, err := NewSSHScriptClient(nil, SSHSettings{Address: address, UserName: "operator", PrivateKey: privateKey, InstanceIdentity: base64.StdEncoding.EncodeToString(der)})
+		require.NoError(t, err)
+
+		_, err = client.ExecuteScript("println 'hello'")
+
+		assert.Error(t, err)
+	})
+	t.Run("unknown user", func(t *testing.T) {
+		client, err := NewSSHScriptClient(nil, SSHSettings{Address: address, UserName: "admin", PrivateKey: privateKey, InstanceIdentity: instanceIdentity})
+		require.NoError(t, err)
+
+		_, err = client.ExecuteScript("println 'hello'")
+
+		assert.Error(t, err)
+	})
+	t.Run("Jenkins unavailable", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		unavailableAddress := listener.Addr().String()
+		require.NoError(t, listener.Close())
+		client, err := NewSSHScriptClient(nil, SSHSettings{Address: unavailableAddress, UserName: "operator", PrivateKey: privateKey, InstanceIdentity: instanceIdentity})
+		require.NoError(t, err)
+
+		_, err = client.ExecuteScript("println 'hello'")
+
+		assert.IsType(t, transientError{}, errors.Cause(err))
+	})
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// HTTPScheme is the default scheme of Jenkins API URL
+	HTTPScheme = "http"
+	// HTTPSScheme is the scheme of Jenkins API exposed over TLS
+	HTTPSScheme = "https"
+
+	// CASecretKey is the key of the CA bundle in the Secret referenced by TLSSettings.CASecret
+	CASecretKey = "ca.crt"
+)
+
+// TLSSettings defines verification of the Jenkins API certificate and the client certificate of the operator
+type TLSSettings struct {
+	// CASecret is the Secret with the PEM encoded CA bundle under the ca.crt key which signed the Jenkins certificate,
+	// the system CA bundle is used when it's empty
+	CASecret types.NamespacedName
+	// ClientCertificateSecret is the kubernetes.io/tls Secret with the client certificate of the operator
+	ClientCertificateSecret types.NamespacedName
+	// InsecureSkipVerify disables verification of the Jenkins certificate, use it only for testing
+	InsecureSkipVerify bool
+	// ServerName is the hostname verified in the Jenkins certificate when Jenkins is reached by another address,
+	// e.g. by the port-forward to the Jenkins master Pod, the hostname of Jenkins API URL is verified when it's empty
+	ServerName string
+
+	// CABundle is the content of the CA bundle loaded from CASecret
+	CABundle []byte
+	// ClientCertificate is the client certificate loaded from ClientCertificateSecret
+	ClientCertificate *tls.Certificate
+}
+
+// IsSet returns true if the Jenkins API connection requires custom TLS configuration
+func (s TLSSettings) IsSet() bool {
+	return len(s.CASecret.Name) > 0 || len(s.ClientCertificateSecret.Name) > 0 || s.InsecureSkipVerify
+}
+
+// NewTLSConfig returns TLS configuration of the Jenkins API client, nil when the defaults are used
+func (s TLSSettings) NewTLSConfig() (*tls.Config, error) {
+	if !s.IsSet() && len(s.ServerName) == 0 {
+		return nil, nil
+	}
+
+	config := &tls.Config{InsecureSkipVerify: s.InsecureSkipVerify, ServerName: s.ServerName}
+	if len(s.CABundle) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(s.CABundle) {
+			return nil, errors.Errorf("no PEM encoded certificates found in the CA bundle of Secret '%s'", s.CASecret)
+		}
+	}
+	if s.ClientCertificate != nil {
+		config.Certificates = []tls.Certificate{*s.ClientCertificate}
+	}
+	return config, nil
+}
+
+// ParseSecretReference parses the reference to the Secret in the namespace/name format, the namespace defaults to
+// the given namespace
+func ParseSecretReference(reference, defaultNamespace string) (types.NamespacedName, error) {
+	if len(reference) == 0 {
+		return types.NamespacedName{}, nil
+	}
+	parts := strings.Split(reference, "/")
+	switch {
+	case len(parts) == 1 && len(parts[0]) > 0:
+		return types.NamespacedName{Namespace: defaultNamespace, Name: parts[0]}, nil
+	case len(parts) == 2 && len(parts[0]) > 0 && len(parts[1]) > 0:
+		return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+	default:
+		return types.NamespacedName{}, errors.Errorf("invalid Secret reference '%s', expected namespace/name", reference)
+	}
+}
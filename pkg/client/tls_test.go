@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestTLSSettings_NewTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	get := func(settings TLSSettings) error {
+		transport, err := JenkinsAPIConnectionSettings{TLS: settings}.newHTTPTransport()
+		require.NoError(t, err)
+		response, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if err == nil {
+			_ = response.Body.Close()
+		}
+		return err
+	}
+
+	t.Run("system CA bundle", func(t *testing.T) {
+		assert.Error(t, get(TLSSettings{}))
+	})
+	t.Run("custom CA bundle", func(t *testing.T) {
+		assert.NoError(t, get(TLSSettings{CASecret: types.NamespacedName{Namespace: "default", Name: "ca"}, CABundle: caBundle}))
+	})
+	t.Run("insecure skip verify", func(t *testing.T) {
+		assert.NoError(t, get(TLSSettings{InsecureSkipVerify: true}))
+	})
+	t.Run("server name", func(t *testing.T) {
+		// the certificate of the test server is issued for example.com and 127.0.0.1
+		ca := types.NamespacedName{Namespace: "default", Name: "ca"}
+
+		assert.NoError(t, get(TLSSettings{CASecret: ca, CABundle: caBundle, ServerName: "example.com"}))
+		assert.Error(t, get(TLSSettings{CASecret: ca, CABundle: caBundle, ServerName: "jenkins.default"}))
+	})
+	t.Run("invalid CA bundle", func(t *testing.T) {
+		settings := TLSSettings{CASecret: types.NamespacedName{Namespace: "default", Name: "ca"}, CABundle: []byte("invalid")}
+
+		_, err := settings.NewTLSConfig()
+
+		assert.EqualError(t, err, "no PEM encoded certificates found in the CA bundle of Secret 'default/ca'")
+	})
+}
+
+func TestParseSecretReference(t *testing.T) {
+	name, err := ParseSecretReference("", "operator")
+	require.NoError(t, err)
+	assert.Equal(t, types.NamespacedName{}, name)
+
+	name, err = ParseSecretReference("jenkins-ca", "operator")
+	require.NoError(t, err)
+	assert.Equal(t, types.NamespacedName{Namespace: "operator", Name: "jenkins-ca"}, name)
+
+	name, err = ParseSecretReference("jenkins/jenkins-ca", "operator")
+	require.NoError(t, err)
+	assert.Equal(t, types.NamespacedName{Namespace: "jenkins", Name: "jenkins-ca"}, name)
+
+	for _, invalid := range []string{"/jenkins-ca", "jenkins/", "a/b/c"} {
+		_, err = ParseSecretReference(invalid, "operator")
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestJenkinsAPIConnectionSettings_Validate(t *testing.T) {
+	assert.NoError(t, JenkinsAPIConnectionSettings{}.Validate())
+	assert.NoError(t, JenkinsAPIConnectionSettings{Scheme: HTTPSScheme, TLS: TLSSettings{InsecureSkipVerify: true}}.Validate())
+	assert.Error(t, JenkinsAPIConnectionSettings{Scheme: "ftp"}.Validate())
+	assert.Error(t, JenkinsAPIConnectionSettings{TLS: TLSSettings{InsecureSkipVerify: true}}.Validate())
+}
+
+func TestJenkinsAPIConnectionSettings_BuildJenkinsAPIUrl(t *testing.T) {
+	assert.Equal(t, "http://jenkins.default:8080", JenkinsAPIConnectionSettings{}.BuildJenkinsAPIUrl("jenkins", "default", 8080, 30080))
+	assert.Equal(t, "https://jenkins.default:8443", JenkinsAPIConnectionSettings{Scheme: HTTPSScheme}.BuildJenkinsAPIUrl("jenkins", "default", 8443, 30080))
+	assert.Equal(t, "https://localhost:30080", JenkinsAPIConnectionSettings{Scheme: HTTPSScheme, Hostname: "localhost", UseNodePort: true}.BuildJenkinsAPIUrl("jenkins", "default", 8443, 30080))
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bndr/gojenkins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserToken(t *testing.T) {
+	var revokedTokenUUID string
+	ts := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		switch {
+		case strings.HasSuffix(request.URL.Path, "/generateNewToken"):
+			_, _ = fmt.Fprint(responseWriter, `{"status":"ok","data":{"tokenName":"token","tokenUuid":"uuid","tokenValue":"value"}}`)
+		case strings.HasSuffix(request.URL.Path, "/revoke"):
+			revokedTokenUUID = request.URL.Query().Get("tokenUuid")
+		default:
+			responseWriter.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	jenkinsClient := &jenkins{}
+	jenkinsClient.Server = ts.URL
+	jenkinsClient.Requester = &gojenkins.Requester{
+		Base:      ts.URL,
+		SslVerify: true,
+		Client:    ts.Client(),
+		BasicAuth: &gojenkins.BasicAuth{Username: "jenkins-operator", Password: "password"},
+	}
+
+	token, err := jenkinsClient.GenerateToken("jenkins-operator", "token")
+	require.NoError(t, err)
+	assert.Equal(t, "value", token.GetToken())
+	assert.Equal(t, "uuid", token.GetUUID())
+
+	err = jenkinsClient.RevokeToken("jenkins-operator", token.GetUUID())
+	require.NoError(t, err)
+	assert.Equal(t, "uuid", revokedTokenUUID)
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+)
+
+// transportKey identifies the transport of Jenkins API requests with the same TLS and proxy settings
+type transportKey struct {
+	proxy              ProxySettings
+	caBundle           string
+	clientCertificate  string
+	insecureSkipVerify bool
+	serverName         string
+}
+
+var (
+	transportsMutex sync.Mutex
+	// transports are shared by clients of Jenkins API, clients are created by every reconcile loop and their
+	// connections are reused by the next one
+	transports = map[transportKey]*http.Transport{}
+)
+
+// newHTTPTransport returns the transport of Jenkins API requests, the in-cluster service of Jenkins is always
+// reached directly
+func (j JenkinsAPIConnectionSettings) newHTTPTransport() (http.RoundTripper, error) {
+	key := transportKey{caBundle: string(j.TLS.CABundle), insecureSkipVerify: j.TLS.InsecureSkipVerify, serverName: j.TLS.ServerName}
+	if len(j.Hostname) > 0 {
+		key.proxy = j.Proxy
+	}
+	if j.TLS.ClientCertificate != nil && len(j.TLS.ClientCertificate.Certificate) > 0 {
+		key.clientCertificate = string(j.TLS.ClientCertificate.Certificate[0])
+	}
+
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+
+	if transport, found := transports[key]; found {
+		return transport, nil
+	}
+
+	tlsConfig, err := j.TLS.NewTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = key.proxy.ProxyFunc()
+	transports[key] = transport
+
+	return transport, nil
+}
@@ -1,8 +1,11 @@
 package backuprestore
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
@@ -10,9 +13,14 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -53,18 +61,57 @@ func (t *backupTriggers) add(namespace string, name string, trigger backupTrigge
 
 var triggers = backupTriggers{triggers: make(map[string]backupTrigger)}
 
+const (
+	preBackupHook  = "pre-backup"
+	postBackupHook = "post-backup"
+
+	backupSucceededReason = "BackupSucceeded"
+	backupFailedReason    = "BackupFailed"
+
+	preBackupHookBindingFmt  = "def backupNumber = %d\n"
+	postBackupHookBindingFmt = "def backupNumber = %d\ndef backupSucceeded = %t\n"
+
+	quietDownScript = `if (Jenkins.instance.isQuietingDown()) {
+    print 'false'
+} else {
+    Jenkins.instance.doQuietDown()
+    print 'true'
+}`
+	cancelQuietDownScript = "Jenkins.instance.doCancelQuietDown()"
+)
+
+// Interface defines Jenkins backup and restore operations, it can be used to make and restore backup
+// programmatically, for example from Velero hooks or plugins
+type Interface interface {
+	// Backup makes backup of JENKINS_HOME in all configured backup destinations
+	Backup(setBackupDoneBeforePodDeletion bool) error
+	// Restore restores the latest backup or the backup set in spec.restore.recoveryOnce
+	Restore(jenkinsClient jenkinsclient.Jenkins) error
+	// RestoreDryRun reports changes of the backup set in spec.restore.dryRunOnce without restoring it
+	RestoreDryRun() error
+}
+
+var _ Interface = &BackupAndRestore{}
+
 // BackupAndRestore represents Jenkins backup and restore client
 type BackupAndRestore struct {
 	configuration.Configuration
 	logger logr.Logger
+
+	// exec and getJenkinsClient default to the Configuration methods, they're replaced in tests
+	exec             func(podName, containerName string, command []string) (stdout, stderr bytes.Buffer, err error)
+	getJenkinsClient func() (jenkinsclient.Jenkins, error)
 }
 
 // New returns Jenkins backup and restore client
 func New(configuration configuration.Configuration, logger logr.Logger) *BackupAndRestore {
-	return &BackupAndRestore{
+	bar := &BackupAndRestore{
 		Configuration: configuration,
 		logger:        logger,
 	}
+	bar.exec = bar.Configuration.Exec
+	bar.getJenkinsClient = bar.Configuration.GetJenkinsClient
+	return bar
 }
 
 // Validate validates backup and restore configuration
@@ -84,6 +131,8 @@ func (bar *BackupAndRestore) Validate() []string {
 		if restore.Action.Exec == nil {
 			messages = append(messages, "spec.restore.action.exec is not configured")
 		}
+	} else if restore.DryRunOnce != 0 {
+		messages = append(messages, "spec.restore.dryRunOnce requires spec.restore.containerName")
 	}
 
 	backup := bar.Configuration.Jenkins.Spec.Backup
@@ -95,21 +144,107 @@ func (bar *BackupAndRestore) Validate() []string {
 		if backup.Action.Exec == nil {
 			messages = append(messages, "spec.backup.action.exec is not configured")
 		}
-		if backup.Interval == 0 {
-			messages = append(messages, "spec.backup.interval is not configured")
+		if len(backup.Destinations) > 0 {
+			messages = append(messages, "spec.backup.containerName and spec.backup.destinations can't be used together")
+		}
+	}
+
+	destinationNames := map[string]bool{}
+	for i, destination := range backup.Destinations {
+		if len(destination.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.backup.destinations[%d].name is not configured", i))
+		} else if destinationNames[destination.Name] {
+			messages = append(messages, fmt.Sprintf("spec.backup.destinations[%d].name '%s' is not unique", i, destination.Name))
+		}
+		destinationNames[destination.Name] = true
+
+		if len(destination.ContainerName) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.backup.destinations[%d].containerName is not configured", i))
+		} else if _, found := allContainers[destination.ContainerName]; !found {
+			messages = append(messages, fmt.Sprintf("backup container '%s' not found in CR spec.master.containers", destination.ContainerName))
+		}
+		if destination.Action.Exec == nil {
+			messages = append(messages, fmt.Sprintf("spec.backup.destinations[%d].action.exec is not configured", i))
+		}
+	}
+
+	for i, configMap := range backup.Hooks.PreBackup {
+		if len(configMap.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.backup.hooks.preBackup[%d].name is not configured", i))
+		}
+	}
+	for i, configMap := range backup.Hooks.PostBackup {
+		if len(configMap.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.backup.hooks.postBackup[%d].name is not configured", i))
 		}
 	}
 
-	if len(restore.ContainerName) > 0 && len(backup.ContainerName) == 0 {
+	messages = append(messages, validateBackupPatterns("spec.backup.include", backup.Include)...)
+	messages = append(messages, validateBackupPatterns("spec.backup.exclude", backup.Exclude)...)
+	messages = append(messages, validateBackupCompression(backup)...)
+
+	isBackupConfigured := len(backupDestinations(backup)) > 0
+	if isBackupConfigured && backup.Interval == 0 {
+		messages = append(messages, "spec.backup.interval is not configured")
+	}
+
+	if len(restore.ContainerName) > 0 && !isBackupConfigured {
 		messages = append(messages, "spec.backup.containerName is not configured")
 	}
-	if len(backup.ContainerName) > 0 && len(restore.ContainerName) == 0 {
+	if isBackupConfigured && len(restore.ContainerName) == 0 {
 		messages = append(messages, "spec.restore.containerName is not configured")
 	}
 
 	return messages
 }
 
+func validateBackupCompression(backup v1alpha2.Backup) []string {
+	var maxLevel int32
+	switch backup.Compression {
+	case "", v1alpha2.BackupCompressionGzip:
+		maxLevel = 9
+	case v1alpha2.BackupCompressionZstd:
+		maxLevel = 19
+	case v1alpha2.BackupCompressionNone:
+		maxLevel = 0
+	default:
+		return []string{fmt.Sprintf("spec.backup.compression '%s' is not supported, use one of: %s, %s, %s", backup.Compression,
+			v1alpha2.BackupCompressionGzip, v1alpha2.BackupCompressionZstd, v1alpha2.BackupCompressionNone)}
+	}
+
+	if backup.CompressionLevel < 0 || backup.CompressionLevel > maxLevel {
+		if maxLevel == 0 {
+			return []string{"spec.backup.compressionLevel can't be set when spec.backup.compression is none"}
+		}
+		return []string{fmt.Sprintf("spec.backup.compressionLevel must be between 1 and %d", maxLevel)}
+	}
+
+	return nil
+}
+
+func validateBackupPatterns(field string, patterns []string) []string {
+	var messages []string
+	for i, pattern := range patterns {
+		if len(strings.TrimSpace(pattern)) == 0 {
+			messages = append(messages, fmt.Sprintf("%s[%d] is empty", field, i))
+			continue
+		}
+		if strings.Contains(pattern, "\n") {
+			messages = append(messages, fmt.Sprintf("%s[%d] '%s' contains new line character", field, i, pattern))
+		}
+		if strings.HasPrefix(pattern, "/") {
+			messages = append(messages, fmt.Sprintf("%s[%d] '%s' must be relative to JENKINS_HOME", field, i, pattern))
+		}
+		for _, part := range strings.Split(pattern, "/") {
+			if part == ".." {
+				messages = append(messages, fmt.Sprintf("%s[%d] '%s' can't point outside of JENKINS_HOME", field, i, pattern))
+				break
+			}
+		}
+	}
+	return messages
+}
+
 // Restore performs Jenkins restore backup operation
 func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error {
 	jenkins := bar.Configuration.Jenkins
@@ -125,7 +260,7 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 		bar.logger.V(log.VDebug).Info("Skipping restore backup")
 		if jenkins.Status.PendingBackup == 0 {
 			jenkins.Status.PendingBackup = 1
-			return bar.Client.Update(context.TODO(), jenkins)
+			return bar.Client.Status().Update(context.TODO(), jenkins)
 		}
 		return nil
 	}
@@ -140,7 +275,7 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 	podName := resources.GetJenkinsMasterPodName(jenkins)
 	command := jenkins.Spec.Restore.Action.Exec.Command
 	command = append(command, fmt.Sprintf("%d", backupNumber))
-	_, _, err := bar.Exec(podName, jenkins.Spec.Restore.ContainerName, command)
+	_, _, err := bar.exec(podName, jenkins.Spec.Restore.ContainerName, command)
 
 	if err == nil {
 		_, err := jenkinsClient.ExecuteScript("Jenkins.instance.reload()")
@@ -148,10 +283,17 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 			return err
 		}
 
-		jenkins.Spec.Restore.RecoveryOnce = 0
 		jenkins.Status.RestoredBackup = backupNumber
 		jenkins.Status.PendingBackup = backupNumber + 1
-		return bar.Client.Update(context.TODO(), jenkins)
+		if err := bar.Client.Status().Update(context.TODO(), jenkins); err != nil {
+			return err
+		}
+		if jenkins.Spec.Restore.RecoveryOnce == 0 {
+			return nil
+		}
+		before := jenkins.DeepCopy()
+		jenkins.Spec.Restore.RecoveryOnce = 0
+		return bar.Client.Patch(context.TODO(), jenkins, k8s.MergeFrom(before))
 	}
 
 	return err
@@ -160,7 +302,8 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 // Backup performs Jenkins backup operation
 func (bar *BackupAndRestore) Backup(setBackupDoneBeforePodDeletion bool) error {
 	jenkins := bar.Configuration.Jenkins
-	if len(jenkins.Spec.Backup.ContainerName) == 0 || jenkins.Spec.Backup.Action.Exec == nil {
+	destinations := backupDestinations(jenkins.Spec.Backup)
+	if len(destinations) == 0 {
 		bar.logger.V(log.VDebug).Info("Skipping restore backup, backup restore not configured")
 		return nil
 	}
@@ -170,25 +313,273 @@ func (bar *BackupAndRestore) Backup(setBackupDoneBeforePodDeletion bool) error {
 	}
 	backupNumber := jenkins.Status.PendingBackup
 	bar.logger.Info(fmt.Sprintf("Performing backup '%d'", backupNumber))
+
+	hooks := jenkins.Spec.Backup.Hooks
+	err := bar.executeBackupHooks(preBackupHook, hooks.PreBackup, fmt.Sprintf(preBackupHookBindingFmt, backupNumber))
+	if err != nil {
+		if !setBackupDoneBeforePodDeletion {
+			return err
+		}
+		bar.logger.V(log.VWarn).Info(fmt.Sprintf("Pre backup hook failed, making backup anyway: %s", err))
+	}
+
+	err = bar.backup(destinations, backupNumber, setBackupDoneBeforePodDeletion)
+
+	hookErr := bar.executeBackupHooks(postBackupHook, hooks.PostBackup, fmt.Sprintf(postBackupHookBindingFmt, backupNumber, err == nil))
+	if hookErr != nil {
+		bar.logger.V(log.VWarn).Info(fmt.Sprintf("Post backup hook failed: %s", hookErr))
+	}
+
+	return err
+}
+
+func (bar *BackupAndRestore) backup(destinations []v1alpha2.BackupDestination, backupNumber uint64, setBackupDoneBeforePodDeletion bool) error {
+	jenkins := bar.Configuration.Jenkins
 	podName := resources.GetJenkinsMasterPodName(jenkins)
-	command := jenkins.Spec.Backup.Action.Exec.Command
-	command = append(command, fmt.Sprintf("%d", backupNumber))
-	_, _, err := bar.Exec(podName, jenkins.Spec.Backup.ContainerName, command)
 
+	if jenkins.Spec.Backup.Consistent && isBackupPending(jenkins, destinations, backupNumber) {
+		release, err := bar.quietDown(setBackupDoneBeforePodDeletion)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	if len(jenkins.Spec.Backup.Destinations) == 0 {
+		err := bar.backupTo(podName, destinations[0], backupNumber)
+		if err != nil {
+			setBackupHealthyCondition(jenkins, fmt.Sprintf("Backup '%d' failed: %s", backupNumber, err))
+			if updateErr := bar.Client.Status().Update(context.TODO(), jenkins); updateErr != nil {
+				return updateErr
+			}
+			return err
+		}
+		return bar.completeBackup(backupNumber, setBackupDoneBeforePodDeletion)
+	}
+
+	var failedDestinations []string
+	statuses := make([]v1alpha2.BackupDestinationStatus, 0, len(destinations))
+	for _, destination := range destinations {
+		status := findBackupDestinationStatus(jenkins.Status.BackupDestinations, destination.Name)
+		if status.LastBackup == backupNumber {
+			bar.logger.V(log.VDebug).Info(fmt.Sprintf("Backup '%d' already stored in destination '%s', skipping", backupNumber, destination.Name))
+			statuses = append(statuses, status)
+			continue
+		}
+
+		err := bar.backupTo(podName, destination, backupNumber)
+		if err != nil {
+			bar.logger.V(log.VWarn).Info(fmt.Sprintf("Backup '%d' failed in destination '%s': %s", backupNumber, destination.Name, err))
+			status.Error = err.Error()
+			failedDestinations = append(failedDestinations, destination.Name)
+		} else {
+			now := metav1.Now()
+			status.LastBackup = backupNumber
+			status.LastBackupTime = &now
+			status.Error = ""
+		}
+		statuses = append(statuses, status)
+	}
+	jenkins.Status.BackupDestinations = statuses
+
+	if len(failedDestinations) > 0 {
+		err := errors.Errorf("backup '%d' failed in destinations: %s", backupNumber, strings.Join(failedDestinations, ", "))
+		setBackupHealthyCondition(jenkins, err.Error())
+		if updateErr := bar.Client.Status().Update(context.TODO(), jenkins); updateErr != nil {
+			return updateErr
+		}
+		return err
+	}
+
+	return bar.completeBackup(backupNumber, setBackupDoneBeforePodDeletion)
+}
+
+// isBackupPending returns false when every destination already stores the backup, e.g. the status update has failed
+// after the backup, so Jenkins isn't put into the quiet mode for nothing
+func isBackupPending(jenkins *v1alpha2.Jenkins, destinations []v1alpha2.BackupDestination, backupNumber uint64) bool {
+	if len(jenkins.Spec.Backup.Destinations) == 0 {
+		return true
+	}
+	for _, destination := range destinations {
+		if findBackupDestinationStatus(jenkins.Status.BackupDestinations, destination.Name).LastBackup != backupNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// executeBackupHooks runs Groovy scripts from given ConfigMaps, binding is prepended to every script
+func (bar *BackupAndRestore) executeBackupHooks(hook string, configMaps []v1alpha2.ConfigMapRef, binding string) error {
+	if len(configMaps) == 0 {
+		return nil
+	}
+
+	jenkinsClient, err := bar.getJenkinsClient()
+	if err != nil {
+		return err
+	}
+
+	for _, configMapRef := range configMaps {
+		configMap := &corev1.ConfigMap{}
+		err := bar.Client.Get(context.TODO(), types.NamespacedName{Name: configMapRef.Name, Namespace: bar.Configuration.Jenkins.Namespace}, configMap)
+		if err != nil {
+			bar.notifyBackupHookFailed(hook, fmt.Sprintf("ConfigMap '%s'", configMapRef.Name), err)
+			return errors.WithStack(err)
+		}
+
+		var names []string
+		for name := range configMap.Data {
+			if strings.HasSuffix(name, ".groovy") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			bar.logger.V(log.VDebug).Info(fmt.Sprintf("Executing %s hook ConfigMap '%s' name '%s'", hook, configMap.Name, name))
+			logs, err := jenkinsClient.ExecuteScript(binding + configMap.Data[name])
+			if err != nil {
+				bar.logger.V(log.VWarn).Info(fmt.Sprintf("%s hook ConfigMap '%s' name '%s' execution failed, logs :\n%s", hook, configMap.Name, name, logs))
+				bar.notifyBackupHookFailed(hook, fmt.Sprintf("ConfigMap '%s' name '%s'", configMap.Name, name), err)
+				return errors.Wrapf(err, "%s hook ConfigMap '%s' name '%s' execution failed", hook, configMap.Name, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (bar *BackupAndRestore) notifyBackupHookFailed(hook, script string, err error) {
+	*bar.Notifications <- event.Event{
+		Jenkins: *bar.Configuration.Jenkins,
+		Phase:   event.PhaseUser,
+		Level:   v1alpha2.NotificationLevelWarning,
+		Reason: reason.NewBackupHookFailed(
+			reason.OperatorSource,
+			[]string{fmt.Sprintf("%s hook %s execution failed", hook, script)},
+			fmt.Sprintf("%s hook %s execution failed: %s", hook, script, err),
+		),
+	}
+}
+
+// quietDown puts Jenkins into the quiet mode and returns function which releases it, Jenkins which has been already
+// in the quiet mode before the backup is not released. When backup is made before pod deletion Jenkins may be
+// already stopping, so the backup continues without the quiet mode.
+func (bar *BackupAndRestore) quietDown(beforePodDeletion bool) (func(), error) {
+	noop := func() {}
+	jenkinsClient, err := bar.getJenkinsClient()
 	if err == nil {
-		bar.logger.V(log.VDebug).Info(fmt.Sprintf("Backup completed '%d', updating status", backupNumber))
-		if jenkins.Status.RestoredBackup == 0 {
-			jenkins.Status.RestoredBackup = backupNumber
+		var output string
+		output, err = jenkinsClient.ExecuteScript(quietDownScript)
+		if err == nil {
+			if strings.TrimSpace(output) != "true" {
+				bar.logger.V(log.VDebug).Info("Jenkins is already in the quiet mode")
+				return noop, nil
+			}
+			bar.logger.V(log.VDebug).Info("Jenkins has been put into the quiet mode for the backup")
+			return func() {
+				if _, err := jenkinsClient.ExecuteScript(cancelQuietDownScript); err != nil {
+					bar.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't cancel Jenkins quiet mode after the backup: %s", err))
+					return
+				}
+				bar.logger.V(log.VDebug).Info("Jenkins quiet mode has been cancelled")
+			}, nil
 		}
-		jenkins.Status.LastBackup = backupNumber
-		jenkins.Status.PendingBackup = backupNumber
-		jenkins.Status.BackupDoneBeforePodDeletion = setBackupDoneBeforePodDeletion
-		return bar.Client.Update(context.TODO(), jenkins)
 	}
 
+	if beforePodDeletion {
+		bar.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't put Jenkins into the quiet mode, making backup anyway: %s", err))
+		return noop, nil
+	}
+	return nil, errors.Wrap(err, "couldn't put Jenkins into the quiet mode")
+}
+
+func (bar *BackupAndRestore) backupTo(podName string, destination v1alpha2.BackupDestination, backupNumber uint64) error {
+	var env []string
+	if destination.BackupCount > 0 {
+		env = append(env, fmt.Sprintf("BACKUP_COUNT=%d", destination.BackupCount))
+	}
+	backup := bar.Configuration.Jenkins.Spec.Backup
+	if len(backup.Include) > 0 {
+		env = append(env, "BACKUP_INCLUDE="+strings.Join(backup.Include, "\n"))
+	}
+	if len(backup.Exclude) > 0 {
+		env = append(env, "BACKUP_EXCLUDE="+strings.Join(backup.Exclude, "\n"))
+	}
+	if len(backup.Compression) > 0 {
+		env = append(env, fmt.Sprintf("BACKUP_COMPRESSION=%s", backup.Compression))
+	}
+	if backup.CompressionLevel > 0 {
+		env = append(env, fmt.Sprintf("BACKUP_COMPRESSION_LEVEL=%d", backup.CompressionLevel))
+	}
+
+	var command []string
+	if len(env) > 0 {
+		command = append([]string{"env"}, env...)
+	}
+	command = append(command, destination.Action.Exec.Command...)
+	command = append(command, fmt.Sprintf("%d", backupNumber))
+	started := time.Now()
+	stdout, _, err := bar.exec(podName, destination.ContainerName, command)
+	observeBackup(bar.Configuration.Jenkins, destination.Name, started, stdout.String(), err)
 	return err
 }
 
+func (bar *BackupAndRestore) completeBackup(backupNumber uint64, setBackupDoneBeforePodDeletion bool) error {
+	jenkins := bar.Configuration.Jenkins
+	bar.logger.V(log.VDebug).Info(fmt.Sprintf("Backup completed '%d', updating status", backupNumber))
+	if jenkins.Status.RestoredBackup == 0 {
+		jenkins.Status.RestoredBackup = backupNumber
+	}
+	jenkins.Status.LastBackup = backupNumber
+	jenkins.Status.PendingBackup = backupNumber
+	jenkins.Status.BackupDoneBeforePodDeletion = setBackupDoneBeforePodDeletion
+	setBackupHealthyCondition(jenkins, "")
+	return bar.Client.Status().Update(context.TODO(), jenkins)
+}
+
+// setBackupHealthyCondition sets the BackupHealthy condition, the backup is healthy when there is no error message
+func setBackupHealthyCondition(jenkins *v1alpha2.Jenkins, errorMessage string) {
+	condition := v1alpha2.JenkinsCondition{
+		Type:   v1alpha2.BackupHealthyCondition,
+		Status: corev1.ConditionTrue,
+		Reason: backupSucceededReason,
+	}
+	if len(errorMessage) > 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = backupFailedReason
+		condition.Message = errorMessage
+	}
+	configuration.SetCondition(&jenkins.Status, condition)
+}
+
+// backupDestinations returns all places where backup is stored, the legacy spec.backup.containerName
+// and spec.backup.action settings are treated as a single unnamed destination
+func backupDestinations(backup v1alpha2.Backup) []v1alpha2.BackupDestination {
+	if len(backup.Destinations) > 0 {
+		var destinations []v1alpha2.BackupDestination
+		for _, destination := range backup.Destinations {
+			if len(destination.ContainerName) > 0 && destination.Action.Exec != nil {
+				destinations = append(destinations, destination)
+			}
+		}
+		return destinations
+	}
+	if len(backup.ContainerName) > 0 && backup.Action.Exec != nil {
+		return []v1alpha2.BackupDestination{{ContainerName: backup.ContainerName, Action: backup.Action}}
+	}
+	return nil
+}
+
+func findBackupDestinationStatus(statuses []v1alpha2.BackupDestinationStatus, name string) v1alpha2.BackupDestinationStatus {
+	for _, status := range statuses {
+		if status.Name == name {
+			return status
+		}
+	}
+	return v1alpha2.BackupDestinationStatus{Name: name}
+}
+
 func triggerBackup(ticker *time.Ticker, k8sClient k8s.Client, logger logr.Logger, namespace, name string) {
 	for range ticker.C {
 		jenkins := &v1alpha2.Jenkins{}
@@ -201,7 +592,7 @@ func triggerBackup(ticker *time.Ticker, k8sClient k8s.Client, logger logr.Logger
 		}
 		if jenkins.Status.LastBackup == jenkins.Status.PendingBackup {
 			jenkins.Status.PendingBackup++
-			err = k8sClient.Update(context.TODO(), jenkins)
+			err = k8sClient.Status().Update(context.TODO(), jenkins)
 			if err != nil {
 				logger.V(log.VWarn).Info(fmt.Sprintf("backup trigger, error when updating CR: %s", err))
 			}
@@ -213,7 +604,7 @@ func triggerBackup(ticker *time.Ticker, k8sClient k8s.Client, logger logr.Logger
 func (bar *BackupAndRestore) EnsureBackupTrigger() error {
 	trigger, found := triggers.get(bar.Configuration.Jenkins.Namespace, bar.Configuration.Jenkins.Name)
 
-	isBackupConfigured := len(bar.Configuration.Jenkins.Spec.Backup.ContainerName) > 0 && bar.Configuration.Jenkins.Spec.Backup.Interval > 0
+	isBackupConfigured := len(backupDestinations(bar.Configuration.Jenkins.Spec.Backup)) > 0 && bar.Configuration.Jenkins.Spec.Backup.Interval > 0
 	if found && !isBackupConfigured {
 		bar.StopBackupTrigger()
 		return nil
@@ -0,0 +1,423 @@
+package backuprestore
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testBackupAndRestore is BackupAndRestore with the fake Kubernetes client, the mocked Jenkins client and exec which
+// records commands and fails for containers listed in failingContainers
+type testBackupAndRestore struct {
+	*BackupAndRestore
+	notifications     chan event.Event
+	commands          [][]string
+	failingContainers map[string]bool
+}
+
+func newTestBackupAndRestore(t *testing.T, jenkinsClient jenkinsclient.Jenkins, jenkins *v1alpha2.Jenkins, objects ...runtime.Object) *testBackupAndRestore {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	fakeClient := fake.NewFakeClient(objects...)
+	require.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+
+	notifications := make(chan event.Event, 10)
+	tbar := &testBackupAndRestore{notifications: notifications, failingContainers: map[string]bool{}}
+	tbar.BackupAndRestore = New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Notifications: &notifications}, log.Log)
+	tbar.getJenkinsClient = func() (jenkinsclient.Jenkins, error) { return jenkinsClient, nil }
+	tbar.exec = func(podName, containerName string, command []string) (stdout, stderr bytes.Buffer, err error) {
+		tbar.commands = append(tbar.commands, command)
+		if tbar.failingContainers[containerName] {
+			err = errors.Errorf("%s failed", containerName)
+		}
+		return
+	}
+	return tbar
+}
+
+func (tbar *testBackupAndRestore) savedJenkins(t *testing.T) *v1alpha2.Jenkins {
+	jenkins := &v1alpha2.Jenkins{}
+	require.NoError(t, tbar.Client.Get(context.TODO(), types.NamespacedName{Name: tbar.Jenkins.Name, Namespace: tbar.Jenkins.Namespace}, jenkins))
+	return jenkins
+}
+
+func backupJenkins(destinations ...v1alpha2.BackupDestination) *v1alpha2.Jenkins {
+	jenkins := &v1alpha2.Jenkins{
+		TypeMeta:   v1alpha2.JenkinsTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Status:     v1alpha2.JenkinsStatus{LastBackup: 1, PendingBackup: 2, RestoredBackup: 1},
+	}
+	if len(destinations) == 0 {
+		jenkins.Spec.Backup.ContainerName = "backup"
+		jenkins.Spec.Backup.Action = v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"backup.sh"}}}
+	}
+	jenkins.Spec.Backup.Destinations = destinations
+	return jenkins
+}
+
+func backupDestination(name string) v1alpha2.BackupDestination {
+	return v1alpha2.BackupDestination{Name: name, ContainerName: name, Action: v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"backup.sh"}}}}
+}
+
+func hookConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Data: data}
+}
+
+func TestValidateBackupCompression(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		assert.Empty(t, validateBackupCompression(v1alpha2.Backup{}))
+	})
+	t.Run("gzip with level", func(t *testing.T) {
+		backup := v1alpha2.Backup{Compression: v1alpha2.BackupCompressionGzip, CompressionLevel: 9}
+
+		assert.Empty(t, validateBackupCompression(backup))
+	})
+	t.Run("zstd with level", func(t *testing.T) {
+		backup := v1alpha2.Backup{Compression: v1alpha2.BackupCompressionZstd, CompressionLevel: 19}
+
+		assert.Empty(t, validateBackupCompression(backup))
+	})
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		backup := v1alpha2.Backup{Compression: "xz"}
+
+		assert.Equal(t, []string{"spec.backup.compression 'xz' is not supported, use one of: gzip, zstd, none"}, validateBackupCompression(backup))
+	})
+	t.Run("gzip level out of range", func(t *testing.T) {
+		backup := v1alpha2.Backup{CompressionLevel: 10}
+
+		assert.Equal(t, []string{"spec.backup.compressionLevel must be between 1 and 9"}, validateBackupCompression(backup))
+	})
+	t.Run("level without compression", func(t *testing.T) {
+		backup := v1alpha2.Backup{Compression: v1alpha2.BackupCompressionNone, CompressionLevel: 1}
+
+		assert.Equal(t, []string{"spec.backup.compressionLevel can't be set when spec.backup.compression is none"}, validateBackupCompression(backup))
+	})
+}
+
+func TestValidateBackupPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{name: "valid", patterns: []string{"jobs", "*.xml", "jobs/*/builds/*/archive", "..backup"}},
+		{name: "empty", patterns: []string{"jobs", "", " "}, want: []string{
+			"spec.backup.exclude[1] is empty",
+			"spec.backup.exclude[2] is empty",
+		}},
+		{name: "absolute path", patterns: []string{"/var/jenkins_home/jobs"}, want: []string{
+			"spec.backup.exclude[0] '/var/jenkins_home/jobs' must be relative to JENKINS_HOME",
+		}},
+		{name: "parent directory", patterns: []string{"..", "jobs/../../etc", "jobs/.."}, want: []string{
+			"spec.backup.exclude[0] '..' can't point outside of JENKINS_HOME",
+			"spec.backup.exclude[1] 'jobs/../../etc' can't point outside of JENKINS_HOME",
+			"spec.backup.exclude[2] 'jobs/..' can't point outside of JENKINS_HOME",
+		}},
+		{name: "new line", patterns: []string{"jobs\nwar"}, want: []string{
+			"spec.backup.exclude[0] 'jobs\nwar' contains new line character",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validateBackupPatterns("spec.backup.exclude", tt.patterns))
+		})
+	}
+}
+
+func TestExecuteBackupHooks(t *testing.T) {
+	preBackup := []v1alpha2.ConfigMapRef{{Name: "second"}, {Name: "first"}}
+	configMaps := []runtime.Object{
+		hookConfigMap("first", map[string]string{"b.groovy": "b", "a.groovy": "a", "README.md": "readme"}),
+		hookConfigMap("second", map[string]string{"c.groovy": "c"}),
+	}
+
+	t.Run("no hooks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		tbar := newTestBackupAndRestore(t, jenkinsclient.NewMockJenkins(ctrl), backupJenkins())
+
+		assert.NoError(t, tbar.executeBackupHooks(preBackupHook, nil, ""))
+	})
+	t.Run("executes scripts in order of ConfigMaps and names", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		gomock.InOrder(
+			jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\nc").Return("", nil),
+			jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\na").Return("", nil),
+			jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\nb").Return("", nil),
+		)
+		tbar := newTestBackupAndRestore(t, jenkinsClient, backupJenkins(), configMaps...)
+
+		assert.NoError(t, tbar.executeBackupHooks(preBackupHook, preBackup, "def backupNumber = 2\n"))
+		assert.Empty(t, tbar.notifications)
+	})
+	t.Run("stops at failed script", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		gomock.InOrder(
+			jenkinsClient.EXPECT().ExecuteScript("c").Return("", nil),
+			jenkinsClient.EXPECT().ExecuteScript("a").Return("logs", errors.New("script error")),
+		)
+		tbar := newTestBackupAndRestore(t, jenkinsClient, backupJenkins(), configMaps...)
+
+		err := tbar.executeBackupHooks(preBackupHook, preBackup, "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pre-backup hook ConfigMap 'first' name 'a.groovy' execution failed: script error")
+		require.Len(t, tbar.notifications, 1)
+		assert.Equal(t, []string{"pre-backup hook ConfigMap 'first' name 'a.groovy' execution failed"}, (<-tbar.notifications).Reason.Short())
+	})
+	t.Run("missing ConfigMap", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		tbar := newTestBackupAndRestore(t, jenkinsclient.NewMockJenkins(ctrl), backupJenkins())
+
+		err := tbar.executeBackupHooks(postBackupHook, []v1alpha2.ConfigMapRef{{Name: "missing"}}, "")
+
+		assert.Error(t, err)
+		require.Len(t, tbar.notifications, 1)
+		assert.Equal(t, []string{"post-backup hook ConfigMap 'missing' execution failed"}, (<-tbar.notifications).Reason.Short())
+	})
+}
+
+func TestBackup_Hooks(t *testing.T) {
+	hooksJenkins := func() *v1alpha2.Jenkins {
+		jenkins := backupJenkins()
+		jenkins.Spec.Backup.Hooks = v1alpha2.BackupHooks{
+			PreBackup:  []v1alpha2.ConfigMapRef{{Name: "pre"}},
+			PostBackup: []v1alpha2.ConfigMapRef{{Name: "post"}},
+		}
+		return jenkins
+	}
+	configMaps := []runtime.Object{
+		hookConfigMap("pre", map[string]string{"pre.groovy": "pre"}),
+		hookConfigMap("post", map[string]string{"post.groovy": "post"}),
+	}
+
+	t.Run("failed pre backup hook aborts backup and skips post backup hooks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\npre").Return("", errors.New("script error"))
+		tbar := newTestBackupAndRestore(t, jenkinsClient, hooksJenkins(), configMaps...)
+
+		err := tbar.Backup(false)
+
+		assert.Error(t, err)
+		assert.Empty(t, tbar.commands)
+		assert.Equal(t, uint64(1), tbar.savedJenkins(t).Status.LastBackup)
+	})
+	t.Run("failed pre backup hook doesn't abort backup before pod deletion", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		gomock.InOrder(
+			jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\npre").Return("", errors.New("script error")),
+			jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\ndef backupSucceeded = true\npost").Return("", nil),
+		)
+		tbar := newTestBackupAndRestore(t, jenkinsClient, hooksJenkins(), configMaps...)
+
+		err := tbar.Backup(true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"backup.sh", "2"}}, tbar.commands)
+		assert.Equal(t, uint64(2), tbar.savedJenkins(t).Status.LastBackup)
+	})
+	t.Run("post backup hooks run after failed backup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		gomock.InOrder(
+			jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\npre").Return("", nil),
+			jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\ndef backupSucceeded = false\npost").Return("", nil),
+		)
+		tbar := newTestBackupAndRestore(t, jenkinsClient, hooksJenkins(), configMaps...)
+		tbar.failingContainers["backup"] = true
+
+		err := tbar.Backup(false)
+
+		assert.EqualError(t, err, "backup failed")
+	})
+	t.Run("failed post backup hook doesn't fail backup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		gomock.InOrder(
+			jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\npre").Return("", nil),
+			jenkinsClient.EXPECT().ExecuteScript("def backupNumber = 2\ndef backupSucceeded = true\npost").Return("", errors.New("script error")),
+		)
+		tbar := newTestBackupAndRestore(t, jenkinsClient, hooksJenkins(), configMaps...)
+
+		err := tbar.Backup(false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), tbar.savedJenkins(t).Status.LastBackup)
+		assert.Len(t, tbar.notifications, 1)
+	})
+}
+
+func TestBackup_QuietDown(t *testing.T) {
+	consistentJenkins := func() *v1alpha2.Jenkins {
+		jenkins := backupJenkins()
+		jenkins.Spec.Backup.Consistent = true
+		return jenkins
+	}
+
+	t.Run("cancelled after successful backup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		gomock.InOrder(
+			jenkinsClient.EXPECT().ExecuteScript(quietDownScript).Return("true", nil),
+			jenkinsClient.EXPECT().ExecuteScript(cancelQuietDownScript).Return("", nil),
+		)
+		tbar := newTestBackupAndRestore(t, jenkinsClient, consistentJenkins())
+
+		err := tbar.Backup(false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"backup.sh", "2"}}, tbar.commands)
+		assert.Equal(t, uint64(2), tbar.savedJenkins(t).Status.LastBackup)
+	})
+	t.Run("cancelled after failed backup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		gomock.InOrder(
+			jenkinsClient.EXPECT().ExecuteScript(quietDownScript).Return("true", nil),
+			jenkinsClient.EXPECT().ExecuteScript(cancelQuietDownScript).Return("", nil),
+		)
+		tbar := newTestBackupAndRestore(t, jenkinsClient, consistentJenkins())
+		tbar.failingContainers["backup"] = true
+
+		err := tbar.Backup(false)
+
+		assert.EqualError(t, err, "backup failed")
+		assert.Equal(t, uint64(1), tbar.savedJenkins(t).Status.LastBackup)
+	})
+	t.Run("not cancelled when Jenkins has been already in the quiet mode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(quietDownScript).Return("false", nil)
+		tbar := newTestBackupAndRestore(t, jenkinsClient, consistentJenkins())
+
+		err := tbar.Backup(false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"backup.sh", "2"}}, tbar.commands)
+	})
+	t.Run("backup isn't made when quiet down fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(quietDownScript).Return("", errors.New("connection refused"))
+		tbar := newTestBackupAndRestore(t, jenkinsClient, consistentJenkins())
+
+		err := tbar.Backup(false)
+
+		assert.EqualError(t, err, "couldn't put Jenkins into the quiet mode: connection refused")
+		assert.Empty(t, tbar.commands)
+	})
+	t.Run("backup before pod deletion is made when quiet down fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(quietDownScript).Return("", errors.New("connection refused"))
+		tbar := newTestBackupAndRestore(t, jenkinsClient, consistentJenkins())
+
+		err := tbar.Backup(true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"backup.sh", "2"}}, tbar.commands)
+		assert.True(t, tbar.savedJenkins(t).Status.BackupDoneBeforePodDeletion)
+	})
+	t.Run("skipped when every destination stores the backup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkins := backupJenkins(backupDestination("local"), backupDestination("s3"))
+		jenkins.Spec.Backup.Consistent = true
+		jenkins.Status.BackupDestinations = []v1alpha2.BackupDestinationStatus{{Name: "local", LastBackup: 2}, {Name: "s3", LastBackup: 2}}
+		tbar := newTestBackupAndRestore(t, jenkinsClient, jenkins)
+
+		err := tbar.Backup(false)
+
+		assert.NoError(t, err)
+		assert.Empty(t, tbar.commands)
+		assert.Equal(t, uint64(2), tbar.savedJenkins(t).Status.LastBackup)
+	})
+}
+
+func TestBackup_Destinations(t *testing.T) {
+	t.Run("all destinations succeed", func(t *testing.T) {
+		tbar := newTestBackupAndRestore(t, nil, backupJenkins(backupDestination("local"), backupDestination("s3")))
+
+		err := tbar.Backup(false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"backup.sh", "2"}, {"backup.sh", "2"}}, tbar.commands)
+		status := tbar.savedJenkins(t).Status
+		assert.Equal(t, uint64(2), status.LastBackup)
+		assert.Equal(t, uint64(2), status.PendingBackup)
+		require.Len(t, status.BackupDestinations, 2)
+		for i, name := range []string{"local", "s3"} {
+			assert.Equal(t, name, status.BackupDestinations[i].Name)
+			assert.Equal(t, uint64(2), status.BackupDestinations[i].LastBackup)
+			assert.NotNil(t, status.BackupDestinations[i].LastBackupTime)
+			assert.Empty(t, status.BackupDestinations[i].Error)
+		}
+		assert.Equal(t, corev1.ConditionTrue, configuration.GetCondition(status, v1alpha2.BackupHealthyCondition).Status)
+	})
+	t.Run("partial failure is retried only in failed destinations", func(t *testing.T) {
+		tbar := newTestBackupAndRestore(t, nil, backupJenkins(backupDestination("local"), backupDestination("s3")))
+		tbar.failingContainers["s3"] = true
+
+		err := tbar.Backup(false)
+
+		assert.EqualError(t, err, "backup '2' failed in destinations: s3")
+		status := tbar.savedJenkins(t).Status
+		assert.Equal(t, uint64(1), status.LastBackup)
+		assert.Equal(t, uint64(2), status.PendingBackup)
+		require.Len(t, status.BackupDestinations, 2)
+		assert.Equal(t, uint64(2), status.BackupDestinations[0].LastBackup)
+		assert.Empty(t, status.BackupDestinations[0].Error)
+		assert.Equal(t, uint64(0), status.BackupDestinations[1].LastBackup)
+		assert.Equal(t, "s3 failed", status.BackupDestinations[1].Error)
+		condition := configuration.GetCondition(status, v1alpha2.BackupHealthyCondition)
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.Equal(t, "backup '2' failed in destinations: s3", condition.Message)
+
+		tbar.failingContainers["s3"] = false
+		tbar.commands = nil
+
+		err = tbar.Backup(false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"backup.sh", "2"}}, tbar.commands)
+		status = tbar.savedJenkins(t).Status
+		assert.Equal(t, uint64(2), status.LastBackup)
+		assert.Equal(t, uint64(2), status.BackupDestinations[1].LastBackup)
+		assert.Empty(t, status.BackupDestinations[1].Error)
+		assert.Equal(t, corev1.ConditionTrue, configuration.GetCondition(status, v1alpha2.BackupHealthyCondition).Status)
+	})
+}
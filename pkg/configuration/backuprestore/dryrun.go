@@ -0,0 +1,149 @@
+package backuprestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	restoreDryRunAdded    = "added"
+	restoreDryRunModified = "modified"
+
+	// RestoreDryRunBackupKey is the ConfigMap key which contains the number of reviewed backup
+	RestoreDryRunBackupKey = "backup"
+	// RestoreDryRunChangesKey is the ConfigMap key which contains all changed files, one per line
+	RestoreDryRunChangesKey = "changes"
+	// RestoreDryRunJobsKey is the ConfigMap key which contains names of changed jobs, one per line
+	RestoreDryRunJobsKey = "jobs"
+	// RestoreDryRunPluginsKey is the ConfigMap key which contains names of changed plugins, one per line
+	RestoreDryRunPluginsKey = "plugins"
+)
+
+// restoreDryRunChanges represents files, jobs and plugins which would be changed by restoring a backup
+type restoreDryRunChanges struct {
+	files   []string
+	jobs    []string
+	plugins []string
+}
+
+// GetRestoreDryRunConfigMapName returns name of ConfigMap which contains the restore dry run report
+func GetRestoreDryRunConfigMapName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-restore-dry-run-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// RestoreDryRun lists changes of the backup set in spec.restore.dryRunOnce compared to the live JENKINS_HOME
+// and stores them in the restore dry run ConfigMap, nothing is restored
+func (bar *BackupAndRestore) RestoreDryRun() error {
+	jenkins := bar.Configuration.Jenkins
+	backupNumber := jenkins.Spec.Restore.DryRunOnce
+	if backupNumber == 0 {
+		return nil
+	}
+	if len(jenkins.Spec.Restore.ContainerName) == 0 || jenkins.Spec.Restore.Action.Exec == nil {
+		bar.logger.V(log.VDebug).Info("Skipping restore dry run, backup restore not configured")
+		return nil
+	}
+
+	bar.logger.Info(fmt.Sprintf("Running restore dry run of backup '%d'", backupNumber))
+	podName := resources.GetJenkinsMasterPodName(jenkins)
+	command := append([]string{"env", "RESTORE_DRY_RUN=true"}, jenkins.Spec.Restore.Action.Exec.Command...)
+	command = append(command, fmt.Sprintf("%d", backupNumber))
+	stdout, stderr, err := bar.exec(podName, jenkins.Spec.Restore.ContainerName, command)
+	if err != nil {
+		return errors.Wrapf(err, "restore dry run of backup '%d' failed, stderr: %s", backupNumber, stderr.String())
+	}
+
+	changes := parseRestoreDryRunOutput(stdout.String())
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: resources.NewResourceObjectMeta(jenkins),
+		Data: map[string]string{
+			RestoreDryRunBackupKey:  fmt.Sprintf("%d", backupNumber),
+			RestoreDryRunChangesKey: strings.Join(changes.files, "\n"),
+			RestoreDryRunJobsKey:    strings.Join(changes.jobs, "\n"),
+			RestoreDryRunPluginsKey: strings.Join(changes.plugins, "\n"),
+		},
+	}
+	configMap.Name = GetRestoreDryRunConfigMapName(jenkins)
+	if err := bar.CreateOrUpdateResource(configMap); err != nil {
+		return errors.Wrap(err, "couldn't save restore dry run report")
+	}
+	bar.logger.Info(fmt.Sprintf("Restore dry run of backup '%d' found %d changed files, report saved in ConfigMap '%s'",
+		backupNumber, len(changes.files), configMap.Name))
+
+	before := jenkins.DeepCopy()
+	jenkins.Spec.Restore.DryRunOnce = 0
+	return bar.Client.Patch(context.TODO(), jenkins, k8s.MergeFrom(before))
+}
+
+// parseRestoreDryRunOutput parses lines in format 'added: <path>' and 'modified: <path>' printed by the restore action
+func parseRestoreDryRunOutput(output string) restoreDryRunChanges {
+	changes := restoreDryRunChanges{}
+	jobs := map[string]bool{}
+	plugins := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), ": ", 2)
+		if len(parts) != 2 || (parts[0] != restoreDryRunAdded && parts[0] != restoreDryRunModified) {
+			continue
+		}
+		path := strings.TrimPrefix(strings.TrimSpace(parts[1]), "./")
+		if len(path) == 0 {
+			continue
+		}
+		changes.files = append(changes.files, fmt.Sprintf("%s: %s", parts[0], path))
+
+		if job := jobNameFromPath(path); len(job) > 0 {
+			jobs[job] = true
+		}
+		if plugin := pluginNameFromPath(path); len(plugin) > 0 {
+			plugins[plugin] = true
+		}
+	}
+	changes.jobs = sortedKeys(jobs)
+	changes.plugins = sortedKeys(plugins)
+
+	return changes
+}
+
+// jobNameFromPath returns full job name for paths like jobs/folder/jobs/job/config.xml, it supports folders
+func jobNameFromPath(path string) string {
+	parts := strings.Split(path, "/")
+	var names []string
+	for i := 0; i+2 < len(parts) && parts[i] == "jobs"; i += 2 {
+		names = append(names, parts[i+1])
+	}
+	return strings.Join(names, "/")
+}
+
+// pluginNameFromPath returns plugin name for paths like plugins/git.jpi
+func pluginNameFromPath(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] != "plugins" {
+		return ""
+	}
+	for _, extension := range []string{".jpi", ".hpi"} {
+		if strings.HasSuffix(parts[1], extension) {
+			return strings.TrimSuffix(parts[1], extension)
+		}
+	}
+	return ""
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
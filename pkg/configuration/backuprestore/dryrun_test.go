@@ -0,0 +1,42 @@
+package backuprestore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRestoreDryRunOutput(t *testing.T) {
+	t.Run("no changes", func(t *testing.T) {
+		changes := parseRestoreDryRunOutput("Running restore dry run\nDone\n")
+
+		assert.Empty(t, changes.files)
+		assert.Empty(t, changes.jobs)
+		assert.Empty(t, changes.plugins)
+	})
+	t.Run("jobs and plugins", func(t *testing.T) {
+		output := `Running restore dry run
+modified: jobs/build/builds/1/build.xml
+added: jobs/build/nextBuildNumber
+added: ./jobs/folder/jobs/deploy/builds/2/log
+modified: plugins/git.jpi
+added: plugins/workflow-aggregator.hpi
+added: plugins/git/META-INF/MANIFEST.MF
+modified: config.xml
+Done
+`
+		changes := parseRestoreDryRunOutput(output)
+
+		assert.Equal(t, []string{
+			"modified: jobs/build/builds/1/build.xml",
+			"added: jobs/build/nextBuildNumber",
+			"added: jobs/folder/jobs/deploy/builds/2/log",
+			"modified: plugins/git.jpi",
+			"added: plugins/workflow-aggregator.hpi",
+			"added: plugins/git/META-INF/MANIFEST.MF",
+			"modified: config.xml",
+		}, changes.files)
+		assert.Equal(t, []string{"build", "folder/deploy"}, changes.jobs)
+		assert.Equal(t, []string{"git", "workflow-aggregator"}, changes.plugins)
+	})
+}
@@ -0,0 +1,85 @@
+package backuprestore
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "jenkins_operator"
+	metricsSubsystem = "backup"
+
+	backupResultSuccess = "success"
+	backupResultFailure = "failure"
+)
+
+// backupSizeRegex matches the line with archive size in bytes printed by the backup action, for example BACKUP_SIZE=1024
+var backupSizeRegex = regexp.MustCompile(`(?m)^BACKUP_SIZE=(\d+)\s*$`)
+
+var (
+	backupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "duration_seconds",
+		Help:      "Duration of the backup action in seconds.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	}, []string{"namespace", "jenkins", "destination"})
+
+	backupSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "size_bytes",
+		Help:      "Size of the latest backup archive in bytes reported by the backup action.",
+	}, []string{"namespace", "jenkins", "destination"})
+
+	backupsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "runs_total",
+		Help:      "Number of backup runs partitioned by result.",
+	}, []string{"namespace", "jenkins", "destination", "result"})
+
+	backupLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix timestamp of the latest successful backup.",
+	}, []string{"namespace", "jenkins", "destination"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(backupDuration, backupSize, backupsTotal, backupLastSuccess)
+}
+
+// observeBackup records metrics of a single backup action run
+func observeBackup(jenkins *v1alpha2.Jenkins, destination string, started time.Time, stdout string, err error) {
+	labels := prometheus.Labels{"namespace": jenkins.Namespace, "jenkins": jenkins.Name, "destination": destination}
+	backupDuration.With(labels).Observe(time.Since(started).Seconds())
+
+	if err != nil {
+		backupsTotal.With(resultLabels(labels, backupResultFailure)).Inc()
+		return
+	}
+
+	backupsTotal.With(resultLabels(labels, backupResultSuccess)).Inc()
+	backupLastSuccess.With(labels).SetToCurrentTime()
+	if matches := backupSizeRegex.FindStringSubmatch(stdout); len(matches) == 2 {
+		if size, err := strconv.ParseFloat(matches[1], 64); err == nil {
+			backupSize.With(labels).Set(size)
+		}
+	}
+}
+
+func resultLabels(labels prometheus.Labels, result string) prometheus.Labels {
+	withResult := prometheus.Labels{"result": result}
+	for key, value := range labels {
+		withResult[key] = value
+	}
+	return withResult
+}
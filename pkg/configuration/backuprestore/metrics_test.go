@@ -0,0 +1,33 @@
+package backuprestore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObserveBackup(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "jenkins"}}
+	labels := prometheus.Labels{"namespace": "ns", "jenkins": "jenkins", "destination": "local"}
+
+	t.Run("successful backup with size", func(t *testing.T) {
+		observeBackup(jenkins, "local", time.Now(), "Running backup\nBACKUP_SIZE=1024\nDone\n", nil)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(backupsTotal.With(resultLabels(labels, backupResultSuccess))))
+		assert.Equal(t, float64(1024), testutil.ToFloat64(backupSize.With(labels)))
+		assert.NotZero(t, testutil.ToFloat64(backupLastSuccess.With(labels)))
+	})
+	t.Run("failed backup", func(t *testing.T) {
+		observeBackup(jenkins, "local", time.Now(), "", errors.New("exec failed"))
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(backupsTotal.With(resultLabels(labels, backupResultFailure))))
+		assert.Equal(t, float64(1024), testutil.ToFloat64(backupSize.With(labels)))
+	})
+}
@@ -0,0 +1,79 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// adminCredentialRotationRetryInterval is the interval of retries of the failed rotation of the operator credentials
+const adminCredentialRotationRetryInterval = 10 * time.Minute
+
+// ensureAdminCredentialRotation rotates the password and the API token of the operator user when they're older than
+// spec.security.adminCredentialRotation.interval, it returns the Jenkins client authenticated with the current
+// credentials and the time left to the next rotation
+func (r *ReconcileJenkinsBaseConfiguration) ensureAdminCredentialRotation(jenkinsClient jenkinsclient.Jenkins) (jenkinsclient.Jenkins, time.Duration, error) {
+	security := r.Configuration.Jenkins.Spec.Security
+	if security == nil || security.AdminCredentialRotation == nil ||
+		r.Configuration.Jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy {
+		return jenkinsClient, 0, nil
+	}
+	interval := security.AdminCredentialRotation.Interval.Duration
+
+	credentialsSecret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, credentialsSecret)
+	if err != nil {
+		return jenkinsClient, adminCredentialRotationRetryInterval, stackerr.WithStack(err)
+	}
+	if remaining := interval - time.Since(getPasswordCreationTime(credentialsSecret)); remaining > 0 {
+		return jenkinsClient, remaining, nil
+	}
+
+	rotatedJenkinsClient, rotationErr := r.Configuration.RotateOperatorCredentials(jenkinsClient)
+	// the Jenkins master pod is recreated when the password doesn't match the status, the status is updated also when
+	// the rotation failed after the Secret had been updated
+	userAndPasswordHash, err := r.calculateUserAndPasswordHash()
+	if err != nil {
+		return jenkinsClient, adminCredentialRotationRetryInterval, err
+	}
+	if userAndPasswordHash != r.Configuration.Jenkins.Status.UserAndPasswordHash {
+		r.Configuration.Jenkins.Status.UserAndPasswordHash = userAndPasswordHash
+		if err = r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins); err != nil {
+			return jenkinsClient, adminCredentialRotationRetryInterval, stackerr.WithStack(err)
+		}
+	}
+	if rotationErr != nil {
+		return jenkinsClient, adminCredentialRotationRetryInterval, rotationErr
+	}
+
+	message := "Password and API token of the operator user have been rotated"
+	r.logger.Info(message)
+	*r.Configuration.Notifications <- event.Event{
+		Jenkins: *r.Configuration.Jenkins,
+		Phase:   event.PhaseBase,
+		Level:   v1alpha2.NotificationLevelInfo,
+		Reason: reason.NewAdminCredentialsRotated(reason.OperatorSource, []string{message},
+			fmt.Sprintf("%s in Secret '%s', the next rotation is in %s", message, credentialsSecret.Name, interval)),
+	}
+	return rotatedJenkinsClient, interval, nil
+}
+
+// getPasswordCreationTime returns the time when the password of the operator user has been generated, passwords which
+// have never been rotated are as old as the operator credentials Secret
+func getPasswordCreationTime(credentialsSecret *corev1.Secret) time.Time {
+	passwordCreationTime := time.Time{}
+	if err := passwordCreationTime.UnmarshalText(credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordCreationKey]); err != nil {
+		return credentialsSecret.CreationTimestamp.Time
+	}
+	return passwordCreationTime
+}
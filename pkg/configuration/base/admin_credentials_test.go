@@ -0,0 +1,114 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileJenkinsBaseConfiguration_ensureAdminCredentialRotation(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newJenkins := func() *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master:             v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName}}},
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy},
+				Security: &v1alpha2.Security{
+					AdminCredentialRotation: &v1alpha2.AdminCredentialRotation{Interval: metav1.Duration{Duration: 24 * time.Hour}},
+				},
+			},
+			Status: v1alpha2.JenkinsStatus{UserAndPasswordHash: "hash"},
+		}
+	}
+	newCredentialsSecret := func(jenkins *v1alpha2.Jenkins, passwordCreationTime time.Time) *corev1.Secret {
+		creationTime, _ := passwordCreationTime.MarshalText()
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.GetOperatorCredentialsSecretName(jenkins), Namespace: defaultNamespace},
+			Data: map[string][]byte{
+				resources.OperatorCredentialsSecretUserNameKey:         []byte(resources.OperatorUserName),
+				resources.OperatorCredentialsSecretPasswordKey:         []byte("password"),
+				resources.OperatorCredentialsSecretPasswordCreationKey: creationTime,
+			},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		r := New(configuration.Configuration{Jenkins: &v1alpha2.Jenkins{}}, client.JenkinsAPIConnectionSettings{})
+
+		jenkinsClient, requeueAfter, err := r.ensureAdminCredentialRotation(nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, jenkinsClient)
+		assert.Equal(t, time.Duration(0), requeueAfter)
+	})
+	t.Run("rotation isn't due", func(t *testing.T) {
+		jenkins := newJenkins()
+		fakeClient := fake.NewFakeClient(jenkins, newCredentialsSecret(jenkins, time.Now().Add(-time.Hour)))
+		r := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient}, client.JenkinsAPIConnectionSettings{})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+
+		currentJenkinsClient, requeueAfter, err := r.ensureAdminCredentialRotation(jenkinsClient)
+
+		require.NoError(t, err)
+		assert.Equal(t, jenkinsClient, currentJenkinsClient)
+		assert.True(t, requeueAfter > 22*time.Hour && requeueAfter <= 23*time.Hour, requeueAfter)
+	})
+	t.Run("rotation failed", func(t *testing.T) {
+		jenkins := newJenkins()
+		credentialsSecret := newCredentialsSecret(jenkins, time.Now().Add(-25*time.Hour))
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.GetJenkinsHTTPServiceName(jenkins), Namespace: defaultNamespace},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 8080}}},
+		}
+		fakeClient := fake.NewFakeClient(jenkins, credentialsSecret, service)
+		notifications := make(chan event.Event, 1)
+		r := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient, Notifications: &notifications}, client.JenkinsAPIConnectionSettings{})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).Return("", errors.New("connection refused"))
+
+		currentJenkinsClient, requeueAfter, err := r.ensureAdminCredentialRotation(jenkinsClient)
+
+		assert.EqualError(t, err, "couldn't change password of the operator: connection refused")
+		assert.Equal(t, jenkinsClient, currentJenkinsClient)
+		assert.Equal(t, adminCredentialRotationRetryInterval, requeueAfter)
+		assert.Empty(t, notifications)
+		actualSecret := &corev1.Secret{}
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: credentialsSecret.Name, Namespace: defaultNamespace}, actualSecret)
+		require.NoError(t, err)
+		assert.Equal(t, credentialsSecret.Data, actualSecret.Data)
+	})
+}
+
+func TestGetPasswordCreationTime(t *testing.T) {
+	secretCreationTime := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	credentialsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(secretCreationTime)}}
+
+	assert.Equal(t, secretCreationTime, getPasswordCreationTime(credentialsSecret))
+
+	credentialsSecret.Data = map[string][]byte{resources.OperatorCredentialsSecretPasswordCreationKey: []byte("2020-06-02T12:00:00Z")}
+
+	assert.Equal(t, secretCreationTime.Add(24*time.Hour), getPasswordCreationTime(credentialsSecret))
+}
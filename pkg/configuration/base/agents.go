@@ -0,0 +1,47 @@
+package base
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	stackerr "github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ensureAgentsNamespaceResources creates the ServiceAccount of agent pods, the Role and RoleBinding which allow Jenkins
+// to manage agent pods and the NetworkPolicy which isolates them in the namespace set in spec.agents.namespace
+func (r *ReconcileJenkinsBaseConfiguration) ensureAgentsNamespaceResources() error {
+	jenkins := r.Configuration.Jenkins
+	if !resources.IsAgentsNamespaceEnabled(jenkins) {
+		return nil
+	}
+
+	meta := resources.NewAgentsResourceObjectMeta(jenkins)
+	// the token of the ServiceAccount is added by Kubernetes, so the existing one isn't updated
+	if err := r.Client.Create(context.TODO(), resources.NewAgentsServiceAccount(meta)); err != nil && !apierrors.IsAlreadyExists(err) {
+		return stackerr.WithStack(err)
+	}
+
+	for _, object := range []runtime.Object{
+		resources.NewAgentsRole(meta),
+		resources.NewAgentsRoleBinding(meta, jenkins),
+		resources.NewAgentsNetworkPolicy(meta),
+	} {
+		if err := r.createOrUpdateAgentsResource(object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createOrUpdateAgentsResource creates or updates the resource in the agents namespace, Jenkins CR isn't set as
+// the owner because owner references across namespaces aren't supported by the garbage collector
+func (r *ReconcileJenkinsBaseConfiguration) createOrUpdateAgentsResource(object runtime.Object) error {
+	err := r.Client.Create(context.TODO(), object)
+	if apierrors.IsAlreadyExists(err) {
+		err = r.Client.Update(context.TODO(), object)
+	}
+	return stackerr.WithStack(err)
+}
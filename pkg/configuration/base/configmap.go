@@ -8,7 +8,19 @@ import (
 )
 
 func (r *ReconcileJenkinsBaseConfiguration) createScriptsConfigMap(meta metav1.ObjectMeta) error {
-	configMap, err := resources.NewScriptsConfigMap(meta, r.Configuration.Jenkins)
+	userPlugins, _, err := r.getUserPlugins()
+	if err != nil {
+		return err
+	}
+	dependencies, _, err := r.resolvePluginDependencies()
+	if err != nil {
+		return err
+	}
+	checksums, _, err := r.resolvePluginChecksums()
+	if err != nil {
+		return err
+	}
+	configMap, err := resources.NewScriptsConfigMap(meta, r.Configuration.Jenkins, userPlugins, dependencies, checksums)
 	if err != nil {
 		return err
 	}
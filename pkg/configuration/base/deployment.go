@@ -21,6 +21,14 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsDeployment(meta metav1.
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	envSecretsHash, err := r.calculateEnvSecretsHash()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	sshCLIHash, err := r.calculateSSHCLIHash()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
 	_, err = r.GetJenkinsDeployment()
 	if apierrors.IsNotFound(err) {
@@ -45,8 +53,10 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsDeployment(meta metav1.
 			LastBackup:          r.Configuration.Jenkins.Status.LastBackup,
 			PendingBackup:       r.Configuration.Jenkins.Status.LastBackup,
 			UserAndPasswordHash: userAndPasswordHash,
+			EnvSecretsHash:      envSecretsHash,
+			SSHCLIHash:          sshCLIHash,
 		}
-		return reconcile.Result{Requeue: true}, r.Client.Update(context.TODO(), r.Configuration.Jenkins)
+		return reconcile.Result{Requeue: true}, r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins)
 	} else if err != nil && !apierrors.IsNotFound(err) {
 		return reconcile.Result{}, stackerr.WithStack(err)
 	}
@@ -0,0 +1,137 @@
+package base
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/audit"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	stackerr "github.com/pkg/errors"
+)
+
+const (
+	restartRequiredMarker = "dynamic-plugin-installation-restart-required"
+
+	dynamicPluginsConfigurationType = "base-plugins"
+	dynamicPluginsScriptName        = "install-plugins.groovy"
+)
+
+const installPluginsDynamicallyGroovyFmt = `import hudson.ProxyConfiguration
+import jenkins.model.Jenkins
+
+import java.security.MessageDigest
+
+def pluginManager = Jenkins.get().pluginManager
+def restartRequired = false
+def pluginsToInstall = [
+%s]
+
+for (plugin in pluginsToInstall) {
+    def name = plugin[0]
+    if (pluginManager.getPlugin(name) != null) {
+        println "Plugin '${name}' is already installed, restart is required"
+        restartRequired = true
+        break
+    }
+
+    def pluginFile = new File(pluginManager.rootDir, name + '.jpi')
+    ProxyConfiguration.getInputStream(new URL(plugin[1])).withStream { input ->
+        pluginFile.withOutputStream { output -> output << input }
+    }
+    if (plugin[2]) {
+        def checksum = MessageDigest.getInstance('SHA-256').digest(pluginFile.bytes).encodeHex().toString()
+        if (checksum != plugin[2]) {
+            pluginFile.delete()
+            throw new Exception("Plugin '${name}' SHA-256 checksum '${checksum}' doesn't match expected '${plugin[2]}'")
+        }
+    }
+    try {
+        pluginManager.dynamicLoad(pluginFile)
+        println "Plugin '${name}' has been loaded dynamically"
+    } catch (Exception e) {
+        println "Plugin '${name}' can't be loaded dynamically: ${e.message}"
+        restartRequired = true
+        break
+    }
+}
+
+if (restartRequired) {
+    println '%s'
+}
+`
+
+// installPluginsDynamically downloads missing plugins and loads them without restarting Jenkins master,
+// it returns false when Jenkins master has to be restarted to finish the installation
+func (r *ReconcileJenkinsBaseConfiguration) installPluginsDynamically(jenkinsClient jenkinsclient.Jenkins, missingPlugins []v1alpha2.Plugin) (bool, error) {
+	checksums, _, err := r.resolvePluginChecksums()
+	if err != nil {
+		return false, err
+	}
+
+	script := r.buildInstallPluginsDynamicallyScript(missingPlugins, checksums)
+	start := time.Now()
+	logs, err := jenkinsClient.ExecuteScript(script)
+	groovy.RecordExecution(r.Client, r.Configuration.Jenkins, groovy.Execution{
+		ConfigurationType: dynamicPluginsConfigurationType,
+		Source:            dynamicPluginsConfigurationType,
+		Name:              dynamicPluginsScriptName,
+		Duration:          time.Since(start),
+		Logs:              logs,
+		Err:               err,
+	})
+	if err != nil {
+		if _, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed); ok {
+			r.logger.V(log.VWarn).Info(fmt.Sprintf("Dynamic plugin installation failed, logs: %s", logs))
+			return false, nil
+		}
+		return false, stackerr.WithStack(err)
+	}
+	r.logger.V(log.VDebug).Info(fmt.Sprintf("Dynamic plugin installation logs: %s", logs))
+	var names []string
+	for _, plugin := range missingPlugins {
+		names = append(names, plugin.Name+":"+plugin.Version)
+	}
+	audit.Add(r.Client, r.Configuration.Jenkins, audit.ActionGroovyScriptExecuted,
+		fmt.Sprintf("Plugins '%s' have been installed dynamically", strings.Join(names, ", ")), audit.Hash(script))
+
+	return !strings.Contains(logs, restartRequiredMarker), nil
+}
+
+func (r *ReconcileJenkinsBaseConfiguration) buildInstallPluginsDynamicallyScript(missingPlugins []v1alpha2.Plugin, checksums map[string]string) string {
+	var pluginsToInstall strings.Builder
+	for _, plugin := range missingPlugins {
+		pluginsToInstall.WriteString(fmt.Sprintf("    [%s, %s, %s],\n", groovyString(plugin.Name),
+			groovyString(getPluginDownloadURL(r.Configuration.Jenkins.Spec.Master, plugin)), groovyString(checksums[plugin.Name])))
+	}
+
+	return fmt.Sprintf(installPluginsDynamicallyGroovyFmt, pluginsToInstall.String(), restartRequiredMarker)
+}
+
+// getPluginDownloadURL returns the URL of the plugin .hpi file in the same way as the install-plugins.sh script
+func getPluginDownloadURL(master v1alpha2.JenkinsMaster, plugin v1alpha2.Plugin) string {
+	if len(plugin.DownloadURL) > 0 {
+		return plugin.DownloadURL
+	}
+
+	downloadURL := master.UpdateCenterDownloadURL
+	if len(downloadURL) == 0 {
+		updateCenterURL := master.UpdateCenterURL
+		if len(updateCenterURL) == 0 {
+			updateCenterURL = plugins.DefaultUpdateCenterURL
+		}
+		downloadURL = strings.TrimSuffix(updateCenterURL, "/") + "/download"
+	}
+
+	return fmt.Sprintf("%s/plugins/%s/%s/%s.hpi", strings.TrimSuffix(downloadURL, "/"), plugin.Name, plugin.Version, plugin.Name)
+}
+
+// groovyString returns value as a single quoted Groovy string literal
+func groovyString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
@@ -0,0 +1,62 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetPluginDownloadURL(t *testing.T) {
+	plugin := v1alpha2.Plugin{Name: "git", Version: "4.3.0"}
+
+	assert.Equal(t, "https://updates.jenkins.io/download/plugins/git/4.3.0/git.hpi", getPluginDownloadURL(v1alpha2.JenkinsMaster{}, plugin))
+	assert.Equal(t, "https://mirror.example.com/download/plugins/git/4.3.0/git.hpi",
+		getPluginDownloadURL(v1alpha2.JenkinsMaster{UpdateCenterURL: "https://mirror.example.com/"}, plugin))
+	assert.Equal(t, "https://files.example.com/plugins/git/4.3.0/git.hpi",
+		getPluginDownloadURL(v1alpha2.JenkinsMaster{UpdateCenterURL: "https://mirror.example.com", UpdateCenterDownloadURL: "https://files.example.com"}, plugin))
+	assert.Equal(t, "https://example.com/git.hpi",
+		getPluginDownloadURL(v1alpha2.JenkinsMaster{}, v1alpha2.Plugin{Name: "git", Version: "4.3.0", DownloadURL: "https://example.com/git.hpi"}))
+}
+
+func TestInstallPluginsDynamically(t *testing.T) {
+	log.SetupLogger(true)
+	missingPlugins := []v1alpha2.Plugin{{Name: "git", Version: "4.3.0"}}
+	r := New(configuration.Configuration{Client: fake.NewFakeClient(), Jenkins: &v1alpha2.Jenkins{}}, client.JenkinsAPIConnectionSettings{})
+	script := r.buildInstallPluginsDynamicallyScript(missingPlugins, nil)
+	assert.Contains(t, script, "    ['git', 'https://updates.jenkins.io/download/plugins/git/4.3.0/git.hpi', ''],\n")
+
+	t.Run("loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(script).Return("Plugin 'git' has been loaded dynamically", nil)
+
+		ok, err := r.installPluginsDynamically(jenkinsClient, missingPlugins)
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+	t.Run("restart required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(script).Return("Plugin 'git' can't be loaded dynamically\n"+restartRequiredMarker, nil)
+
+		ok, err := r.installPluginsDynamically(jenkinsClient, missingPlugins)
+
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestGroovyString(t *testing.T) {
+	assert.Equal(t, `'it\'s a \\ test'`, groovyString(`it's a \ test`))
+}
@@ -0,0 +1,72 @@
+package base
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// getMetricsPath returns the path of the Prometheus metrics endpoint in Jenkins including the Jenkins prefix
+func (r *ReconcileJenkinsBaseConfiguration) getMetricsPath() string {
+	prefix := configuration.GetJenkinsOpts(*r.Configuration.Jenkins)["prefix"]
+	return prefix + "/" + r.Configuration.Jenkins.Spec.Monitoring.Path + "/"
+}
+
+// ensureServiceMonitor creates the ServiceMonitor of Jenkins metrics when monitoring is enabled and deletes it when
+// monitoring is disabled
+func (r *ReconcileJenkinsBaseConfiguration) ensureServiceMonitor(meta metav1.ObjectMeta) error {
+	found := &monitoringv1.ServiceMonitor{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetServiceMonitorName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.Namespace}, found)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return stackerr.WithStack(err)
+	}
+	notFound := apierrors.IsNotFound(err)
+
+	if !resources.IsMonitoringEnabled(r.Configuration.Jenkins) {
+		if notFound {
+			return nil
+		}
+		return stackerr.WithStack(r.Client.Delete(context.TODO(), found))
+	}
+
+	serviceMonitor := resources.NewServiceMonitor(meta, r.Configuration.Jenkins, r.getMetricsPath())
+	if notFound {
+		return stackerr.WithStack(r.CreateResource(serviceMonitor))
+	}
+	if reflect.DeepEqual(found.Spec, serviceMonitor.Spec) && reflect.DeepEqual(found.Labels, serviceMonitor.Labels) {
+		return nil
+	}
+	found.Labels = serviceMonitor.Labels
+	found.Spec = serviceMonitor.Spec
+	return stackerr.WithStack(r.UpdateResource(found))
+}
+
+// ensureGrafanaDashboards creates the ConfigMap with Grafana dashboards when monitoring is enabled and deletes it when
+// monitoring is disabled
+func (r *ReconcileJenkinsBaseConfiguration) ensureGrafanaDashboards(meta metav1.ObjectMeta) error {
+	if resources.IsMonitoringEnabled(r.Configuration.Jenkins) {
+		configMap, err := resources.NewGrafanaDashboardsConfigMap(meta, r.Configuration.Jenkins)
+		if err != nil {
+			return err
+		}
+		return stackerr.WithStack(r.CreateOrUpdateResource(configMap))
+	}
+
+	found := &corev1.ConfigMap{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetGrafanaDashboardsConfigMapName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.Namespace}, found)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	return stackerr.WithStack(r.Client.Delete(context.TODO(), found))
+}
@@ -1,7 +1,11 @@
 package base
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
@@ -10,12 +14,26 @@ import (
 
 	"github.com/bndr/gojenkins"
 	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (r *ReconcileJenkinsBaseConfiguration) verifyPlugins(jenkinsClient jenkinsclient.Jenkins) (bool, error) {
+const (
+	defaultPluginsConfigMapKey = "plugins.txt"
+	// upgradeLatestPluginsAnnotation requests resolving the latest versions of plugins again
+	upgradeLatestPluginsAnnotation = "jenkins.io/upgrade-latest-plugins"
+)
+
+// verifyPlugins checks whether required plugins are installed in the required versions, user plugins which aren't
+// installed at all are returned separately when dynamic plugin installation is enabled, so they can be installed
+// without restarting Jenkins master
+func (r *ReconcileJenkinsBaseConfiguration) verifyPlugins(jenkinsClient jenkinsclient.Jenkins) (bool, []v1alpha2.Plugin, error) {
 	allPluginsInJenkins, err := jenkinsClient.GetPlugins(fetchAllPlugins)
 	if err != nil {
-		return false, stackerr.WithStack(err)
+		return false, nil, stackerr.WithStack(err)
 	}
 
 	var installedPlugins []string
@@ -26,11 +44,35 @@ func (r *ReconcileJenkinsBaseConfiguration) verifyPlugins(jenkinsClient jenkinsc
 	}
 	r.logger.V(log.VDebug).Info(fmt.Sprintf("Installed plugins '%+v'", installedPlugins))
 
+	userPlugins, _, err := r.getUserPlugins()
+	if err != nil {
+		return false, nil, err
+	}
+
+	dynamicPluginInstallation := r.Configuration.Jenkins.Spec.Master.DynamicPluginInstallation
+	var missingUserPlugins []v1alpha2.Plugin
+	if dynamicPluginInstallation {
+		dependencyPlugins, _, err := r.resolvePluginDependencies()
+		if err != nil {
+			return false, nil, err
+		}
+		for _, plugin := range dependencyPlugins {
+			if allPluginsInJenkins.Contains(plugin.Name) == nil {
+				missingUserPlugins = append(missingUserPlugins, plugin)
+			}
+		}
+	}
+
 	status := true
-	allRequiredPlugins := [][]v1alpha2.Plugin{r.Configuration.Jenkins.Spec.Master.BasePlugins, r.Configuration.Jenkins.Spec.Master.Plugins}
-	for _, requiredPlugins := range allRequiredPlugins {
+	allRequiredPlugins := [][]v1alpha2.Plugin{r.Configuration.Jenkins.Spec.Master.BasePlugins, userPlugins}
+	for i, requiredPlugins := range allRequiredPlugins {
 		for _, plugin := range requiredPlugins {
 			if _, ok := isPluginInstalled(allPluginsInJenkins, plugin); !ok {
+				if dynamicPluginInstallation && i > 0 && allPluginsInJenkins.Contains(plugin.Name) == nil {
+					r.logger.Info(fmt.Sprintf("Missing plugin '%s', it will be installed dynamically", plugin))
+					missingUserPlugins = append(missingUserPlugins, plugin)
+					continue
+				}
 				r.logger.V(log.VWarn).Info(fmt.Sprintf("Missing plugin '%s'", plugin))
 				status = false
 				continue
@@ -42,7 +84,294 @@ func (r *ReconcileJenkinsBaseConfiguration) verifyPlugins(jenkinsClient jenkinsc
 		}
 	}
 
-	return status, nil
+	return status, missingUserPlugins, nil
+}
+
+// ensurePluginInventory reports plugins installed in Jenkins in the status
+func (r *ReconcileJenkinsBaseConfiguration) ensurePluginInventory(jenkinsClient jenkinsclient.Jenkins) error {
+	allPluginsInJenkins, err := jenkinsClient.GetPlugins(fetchAllPlugins)
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	var installedPlugins []v1alpha2.InstalledPlugin
+	for _, jenkinsPlugin := range allPluginsInJenkins.Raw.Plugins {
+		if jenkinsPlugin.Deleted {
+			continue
+		}
+		installedPlugins = append(installedPlugins, v1alpha2.InstalledPlugin{
+			Name:    jenkinsPlugin.ShortName,
+			Version: jenkinsPlugin.Version,
+			Enabled: jenkinsPlugin.Enabled,
+		})
+	}
+	sort.Slice(installedPlugins, func(i, j int) bool {
+		return installedPlugins[i].Name < installedPlugins[j].Name
+	})
+
+	if reflect.DeepEqual(installedPlugins, r.Configuration.Jenkins.Status.Plugins) {
+		return nil
+	}
+
+	r.Configuration.Jenkins.Status.Plugins = installedPlugins
+	return stackerr.WithStack(r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins))
+}
+
+// getUserPlugins returns user plugins with the latest versions replaced by versions pinned by the operator.
+// Messages describe invalid lines in the plugins.txt file.
+func (r *ReconcileJenkinsBaseConfiguration) getUserPlugins() ([]v1alpha2.Plugin, []string, error) {
+	userPlugins, messages, err := r.getDeclaredUserPlugins()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, plugin := range userPlugins {
+		if plugin.Version != plugins.LatestVersion {
+			continue
+		}
+		for _, pinnedPlugin := range r.Configuration.Jenkins.Status.PinnedPlugins {
+			if pinnedPlugin.Name == plugin.Name {
+				userPlugins[i].Version = pinnedPlugin.Version
+			}
+		}
+	}
+
+	return userPlugins, messages, nil
+}
+
+// getDeclaredUserPlugins returns plugins from spec.master.plugins and the plugins.txt ConfigMap, plugins from
+// spec.master.plugins take precedence. Messages describe invalid lines in the plugins.txt file.
+func (r *ReconcileJenkinsBaseConfiguration) getDeclaredUserPlugins() ([]v1alpha2.Plugin, []string, error) {
+	userPlugins := r.Configuration.Jenkins.Spec.Master.Plugins
+	pluginsConfigMap := r.Configuration.Jenkins.Spec.Master.PluginsConfigMap
+	if pluginsConfigMap == nil {
+		return append([]v1alpha2.Plugin{}, userPlugins...), nil, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: pluginsConfigMap.Name, Namespace: r.Configuration.Jenkins.Namespace}, configMap)
+	if err != nil && apierrors.IsNotFound(err) {
+		return append([]v1alpha2.Plugin{}, userPlugins...), []string{fmt.Sprintf("ConfigMap '%s' configured in spec.master.pluginsConfigMap not found", pluginsConfigMap.Name)}, nil
+	} else if err != nil {
+		return nil, nil, stackerr.WithStack(err)
+	}
+
+	key := pluginsConfigMap.Key
+	if len(key) == 0 {
+		key = defaultPluginsConfigMapKey
+	}
+	content, ok := configMap.Data[key]
+	if !ok {
+		return append([]v1alpha2.Plugin{}, userPlugins...), []string{fmt.Sprintf("ConfigMap '%s' configured in spec.master.pluginsConfigMap has no '%s' key", pluginsConfigMap.Name, key)}, nil
+	}
+
+	pluginsFromConfigMap, parseMessages := plugins.ParsePluginsTxt(content)
+	var messages []string
+	for _, message := range parseMessages {
+		messages = append(messages, fmt.Sprintf("ConfigMap '%s' key '%s' %s", pluginsConfigMap.Name, key, message))
+	}
+
+	result := append([]v1alpha2.Plugin{}, userPlugins...)
+	for _, plugin := range pluginsFromConfigMap {
+		if isPluginDefined(userPlugins, plugin.Name) {
+			continue
+		}
+		result = append(result, v1alpha2.Plugin{Name: plugin.Name, Version: plugin.Version, DownloadURL: plugin.DownloadURL})
+	}
+
+	return result, messages, nil
+}
+
+// ensurePinnedPlugins resolves the latest versions of user plugins which aren't pinned yet, all of them are resolved
+// again when the upgrade annotation is set
+func (r *ReconcileJenkinsBaseConfiguration) ensurePinnedPlugins() error {
+	jenkins := r.Configuration.Jenkins
+	upgrade := jenkins.Annotations[upgradeLatestPluginsAnnotation] == "true"
+
+	userPlugins, _, err := r.getDeclaredUserPlugins()
+	if err != nil {
+		return err
+	}
+
+	var pinnedPlugins []v1alpha2.PinnedPlugin
+	var notPinnedPlugins []string
+	for _, plugin := range userPlugins {
+		if plugin.Version != plugins.LatestVersion {
+			continue
+		}
+		if pinnedPlugin := findPinnedPlugin(jenkins.Status.PinnedPlugins, plugin.Name); pinnedPlugin != nil && !upgrade {
+			pinnedPlugins = append(pinnedPlugins, *pinnedPlugin)
+			continue
+		}
+		notPinnedPlugins = append(notPinnedPlugins, plugin.Name)
+	}
+
+	if len(notPinnedPlugins) > 0 {
+		versions, err := plugins.FetchLatestVersions(getUpdateCenterJSONURL(jenkins.Spec.Master), r.Configuration.GetProxySettings())
+		if err != nil {
+			return err
+		}
+		now := metav1.Now()
+		for _, name := range notPinnedPlugins {
+			version, ok := versions[name]
+			if !ok {
+				return stackerr.Errorf("couldn't resolve the latest version of plugin '%s', it's not published in the update center", name)
+			}
+			if pinnedPlugin := findPinnedPlugin(jenkins.Status.PinnedPlugins, name); pinnedPlugin != nil && pinnedPlugin.Version == version {
+				pinnedPlugins = append(pinnedPlugins, *pinnedPlugin)
+				continue
+			}
+			r.logger.Info(fmt.Sprintf("Pinning plugin '%s' latest version to '%s'", name, version))
+			pinnedPlugins = append(pinnedPlugins, v1alpha2.PinnedPlugin{Name: name, Version: version, PinTime: now})
+		}
+	}
+	sort.Slice(pinnedPlugins, func(i, j int) bool {
+		return pinnedPlugins[i].Name < pinnedPlugins[j].Name
+	})
+
+	if !upgrade && reflect.DeepEqual(pinnedPlugins, jenkins.Status.PinnedPlugins) {
+		return nil
+	}
+
+	jenkins.Status.PinnedPlugins = pinnedPlugins
+	if err := r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return stackerr.WithStack(err)
+	}
+	if _, found := jenkins.Annotations[upgradeLatestPluginsAnnotation]; !found {
+		return nil
+	}
+	before := jenkins.DeepCopy()
+	delete(jenkins.Annotations, upgradeLatestPluginsAnnotation)
+	return stackerr.WithStack(r.Client.Patch(context.TODO(), jenkins, client.MergeFrom(before)))
+}
+
+func findPinnedPlugin(pinnedPlugins []v1alpha2.PinnedPlugin, name string) *v1alpha2.PinnedPlugin {
+	for _, pinnedPlugin := range pinnedPlugins {
+		if pinnedPlugin.Name == name {
+			return &pinnedPlugin
+		}
+	}
+
+	return nil
+}
+
+// getUpdateCenterJSONURL returns URL of the update center metadata with the latest plugin versions and security warnings
+func getUpdateCenterJSONURL(master v1alpha2.JenkinsMaster) string {
+	updateCenterURL := master.UpdateCenterURL
+	if len(updateCenterURL) == 0 {
+		updateCenterURL = plugins.DefaultUpdateCenterURL
+	}
+
+	return strings.TrimSuffix(updateCenterURL, "/") + plugins.UpdateCenterJSONPath
+}
+
+func isPluginDefined(plugins []v1alpha2.Plugin, name string) bool {
+	for _, plugin := range plugins {
+		if plugin.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolvePluginDependencies returns transitive dependencies of base and user plugins pinned by the operator
+// and messages describing conflicts between them
+func (r *ReconcileJenkinsBaseConfiguration) resolvePluginDependencies() ([]v1alpha2.Plugin, []string, error) {
+	resolution := r.Configuration.Jenkins.Spec.Master.PluginDependencyResolution
+	if resolution == nil {
+		return nil, nil, nil
+	}
+
+	updateCenter, err := plugins.FetchUpdateCenter(getPluginVersionsURL(r.Configuration.Jenkins.Spec.Master), r.Configuration.GetProxySettings())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userPlugins, _, err := r.getUserPlugins()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var requestedPlugins []plugins.Plugin
+	for _, requiredPlugins := range [][]v1alpha2.Plugin{r.Configuration.Jenkins.Spec.Master.BasePlugins, userPlugins} {
+		for _, plugin := range requiredPlugins {
+			requestedPlugins = append(requestedPlugins, plugins.Plugin{Name: plugin.Name, Version: plugin.Version, DownloadURL: plugin.DownloadURL})
+		}
+	}
+
+	dependencies, messages := updateCenter.ResolveDependencies(requestedPlugins)
+	var result []v1alpha2.Plugin
+	for _, dependency := range dependencies {
+		result = append(result, v1alpha2.Plugin{Name: dependency.Name, Version: dependency.Version})
+	}
+	r.logger.V(log.VDebug).Info(fmt.Sprintf("Resolved plugin dependencies '%+v'", dependencies))
+
+	return result, messages, nil
+}
+
+// resolvePluginChecksums returns hex encoded SHA-256 checksums of all plugins installed by the operator, checksums
+// declared in the Jenkins CR take precedence over the update center metadata
+func (r *ReconcileJenkinsBaseConfiguration) resolvePluginChecksums() (map[string]string, []string, error) {
+	master := r.Configuration.Jenkins.Spec.Master
+	if !master.PluginChecksumVerification {
+		return nil, nil, nil
+	}
+
+	userPlugins, _, err := r.getUserPlugins()
+	if err != nil {
+		return nil, nil, err
+	}
+	dependencyPlugins, _, err := r.resolvePluginDependencies()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updateCenter *plugins.UpdateCenter
+	var messages []string
+	checksums := map[string]string{}
+	for _, requiredPlugins := range [][]v1alpha2.Plugin{master.BasePlugins, userPlugins, dependencyPlugins} {
+		for _, plugin := range requiredPlugins {
+			checksum := plugin.SHA256
+			if len(checksum) == 0 && master.PluginBundle != nil {
+				// the update center isn't available when plugins are installed offline
+				messages = append(messages, fmt.Sprintf("Plugin '%s:%s' has no SHA-256 checksum, set it in the sha256 field", plugin.Name, plugin.Version))
+				continue
+			}
+			if len(checksum) == 0 {
+				if updateCenter == nil {
+					if updateCenter, err = plugins.FetchUpdateCenter(getPluginVersionsURL(master), r.Configuration.GetProxySettings()); err != nil {
+						return nil, nil, err
+					}
+				}
+				var found bool
+				if checksum, found = updateCenter.Checksum(plugin.Name, plugin.Version); !found {
+					messages = append(messages, fmt.Sprintf("Plugin '%s:%s' has no SHA-256 checksum in the update center, set it in the sha256 field", plugin.Name, plugin.Version))
+					continue
+				}
+			}
+			normalized, err := plugins.NormalizeSHA256(checksum)
+			if err != nil {
+				messages = append(messages, fmt.Sprintf("Plugin '%s:%s' has invalid checksum, %s", plugin.Name, plugin.Version, err))
+				continue
+			}
+			checksums[plugin.Name] = normalized
+		}
+	}
+
+	return checksums, messages, nil
+}
+
+// getPluginVersionsURL returns URL of the update center metadata with all plugin versions
+func getPluginVersionsURL(master v1alpha2.JenkinsMaster) string {
+	if master.PluginDependencyResolution != nil && len(master.PluginDependencyResolution.PluginVersionsURL) > 0 {
+		return master.PluginDependencyResolution.PluginVersionsURL
+	}
+	if len(master.UpdateCenterURL) > 0 {
+		return strings.TrimSuffix(master.UpdateCenterURL, "/") + plugins.PluginVersionsPath
+	}
+
+	return plugins.DefaultPluginVersionsURL
 }
 
 func isPluginVersionCompatible(plugins *gojenkins.Plugins, plugin v1alpha2.Plugin) (gojenkins.Plugin, bool) {
@@ -0,0 +1,256 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolvePluginDependencies(t *testing.T) {
+	log.SetupLogger(true)
+	t.Run("disabled", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example"}}
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+
+		dependencies, messages, err := baseReconcileLoop.resolvePluginDependencies()
+
+		require.NoError(t, err)
+		assert.Nil(t, messages)
+		assert.Nil(t, dependencies)
+	})
+	t.Run("resolved", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"plugins": {
+				"git": {"4.2.2": {"version": "4.2.2", "dependencies": [{"name": "scm-api", "version": "2.6.3", "optional": false}]}},
+				"job-dsl": {"1.77": {"version": "1.77", "dependencies": [{"name": "git", "version": "4.3.0", "optional": false}]}},
+				"scm-api": {"2.6.3": {"version": "2.6.3"}}
+			}}`))
+		}))
+		defer server.Close()
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					BasePlugins:                []v1alpha2.Plugin{{Name: "git", Version: "4.2.2"}},
+					Plugins:                    []v1alpha2.Plugin{{Name: "job-dsl", Version: "1.77"}},
+					PluginDependencyResolution: &v1alpha2.PluginDependencyResolution{PluginVersionsURL: server.URL},
+				},
+			},
+		}
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+
+		dependencies, messages, err := baseReconcileLoop.resolvePluginDependencies()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Plugin 'job-dsl:1.77' requires 'git' in version '4.3.0' or newer but 'git:4.2.2' is requested"}, messages)
+		assert.Equal(t, []v1alpha2.Plugin{{Name: "scm-api", Version: "2.6.3"}}, dependencies)
+	})
+}
+
+func TestGetUserPlugins(t *testing.T) {
+	newJenkins := func(pluginsConfigMap *v1alpha2.PluginsConfigMap) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Plugins:          []v1alpha2.Plugin{{Name: "git", Version: "4.2.2"}},
+					PluginsConfigMap: pluginsConfigMap,
+				},
+			},
+		}
+	}
+
+	t.Run("without ConfigMap", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: newJenkins(nil)}, client.JenkinsAPIConnectionSettings{})
+
+		plugins, messages, err := baseReconcileLoop.getUserPlugins()
+
+		require.NoError(t, err)
+		assert.Nil(t, messages)
+		assert.Equal(t, []v1alpha2.Plugin{{Name: "git", Version: "4.2.2"}}, plugins)
+	})
+	t.Run("ConfigMap not found", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.PluginsConfigMap{Name: "plugins"})
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins, Client: fake.NewFakeClient()}, client.JenkinsAPIConnectionSettings{})
+
+		_, messages, err := baseReconcileLoop.getUserPlugins()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap 'plugins' configured in spec.master.pluginsConfigMap not found"}, messages)
+	})
+	t.Run("plugins from ConfigMap", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.PluginsConfigMap{Name: "plugins"})
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "plugins", Namespace: defaultNamespace},
+			Data:       map[string]string{"plugins.txt": "git:4.3.0\nsimple-theme-plugin:0.5.1\ninvalid!:1.0"},
+		}
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins, Client: fake.NewFakeClient(configMap)}, client.JenkinsAPIConnectionSettings{})
+
+		plugins, messages, err := baseReconcileLoop.getUserPlugins()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap 'plugins' key 'plugins.txt' line 3: invalid plugin name 'invalid!:1.0', must follow pattern '^[0-9a-zA-Z-_]+$'"}, messages)
+		assert.Equal(t, []v1alpha2.Plugin{{Name: "git", Version: "4.2.2"}, {Name: "simple-theme-plugin", Version: "0.5.1"}}, plugins)
+	})
+	t.Run("missing key", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.PluginsConfigMap{Name: "plugins", Key: "team-plugins.txt"})
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "plugins", Namespace: defaultNamespace},
+			Data:       map[string]string{"plugins.txt": "git:4.3.0"},
+		}
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins, Client: fake.NewFakeClient(configMap)}, client.JenkinsAPIConnectionSettings{})
+
+		_, messages, err := baseReconcileLoop.getUserPlugins()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap 'plugins' configured in spec.master.pluginsConfigMap has no 'team-plugins.txt' key"}, messages)
+	})
+}
+
+func TestEnsurePinnedPlugins(t *testing.T) {
+	log.SetupLogger(true)
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"plugins": {"git": {"version": "4.3.0"}, "job-dsl": {"version": "1.77"}}, "warnings": []}`))
+	}))
+	defer server.Close()
+	pinTime := metav1.Now()
+	newJenkins := func(annotations map[string]string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace, Annotations: annotations},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Plugins: []v1alpha2.Plugin{
+						{Name: "git", Version: plugins.LatestVersion},
+						{Name: "job-dsl", Version: plugins.LatestVersion},
+						{Name: "simple-theme-plugin", Version: "0.5.1"},
+					},
+					UpdateCenterURL: server.URL,
+				},
+			},
+			Status: v1alpha2.JenkinsStatus{
+				PinnedPlugins: []v1alpha2.PinnedPlugin{{Name: "git", Version: "4.2.2", PinTime: pinTime}},
+			},
+		}
+	}
+
+	t.Run("keeps existing pins", func(t *testing.T) {
+		jenkins := newJenkins(nil)
+		fakeClient := fake.NewFakeClient(jenkins)
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient}, client.JenkinsAPIConnectionSettings{})
+
+		err := baseReconcileLoop.ensurePinnedPlugins()
+		require.NoError(t, err)
+
+		require.Len(t, jenkins.Status.PinnedPlugins, 2)
+		assert.Equal(t, "4.2.2", jenkins.Status.PinnedPlugins[0].Version)
+		assert.Equal(t, "job-dsl", jenkins.Status.PinnedPlugins[1].Name)
+		assert.Equal(t, "1.77", jenkins.Status.PinnedPlugins[1].Version)
+		userPlugins, _, err := baseReconcileLoop.getUserPlugins()
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha2.Plugin{
+			{Name: "git", Version: "4.2.2"},
+			{Name: "job-dsl", Version: "1.77"},
+			{Name: "simple-theme-plugin", Version: "0.5.1"},
+		}, userPlugins)
+	})
+	t.Run("upgrades pins on request", func(t *testing.T) {
+		jenkins := newJenkins(map[string]string{upgradeLatestPluginsAnnotation: "true"})
+		fakeClient := fake.NewFakeClient(jenkins)
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient}, client.JenkinsAPIConnectionSettings{})
+
+		err := baseReconcileLoop.ensurePinnedPlugins()
+		require.NoError(t, err)
+
+		require.Len(t, jenkins.Status.PinnedPlugins, 2)
+		assert.Equal(t, "4.3.0", jenkins.Status.PinnedPlugins[0].Version)
+		assert.NotContains(t, jenkins.Annotations, upgradeLatestPluginsAnnotation)
+	})
+}
+
+func TestResolvePluginChecksums(t *testing.T) {
+	log.SetupLogger(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"plugins": {
+			"git": {"4.2.2": {"version": "4.2.2", "sha256": "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="}},
+			"job-dsl": {"1.77": {"version": "1.77"}}
+		}}`))
+	}))
+	defer server.Close()
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				BasePlugins: []v1alpha2.Plugin{{Name: "git", Version: "4.2.2"}},
+				Plugins: []v1alpha2.Plugin{
+					{Name: "job-dsl", Version: "1.77"},
+					{Name: "simple-theme-plugin", Version: "0.5.1", SHA256: "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08"},
+				},
+				UpdateCenterURL:            server.URL,
+				PluginChecksumVerification: true,
+			},
+		},
+	}
+	baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+
+	checksums, messages, err := baseReconcileLoop.resolvePluginChecksums()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Plugin 'job-dsl:1.77' has no SHA-256 checksum in the update center, set it in the sha256 field"}, messages)
+	assert.Equal(t, map[string]string{
+		"git":                 "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		"simple-theme-plugin": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}, checksums)
+}
+
+func TestEnsurePluginInventory(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace}}
+	fakeClient := fake.NewFakeClient(jenkins)
+	baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient}, client.JenkinsAPIConnectionSettings{})
+	pluginsInJenkins := &gojenkins.Plugins{
+		Raw: &gojenkins.PluginResponse{
+			Plugins: []gojenkins.Plugin{
+				{ShortName: "job-dsl", Version: "1.77", Enabled: false},
+				{ShortName: "git", Version: "4.2.2", Enabled: true},
+				{ShortName: "removed", Version: "1.0", Enabled: true, Deleted: true},
+			},
+		},
+	}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jenkinsClient := client.NewMockJenkins(ctrl)
+	jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
+
+	err = baseReconcileLoop.ensurePluginInventory(jenkinsClient)
+	require.NoError(t, err)
+
+	expected := []v1alpha2.InstalledPlugin{
+		{Name: "git", Version: "4.2.2", Enabled: true},
+		{Name: "job-dsl", Version: "1.77", Enabled: false},
+	}
+	assert.Equal(t, expected, jenkins.Status.Plugins)
+	actual := &v1alpha2.Jenkins{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "example", Namespace: defaultNamespace}, actual)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual.Status.Plugins)
+}
@@ -20,7 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-func (r *ReconcileJenkinsBaseConfiguration) checkForPodRecreation(currentJenkinsMasterPod corev1.Pod, userAndPasswordHash string) reason.Reason {
+func (r *ReconcileJenkinsBaseConfiguration) checkForPodRecreation(currentJenkinsMasterPod corev1.Pod, userAndPasswordHash, envSecretsHash, sshCLIHash string) reason.Reason {
 	var messages []string
 	var verbose []string
 
@@ -41,6 +41,17 @@ func (r *ReconcileJenkinsBaseConfiguration) checkForPodRecreation(currentJenkins
 		verbose = append(verbose, "User or password have changed, recreating pod")
 	}
 
+	if envSecretsHash != r.Configuration.Jenkins.Status.EnvSecretsHash && r.Configuration.Jenkins.Status.EnvSecretsHash != "" {
+		messages = append(messages, "Secrets referenced by environment variables have changed")
+		verbose = append(verbose, fmt.Sprintf("Secrets '%v' referenced by environment variables have changed, recreating pod",
+			resources.GetJenkinsMasterEnvSecretNames(r.Configuration.Jenkins)))
+	}
+
+	if sshCLIHash != r.Configuration.Jenkins.Status.SSHCLIHash && sshCLIHash != "" {
+		messages = append(messages, "Jenkins CLI over SSH has changed")
+		verbose = append(verbose, "Port of the Jenkins SSH server or SSH key of the operator have changed, recreating pod")
+	}
+
 	if r.Configuration.Jenkins.Spec.Restore.RecoveryOnce != 0 && r.Configuration.Jenkins.Status.RestoredBackup != 0 {
 		messages = append(messages, "spec.restore.recoveryOnce is set")
 		verbose = append(verbose, "spec.restore.recoveryOnce is set, recreating pod")
@@ -76,10 +87,11 @@ func (r *ReconcileJenkinsBaseConfiguration) checkForPodRecreation(currentJenkins
 			currentJenkinsMasterPod.Labels, r.Configuration.Jenkins.Spec.Master.Labels))
 	}
 
-	if !compareMap(r.Configuration.Jenkins.Spec.Master.Annotations, currentJenkinsMasterPod.ObjectMeta.Annotations) {
+	annotations := resources.GetJenkinsMasterPodAnnotations(r.Configuration.Jenkins)
+	if !compareMap(annotations, currentJenkinsMasterPod.ObjectMeta.Annotations) {
 		messages = append(messages, "Jenkins pod annotations have changed")
 		verbose = append(verbose, fmt.Sprintf("Jenkins pod annotations have changed, actual '%+v' required '%+v'",
-			currentJenkinsMasterPod.ObjectMeta.Annotations, r.Configuration.Jenkins.Spec.Master.Annotations))
+			currentJenkinsMasterPod.ObjectMeta.Annotations, annotations))
 	}
 
 	if !r.compareVolumes(currentJenkinsMasterPod) {
@@ -100,6 +112,12 @@ func (r *ReconcileJenkinsBaseConfiguration) checkForPodRecreation(currentJenkins
 			currentJenkinsMasterPod.Spec.PriorityClassName, r.Configuration.Jenkins.Spec.Master.PriorityClassName))
 	}
 
+	if !compareInitContainerImages(resources.NewJenkinsMasterInitContainers(r.Configuration.Jenkins), currentJenkinsMasterPod.Spec.InitContainers) {
+		messages = append(messages, "Jenkins pod init containers have changed")
+		verbose = append(verbose, fmt.Sprintf("Jenkins pod init containers have changed, actual '%+v' required '%+v'",
+			currentJenkinsMasterPod.Spec.InitContainers, resources.NewJenkinsMasterInitContainers(r.Configuration.Jenkins)))
+	}
+
 	customResourceReplaced := (r.Configuration.Jenkins.Status.BaseConfigurationCompletedTime == nil ||
 		r.Configuration.Jenkins.Status.UserConfigurationCompletedTime == nil) &&
 		r.Configuration.Jenkins.Status.UserAndPasswordHash == ""
@@ -145,6 +163,14 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsMasterPod(meta metav1.O
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	envSecretsHash, err := r.calculateEnvSecretsHash()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	sshCLIHash, err := r.calculateSSHCLIHash()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
 	// Check if this Pod already exists
 	currentJenkinsMasterPod, err := r.Configuration.GetJenkinsMasterPod()
@@ -164,7 +190,9 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsMasterPod(meta metav1.O
 
 		currentJenkinsMasterPod, err := r.waitUntilCreateJenkinsMasterPod()
 		if err == nil {
-			r.handleAdmissionControllerChanges(currentJenkinsMasterPod)
+			if err = r.handleAdmissionControllerChanges(currentJenkinsMasterPod); err != nil {
+				return reconcile.Result{}, err
+			}
 		} else {
 			r.logger.V(log.VWarn).Info(fmt.Sprintf("waitUntilCreateJenkinsMasterPod has failed: %s", err))
 		}
@@ -176,8 +204,11 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsMasterPod(meta metav1.O
 			LastBackup:          r.Configuration.Jenkins.Status.LastBackup,
 			PendingBackup:       r.Configuration.Jenkins.Status.LastBackup,
 			UserAndPasswordHash: userAndPasswordHash,
+			EnvSecretsHash:      envSecretsHash,
+			SSHCLIHash:          sshCLIHash,
+			PinnedPlugins:       r.Configuration.Jenkins.Status.PinnedPlugins,
 		}
-		return reconcile.Result{Requeue: true}, r.Client.Update(context.TODO(), r.Configuration.Jenkins)
+		return reconcile.Result{Requeue: true}, r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins)
 	} else if err != nil && !apierrors.IsNotFound(err) {
 		return reconcile.Result{}, stackerr.WithStack(err)
 	}
@@ -204,7 +235,7 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsMasterPod(meta metav1.O
 	}
 
 	if !r.IsJenkinsTerminating(*currentJenkinsMasterPod) {
-		restartReason := r.checkForPodRecreation(*currentJenkinsMasterPod, userAndPasswordHash)
+		restartReason := r.checkForPodRecreation(*currentJenkinsMasterPod, userAndPasswordHash, envSecretsHash, sshCLIHash)
 		if restartReason.HasMessages() {
 			for _, msg := range restartReason.Verbose() {
 				r.logger.Info(msg)
@@ -214,5 +245,17 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsMasterPod(meta metav1.O
 		}
 	}
 
+	// the hash is recorded without restart when it's missing, e.g. the pod has been created by the older operator
+	if envSecretsHash != r.Configuration.Jenkins.Status.EnvSecretsHash && r.Configuration.Jenkins.Status.EnvSecretsHash == "" {
+		r.Configuration.Jenkins.Status.EnvSecretsHash = envSecretsHash
+		return reconcile.Result{Requeue: true}, stackerr.WithStack(r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins))
+	}
+
+	// disabling spec.jenkinsAPISettings.sshCLI doesn't require restart, the hash is cleared so enabling it again does
+	if sshCLIHash == "" && r.Configuration.Jenkins.Status.SSHCLIHash != "" {
+		r.Configuration.Jenkins.Status.SSHCLIHash = sshCLIHash
+		return reconcile.Result{Requeue: true}, stackerr.WithStack(r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins))
+	}
+
 	return reconcile.Result{}, nil
 }
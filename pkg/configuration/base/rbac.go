@@ -9,7 +9,10 @@ import (
 
 	stackerr "github.com/pkg/errors"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -20,6 +23,10 @@ func (r *ReconcileJenkinsBaseConfiguration) createRBAC(meta metav1.ObjectMeta) e
 	}
 
 	role := resources.NewRole(meta)
+	if resources.IsKubernetesCredentialsProviderEnabled(r.Configuration.Jenkins) {
+		// Secrets are readable only with the kubernetes-credentials-provider Role
+		role.Rules = resources.RemoveSecretsPolicyRule(role.Rules)
+	}
 	err = r.CreateOrUpdateResource(role)
 	if err != nil {
 		return stackerr.WithStack(err)
@@ -35,6 +42,44 @@ func (r *ReconcileJenkinsBaseConfiguration) createRBAC(meta metav1.ObjectMeta) e
 		return stackerr.WithStack(err)
 	}
 
+	return r.ensureKubernetesCredentialsProviderRBAC(meta)
+}
+
+// ensureKubernetesCredentialsProviderRBAC creates the Role and RoleBinding which allow kubernetes-credentials-provider
+// plugin to read Secrets, they're deleted when the plugin setup isn't managed by the operator
+func (r *ReconcileJenkinsBaseConfiguration) ensureKubernetesCredentialsProviderRBAC(meta metav1.ObjectMeta) error {
+	name := resources.GetKubernetesCredentialsProviderRoleName(meta.Name)
+	roleMeta := *meta.DeepCopy()
+	roleMeta.Name = name
+	role := resources.NewKubernetesCredentialsProviderRole(roleMeta)
+	roleBinding := resources.NewRoleBinding(name, meta.Namespace, meta.Name, rbacv1.RoleRef{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "Role",
+		Name:     name,
+	})
+
+	if resources.IsKubernetesCredentialsProviderEnabled(r.Configuration.Jenkins) {
+		if err := r.CreateOrUpdateResource(role); err != nil {
+			return stackerr.WithStack(err)
+		}
+		if err := r.CreateOrUpdateResource(roleBinding); err != nil {
+			return stackerr.WithStack(err)
+		}
+		return nil
+	}
+
+	for kind, object := range map[string]runtime.Object{"RoleBinding": &rbacv1.RoleBinding{}, "Role": &rbacv1.Role{}} {
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: meta.Namespace}, object)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return stackerr.WithStack(err)
+		}
+		r.logger.Info(fmt.Sprintf("Deleting %s '%s'", kind, name))
+		if err = r.Client.Delete(context.TODO(), object); err != nil && !apierrors.IsNotFound(err) {
+			return stackerr.WithStack(err)
+		}
+	}
 	return nil
 }
 
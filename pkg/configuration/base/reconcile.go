@@ -103,10 +103,24 @@ func (r *ReconcileJenkinsBaseConfiguration) Reconcile() (reconcile.Result, jenki
 	}
 	r.logger.V(log.VDebug).Info("Jenkins API client set")
 
-	ok, err := r.verifyPlugins(jenkinsClient)
+	jenkinsClient, credentialRotationRequeueAfter, err := r.ensureAdminCredentialRotation(jenkinsClient)
+	if err != nil {
+		r.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't rotate credentials of the operator user: %s", err))
+	}
+
+	ok, missingUserPlugins, err := r.verifyPlugins(jenkinsClient)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
+	if ok && len(missingUserPlugins) > 0 {
+		ok, err = r.installPluginsDynamically(jenkinsClient, missingUserPlugins)
+		if err != nil {
+			return reconcile.Result{}, nil, err
+		}
+		if ok {
+			r.logger.Info("Missing plugins have been installed dynamically")
+		}
+	}
 	if !ok {
 		//TODO add what plugins have been changed
 		message := "Some plugins have changed, restarting Jenkins"
@@ -119,9 +133,36 @@ func (r *ReconcileJenkinsBaseConfiguration) Reconcile() (reconcile.Result, jenki
 		return reconcile.Result{Requeue: true}, nil, r.Configuration.RestartJenkinsMasterPod(restartReason)
 	}
 
+	securityWarningsRequeueAfter, err := r.ensurePluginSecurityWarnings(jenkinsClient)
+	if err != nil {
+		r.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't check plugin security warnings: %s", err))
+		securityWarningsRequeueAfter = pluginSecurityWarningsRetryInterval
+	}
+
+	versionRequeueAfter, err := r.ensureJenkinsVersion(jenkinsClient)
+	if err != nil {
+		r.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't check the latest version of Jenkins: %s", err))
+		versionRequeueAfter = upgradeCheckRetryInterval
+	}
+
 	result, err = r.ensureBaseConfiguration(jenkinsClient)
+	if err != nil || result.Requeue {
+		return result, jenkinsClient, err
+	}
 
-	return result, jenkinsClient, err
+	if err := r.ensurePluginInventory(jenkinsClient); err != nil {
+		return reconcile.Result{}, jenkinsClient, err
+	}
+	r.logger.V(log.VDebug).Info("Installed plugins are reported in status")
+	result.RequeueAfter = securityWarningsRequeueAfter
+	if result.RequeueAfter == 0 || (versionRequeueAfter > 0 && versionRequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = versionRequeueAfter
+	}
+	if result.RequeueAfter == 0 || (credentialRotationRequeueAfter > 0 && credentialRotationRequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = credentialRotationRequeueAfter
+	}
+
+	return result, jenkinsClient, nil
 }
 
 func useDeploymentForJenkinsMaster(jenkins *v1alpha2.Jenkins) bool {
@@ -139,6 +180,26 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureResourcesRequiredForJenkinsPod
 	}
 	r.logger.V(log.VDebug).Info("Operator credentials secret is present")
 
+	if err := r.ensureOperatorSSHKey(); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Operator SSH key is present")
+
+	if err := r.createBreakGlassSecret(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Break-glass secret is present")
+
+	if err := r.ensurePinnedPlugins(); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Latest versions of plugins are pinned")
+
+	if err := r.ensurePluginCache(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Plugin cache is present")
+
 	if err := r.createScriptsConfigMap(metaObject); err != nil {
 		return err
 	}
@@ -164,6 +225,13 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureResourcesRequiredForJenkinsPod
 	}
 	r.logger.V(log.VDebug).Info("ConfigurationAsCode Secret and ConfigMap added watched labels")
 
+	if pluginsConfigMap := r.Configuration.Jenkins.Spec.Master.PluginsConfigMap; pluginsConfigMap != nil {
+		if err := r.addLabelForWatchesResources(v1alpha2.Customization{Configurations: []v1alpha2.ConfigMapRef{{Name: pluginsConfigMap.Name}}}); err != nil {
+			return err
+		}
+		r.logger.V(log.VDebug).Info("Plugins ConfigMap added watched labels")
+	}
+
 	if err := r.createRBAC(metaObject); err != nil {
 		return err
 	}
@@ -174,8 +242,17 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureResourcesRequiredForJenkinsPod
 	}
 	r.logger.V(log.VDebug).Info("Extra role bindings are present")
 
+	if err := r.ensureAgentsNamespaceResources(); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Agents namespace resources are present")
+
 	httpServiceName := resources.GetJenkinsHTTPServiceName(r.Configuration.Jenkins)
-	if err := r.createService(metaObject, httpServiceName, r.Configuration.Jenkins.Spec.Service); err != nil {
+	httpService := r.Configuration.Jenkins.Spec.Service
+	if resources.IsMonitoringEnabled(r.Configuration.Jenkins) {
+		httpService = resources.NewMetricsService(httpService, r.getMetricsPath())
+	}
+	if err := r.createService(metaObject, httpServiceName, httpService); err != nil {
 		return err
 	}
 	r.logger.V(log.VDebug).Info("Jenkins HTTP Service is present")
@@ -193,6 +270,18 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureResourcesRequiredForJenkinsPod
 		r.logger.V(log.VDebug).Info("Jenkins Route is present")
 	}
 
+	if resources.IsServiceMonitorAPIAvailable(&r.ClientSet) {
+		if err := r.ensureServiceMonitor(metaObject); err != nil {
+			return err
+		}
+		r.logger.V(log.VDebug).Info("Jenkins ServiceMonitor is reconciled")
+	}
+
+	if err := r.ensureGrafanaDashboards(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Grafana dashboards config map is reconciled")
+
 	return nil
 }
 
@@ -213,6 +302,71 @@ func (r *ReconcileJenkinsBaseConfiguration) createOperatorCredentialsSecret(meta
 	return stackerr.WithStack(r.UpdateResource(resources.NewOperatorCredentialsSecret(meta, r.Configuration.Jenkins)))
 }
 
+// createBreakGlassSecret generates credentials of the local admin account of the OpenID Connect security realm unless
+// they are provided by the user, the existing password is never regenerated
+func (r *ReconcileJenkinsBaseConfiguration) createBreakGlassSecret(meta metav1.ObjectMeta) error {
+	if !resources.IsBreakGlassSecretGenerated(r.Configuration.Jenkins) {
+		return nil
+	}
+
+	found := &corev1.Secret{}
+	err := r.Configuration.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetBreakGlassSecretName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, found)
+	if err != nil && apierrors.IsNotFound(err) {
+		return stackerr.WithStack(r.CreateResource(resources.NewBreakGlassSecret(meta, r.Configuration.Jenkins)))
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	if len(found.Data[resources.BreakGlassSecretUserNameKey]) > 0 && len(found.Data[resources.BreakGlassSecretPasswordKey]) > 0 {
+		return nil
+	}
+	return stackerr.WithStack(r.UpdateResource(resources.NewBreakGlassSecret(meta, r.Configuration.Jenkins)))
+}
+
+// ensureOperatorSSHKey adds the SSH key of the operator user to the operator credentials secret when Groovy scripts
+// are executed by the Jenkins CLI over SSH, the key is added to Jenkins on start, the Jenkins master pod is restarted
+// when the key or the port of the SSH server change
+func (r *ReconcileJenkinsBaseConfiguration) ensureOperatorSSHKey() error {
+	if r.Configuration.Jenkins.Spec.JenkinsAPISettings.SSHCLI == nil {
+		return nil
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, credentialsSecret)
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+	if len(credentialsSecret.Data[resources.OperatorCredentialsSecretSSHPrivateKeyKey]) > 0 &&
+		len(credentialsSecret.Data[resources.OperatorCredentialsSecretSSHPublicKeyKey]) > 0 {
+		return nil
+	}
+
+	privateKey, publicKey, err := resources.NewOperatorSSHKey()
+	if err != nil {
+		return err
+	}
+	credentialsSecret.Data[resources.OperatorCredentialsSecretSSHPrivateKeyKey] = privateKey
+	credentialsSecret.Data[resources.OperatorCredentialsSecretSSHPublicKeyKey] = publicKey
+	return stackerr.WithStack(r.UpdateResource(credentialsSecret))
+}
+
+// ensurePluginCache creates the plugin cache PersistentVolumeClaim when it's managed by the operator,
+// the PersistentVolumeClaim isn't updated later because most of its spec is immutable
+func (r *ReconcileJenkinsBaseConfiguration) ensurePluginCache(meta metav1.ObjectMeta) error {
+	pluginCache := r.Configuration.Jenkins.Spec.Master.PluginCache
+	if pluginCache == nil || len(pluginCache.PersistentVolumeClaimName) > 0 {
+		return nil
+	}
+
+	found := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetPluginCachePersistentVolumeClaimName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.Namespace}, found)
+	if err != nil && apierrors.IsNotFound(err) {
+		return stackerr.WithStack(r.CreateResource(resources.NewPluginCachePersistentVolumeClaim(meta, r.Configuration.Jenkins)))
+	}
+
+	return stackerr.WithStack(err)
+}
+
 func (r *ReconcileJenkinsBaseConfiguration) calculateUserAndPasswordHash() (string, error) {
 	credentialsSecret := &corev1.Secret{}
 	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, credentialsSecret)
@@ -226,6 +380,47 @@ func (r *ReconcileJenkinsBaseConfiguration) calculateUserAndPasswordHash() (stri
 	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
 }
 
+// calculateEnvSecretsHash returns hash of resource versions of Secrets referenced by environment variables of Jenkins
+// master containers, it's empty when there are no such Secrets
+func (r *ReconcileJenkinsBaseConfiguration) calculateEnvSecretsHash() (string, error) {
+	names := resources.GetJenkinsMasterEnvSecretNames(r.Configuration.Jenkins)
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	hash := sha256.New()
+	for _, name := range names {
+		secret := &corev1.Secret{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", stackerr.WithStack(err)
+		}
+		hash.Write([]byte(name))
+		hash.Write([]byte(secret.ResourceVersion))
+	}
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+// calculateSSHCLIHash returns hash of the port of the Jenkins SSH server and the SSH public key of the operator user,
+// it's empty when Groovy scripts aren't executed by the Jenkins CLI over SSH
+func (r *ReconcileJenkinsBaseConfiguration) calculateSSHCLIHash() (string, error) {
+	sshCLI := r.Configuration.Jenkins.Spec.JenkinsAPISettings.SSHCLI
+	if sshCLI == nil {
+		return "", nil
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, credentialsSecret)
+	if err != nil {
+		return "", stackerr.WithStack(err)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(fmt.Sprint(sshCLI.Port)))
+	hash.Write(credentialsSecret.Data[resources.OperatorCredentialsSecretSSHPublicKeyKey])
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
 func compareImagePullSecrets(expected, actual []corev1.LocalObjectReference) bool {
 	for _, expected := range expected {
 		found := false
@@ -280,6 +475,20 @@ func CompareContainerVolumeMounts(expected corev1.Container, actual corev1.Conta
 	return reflect.DeepEqual(expected.VolumeMounts, withoutServiceAccount)
 }
 
+// compareInitContainerImages returns true if init containers have the same names and images
+func compareInitContainerImages(expected, actual []corev1.Container) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		if expected[i].Name != actual[i].Name || expected[i].Image != actual[i].Image {
+			return false
+		}
+	}
+
+	return true
+}
+
 // compareVolumes returns true if Jenkins pod and Jenkins CR volumes are the same
 func (r *ReconcileJenkinsBaseConfiguration) compareVolumes(actualPod corev1.Pod) bool {
 	var withoutServiceAccount []corev1.Volume
@@ -417,15 +626,32 @@ func (r *ReconcileJenkinsBaseConfiguration) waitUntilCreateJenkinsMasterPod() (c
 	return
 }
 
-func (r *ReconcileJenkinsBaseConfiguration) handleAdmissionControllerChanges(currentJenkinsMasterPod *corev1.Pod) {
+// handleAdmissionControllerChanges copies security contexts set by the admission controller, e.g. OpenShift SCC, from
+// the Jenkins master pod to the Jenkins CR spec, so they aren't reported as changes requiring the pod recreation
+func (r *ReconcileJenkinsBaseConfiguration) handleAdmissionControllerChanges(currentJenkinsMasterPod *corev1.Pod) error {
+	before := r.Configuration.Jenkins.DeepCopy()
 	if !reflect.DeepEqual(r.Configuration.Jenkins.Spec.Master.SecurityContext, currentJenkinsMasterPod.Spec.SecurityContext) {
 		r.Configuration.Jenkins.Spec.Master.SecurityContext = currentJenkinsMasterPod.Spec.SecurityContext
 		r.logger.Info(fmt.Sprintf("The Admission controller has changed the Jenkins master pod spec.securityContext, changing the Jenkinc CR spec.master.securityContext to '%+v'", currentJenkinsMasterPod.Spec.SecurityContext))
 	}
 	for i, container := range r.Configuration.Jenkins.Spec.Master.Containers {
+		if i >= len(currentJenkinsMasterPod.Spec.Containers) {
+			break
+		}
 		if !reflect.DeepEqual(container.SecurityContext, currentJenkinsMasterPod.Spec.Containers[i].SecurityContext) {
 			r.Configuration.Jenkins.Spec.Master.Containers[i].SecurityContext = currentJenkinsMasterPod.Spec.Containers[i].SecurityContext
 			r.logger.Info(fmt.Sprintf("The Admission controller has changed the securityContext, changing the Jenkins CR spec.master.containers[%s].securityContext to '+%v'", container.Name, currentJenkinsMasterPod.Spec.Containers[i].SecurityContext))
 		}
 	}
+	if reflect.DeepEqual(before.Spec, r.Configuration.Jenkins.Spec) {
+		return nil
+	}
+
+	// the status subresource ignores spec changes, so the spec is saved by the patch before the status update
+	status := r.Configuration.Jenkins.Status
+	if err := r.Client.Patch(context.TODO(), r.Configuration.Jenkins, client.MergeFrom(before)); err != nil {
+		return stackerr.WithStack(err)
+	}
+	r.Configuration.Jenkins.Status = status
+	return nil
 }
@@ -13,9 +13,12 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -180,7 +183,7 @@ func TestReconcileJenkinsBaseConfiguration_verifyPlugins(t *testing.T) {
 		jenkinsClient := client.NewMockJenkins(ctrl)
 		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
 
-		got, err := r.verifyPlugins(jenkinsClient)
+		got, _, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
 		assert.True(t, got)
@@ -225,7 +228,7 @@ func TestReconcileJenkinsBaseConfiguration_verifyPlugins(t *testing.T) {
 		jenkinsClient := client.NewMockJenkins(ctrl)
 		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
 
-		got, err := r.verifyPlugins(jenkinsClient)
+		got, _, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
 		assert.True(t, got)
@@ -262,7 +265,7 @@ func TestReconcileJenkinsBaseConfiguration_verifyPlugins(t *testing.T) {
 		jenkinsClient := client.NewMockJenkins(ctrl)
 		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
 
-		got, err := r.verifyPlugins(jenkinsClient)
+		got, _, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
 		assert.True(t, got)
@@ -299,7 +302,7 @@ func TestReconcileJenkinsBaseConfiguration_verifyPlugins(t *testing.T) {
 		jenkinsClient := client.NewMockJenkins(ctrl)
 		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
 
-		got, err := r.verifyPlugins(jenkinsClient)
+		got, _, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
 		assert.True(t, got)
@@ -336,7 +339,7 @@ func TestReconcileJenkinsBaseConfiguration_verifyPlugins(t *testing.T) {
 		jenkinsClient := client.NewMockJenkins(ctrl)
 		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
 
-		got, err := r.verifyPlugins(jenkinsClient)
+		got, _, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
 		assert.False(t, got)
@@ -373,7 +376,7 @@ func TestReconcileJenkinsBaseConfiguration_verifyPlugins(t *testing.T) {
 		jenkinsClient := client.NewMockJenkins(ctrl)
 		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
 
-		got, err := r.verifyPlugins(jenkinsClient)
+		got, _, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
 		assert.False(t, got)
@@ -402,7 +405,7 @@ func TestReconcileJenkinsBaseConfiguration_verifyPlugins(t *testing.T) {
 		jenkinsClient := client.NewMockJenkins(ctrl)
 		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
 
-		got, err := r.verifyPlugins(jenkinsClient)
+		got, _, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
 		assert.False(t, got)
@@ -431,11 +434,42 @@ func TestReconcileJenkinsBaseConfiguration_verifyPlugins(t *testing.T) {
 		jenkinsClient := client.NewMockJenkins(ctrl)
 		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
 
-		got, err := r.verifyPlugins(jenkinsClient)
+		got, _, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
 		assert.False(t, got)
 	})
+	t.Run("missing user plugin with dynamic plugin installation", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Plugins:                   []v1alpha2.Plugin{{Name: "plugin-name", Version: "0.0.2"}},
+					DynamicPluginInstallation: true,
+				},
+			},
+		}
+		r := ReconcileJenkinsBaseConfiguration{
+			logger: log.Log,
+			Configuration: configuration.Configuration{
+				Jenkins: jenkins,
+			},
+		}
+		pluginsInJenkins := &gojenkins.Plugins{
+			Raw: &gojenkins.PluginResponse{
+				Plugins: []gojenkins.Plugin{},
+			},
+		}
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
+
+		got, missingUserPlugins, err := r.verifyPlugins(jenkinsClient)
+
+		assert.NoError(t, err)
+		assert.True(t, got)
+		assert.Equal(t, []v1alpha2.Plugin{{Name: "plugin-name", Version: "0.0.2"}}, missingUserPlugins)
+	})
 }
 
 func Test_compareEnv(t *testing.T) {
@@ -806,6 +840,257 @@ func TestEnsureExtraRBAC(t *testing.T) {
 	})
 }
 
+func TestEnsureKubernetesCredentialsProviderRBAC(t *testing.T) {
+	log.SetupLogger(true)
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	fakeClient := fake.NewFakeClient()
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Security: &v1alpha2.Security{
+				KubernetesCredentialsProvider: &v1alpha2.KubernetesCredentialsProvider{Enabled: true},
+			},
+		},
+	}
+	reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+	metaObject := resources.NewResourceObjectMeta(jenkins)
+	providerName := types.NamespacedName{Name: resources.GetKubernetesCredentialsProviderRoleName(metaObject.Name), Namespace: "default"}
+
+	t.Run("enabled", func(t *testing.T) {
+		err := reconciler.createRBAC(metaObject)
+
+		assert.NoError(t, err)
+		role := &rbacv1.Role{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: metaObject.Name, Namespace: "default"}, role))
+		for _, rule := range role.Rules {
+			assert.NotContains(t, rule.Resources, "secrets")
+		}
+		providerRole := &rbacv1.Role{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), providerName, providerRole))
+		assert.Equal(t, []string{"secrets"}, providerRole.Rules[0].Resources)
+		providerRoleBinding := &rbacv1.RoleBinding{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), providerName, providerRoleBinding))
+		assert.Equal(t, providerName.Name, providerRoleBinding.RoleRef.Name)
+		assert.Equal(t, metaObject.Name, providerRoleBinding.Subjects[0].Name)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		jenkins.Spec.Security.KubernetesCredentialsProvider.Enabled = false
+
+		err := reconciler.createRBAC(metaObject)
+
+		assert.NoError(t, err)
+		role := &rbacv1.Role{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: metaObject.Name, Namespace: "default"}, role))
+		assert.Equal(t, resources.NewDefaultPolicyRules(), role.Rules)
+		assert.True(t, apierrors.IsNotFound(fakeClient.Get(context.TODO(), providerName, &rbacv1.Role{})))
+		assert.True(t, apierrors.IsNotFound(fakeClient.Get(context.TODO(), providerName, &rbacv1.RoleBinding{})))
+	})
+}
+
+func TestEnsureAgentsNamespaceResources(t *testing.T) {
+	log.SetupLogger(true)
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	fakeClient := fake.NewFakeClient()
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec:       v1alpha2.JenkinsSpec{Agents: &v1alpha2.Agents{Namespace: "jenkins-agents"}},
+	}
+	reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+	name := types.NamespacedName{Name: "jenkins-operator-agent-example", Namespace: "jenkins-agents"}
+
+	// the second run updates existing resources
+	for i := 0; i < 2; i++ {
+		err = reconciler.ensureAgentsNamespaceResources()
+		assert.NoError(t, err)
+	}
+
+	serviceAccount := &corev1.ServiceAccount{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), name, serviceAccount))
+	role := &rbacv1.Role{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), name, role))
+	assert.Empty(t, role.OwnerReferences)
+	roleBinding := &rbacv1.RoleBinding{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), name, roleBinding))
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "jenkins-operator-example", Namespace: "default"}}, roleBinding.Subjects)
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), name, networkPolicy))
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes)
+}
+
+func TestHandleAdmissionControllerChanges(t *testing.T) {
+	log.SetupLogger(true)
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	jenkins := &v1alpha2.Jenkins{
+		TypeMeta:   v1alpha2.JenkinsTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName, Image: "jenkins/jenkins:lts"}},
+			},
+		},
+	}
+	fakeClient := fake.NewFakeClient()
+	assert.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+	pod := resources.NewJenkinsMasterPod(resources.NewResourceObjectMeta(jenkins), jenkins)
+	pod.Status.Phase = corev1.PodRunning
+	// the admission controller, e.g. OpenShift SCC, sets the user id range of the namespace
+	runAsUser := int64(1000680000)
+	pod.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsUser: &runAsUser, FSGroup: &runAsUser}
+	pod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{RunAsUser: &runAsUser}
+	reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+
+	err = reconciler.handleAdmissionControllerChanges(pod)
+
+	assert.NoError(t, err)
+	saved := &v1alpha2.Jenkins{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: "example", Namespace: "default"}, saved))
+	assert.Equal(t, pod.Spec.SecurityContext, saved.Spec.Master.SecurityContext)
+	assert.Equal(t, pod.Spec.Containers[0].SecurityContext, saved.Spec.Master.Containers[0].SecurityContext)
+
+	restartReason := New(configuration.Configuration{Client: fakeClient, Jenkins: saved, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{}).
+		checkForPodRecreation(*pod, "", "", "")
+	assert.NotContains(t, restartReason.Short(), "Jenkins pod security context has changed")
+	for _, message := range restartReason.Short() {
+		assert.NotContains(t, message, "securityContext")
+	}
+}
+
+func TestCreateBreakGlassSecret(t *testing.T) {
+	log.SetupLogger(true)
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	fakeClient := fake.NewFakeClient()
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Security: &v1alpha2.Security{
+				Realm: &v1alpha2.SecurityRealm{OIDC: &v1alpha2.OIDCSecurityRealm{Issuer: "https://idp.example.com"}},
+			},
+		},
+	}
+	reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+	metaObject := resources.NewResourceObjectMeta(jenkins)
+	secretName := types.NamespacedName{Name: "jenkins-operator-break-glass-example", Namespace: "default"}
+
+	t.Run("generated", func(t *testing.T) {
+		err := reconciler.createBreakGlassSecret(metaObject)
+
+		assert.NoError(t, err)
+		secret := &corev1.Secret{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), secretName, secret))
+		assert.Equal(t, resources.BreakGlassUserName, string(secret.Data[resources.BreakGlassSecretUserNameKey]))
+		password := secret.Data[resources.BreakGlassSecretPasswordKey]
+		assert.NotEmpty(t, password)
+
+		err = reconciler.createBreakGlassSecret(metaObject)
+
+		assert.NoError(t, err)
+		assert.NoError(t, fakeClient.Get(context.TODO(), secretName, secret))
+		assert.Equal(t, password, secret.Data[resources.BreakGlassSecretPasswordKey])
+	})
+	t.Run("provided by user", func(t *testing.T) {
+		jenkins.Spec.Security.Realm.OIDC.BreakGlassSecret = v1alpha2.SecretRef{Name: "break-glass"}
+
+		err := reconciler.createBreakGlassSecret(metaObject)
+
+		assert.NoError(t, err)
+		assert.True(t, apierrors.IsNotFound(fakeClient.Get(context.TODO(), types.NamespacedName{Name: "break-glass", Namespace: "default"}, &corev1.Secret{})))
+	})
+}
+
+func TestCalculateEnvSecretsHash(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"}, Data: map[string][]byte{"token": []byte("old")}}
+	fakeClient := fake.NewFakeClient(secret)
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+	}
+	reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+
+	hash, err := reconciler.calculateEnvSecretsHash()
+
+	assert.NoError(t, err)
+	assert.Empty(t, hash)
+
+	jenkins.Spec.Master.Containers = []v1alpha2.Container{{
+		Name: resources.JenkinsMasterContainerName,
+		Env: []corev1.EnvVar{{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "token",
+		}}}},
+	}}
+	hash, err = reconciler.calculateEnvSecretsHash()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hash)
+
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: "token", Namespace: "default"}, secret))
+	secret.Data["token"] = []byte("rotated")
+	assert.NoError(t, fakeClient.Update(context.TODO(), secret))
+
+	rotatedHash, err := reconciler.calculateEnvSecretsHash()
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, rotatedHash)
+}
+
+func TestCalculateSSHCLIHash(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName, Image: "jenkins/jenkins:lts"}},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.GetOperatorCredentialsSecretName(jenkins), Namespace: "default"},
+		Data:       map[string][]byte{resources.OperatorCredentialsSecretSSHPublicKeyKey: []byte("ssh-ed25519 old")},
+	}
+	fakeClient := fake.NewFakeClient(secret)
+	reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+
+	hash, err := reconciler.calculateSSHCLIHash()
+
+	assert.NoError(t, err)
+	assert.Empty(t, hash)
+
+	jenkins.Spec.JenkinsAPISettings.SSHCLI = &v1alpha2.SSHCLI{Port: 2222}
+	hash, err = reconciler.calculateSSHCLIHash()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hash)
+
+	jenkins.Status.SSHCLIHash = hash
+	pod := resources.NewJenkinsMasterPod(resources.NewResourceObjectMeta(jenkins), jenkins)
+	pod.Status.Phase = corev1.PodRunning
+	assert.NotContains(t, reconciler.checkForPodRecreation(*pod, "", "", hash).Short(), "Jenkins CLI over SSH has changed")
+
+	jenkins.Spec.JenkinsAPISettings.SSHCLI.Port = 2022
+	portHash, err := reconciler.calculateSSHCLIHash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, portHash)
+
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: "default"}, secret))
+	secret.Data[resources.OperatorCredentialsSecretSSHPublicKeyKey] = []byte("ssh-ed25519 new")
+	assert.NoError(t, fakeClient.Update(context.TODO(), secret))
+
+	keyHash, err := reconciler.calculateSSHCLIHash()
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, portHash, keyHash)
+	assert.Contains(t, reconciler.checkForPodRecreation(*pod, "", "", keyHash).Short(), "Jenkins CLI over SSH has changed")
+}
+
 func TestCompareContainerResources(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		var expected corev1.ResourceRequirements
@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IsAgentsNamespaceEnabled returns true when agent pods run in the dedicated namespace set in spec.agents.namespace
+func IsAgentsNamespaceEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Agents != nil && len(jenkins.Spec.Agents.Namespace) > 0
+}
+
+// GetAgentsResourceName returns name of the ServiceAccount, Role, RoleBinding and NetworkPolicy in the agents namespace
+func GetAgentsResourceName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-agent-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// NewAgentsResourceObjectMeta returns meta of resources in the agents namespace
+func NewAgentsResourceObjectMeta(jenkins *v1alpha2.Jenkins) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      GetAgentsResourceName(jenkins),
+		Namespace: jenkins.Spec.Agents.Namespace,
+		Labels:    BuildResourceLabels(jenkins),
+	}
+}
+
+// NewAgentsServiceAccount returns the ServiceAccount of agent pods
+func NewAgentsServiceAccount(meta metav1.ObjectMeta) *corev1.ServiceAccount {
+	return NewServiceAccount(meta, nil)
+}
+
+// NewAgentsRole returns rbac role which allows kubernetes-plugin to manage agent pods
+func NewAgentsRole(meta metav1.ObjectMeta) *rbacv1.Role {
+	manage := []string{createVerb, deleteVerb, getVerb, listVerb, patchVerb, updateVerb, watchVerb}
+	readOnly := []string{getVerb, listVerb, watchVerb}
+	return &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: meta,
+		Rules: []rbacv1.PolicyRule{
+			NewPolicyRule(EmptyAPIGroup, "pods", manage),
+			NewPolicyRule(EmptyAPIGroup, "pods/exec", manage),
+			NewPolicyRule(EmptyAPIGroup, "pods/log", readOnly),
+			NewPolicyRule(EmptyAPIGroup, "events", readOnly),
+		},
+	}
+}
+
+// NewAgentsRoleBinding returns rbac role binding of the agents Role to the ServiceAccount of Jenkins master from
+// the namespace of the Jenkins CR
+func NewAgentsRoleBinding(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     meta.Name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      GetResourceName(jenkins),
+				Namespace: jenkins.ObjectMeta.Namespace,
+			},
+		},
+	}
+}
+
+// NewAgentsNetworkPolicy returns the NetworkPolicy which denies ingress to agent pods from other namespaces, agents
+// connect to Jenkins themselves so they don't need to be reachable from the namespace of the Jenkins CR
+func NewAgentsNetworkPolicy(meta metav1.ObjectMeta) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: meta,
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{}},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
@@ -19,6 +19,7 @@ const (
 	configureKubernetesPluginGroovyScriptName   = "6-configure-kubernetes-plugin.groovy"
 	configureViewsGroovyScriptName              = "7-configure-views.groovy"
 	disableJobDslScriptApprovalGroovyScriptName = "8-disable-job-dsl-script-approval.groovy"
+	configurePrometheusPluginGroovyScriptName   = "9-configure-prometheus-plugin.groovy"
 )
 
 const basicSettingsFmt = `
@@ -120,17 +121,19 @@ import org.csanchez.jenkins.plugins.kubernetes.KubernetesCloud
 
 def jenkins = Jenkins.getInstance()
 
-def kubernetes = Jenkins.instance.clouds.getByName("kubernetes")
+def kubernetes = Jenkins.instance.clouds.getByName("%s")
 def add = false
 if (kubernetes == null) {
     add = true
-	kubernetes = new KubernetesCloud("kubernetes")
+	kubernetes = new KubernetesCloud("%s")
 }
-kubernetes.setServerUrl("https://kubernetes.default.svc.cluster.local:443")
+kubernetes.setServerUrl("%s")
 kubernetes.setNamespace("%s")
 kubernetes.setJenkinsUrl("%s")
 kubernetes.setJenkinsTunnel("%s")
-kubernetes.setRetentionTimeout(15)
+kubernetes.setRetentionTimeout(%d)
+kubernetes.setContainerCap(%d)
+kubernetes.setMaxRequestsPerHost(%d)
 if (add) {
 	jenkins.clouds.add(kubernetes)
 }
@@ -172,6 +175,22 @@ GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).useScript
 GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).save()
 `
 
+// configurePrometheusPluginFmt configures the Prometheus plugin with Configuration as Code, the metrics endpoint
+// doesn't require authentication so it's scraped by Prometheus without Jenkins credentials
+const configurePrometheusPluginFmt = `
+import io.jenkins.plugins.casc.ConfigurationAsCode
+import io.jenkins.plugins.casc.yaml.YamlSource
+
+def configuration = '''
+unclassified:
+  prometheusConfiguration:
+    path: "%s"
+    useAuthenticatedEndpoint: false
+'''
+
+ConfigurationAsCode.get().configureWith(YamlSource.of(new ByteArrayInputStream(configuration.getBytes('UTF-8'))))
+`
+
 // GetBaseConfigurationConfigMapName returns name of Kubernetes config map used to base configuration.
 func GetBaseConfigurationConfigMapName(jenkins *v1alpha2.Jenkins) string {
 	return fmt.Sprintf("%s-base-configuration-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
@@ -180,11 +199,7 @@ func GetBaseConfigurationConfigMapName(jenkins *v1alpha2.Jenkins) string {
 // NewBaseConfigurationConfigMap builds Kubernetes config map used to base configuration.
 func NewBaseConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) (*corev1.ConfigMap, error) {
 	meta.Name = GetBaseConfigurationConfigMapName(jenkins)
-	jenkinsServiceFQDN, err := GetJenkinsHTTPServiceFQDN(jenkins)
-	if err != nil {
-		return nil, err
-	}
-	jenkinsSlavesServiceFQDN, err := GetJenkinsSlavesServiceFQDN(jenkins)
+	kubernetesCloud, err := GetKubernetesCloudSettings(jenkins)
 	if err != nil {
 		return nil, err
 	}
@@ -195,9 +210,15 @@ func NewBaseConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jen
 		enableMasterAccessControlGroovyScriptName: enableMasterAccessControl,
 		disableInsecureFeaturesGroovyScriptName:   disableInsecureFeatures,
 		configureKubernetesPluginGroovyScriptName: fmt.Sprintf(configureKubernetesPluginFmt,
-			jenkins.ObjectMeta.Namespace,
-			fmt.Sprintf("http://%s:%d", jenkinsServiceFQDN, jenkins.Spec.Service.Port),
-			fmt.Sprintf("%s:%d", jenkinsSlavesServiceFQDN, jenkins.Spec.SlaveService.Port),
+			KubernetesCloudName,
+			KubernetesCloudName,
+			KubernetesCloudServerURL,
+			kubernetesCloud.Namespace,
+			kubernetesCloud.JenkinsURL,
+			kubernetesCloud.JenkinsTunnel,
+			KubernetesCloudRetentionTimeout,
+			kubernetesCloud.ContainerCap,
+			kubernetesCloud.MaxRequestsPerHost,
 		),
 		configureViewsGroovyScriptName:              configureViews,
 		disableJobDslScriptApprovalGroovyScriptName: disableJobDSLScriptApproval,
@@ -205,6 +226,9 @@ func NewBaseConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jen
 	if jenkins.Spec.Master.DisableCSRFProtection {
 		delete(groovyScriptsMap, enableCSRFGroovyScriptName)
 	}
+	if IsMonitoringEnabled(jenkins) {
+		groovyScriptsMap[configurePrometheusPluginGroovyScriptName] = fmt.Sprintf(configurePrometheusPluginFmt, jenkins.Spec.Monitoring.Path)
+	}
 	return &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
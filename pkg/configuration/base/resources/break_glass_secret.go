@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BreakGlassUserName is the default username of the local admin account which can log in when the OpenID Connect
+	// identity provider doesn't work
+	BreakGlassUserName = "break-glass-admin"
+	// BreakGlassSecretUserNameKey defines key of username in break-glass secret
+	BreakGlassSecretUserNameKey = "username"
+	// BreakGlassSecretPasswordKey defines key of password in break-glass secret
+	BreakGlassSecretPasswordKey = "password"
+)
+
+// IsBreakGlassSecretGenerated returns true when the break-glass secret of the OpenID Connect security realm is
+// generated by the operator
+func IsBreakGlassSecretGenerated(jenkins *v1alpha2.Jenkins) bool {
+	security := jenkins.Spec.Security
+	return security != nil && security.Realm != nil && security.Realm.OIDC != nil && len(security.Realm.OIDC.BreakGlassSecret.Name) == 0
+}
+
+// GetBreakGlassSecretName returns name of Kubernetes secret with credentials of the local admin account of
+// the OpenID Connect security realm
+func GetBreakGlassSecretName(jenkins *v1alpha2.Jenkins) string {
+	if security := jenkins.Spec.Security; security != nil && security.Realm != nil && security.Realm.OIDC != nil &&
+		len(security.Realm.OIDC.BreakGlassSecret.Name) > 0 {
+		return security.Realm.OIDC.BreakGlassSecret.Name
+	}
+	return fmt.Sprintf("%s-break-glass-%s", constants.OperatorName, jenkins.Name)
+}
+
+// NewBreakGlassSecret builds the Kubernetes secret with credentials of the local admin account of the OpenID Connect
+// security realm
+func NewBreakGlassSecret(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *corev1.Secret {
+	meta.Name = GetBreakGlassSecretName(jenkins)
+	return &corev1.Secret{
+		TypeMeta:   buildSecretTypeMeta(),
+		ObjectMeta: meta,
+		Data: map[string][]byte{
+			BreakGlassSecretUserNameKey: []byte(BreakGlassUserName),
+			BreakGlassSecretPasswordKey: []byte(randomString(24)),
+		},
+	}
+}
@@ -14,7 +14,7 @@ import (
 // NewJenkinsMasterPod builds Jenkins Master Kubernetes Pod resource.
 func NewJenkinsDeployment(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *appsv1.Deployment {
 	serviceAccountName := objectMeta.Name
-	objectMeta.Annotations = jenkins.Spec.Master.Annotations
+	objectMeta.Annotations = GetJenkinsMasterPodAnnotations(jenkins)
 	objectMeta.Name = GetJenkinsDeploymentName(jenkins)
 	selector := &metav1.LabelSelector{MatchLabels: objectMeta.Labels}
 	return &appsv1.Deployment{
@@ -31,6 +31,7 @@ func NewJenkinsDeployment(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkin
 				Spec: corev1.PodSpec{
 					ServiceAccountName: serviceAccountName,
 					NodeSelector:       jenkins.Spec.Master.NodeSelector,
+					InitContainers:     NewJenkinsMasterInitContainers(jenkins),
 					Containers:         newContainers(jenkins),
 					Volumes:            append(GetJenkinsMasterPodBaseVolumes(jenkins), jenkins.Spec.Master.Volumes...),
 					SecurityContext:    jenkins.Spec.Master.SecurityContext,
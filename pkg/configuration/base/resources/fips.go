@@ -0,0 +1,31 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+)
+
+const (
+	// FIPSComplianceProperty is the system property which enables FIPS 140 compliance mode of Jenkins core and plugins
+	FIPSComplianceProperty = "jenkins.security.FIPS140.COMPLIANCE"
+	// redHatFIPSProperty makes Red Hat OpenJDK use FIPS validated cryptography when the node runs in FIPS mode
+	redHatFIPSProperty = "com.redhat.fips"
+)
+
+// IsFIPSEnabled returns true when Jenkins runs in FIPS compliant mode
+func IsFIPSEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Security != nil && jenkins.Spec.Security.FIPS
+}
+
+// getFIPSSystemProperties returns JVM options which enable FIPS compliant mode of JVM and Jenkins
+func getFIPSSystemProperties(jenkins *v1alpha2.Jenkins) []string {
+	if !IsFIPSEnabled(jenkins) {
+		return nil
+	}
+
+	return []string{
+		fmt.Sprintf("-D%s=true", redHatFIPSProperty),
+		fmt.Sprintf("-D%s=true", FIPSComplianceProperty),
+	}
+}
@@ -0,0 +1,38 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetJenkinsMasterContainerBaseEnvs_JavaToolOptions(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}}},
+		},
+	}
+	assert.NotContains(t, envNames(GetJenkinsMasterContainerBaseEnvs(jenkins)), javaToolOptionsEnvVariableName)
+
+	jenkins.Spec.Security = &v1alpha2.Security{
+		FIPS:                          true,
+		KubernetesCredentialsProvider: &v1alpha2.KubernetesCredentialsProvider{Enabled: true, LabelSelector: "team=backend"},
+	}
+
+	assert.Contains(t, GetJenkinsMasterContainerBaseEnvs(jenkins), corev1.EnvVar{
+		Name: javaToolOptionsEnvVariableName,
+		Value: "-D" + KubernetesCredentialsProviderLabelSelectorProperty + "=team=backend " +
+			"-Dcom.redhat.fips=true -Djenkins.security.FIPS140.COMPLIANCE=true",
+	})
+}
+
+func envNames(envs []corev1.EnvVar) []string {
+	var names []string
+	for _, env := range envs {
+		names = append(names, env.Name)
+	}
+	return names
+}
@@ -0,0 +1,218 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GrafanaDashboardLabelKey is the label of ConfigMaps loaded by the dashboard sidecar of Grafana
+	GrafanaDashboardLabelKey = "grafana_dashboard"
+	// GrafanaDashboardLabelValue is the value of GrafanaDashboardLabelKey
+	GrafanaDashboardLabelValue = "1"
+
+	// Operator metrics shown in the dashboards, they have to be kept in sync with the metrics registered by the operator
+	reconcileErrorsTotalMetric         = "jenkins_operator_reconcile_errors_total"
+	reconcilePhaseDurationMetric       = "jenkins_operator_reconcile_phase_duration_seconds"
+	reconcileConsecutiveFailuresMetric = "jenkins_operator_reconcile_consecutive_failures"
+	reconcileBackoffSecondsMetric      = "jenkins_operator_reconcile_backoff_seconds"
+	podRestartsTotalMetric             = "jenkins_operator_jenkins_master_pod_restarts_total"
+	seedJobFailuresTotalMetric         = "jenkins_operator_seed_job_failures_total"
+	notificationFailuresTotalMetric    = "jenkins_operator_notification_delivery_failures_total"
+	jenkinsAPIRequestDurationMetric    = "jenkins_operator_jenkins_api_request_duration_seconds"
+
+	grafanaSchemaVersion = 22
+	grafanaPanelWidth    = 12
+	grafanaPanelHeight   = 8
+)
+
+type grafanaDashboard struct {
+	UID           string         `json:"uid"`
+	Title         string         `json:"title"`
+	Tags          []string       `json:"tags"`
+	SchemaVersion int            `json:"schemaVersion"`
+	Editable      bool           `json:"editable"`
+	Refresh       string         `json:"refresh"`
+	Time          grafanaTime    `json:"time"`
+	Panels        []grafanaPanel `json:"panels"`
+}
+
+type grafanaTime struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaPanel struct {
+	ID      int             `json:"id"`
+	Title   string          `json:"title"`
+	Type    string          `json:"type"`
+	GridPos grafanaGridPos  `json:"gridPos"`
+	Targets []grafanaTarget `json:"targets"`
+}
+
+type grafanaGridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type grafanaTarget struct {
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat,omitempty"`
+	RefID        string `json:"refId"`
+}
+
+type grafanaQuery struct {
+	expr   string
+	legend string
+}
+
+type grafanaGraph struct {
+	title   string
+	queries []grafanaQuery
+}
+
+// GetGrafanaDashboardsConfigMapName returns name of Kubernetes config map with Grafana dashboards of Jenkins
+func GetGrafanaDashboardsConfigMapName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-grafana-dashboards-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// NewGrafanaDashboardsConfigMap builds Kubernetes config map with Grafana dashboards of the operator reconcile health
+// and the Jenkins metrics, it's labeled to be loaded by the dashboard sidecar of Grafana
+func NewGrafanaDashboardsConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) (*corev1.ConfigMap, error) {
+	operatorDashboard, err := json.MarshalIndent(newOperatorDashboard(jenkins), "", "  ")
+	if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+	jenkinsDashboard, err := json.MarshalIndent(newJenkinsDashboard(jenkins), "", "  ")
+	if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	labels := map[string]string{GrafanaDashboardLabelKey: GrafanaDashboardLabelValue}
+	for key, value := range meta.Labels {
+		labels[key] = value
+	}
+	meta.Name = GetGrafanaDashboardsConfigMapName(jenkins)
+	meta.Labels = labels
+
+	return &corev1.ConfigMap{
+		TypeMeta:   buildConfigMapTypeMeta(),
+		ObjectMeta: meta,
+		Data: map[string]string{
+			fmt.Sprintf("%s-%s-%s.json", constants.OperatorName, jenkins.Namespace, jenkins.Name): string(operatorDashboard),
+			fmt.Sprintf("jenkins-%s-%s.json", jenkins.Namespace, jenkins.Name):                    string(jenkinsDashboard),
+		},
+	}, nil
+}
+
+func newOperatorDashboard(jenkins *v1alpha2.Jenkins) grafanaDashboard {
+	selector := fmt.Sprintf(`namespace="%s",jenkins="%s"`, jenkins.Namespace, jenkins.Name)
+	serverSelector := fmt.Sprintf(`server=~"https?://%s\\..*"`, GetJenkinsHTTPServiceName(jenkins))
+	graphs := []grafanaGraph{
+		{"Reconcile errors", []grafanaQuery{
+			{fmt.Sprintf("sum by (reason) (rate(%s{%s}[5m]))", reconcileErrorsTotalMetric, selector), "{{reason}}"},
+		}},
+		{"Reconcile phase duration (p95)", []grafanaQuery{
+			{fmt.Sprintf("histogram_quantile(0.95, sum by (phase, le) (rate(%s_bucket{%s}[5m])))", reconcilePhaseDurationMetric, selector), "{{phase}}"},
+		}},
+		{"Consecutive reconcile failures", []grafanaQuery{
+			{fmt.Sprintf("max(%s{%s})", reconcileConsecutiveFailuresMetric, selector), "failures"},
+		}},
+		{"Reconcile backoff", []grafanaQuery{
+			{fmt.Sprintf("max(%s{%s})", reconcileBackoffSecondsMetric, selector), "seconds"},
+		}},
+		{"Jenkins master pod restarts", []grafanaQuery{
+			{fmt.Sprintf("sum(increase(%s{%s}[1h]))", podRestartsTotalMetric, selector), "restarts"},
+		}},
+		{"Seed job failures", []grafanaQuery{
+			{fmt.Sprintf("sum by (seed_job) (increase(%s{%s}[1h]))", seedJobFailuresTotalMetric, selector), "{{seed_job}}"},
+		}},
+		{"Notification delivery failures", []grafanaQuery{
+			{fmt.Sprintf("sum by (notification) (increase(%s{%s}[1h]))", notificationFailuresTotalMetric, selector), "{{notification}}"},
+		}},
+		{"Jenkins API request duration (p95)", []grafanaQuery{
+			{fmt.Sprintf("histogram_quantile(0.95, sum by (endpoint, le) (rate(%s_bucket{%s}[5m])))", jenkinsAPIRequestDurationMetric, serverSelector), "{{endpoint}}"},
+		}},
+	}
+
+	return newGrafanaDashboard(fmt.Sprintf("%s-%s", constants.OperatorName, jenkins.UID),
+		fmt.Sprintf("Jenkins Operator / %s / %s", jenkins.Namespace, jenkins.Name), graphs)
+}
+
+func newJenkinsDashboard(jenkins *v1alpha2.Jenkins) grafanaDashboard {
+	selector := fmt.Sprintf(`namespace="%s",service="%s"`, jenkins.Namespace, GetJenkinsHTTPServiceName(jenkins))
+	graphs := []grafanaGraph{
+		{"Up", []grafanaQuery{
+			{fmt.Sprintf("up{%s}", selector), "{{pod}}"},
+		}},
+		{"Health check score", []grafanaQuery{
+			{fmt.Sprintf("jenkins_health_check_score{%s}", selector), "score"},
+		}},
+		{"Executors", []grafanaQuery{
+			{fmt.Sprintf("jenkins_executor_in_use_value{%s}", selector), "in use"},
+			{fmt.Sprintf("jenkins_executor_count_value{%s}", selector), "total"},
+		}},
+		{"Build queue", []grafanaQuery{
+			{fmt.Sprintf("jenkins_queue_size_value{%s}", selector), "queued"},
+		}},
+		{"Online nodes", []grafanaQuery{
+			{fmt.Sprintf("jenkins_node_online_value{%s}", selector), "online"},
+		}},
+		{"Builds", []grafanaQuery{
+			{fmt.Sprintf("sum(rate(jenkins_runs_success_total{%s}[5m]))", selector), "success"},
+			{fmt.Sprintf("sum(rate(jenkins_runs_failure_total{%s}[5m]))", selector), "failure"},
+		}},
+		{"JVM heap usage", []grafanaQuery{
+			{fmt.Sprintf("vm_memory_heap_usage{%s}", selector), "heap"},
+		}},
+	}
+
+	return newGrafanaDashboard(fmt.Sprintf("jenkins-%s", jenkins.UID),
+		fmt.Sprintf("Jenkins / %s / %s", jenkins.Namespace, jenkins.Name), graphs)
+}
+
+func newGrafanaDashboard(uid, title string, graphs []grafanaGraph) grafanaDashboard {
+	panels := make([]grafanaPanel, 0, len(graphs))
+	for i, graph := range graphs {
+		targets := make([]grafanaTarget, 0, len(graph.queries))
+		for j, query := range graph.queries {
+			targets = append(targets, grafanaTarget{Expr: query.expr, LegendFormat: query.legend, RefID: string(rune('A' + j))})
+		}
+		panels = append(panels, grafanaPanel{
+			ID:    i + 1,
+			Title: graph.title,
+			Type:  "graph",
+			GridPos: grafanaGridPos{
+				X: (i % 2) * grafanaPanelWidth,
+				Y: (i / 2) * grafanaPanelHeight,
+				W: grafanaPanelWidth,
+				H: grafanaPanelHeight,
+			},
+			Targets: targets,
+		})
+	}
+
+	// Grafana limits uid to 40 characters
+	if len(uid) > 40 {
+		uid = uid[:40]
+	}
+	return grafanaDashboard{
+		UID:           uid,
+		Title:         title,
+		Tags:          []string{constants.OperatorName},
+		SchemaVersion: grafanaSchemaVersion,
+		Editable:      false,
+		Refresh:       "1m",
+		Time:          grafanaTime{From: "now-6h", To: "now"},
+		Panels:        panels,
+	}
+}
@@ -0,0 +1,42 @@
+package resources
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewGrafanaDashboardsConfigMap(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example", UID: "3c4a5b2e-0b8e-4e0a-9a7c-5a2b3c4d5e6f"},
+	}
+
+	configMap, err := NewGrafanaDashboardsConfigMap(NewResourceObjectMeta(jenkins), jenkins)
+
+	require.NoError(t, err)
+	assert.Equal(t, "jenkins-operator-grafana-dashboards-example", configMap.Name)
+	assert.Equal(t, GrafanaDashboardLabelValue, configMap.Labels[GrafanaDashboardLabelKey])
+	assert.Equal(t, "example", configMap.Labels["jenkins-cr"])
+	require.Len(t, configMap.Data, 2)
+
+	var operatorDashboard grafanaDashboard
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data["jenkins-operator-default-example.json"]), &operatorDashboard))
+	assert.Equal(t, "jenkins-operator-3c4a5b2e-0b8e-4e0a-9a7c", operatorDashboard.UID)
+	assert.Len(t, operatorDashboard.UID, 40)
+	assert.Equal(t, `sum by (reason) (rate(jenkins_operator_reconcile_errors_total{namespace="default",jenkins="example"}[5m]))`,
+		operatorDashboard.Panels[0].Targets[0].Expr)
+
+	var jenkinsDashboard grafanaDashboard
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data["jenkins-default-example.json"]), &jenkinsDashboard))
+	for _, panel := range jenkinsDashboard.Panels {
+		for i, target := range panel.Targets {
+			assert.Contains(t, target.Expr, `{namespace="default",service="jenkins-operator-http-example"}`)
+			assert.Equal(t, string(rune('A'+i)), target.RefID)
+		}
+	}
+}
@@ -12,7 +12,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const createOperatorUserFileName = "createOperatorUser.groovy"
+const (
+	createOperatorUserFileName       = "createOperatorUser.groovy"
+	createOperatorUserSSHKeyFileName = "createOperatorUserSSHKey.groovy"
+)
 
 var createOperatorUserGroovyFmtTemplate = template.Must(template.New(createOperatorUserFileName).Parse(`
 import hudson.security.*
@@ -38,6 +41,33 @@ if (!operatorUserCreatedFile.exists()) {
 {{- end }}
 `))
 
+var createOperatorUserSSHKeyGroovyTemplate = template.Must(template.New(createOperatorUserSSHKeyFileName).Parse(`
+import hudson.model.User
+import org.jenkinsci.main.modules.cli.auth.ssh.UserPropertyImpl
+import org.jenkinsci.main.modules.sshd.SSHD
+
+SSHD.get().setPort({{ .Port }})
+
+def user = User.getById(new File('{{ .OperatorCredentialsPath }}/{{ .OperatorUserNameFile }}').text, true)
+user.addProperty(new UserPropertyImpl(new File('{{ .OperatorCredentialsPath }}/{{ .OperatorSSHPublicKeyFile }}').text))
+`))
+
+func buildCreateJenkinsOperatorUserSSHKeyGroovyScript(jenkins *v1alpha2.Jenkins) (string, error) {
+	data := struct {
+		Port                     int32
+		OperatorCredentialsPath  string
+		OperatorUserNameFile     string
+		OperatorSSHPublicKeyFile string
+	}{
+		Port:                     jenkins.Spec.JenkinsAPISettings.SSHCLI.Port,
+		OperatorCredentialsPath:  jenkinsOperatorCredentialsVolumePath,
+		OperatorUserNameFile:     OperatorCredentialsSecretUserNameKey,
+		OperatorSSHPublicKeyFile: OperatorCredentialsSecretSSHPublicKeyKey,
+	}
+
+	return render.Render(createOperatorUserSSHKeyGroovyTemplate, data)
+}
+
 func buildCreateJenkinsOperatorUserGroovyScript(jenkins *v1alpha2.Jenkins) (*string, error) {
 	data := struct {
 		Enable                      bool
@@ -75,11 +105,20 @@ func NewInitConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jen
 		return nil, err
 	}
 
-	return &corev1.ConfigMap{
+	configMap := &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
 		Data: map[string]string{
 			createOperatorUserFileName: *createJenkinsOperatorUserGroovy,
 		},
-	}, nil
+	}
+	if jenkins.Spec.JenkinsAPISettings.SSHCLI != nil {
+		createJenkinsOperatorUserSSHKeyGroovy, err := buildCreateJenkinsOperatorUserSSHKeyGroovyScript(jenkins)
+		if err != nil {
+			return nil, err
+		}
+		configMap.Data[createOperatorUserSSHKeyFileName] = createJenkinsOperatorUserSSHKeyGroovy
+	}
+
+	return configMap, nil
 }
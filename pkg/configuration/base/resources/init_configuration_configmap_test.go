@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewInitConfigurationConfigMap(t *testing.T) {
+	t.Run("without SSH CLI", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example"},
+			Spec:       v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}}}},
+		}
+
+		configMap, err := NewInitConfigurationConfigMap(metav1.ObjectMeta{}, jenkins)
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data, createOperatorUserFileName)
+		assert.NotContains(t, configMap.Data, createOperatorUserSSHKeyFileName)
+	})
+	t.Run("with SSH CLI", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}}},
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{
+					AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy,
+					SSHCLI:                &v1alpha2.SSHCLI{Port: 2222},
+				},
+			},
+		}
+
+		configMap, err := NewInitConfigurationConfigMap(metav1.ObjectMeta{}, jenkins)
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[createOperatorUserSSHKeyFileName], "SSHD.get().setPort(2222)")
+		assert.Contains(t, configMap.Data[createOperatorUserSSHKeyFileName], "/var/jenkins/operator-credentials/sshPublicKey")
+	})
+}
+
+func TestNewOperatorSSHKey(t *testing.T) {
+	privateKey, publicKey, err := NewOperatorSSHKey()
+	require.NoError(t, err)
+
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, string(ssh.MarshalAuthorizedKey(signer.PublicKey())), string(publicKey))
+}
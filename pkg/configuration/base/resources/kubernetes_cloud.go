@@ -0,0 +1,78 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+)
+
+const (
+	// KubernetesCloudName is the name of the kubernetes-plugin cloud configured by the operator
+	KubernetesCloudName = "kubernetes"
+	// KubernetesCloudServerURL is the URL of Kubernetes API used by the kubernetes-plugin cloud
+	KubernetesCloudServerURL = "https://kubernetes.default.svc.cluster.local:443"
+	// KubernetesCloudRetentionTimeout is the number of minutes after which idle connections to Kubernetes API are closed
+	KubernetesCloudRetentionTimeout = 15
+	// KubernetesCloudDefaultMaxRequestsPerHost is the default of the kubernetes-plugin for concurrent requests to
+	// Kubernetes API
+	KubernetesCloudDefaultMaxRequestsPerHost = 32
+)
+
+// KubernetesCloudSettings are settings of the kubernetes-plugin cloud configured by the operator
+type KubernetesCloudSettings struct {
+	Namespace     string
+	JenkinsURL    string
+	JenkinsTunnel string
+	// ServiceAccountName is the default ServiceAccount of agent pods, empty means the default of the namespace
+	ServiceAccountName string
+	// ContainerCap is the maximum number of concurrently running agent pods, zero means unlimited
+	ContainerCap       int
+	MaxRequestsPerHost int
+}
+
+// GetKubernetesCloudSettings returns settings of the kubernetes-plugin cloud derived from the Jenkins CR with
+// the overrides from spec.agents.kubernetesCloud, agent pods run as the generated ServiceAccount in spec.agents.namespace
+// when it's set
+func GetKubernetesCloudSettings(jenkins *v1alpha2.Jenkins) (KubernetesCloudSettings, error) {
+	jenkinsServiceFQDN, err := GetJenkinsHTTPServiceFQDN(jenkins)
+	if err != nil {
+		return KubernetesCloudSettings{}, err
+	}
+	jenkinsSlavesServiceFQDN, err := GetJenkinsSlavesServiceFQDN(jenkins)
+	if err != nil {
+		return KubernetesCloudSettings{}, err
+	}
+
+	settings := KubernetesCloudSettings{
+		Namespace:          jenkins.ObjectMeta.Namespace,
+		JenkinsURL:         fmt.Sprintf("http://%s:%d", jenkinsServiceFQDN, jenkins.Spec.Service.Port),
+		JenkinsTunnel:      fmt.Sprintf("%s:%d", jenkinsSlavesServiceFQDN, jenkins.Spec.SlaveService.Port),
+		MaxRequestsPerHost: KubernetesCloudDefaultMaxRequestsPerHost,
+	}
+	if IsAgentsNamespaceEnabled(jenkins) {
+		settings.Namespace = jenkins.Spec.Agents.Namespace
+		settings.ServiceAccountName = GetAgentsResourceName(jenkins)
+	}
+	if jenkins.Spec.Agents == nil || jenkins.Spec.Agents.KubernetesCloud == nil {
+		return settings, nil
+	}
+
+	overrides := jenkins.Spec.Agents.KubernetesCloud
+	if len(overrides.Namespace) > 0 {
+		settings.Namespace = overrides.Namespace
+	}
+	if len(overrides.JenkinsURL) > 0 {
+		settings.JenkinsURL = overrides.JenkinsURL
+	}
+	if len(overrides.JenkinsTunnel) > 0 {
+		settings.JenkinsTunnel = overrides.JenkinsTunnel
+	}
+	if overrides.MaxRequestsPerHost > 0 {
+		settings.MaxRequestsPerHost = overrides.MaxRequestsPerHost
+	}
+	if len(overrides.ServiceAccountName) > 0 {
+		settings.ServiceAccountName = overrides.ServiceAccountName
+	}
+	settings.ContainerCap = overrides.ContainerCap
+	return settings, nil
+}
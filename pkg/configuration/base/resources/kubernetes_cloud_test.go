@@ -0,0 +1,125 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetKubernetesCloudSettings(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+		Spec: v1alpha2.JenkinsSpec{
+			Service:      v1alpha2.Service{Port: 8080},
+			SlaveService: v1alpha2.Service{Port: 50000},
+		},
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		settings, err := GetKubernetesCloudSettings(jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, KubernetesCloudSettings{
+			Namespace:          "default",
+			JenkinsURL:         "http://jenkins-operator-http-example.default.svc.cluster.local:8080",
+			JenkinsTunnel:      "jenkins-operator-slave-example.default.svc.cluster.local:50000",
+			MaxRequestsPerHost: KubernetesCloudDefaultMaxRequestsPerHost,
+		}, settings)
+	})
+	t.Run("overrides", func(t *testing.T) {
+		jenkins := jenkins.DeepCopy()
+		jenkins.Spec.Agents = &v1alpha2.Agents{KubernetesCloud: &v1alpha2.KubernetesCloud{
+			JenkinsURL:         "https://jenkins.example.com",
+			Namespace:          "agents",
+			ServiceAccountName: "jenkins-agent",
+			ContainerCap:       10,
+		}}
+
+		settings, err := GetKubernetesCloudSettings(jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, KubernetesCloudSettings{
+			Namespace:          "agents",
+			JenkinsURL:         "https://jenkins.example.com",
+			JenkinsTunnel:      "jenkins-operator-slave-example.default.svc.cluster.local:50000",
+			ServiceAccountName: "jenkins-agent",
+			ContainerCap:       10,
+			MaxRequestsPerHost: KubernetesCloudDefaultMaxRequestsPerHost,
+		}, settings)
+	})
+	t.Run("agents namespace", func(t *testing.T) {
+		jenkins := jenkins.DeepCopy()
+		jenkins.Spec.Agents = &v1alpha2.Agents{Namespace: "jenkins-agents"}
+
+		settings, err := GetKubernetesCloudSettings(jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, "jenkins-agents", settings.Namespace)
+		assert.Equal(t, "jenkins-operator-agent-example", settings.ServiceAccountName)
+	})
+	t.Run("agents namespace with service account override", func(t *testing.T) {
+		jenkins := jenkins.DeepCopy()
+		jenkins.Spec.Agents = &v1alpha2.Agents{
+			Namespace:       "jenkins-agents",
+			KubernetesCloud: &v1alpha2.KubernetesCloud{ServiceAccountName: "jenkins-agent"},
+		}
+
+		settings, err := GetKubernetesCloudSettings(jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, "jenkins-agents", settings.Namespace)
+		assert.Equal(t, "jenkins-agent", settings.ServiceAccountName)
+	})
+}
+
+func TestNewAgentsNamespaceResources(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+		Spec:       v1alpha2.JenkinsSpec{Agents: &v1alpha2.Agents{Namespace: "jenkins-agents"}},
+	}
+	meta := NewAgentsResourceObjectMeta(jenkins)
+
+	assert.Equal(t, "jenkins-operator-agent-example", meta.Name)
+	assert.Equal(t, "jenkins-agents", meta.Namespace)
+
+	roleBinding := NewAgentsRoleBinding(meta, jenkins)
+	assert.Equal(t, meta.Name, roleBinding.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "jenkins-operator-example", Namespace: "default"}}, roleBinding.Subjects)
+
+	networkPolicy := NewAgentsNetworkPolicy(meta)
+	assert.Empty(t, networkPolicy.Spec.PodSelector.MatchLabels)
+	require.Len(t, networkPolicy.Spec.Ingress, 1)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}, networkPolicy.Spec.Ingress[0].From)
+}
+
+func TestNewBaseConfigurationConfigMapKubernetesCloud(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+		Spec: v1alpha2.JenkinsSpec{
+			Service:      v1alpha2.Service{Port: 8080},
+			SlaveService: v1alpha2.Service{Port: 50000},
+			Agents: &v1alpha2.Agents{KubernetesCloud: &v1alpha2.KubernetesCloud{
+				JenkinsURL:         "https://jenkins.example.com",
+				ContainerCap:       10,
+				MaxRequestsPerHost: 64,
+			}},
+		},
+	}
+
+	configMap, err := NewBaseConfigurationConfigMap(NewResourceObjectMeta(jenkins), jenkins)
+
+	require.NoError(t, err)
+	script := configMap.Data[configureKubernetesPluginGroovyScriptName]
+	assert.Contains(t, script, `Jenkins.instance.clouds.getByName("kubernetes")`)
+	assert.Contains(t, script, `kubernetes.setNamespace("default")`)
+	assert.Contains(t, script, `kubernetes.setJenkinsUrl("https://jenkins.example.com")`)
+	assert.Contains(t, script, `kubernetes.setJenkinsTunnel("jenkins-operator-slave-example.default.svc.cluster.local:50000")`)
+	assert.Contains(t, script, "kubernetes.setContainerCap(10)")
+	assert.Contains(t, script, "kubernetes.setMaxRequestsPerHost(64)")
+}
@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// KubernetesCredentialsProviderLabelSelectorProperty is the system property with the label selector of Secrets
+	// converted by kubernetes-credentials-provider plugin to Jenkins credentials
+	KubernetesCredentialsProviderLabelSelectorProperty = "com.cloudbees.jenkins.plugins.kubernetes_credentials_provider.KubernetesCredentialProvider.labelSelector"
+
+	secretsResource = "secrets"
+)
+
+// IsKubernetesCredentialsProviderEnabled returns true when access of kubernetes-credentials-provider plugin to Secrets
+// is managed by the operator
+func IsKubernetesCredentialsProviderEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Security != nil && jenkins.Spec.Security.KubernetesCredentialsProvider != nil &&
+		jenkins.Spec.Security.KubernetesCredentialsProvider.Enabled
+}
+
+// GetKubernetesCredentialsProviderRoleName returns name of Role and RoleBinding which allow kubernetes-credentials-provider
+// plugin to read Secrets
+func GetKubernetesCredentialsProviderRoleName(serviceAccountName string) string {
+	return fmt.Sprintf("%s-credentials-provider", serviceAccountName)
+}
+
+// NewKubernetesCredentialsProviderRole returns rbac role which allows kubernetes-credentials-provider plugin to read Secrets
+func NewKubernetesCredentialsProviderRole(meta metav1.ObjectMeta) *v1.Role {
+	return &v1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: meta,
+		Rules: []v1.PolicyRule{
+			NewPolicyRule(EmptyAPIGroup, secretsResource, []string{getVerb, listVerb, watchVerb}),
+		},
+	}
+}
+
+// RemoveSecretsPolicyRule returns the policy rules without access to Secrets
+func RemoveSecretsPolicyRule(rules []v1.PolicyRule) []v1.PolicyRule {
+	var filtered []v1.PolicyRule
+	for _, rule := range rules {
+		if len(rule.Resources) == 1 && rule.Resources[0] == secretsResource {
+			continue
+		}
+		filtered = append(filtered, rule)
+	}
+	return filtered
+}
+
+// getKubernetesCredentialsProviderSystemProperties returns JVM options which configure the label selector of
+// kubernetes-credentials-provider plugin
+func getKubernetesCredentialsProviderSystemProperties(jenkins *v1alpha2.Jenkins) []string {
+	if !IsKubernetesCredentialsProviderEnabled(jenkins) || len(jenkins.Spec.Security.KubernetesCredentialsProvider.LabelSelector) == 0 {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("-D%s=%s", KubernetesCredentialsProviderLabelSelectorProperty, jenkins.Spec.Security.KubernetesCredentialsProvider.LabelSelector)}
+}
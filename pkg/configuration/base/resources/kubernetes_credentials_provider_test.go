@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRemoveSecretsPolicyRule(t *testing.T) {
+	rules := RemoveSecretsPolicyRule(NewDefaultPolicyRules())
+
+	assert.Len(t, rules, len(NewDefaultPolicyRules())-1)
+	for _, rule := range rules {
+		assert.NotContains(t, rule.Resources, secretsResource)
+	}
+	role := NewKubernetesCredentialsProviderRole(metav1.ObjectMeta{Name: GetKubernetesCredentialsProviderRoleName("jenkins-operator-example")})
+	assert.Equal(t, "jenkins-operator-example-credentials-provider", role.Name)
+	assert.Equal(t, []string{secretsResource}, role.Rules[0].Resources)
+	assert.Equal(t, []string{getVerb, listVerb, watchVerb}, role.Rules[0].Verbs)
+}
+
+func TestGetKubernetesCredentialsProviderSystemProperties(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{}
+	assert.Nil(t, getKubernetesCredentialsProviderSystemProperties(jenkins))
+
+	jenkins.Spec.Security = &v1alpha2.Security{KubernetesCredentialsProvider: &v1alpha2.KubernetesCredentialsProvider{Enabled: true}}
+	assert.Nil(t, getKubernetesCredentialsProviderSystemProperties(jenkins))
+
+	jenkins.Spec.Security.KubernetesCredentialsProvider.LabelSelector = "team=backend"
+	assert.Equal(t, []string{"-D" + KubernetesCredentialsProviderLabelSelectorProperty + "=team=backend"},
+		getKubernetesCredentialsProviderSystemProperties(jenkins))
+}
@@ -0,0 +1,110 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultMonitoringPath is the default path of the Prometheus metrics endpoint in Jenkins
+	DefaultMonitoringPath = "prometheus"
+
+	// MetricsServiceLabelKey is the label of the Jenkins HTTP Service selected by the ServiceMonitor
+	MetricsServiceLabelKey = "jenkins.io/metrics"
+	// MetricsServiceLabelValue is the value of MetricsServiceLabelKey
+	MetricsServiceLabelValue = "true"
+
+	prometheusScrapeAnnotation = "prometheus.io/scrape"
+	prometheusPathAnnotation   = "prometheus.io/path"
+	prometheusPortAnnotation   = "prometheus.io/port"
+)
+
+var isServiceMonitorAPIAvailable = false
+var serviceMonitorAPIChecked = false
+
+// IsMonitoringEnabled returns true if Prometheus monitoring of Jenkins is enabled
+func IsMonitoringEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Monitoring != nil && jenkins.Spec.Monitoring.Enabled
+}
+
+// GetServiceMonitorName returns name of the ServiceMonitor of Jenkins metrics
+func GetServiceMonitorName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-monitoring-%s", constants.OperatorName, jenkins.Name)
+}
+
+// NewMetricsService returns the Jenkins HTTP Service settings which expose the metrics path to Prometheus
+func NewMetricsService(service v1alpha2.Service, metricsPath string) v1alpha2.Service {
+	annotations := map[string]string{}
+	for key, value := range service.Annotations {
+		annotations[key] = value
+	}
+	annotations[prometheusScrapeAnnotation] = "true"
+	annotations[prometheusPathAnnotation] = metricsPath
+	annotations[prometheusPortAnnotation] = fmt.Sprint(service.Port)
+	service.Annotations = annotations
+
+	labels := map[string]string{}
+	for key, value := range service.Labels {
+		labels[key] = value
+	}
+	labels[MetricsServiceLabelKey] = MetricsServiceLabelValue
+	service.Labels = labels
+	return service
+}
+
+// NewServiceMonitor builds the ServiceMonitor of Prometheus Operator which scrapes Jenkins metrics
+func NewServiceMonitor(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, metricsPath string) *monitoringv1.ServiceMonitor {
+	selector := map[string]string{MetricsServiceLabelKey: MetricsServiceLabelValue}
+	for key, value := range meta.Labels {
+		selector[key] = value
+	}
+	labels := map[string]string{}
+	for key, value := range jenkins.Spec.Monitoring.Labels {
+		labels[key] = value
+	}
+	for key, value := range meta.Labels {
+		labels[key] = value
+	}
+	meta.Name = GetServiceMonitorName(jenkins)
+	meta.Labels = labels
+	targetPort := intstr.FromString(httpPortName)
+
+	return &monitoringv1.ServiceMonitor{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       monitoringv1.ServiceMonitorsKind,
+			APIVersion: monitoringv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: meta,
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{MatchLabels: selector},
+			Endpoints: []monitoringv1.Endpoint{{
+				TargetPort: &targetPort,
+				Path:       metricsPath,
+				Interval:   jenkins.Spec.Monitoring.Interval,
+			}},
+		},
+	}
+}
+
+// IsServiceMonitorAPIAvailable tells if the ServiceMonitor API of Prometheus Operator is installed and discoverable
+func IsServiceMonitorAPIAvailable(clientSet *kubernetes.Clientset) bool {
+	if serviceMonitorAPIChecked {
+		return isServiceMonitorAPIAvailable
+	}
+	gv := schema.GroupVersion{
+		Group:   monitoringv1.SchemeGroupVersion.Group,
+		Version: monitoringv1.SchemeGroupVersion.Version,
+	}
+	serviceMonitorAPIChecked = true
+	isServiceMonitorAPIAvailable = discovery.ServerSupportsVersion(clientSet, gv) == nil
+	return isServiceMonitorAPIAvailable
+}
@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewMetricsService(t *testing.T) {
+	service := v1alpha2.Service{Port: 8080, Annotations: map[string]string{"team": "ci"}}
+
+	metricsService := NewMetricsService(service, "/jenkins/prometheus/")
+
+	assert.Equal(t, map[string]string{
+		"team":                 "ci",
+		"prometheus.io/scrape": "true",
+		"prometheus.io/path":   "/jenkins/prometheus/",
+		"prometheus.io/port":   "8080",
+	}, metricsService.Annotations)
+	assert.Equal(t, map[string]string{MetricsServiceLabelKey: MetricsServiceLabelValue}, metricsService.Labels)
+	assert.Equal(t, map[string]string{"team": "ci"}, service.Annotations)
+}
+
+func TestNewServiceMonitor(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+		Spec: v1alpha2.JenkinsSpec{Monitoring: &v1alpha2.Monitoring{
+			Enabled:  true,
+			Path:     "prometheus",
+			Interval: "30s",
+			Labels:   map[string]string{"release": "prometheus"},
+		}},
+	}
+
+	serviceMonitor := NewServiceMonitor(NewResourceObjectMeta(jenkins), jenkins, "/prometheus/")
+
+	assert.Equal(t, "jenkins-operator-monitoring-example", serviceMonitor.Name)
+	assert.Equal(t, "prometheus", serviceMonitor.Labels["release"])
+	assert.Equal(t, BuildResourceLabels(jenkins)["jenkins-cr"], serviceMonitor.Labels["jenkins-cr"])
+	assert.Equal(t, MetricsServiceLabelValue, serviceMonitor.Spec.Selector.MatchLabels[MetricsServiceLabelKey])
+	if assert.Len(t, serviceMonitor.Spec.Endpoints, 1) {
+		assert.Equal(t, "/prometheus/", serviceMonitor.Spec.Endpoints[0].Path)
+		assert.Equal(t, "30s", serviceMonitor.Spec.Endpoints[0].Interval)
+		assert.Equal(t, httpPortName, serviceMonitor.Spec.Endpoints[0].TargetPort.StrVal)
+	}
+}
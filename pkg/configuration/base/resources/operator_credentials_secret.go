@@ -1,11 +1,17 @@
 package resources
 
 import (
+	"crypto/ed25519"
+	cryptorand "crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
 
+	stackerr "github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -17,10 +23,22 @@ const (
 	OperatorCredentialsSecretUserNameKey = "user"
 	// OperatorCredentialsSecretPasswordKey defines key of password in operator credentials secret
 	OperatorCredentialsSecretPasswordKey = "password"
+	// OperatorCredentialsSecretPasswordCreationKey defines key of password creation time in operator credentials secret,
+	// it's set when the password is rotated
+	OperatorCredentialsSecretPasswordCreationKey = "passwordCreationTime"
 	// OperatorCredentialsSecretTokenKey defines key of token in operator credentials secret
 	OperatorCredentialsSecretTokenKey = "token"
 	// OperatorCredentialsSecretTokenCreationKey defines key of token creation time in operator credentials secret
 	OperatorCredentialsSecretTokenCreationKey = "tokenCreationTime"
+	// OperatorCredentialsSecretTokenUUIDKey defines key of token UUID in operator credentials secret, it's used to
+	// revoke the token when it's rotated
+	OperatorCredentialsSecretTokenUUIDKey = "tokenUUID"
+	// OperatorCredentialsSecretSSHPrivateKeyKey defines key of SSH private key in operator credentials secret, it's
+	// used to execute Groovy scripts by the Jenkins CLI over SSH
+	OperatorCredentialsSecretSSHPrivateKeyKey = "sshPrivateKey"
+	// OperatorCredentialsSecretSSHPublicKeyKey defines key of SSH public key in operator credentials secret, it's
+	// added to the operator user on Jenkins start
+	OperatorCredentialsSecretSSHPublicKeyKey = "sshPublicKey"
 )
 
 func buildSecretTypeMeta() metav1.TypeMeta {
@@ -45,7 +63,30 @@ func NewOperatorCredentialsSecret(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenk
 		ObjectMeta: meta,
 		Data: map[string][]byte{
 			OperatorCredentialsSecretUserNameKey: []byte(OperatorUserName),
-			OperatorCredentialsSecretPasswordKey: []byte(randomString(20)),
+			OperatorCredentialsSecretPasswordKey: []byte(NewOperatorPassword()),
 		},
 	}
 }
+
+// NewOperatorPassword generates the password of the operator user
+func NewOperatorPassword() string {
+	return randomString(20)
+}
+
+// NewOperatorSSHKey generates the SSH key of the operator user, it returns the PEM encoded private key and the public
+// key in the authorized_keys format
+func NewOperatorSSHKey() (privateKey []byte, publicKey []byte, err error) {
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(cryptorand.Reader)
+	if err != nil {
+		return nil, nil, stackerr.WithStack(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(edPrivateKey)
+	if err != nil {
+		return nil, nil, stackerr.WithStack(err)
+	}
+	sshPublicKey, err := ssh.NewPublicKey(edPublicKey)
+	if err != nil {
+		return nil, nil, stackerr.WithStack(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), ssh.MarshalAuthorizedKey(sshPublicKey), nil
+}
@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	pluginCacheVolumeName = "plugin-cache"
+	pluginCacheVolumePath = jenkinsPath + "/plugin-cache"
+)
+
+var defaultPluginCacheSize = resource.MustParse("1Gi")
+
+// GetPluginCachePersistentVolumeClaimName returns name of the PersistentVolumeClaim used as the plugin download cache
+func GetPluginCachePersistentVolumeClaimName(jenkins *v1alpha2.Jenkins) string {
+	if name := jenkins.Spec.Master.PluginCache.PersistentVolumeClaimName; len(name) > 0 {
+		return name
+	}
+
+	return fmt.Sprintf("%s-plugin-cache-%s", constants.OperatorName, jenkins.Name)
+}
+
+// NewPluginCachePersistentVolumeClaim builds the PersistentVolumeClaim used as the plugin download cache
+func NewPluginCachePersistentVolumeClaim(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *corev1.PersistentVolumeClaim {
+	meta.Name = GetPluginCachePersistentVolumeClaimName(jenkins)
+	size := defaultPluginCacheSize
+	if jenkins.Spec.Master.PluginCache.Size != nil {
+		size = *jenkins.Spec.Master.PluginCache.Size
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: meta,
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: jenkins.Spec.Master.PluginCache.StorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewPluginCachePersistentVolumeClaim(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example"},
+			Spec:       v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{PluginCache: &v1alpha2.PluginCache{}}},
+		}
+
+		persistentVolumeClaim := NewPluginCachePersistentVolumeClaim(metav1.ObjectMeta{}, jenkins)
+
+		assert.Equal(t, "jenkins-operator-plugin-cache-example", persistentVolumeClaim.Name)
+		assert.Nil(t, persistentVolumeClaim.Spec.StorageClassName)
+		assert.Equal(t, resource.MustParse("1Gi"), persistentVolumeClaim.Spec.Resources.Requests[corev1.ResourceStorage])
+	})
+	t.Run("custom storage", func(t *testing.T) {
+		storageClassName := "fast"
+		size := resource.MustParse("5Gi")
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example"},
+			Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+				PluginCache: &v1alpha2.PluginCache{StorageClassName: &storageClassName, Size: &size},
+			}},
+		}
+
+		persistentVolumeClaim := NewPluginCachePersistentVolumeClaim(metav1.ObjectMeta{}, jenkins)
+
+		assert.Equal(t, &storageClassName, persistentVolumeClaim.Spec.StorageClassName)
+		assert.Equal(t, size, persistentVolumeClaim.Spec.Resources.Requests[corev1.ResourceStorage])
+	})
+}
+
+func TestGetJenkinsMasterPodBaseVolumes_PluginCache(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+			Containers:  []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+			PluginCache: &v1alpha2.PluginCache{PersistentVolumeClaimName: "cache"},
+		}},
+	}
+
+	volumes := GetJenkinsMasterPodBaseVolumes(jenkins)
+	volumeMounts := GetJenkinsMasterContainerBaseVolumeMounts(jenkins)
+
+	assert.Contains(t, volumes, corev1.Volume{
+		Name: pluginCacheVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "cache"},
+		},
+	})
+	assert.Contains(t, volumeMounts, corev1.VolumeMount{Name: pluginCacheVolumeName, MountPath: pluginCacheVolumePath})
+}
@@ -2,6 +2,8 @@ package resources
 
 import (
 	"fmt"
+	"path"
+	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
@@ -36,8 +38,17 @@ const (
 	// This script is provided by user
 	ConfigurationAsCodeSecretVolumePath = jenkinsPath + "/configuration-as-code-secrets"
 
+	// PluginBundleInitContainerName is the name of init container which copies plugin files from the bundle image
+	PluginBundleInitContainerName = "plugin-bundle"
+	pluginBundleVolumeName        = "plugin-bundle"
+	pluginBundleVolumePath        = jenkinsPath + "/plugin-bundle"
+	defaultPluginBundleImagePath  = "/plugins"
+
 	httpPortName  = "http"
 	slavePortName = "slavelistener"
+
+	// javaToolOptionsEnvVariableName is read by JVM, unlike JAVA_OPTS it isn't set by users in the Jenkins CR
+	javaToolOptionsEnvVariableName = "JAVA_TOOL_OPTIONS"
 )
 
 func buildPodTypeMeta() metav1.TypeMeta {
@@ -72,6 +83,19 @@ func GetJenkinsMasterContainerBaseEnvs(jenkins *v1alpha2.Jenkins) []corev1.EnvVa
 			Value: ConfigurationAsCodeSecretVolumePath,
 		})
 	}
+	if jenkins.Spec.Master.ReadOnlyRootFilesystem {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  referenceEnvVariableName,
+			Value: pluginsStagingVolumePath,
+		})
+	}
+	systemProperties := append(getKubernetesCredentialsProviderSystemProperties(jenkins), getFIPSSystemProperties(jenkins)...)
+	if len(systemProperties) > 0 {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  javaToolOptionsEnvVariableName,
+			Value: strings.Join(systemProperties, " "),
+		})
+	}
 
 	return envVars
 }
@@ -155,7 +179,36 @@ func GetJenkinsMasterPodBaseVolumes(jenkins *v1alpha2.Jenkins) []corev1.Volume {
 		})
 	}
 
-	return volumes
+	if bundle := jenkins.Spec.Master.PluginBundle; bundle != nil {
+		volume := corev1.Volume{
+			Name: pluginBundleVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}
+		if len(bundle.PersistentVolumeClaimName) > 0 {
+			volume.VolumeSource = corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: bundle.PersistentVolumeClaimName,
+					ReadOnly:  true,
+				},
+			}
+		}
+		volumes = append(volumes, volume)
+	}
+
+	if jenkins.Spec.Master.PluginCache != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: pluginCacheVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: GetPluginCachePersistentVolumeClaimName(jenkins),
+				},
+			},
+		})
+	}
+
+	return append(volumes, getReadOnlyRootFilesystemVolumes(jenkins)...)
 }
 
 func getGroovyScriptsSecretVolumeName(jenkins *v1alpha2.Jenkins) string {
@@ -206,7 +259,72 @@ func GetJenkinsMasterContainerBaseVolumeMounts(jenkins *v1alpha2.Jenkins) []core
 		})
 	}
 
-	return volumeMounts
+	if jenkins.Spec.Master.PluginBundle != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      pluginBundleVolumeName,
+			MountPath: pluginBundleVolumePath,
+			ReadOnly:  true,
+		})
+	}
+	if jenkins.Spec.Master.PluginCache != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      pluginCacheVolumeName,
+			MountPath: pluginCacheVolumePath,
+			ReadOnly:  false,
+		})
+	}
+
+	return append(volumeMounts, getReadOnlyRootFilesystemVolumeMounts(jenkins)...)
+}
+
+// getPluginBundlePath returns the directory with plugin files in the Jenkins master container
+func getPluginBundlePath(jenkins *v1alpha2.Jenkins) string {
+	bundle := jenkins.Spec.Master.PluginBundle
+	if bundle == nil {
+		return ""
+	}
+	if len(bundle.PersistentVolumeClaimName) > 0 && len(bundle.Path) > 0 {
+		return path.Join(pluginBundleVolumePath, bundle.Path)
+	}
+	return pluginBundleVolumePath
+}
+
+// NewJenkinsMasterInitContainers returns init containers of the Jenkins master pod
+func NewJenkinsMasterInitContainers(jenkins *v1alpha2.Jenkins) []corev1.Container {
+	bundle := jenkins.Spec.Master.PluginBundle
+	if bundle == nil || len(bundle.Image) == 0 {
+		return nil
+	}
+
+	imagePath := bundle.Path
+	if len(imagePath) == 0 {
+		imagePath = defaultPluginBundleImagePath
+	}
+	imagePullPolicy := bundle.ImagePullPolicy
+	if len(imagePullPolicy) == 0 {
+		imagePullPolicy = corev1.PullIfNotPresent
+	}
+
+	var securityContext *corev1.SecurityContext
+	if IsRestrictedSecurityContextEnabled(jenkins) {
+		securityContext = NewRestrictedSecurityContext()
+	}
+
+	return []corev1.Container{
+		{
+			Name:            PluginBundleInitContainerName,
+			SecurityContext: securityContext,
+			Image:           bundle.Image,
+			ImagePullPolicy: imagePullPolicy,
+			Command:         []string{"sh", "-c", fmt.Sprintf("cp -r %s/. %s/", imagePath, pluginBundleVolumePath)},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      pluginBundleVolumeName,
+					MountPath: pluginBundleVolumePath,
+				},
+			},
+		},
+	}
 }
 
 // NewJenkinsMasterContainer returns Jenkins master Kubernetes container
@@ -309,10 +427,32 @@ func GetJenkinsMasterPodLabels(jenkins v1alpha2.Jenkins) map[string]string {
 	return labels
 }
 
+// GetJenkinsMasterPodAnnotations returns Jenkins pod annotations for given CR
+func GetJenkinsMasterPodAnnotations(jenkins *v1alpha2.Jenkins) map[string]string {
+	seccompAnnotations := getSeccompAnnotations(jenkins)
+	if !jenkins.Spec.Backup.VeleroHooks && len(seccompAnnotations) == 0 {
+		return jenkins.Spec.Master.Annotations
+	}
+
+	annotations := map[string]string{}
+	for key, value := range seccompAnnotations {
+		annotations[key] = value
+	}
+	for key, value := range jenkins.Spec.Master.Annotations {
+		annotations[key] = value
+	}
+	if jenkins.Spec.Backup.VeleroHooks {
+		for key, value := range getVeleroHookAnnotations(jenkins) {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
 // NewJenkinsMasterPod builds Jenkins Master Kubernetes Pod resource
 func NewJenkinsMasterPod(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *corev1.Pod {
 	serviceAccountName := objectMeta.Name
-	objectMeta.Annotations = jenkins.Spec.Master.Annotations
+	objectMeta.Annotations = GetJenkinsMasterPodAnnotations(jenkins)
 	objectMeta.Name = GetJenkinsMasterPodName(jenkins)
 	objectMeta.Labels = GetJenkinsMasterPodLabels(*jenkins)
 
@@ -323,6 +463,7 @@ func NewJenkinsMasterPod(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins
 			ServiceAccountName: serviceAccountName,
 			RestartPolicy:      corev1.RestartPolicyNever,
 			NodeSelector:       jenkins.Spec.Master.NodeSelector,
+			InitContainers:     NewJenkinsMasterInitContainers(jenkins),
 			Containers:         newContainers(jenkins),
 			Volumes:            append(GetJenkinsMasterPodBaseVolumes(jenkins), jenkins.Spec.Master.Volumes...),
 			SecurityContext:    jenkins.Spec.Master.SecurityContext,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestGetJenkinsMasterPodBaseVolumes(t *testing.T) {
@@ -155,3 +156,110 @@ func checkSecretVolumesPresence(jenkins *v1alpha2.Jenkins) (groovyExists bool, c
 	}
 	return groovyExists, cascExists
 }
+
+func TestGetJenkinsMasterPodAnnotations(t *testing.T) {
+	t.Run("without Velero hooks", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Annotations: map[string]string{"one": "two"},
+				},
+			},
+		}
+
+		annotations := GetJenkinsMasterPodAnnotations(jenkins)
+
+		assert.Equal(t, map[string]string{"one": "two", corev1.SeccompPodAnnotationKey: corev1.SeccompProfileRuntimeDefault}, annotations)
+		assert.Len(t, jenkins.Spec.Master.Annotations, 1)
+	})
+	t.Run("seccomp profile set by user", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Annotations: map[string]string{corev1.SeccompPodAnnotationKey: "unconfined"},
+				},
+			},
+		}
+
+		annotations := GetJenkinsMasterPodAnnotations(jenkins)
+
+		assert.Equal(t, map[string]string{corev1.SeccompPodAnnotationKey: "unconfined"}, annotations)
+	})
+	t.Run("restricted security context disabled", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Annotations:                      map[string]string{"one": "two"},
+					DisableRestrictedSecurityContext: true,
+				},
+			},
+		}
+
+		annotations := GetJenkinsMasterPodAnnotations(jenkins)
+
+		assert.Equal(t, map[string]string{"one": "two"}, annotations)
+	})
+	t.Run("with Velero hooks", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Annotations: map[string]string{"one": "two"},
+					Containers:  []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+				},
+				Backup: v1alpha2.Backup{
+					VeleroHooks: true,
+				},
+			},
+		}
+
+		annotations := GetJenkinsMasterPodAnnotations(jenkins)
+
+		assert.Equal(t, "two", annotations["one"])
+		assert.Equal(t, JenkinsMasterContainerName, annotations["pre.hook.backup.velero.io/container"])
+		assert.Equal(t, `["/var/lib/jenkins/scripts/velero-hook.sh","pre"]`, annotations["pre.hook.backup.velero.io/command"])
+		assert.Equal(t, JenkinsMasterContainerName, annotations["post.hook.backup.velero.io/container"])
+		assert.Equal(t, `["/var/lib/jenkins/scripts/velero-hook.sh","post"]`, annotations["post.hook.backup.velero.io/command"])
+		assert.Len(t, jenkins.Spec.Master.Annotations, 1)
+	})
+}
+
+func TestNewJenkinsMasterInitContainers(t *testing.T) {
+	t.Run("without plugin bundle", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{}
+
+		assert.Nil(t, NewJenkinsMasterInitContainers(jenkins))
+	})
+	t.Run("plugin bundle from persistent volume claim", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					PluginBundle: &v1alpha2.PluginBundle{PersistentVolumeClaimName: "plugins", Path: "2.222"},
+				},
+			},
+		}
+
+		assert.Nil(t, NewJenkinsMasterInitContainers(jenkins))
+		assert.Equal(t, "/var/jenkins/plugin-bundle/2.222", getPluginBundlePath(jenkins))
+		volumes := GetJenkinsMasterPodBaseVolumes(jenkins)
+		assert.Equal(t, "plugins", volumes[len(volumes)-1].PersistentVolumeClaim.ClaimName)
+	})
+	t.Run("plugin bundle from image", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					PluginBundle: &v1alpha2.PluginBundle{Image: "registry.example.com/jenkins/plugins:1.0"},
+				},
+			},
+		}
+
+		initContainers := NewJenkinsMasterInitContainers(jenkins)
+
+		assert.Len(t, initContainers, 1)
+		assert.Equal(t, "registry.example.com/jenkins/plugins:1.0", initContainers[0].Image)
+		assert.Equal(t, []string{"sh", "-c", "cp -r /plugins/. /var/jenkins/plugin-bundle/"}, initContainers[0].Command)
+		assert.Equal(t, NewRestrictedSecurityContext(), initContainers[0].SecurityContext)
+		assert.Equal(t, "/var/jenkins/plugin-bundle", getPluginBundlePath(jenkins))
+		volumes := GetJenkinsMasterPodBaseVolumes(jenkins)
+		assert.NotNil(t, volumes[len(volumes)-1].EmptyDir)
+	})
+}
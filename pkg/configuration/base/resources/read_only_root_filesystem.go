@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"path"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	tmpVolumeName = "tmp"
+	tmpVolumePath = "/tmp"
+
+	// warVolumeName is the cache of the Jenkins WAR file extracted by Jenkins to JENKINS_HOME/war at startup
+	warVolumeName = "war"
+
+	// pluginsStagingVolumeName is the directory where plugins are installed before Jenkins copies them to JENKINS_HOME,
+	// the REF environment variable of the official Jenkins images points to it
+	pluginsStagingVolumeName = "plugins-staging"
+	pluginsStagingVolumePath = jenkinsPath + "/ref"
+	// imageReferencePath is the default plugins staging directory of the official Jenkins images
+	imageReferencePath = "/usr/share/jenkins/ref"
+
+	referenceEnvVariableName = "REF"
+)
+
+// getWarVolumePath returns the directory where Jenkins extracts the WAR file
+func getWarVolumePath(jenkins *v1alpha2.Jenkins) string {
+	return path.Join(getJenkinsHomePath(jenkins), "war")
+}
+
+// getReadOnlyRootFilesystemVolumes returns writable volumes required by Jenkins when the root filesystem of the Jenkins
+// master container is read-only
+func getReadOnlyRootFilesystemVolumes(jenkins *v1alpha2.Jenkins) []corev1.Volume {
+	if !jenkins.Spec.Master.ReadOnlyRootFilesystem {
+		return nil
+	}
+
+	var volumes []corev1.Volume
+	for _, name := range []string{tmpVolumeName, warVolumeName, pluginsStagingVolumeName} {
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+	return volumes
+}
+
+// getReadOnlyRootFilesystemVolumeMounts returns mounts of writable volumes required by Jenkins when the root
+// filesystem of the Jenkins master container is read-only
+func getReadOnlyRootFilesystemVolumeMounts(jenkins *v1alpha2.Jenkins) []corev1.VolumeMount {
+	if !jenkins.Spec.Master.ReadOnlyRootFilesystem {
+		return nil
+	}
+
+	return []corev1.VolumeMount{
+		{
+			Name:      tmpVolumeName,
+			MountPath: tmpVolumePath,
+		},
+		{
+			Name:      warVolumeName,
+			MountPath: getWarVolumePath(jenkins),
+		},
+		{
+			Name:      pluginsStagingVolumeName,
+			MountPath: pluginsStagingVolumePath,
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewJenkinsMasterContainer_ReadOnlyRootFilesystem(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+			}},
+		}
+
+		container := NewJenkinsMasterContainer(jenkins)
+
+		assert.NotContains(t, container.Env, corev1.EnvVar{Name: "REF", Value: "/var/jenkins/ref"})
+		assert.NotContains(t, container.VolumeMounts, corev1.VolumeMount{Name: tmpVolumeName, MountPath: "/tmp"})
+		assert.Len(t, GetJenkinsMasterPodBaseVolumes(jenkins), 4)
+	})
+	t.Run("enabled", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+				ReadOnlyRootFilesystem: true,
+				Containers: []v1alpha2.Container{{
+					Name: JenkinsMasterContainerName,
+					Env:  []corev1.EnvVar{{Name: "JENKINS_HOME", Value: "/jenkins"}},
+				}},
+			}},
+		}
+
+		container := NewJenkinsMasterContainer(jenkins)
+		volumes := GetJenkinsMasterPodBaseVolumes(jenkins)
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "REF", Value: "/var/jenkins/ref"})
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: tmpVolumeName, MountPath: "/tmp"})
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: warVolumeName, MountPath: "/jenkins/war"})
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: pluginsStagingVolumeName, MountPath: "/var/jenkins/ref"})
+		for _, name := range []string{tmpVolumeName, warVolumeName, pluginsStagingVolumeName} {
+			assert.Contains(t, volumes, corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"sort"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+)
+
+// GetConfigurationSecretNames returns sorted names of Secrets which content is applied to the running Jenkins by
+// the operator, the configuration is applied again when they change
+func GetConfigurationSecretNames(jenkins *v1alpha2.Jenkins) []string {
+	names := map[string]bool{
+		jenkins.Spec.ConfigurationAsCode.Secret.Name: true,
+		jenkins.Spec.GroovyScripts.Secret.Name:       true,
+	}
+	if security := jenkins.Spec.Security; security != nil && security.Realm != nil {
+		if ldap := security.Realm.LDAP; ldap != nil {
+			names[ldap.BindSecret.Name] = true
+		}
+		if oidc := security.Realm.OIDC; oidc != nil {
+			names[oidc.ClientSecret.Name] = true
+			names[GetBreakGlassSecretName(jenkins)] = true
+		}
+	}
+
+	return sortedNames(names)
+}
+
+// GetJenkinsMasterEnvSecretNames returns sorted names of Secrets referenced by environment variables of Jenkins master
+// containers, they are read only when the containers start, so the Jenkins master pod is restarted when they change
+func GetJenkinsMasterEnvSecretNames(jenkins *v1alpha2.Jenkins) []string {
+	names := map[string]bool{}
+	for _, container := range jenkins.Spec.Master.Containers {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				names[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				names[envFrom.SecretRef.Name] = true
+			}
+		}
+	}
+
+	return sortedNames(names)
+}
+
+func sortedNames(names map[string]bool) []string {
+	var sorted []string
+	for name := range names {
+		if len(name) > 0 {
+			sorted = append(sorted, name)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetConfigurationSecretNames(t *testing.T) {
+	t.Run("no secrets", func(t *testing.T) {
+		assert.Nil(t, GetConfigurationSecretNames(&v1alpha2.Jenkins{}))
+	})
+	t.Run("customization and security realm secrets", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example"},
+			Spec: v1alpha2.JenkinsSpec{
+				ConfigurationAsCode: v1alpha2.ConfigurationAsCode{Customization: v1alpha2.Customization{Secret: v1alpha2.SecretRef{Name: "casc"}}},
+				GroovyScripts:       v1alpha2.GroovyScripts{Customization: v1alpha2.Customization{Secret: v1alpha2.SecretRef{Name: "casc"}}},
+				Security: &v1alpha2.Security{
+					Realm: &v1alpha2.SecurityRealm{OIDC: &v1alpha2.OIDCSecurityRealm{ClientSecret: v1alpha2.SecretRef{Name: "oidc"}}},
+				},
+			},
+		}
+
+		assert.Equal(t, []string{"casc", "jenkins-operator-break-glass-example", "oidc"}, GetConfigurationSecretNames(jenkins))
+	})
+}
+
+func TestGetJenkinsMasterEnvSecretNames(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{
+					{
+						Name: JenkinsMasterContainerName,
+						Env: []corev1.EnvVar{
+							{Name: "PLAIN", Value: "value"},
+							{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "token",
+							}}},
+						},
+					},
+					{
+						Name:    "sidecar",
+						EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "aws"}}}},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"aws", "token"}, GetJenkinsMasterEnvSecretNames(jenkins))
+}
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/jenkinsci/kubernetes-operator/internal/render"
@@ -14,6 +15,68 @@ import (
 
 const installPluginsCommand = "install-plugins.sh"
 
+const installPluginsFromBundleCommand = "install-plugins-from-bundle.sh"
+
+// installPluginsFromBundleBashFmt installs plugins offline from the plugin bundle, it fails when any plugin is missing
+// in the bundle or has different version
+const installPluginsFromBundleBashFmt = `#!/bin/bash -eu
+
+BUNDLE_DIR=%s
+REF_DIR=${REF:-%s/plugins}
+
+mkdir -p "$REF_DIR"
+missing=()
+tampered=()
+while read -r spec || [[ -n "$spec" ]]; do
+    [[ -z "$spec" ]] && continue
+    plugin="${spec%%%%:*}"
+    version="${spec#*:}"
+    version="${version%%%%:*}"
+
+    file=""
+    for extension in hpi jpi; do
+        if [[ -f "$BUNDLE_DIR/$plugin.$extension" ]]; then
+            file="$BUNDLE_DIR/$plugin.$extension"
+            break
+        fi
+    done
+    if [[ -z "$file" ]]; then
+        missing+=("$plugin:$version")
+        continue
+    fi
+
+    actualVersion=$(unzip -p "$file" META-INF/MANIFEST.MF | tr -d '\r' | sed -n 's/^Plugin-Version: //p')
+    if [[ "$actualVersion" != "$version" ]]; then
+        missing+=("$plugin:$version (bundle contains version '$actualVersion')")
+        continue
+    fi
+
+    if [[ -n "${PLUGIN_CHECKSUMS_FILE:-}" ]]; then
+        expected="$(grep "^${plugin}:" "$PLUGIN_CHECKSUMS_FILE" | head -n 1 | cut -d: -f2)"
+        actual="$(sha256sum "$file" | cut -d' ' -f1)"
+        if [[ "$expected" != "$actual" ]]; then
+            tampered+=("$plugin:$version (checksum '$actual' doesn't match expected '$expected')")
+            continue
+        fi
+    fi
+
+    echo "Installing $plugin:$version from plugin bundle"
+    cp "$file" "$REF_DIR/$plugin.jpi"
+done < "${1:-/dev/stdin}"
+
+if [[ ${#missing[@]} -gt 0 ]]; then
+    echo "Plugin bundle $BUNDLE_DIR is incomplete, missing plugins:"
+    printf '  %%s\n' "${missing[@]}"
+fi
+if [[ ${#tampered[@]} -gt 0 ]]; then
+    echo "Plugin bundle $BUNDLE_DIR contains plugins with invalid checksum:"
+    printf '  %%s\n' "${tampered[@]}"
+fi
+if [[ ${#missing[@]} -gt 0 || ${#tampered[@]} -gt 0 ]]; then
+    exit 1
+fi
+`
+
 // bash scripts installs single jenkins plugin with specific version
 const installPluginsBashFmt = `#!/bin/bash -eu
 
@@ -63,6 +126,12 @@ download() {
             return 1
         fi
 
+        if ! verifyChecksum "$plugin"; then
+            echo "Checksum verification failed: $(getArchiveFilename "$plugin")" >&2
+            echo "Checksum verification: ${plugin}" >> "$FAILED"
+            return 1
+        fi
+
     fi
 }
 
@@ -78,6 +147,17 @@ doDownload() {
         return 0
     fi
 
+    # Plugins with a fixed version are reused from the plugin cache when their checksum matches
+    local cached=
+    if [[ -n "$PLUGIN_CACHE_DIR" && "$version" != "latest" && "$version" != "experimental" && "$version" != incrementals* ]]; then
+        cached="$PLUGIN_CACHE_DIR/$plugin/$version/${plugin}.hpi"
+        if test -f "$cached" && test -f "$cached.sha256" && [[ "$(sha256sum "$cached" | cut -d' ' -f1)" == "$(cat "$cached.sha256")" ]]; then
+            echo "Using cached plugin: $plugin from $cached"
+            cp "$cached" "$jpi"
+            return 0
+        fi
+    fi
+
     if [[ "$version" == "latest" && -n "$JENKINS_UC_LATEST" ]]; then
         # If version-specific Update Center is available, which is the case for LTS versions,
         # use it to resolve latest versions.
@@ -99,8 +179,14 @@ doDownload() {
     fi
 
     echo "Downloading plugin: $plugin from $url"
-    retry_command curl "${CURL_OPTIONS:--sSfL}" --connect-timeout "${CURL_CONNECTION_TIMEOUT:-20}" --retry "${CURL_RETRY:-5}" --retry-delay "${CURL_RETRY_DELAY:-0}" --retry-max-time "${CURL_RETRY_MAX_TIME:-60}" "$url" -o "$jpi"
-    return $?
+    retry_command curl "${CURL_OPTIONS:--sSfL}" --connect-timeout "${CURL_CONNECTION_TIMEOUT:-20}" --retry "${CURL_RETRY:-5}" --retry-delay "${CURL_RETRY_DELAY:-0}" --retry-max-time "${CURL_RETRY_MAX_TIME:-60}" "$url" -o "$jpi" || return $?
+
+    if [[ -n "$cached" ]] && unzip -t -qq "$jpi" >/dev/null; then
+        # Write to a temporary file first, so a partially copied file is never used
+        mkdir -p "$(dirname "$cached")"
+        cp "$jpi" "$cached.$$" && mv "$cached.$$" "$cached" && sha256sum "$cached" | cut -d' ' -f1 > "$cached.sha256"
+    fi
+    return 0
 }
 
 checkIntegrity() {
@@ -112,6 +198,23 @@ checkIntegrity() {
     return $?
 }
 
+verifyChecksum() {
+    local plugin jpi expected actual
+    plugin="$1"
+    jpi="$(getArchiveFilename "$plugin")"
+
+    if [[ -z "${PLUGIN_CHECKSUMS_FILE:-}" ]]; then
+        return 0
+    fi
+    # Plugins without a known checksum are refused too
+    expected="$(grep "^${plugin%%-plugin}:\|^${plugin}:" "$PLUGIN_CHECKSUMS_FILE" | head -n 1 | cut -d: -f2)"
+    actual="$(sha256sum "$jpi" | cut -d' ' -f1)"
+    if [[ "$expected" != "$actual" ]]; then
+        echo "Plugin $plugin SHA-256 checksum '$actual' doesn't match expected '$expected'" >&2
+        return 1
+    fi
+}
+
 bundledPlugins() {
     local JENKINS_WAR=/usr/share/jenkins/jenkins.war
     if [ -f $JENKINS_WAR ]
@@ -262,9 +365,55 @@ mkdir -p {{ .JenkinsHomePath }}/scripts
 cp {{ .JenkinsScriptsVolumePath }}/*.sh {{ .JenkinsHomePath }}/scripts
 chmod +x {{ .JenkinsHomePath }}/scripts/*.sh
 
+{{- if .ImageReferencePath }}
+
+# the root filesystem is read-only, files provided by the image are copied to the writable plugins staging directory
+if [ -d {{ .ImageReferencePath }} ]; then
+  cp -rn {{ .ImageReferencePath }}/. "${REF}"/
+fi
+{{- end }}
+
 {{- $jenkinsHomePath := .JenkinsHomePath }}
 {{- $installPluginsCommand := .InstallPluginsCommand }}
 
+{{- if .UpdateCenterURL }}
+
+export JENKINS_UC="{{ .UpdateCenterURL }}"
+{{- end }}
+{{- if .UpdateCenterDownloadURL }}
+
+export JENKINS_UC_DOWNLOAD="{{ .UpdateCenterDownloadURL }}"
+{{- end }}
+{{- if .PluginCacheDir }}
+
+export PLUGIN_CACHE_DIR="{{ .PluginCacheDir }}"
+{{- end }}
+{{- if .PluginChecksums }}
+
+cat > {{ .JenkinsHomePath }}/plugin-checksums << EOF
+{{- range $name, $checksum := .PluginChecksums }}
+{{ $name }}:{{ $checksum }}
+{{- end }}
+EOF
+export PLUGIN_CHECKSUMS_FILE="{{ .JenkinsHomePath }}/plugin-checksums"
+{{- end }}
+{{- if .DependencyPlugins }}
+
+echo "Installing plugin dependencies resolved by Operator - begin"
+cat > {{ .JenkinsHomePath }}/dependency-plugins << EOF
+{{ range $index, $plugin := .DependencyPlugins }}
+{{ $plugin.Name }}:{{ $plugin.Version }}
+{{ end }}
+EOF
+
+if [[ -z "${OPENSHIFT_JENKINS_IMAGE_VERSION}" ]]; then
+  {{ $installPluginsCommand }} < {{ .JenkinsHomePath }}/dependency-plugins
+else
+  {{ $installPluginsCommand }} {{ .JenkinsHomePath }}/dependency-plugins
+fi
+echo "Installing plugin dependencies resolved by Operator - end"
+{{- end }}
+
 echo "Installing plugins required by Operator - begin"
 cat > {{ .JenkinsHomePath }}/base-plugins << EOF
 {{ range $index, $plugin := .BasePlugins }}
@@ -300,7 +449,7 @@ func buildConfigMapTypeMeta() metav1.TypeMeta {
 	}
 }
 
-func buildInitBashScript(jenkins *v1alpha2.Jenkins) (*string, error) {
+func buildInitBashScript(jenkins *v1alpha2.Jenkins, userPlugins, dependencyPlugins []v1alpha2.Plugin, pluginChecksums map[string]string) (*string, error) {
 	data := struct {
 		JenkinsHomePath          string
 		InitConfigurationPath    string
@@ -308,15 +457,39 @@ func buildInitBashScript(jenkins *v1alpha2.Jenkins) (*string, error) {
 		JenkinsScriptsVolumePath string
 		BasePlugins              []v1alpha2.Plugin
 		UserPlugins              []v1alpha2.Plugin
+		DependencyPlugins        []v1alpha2.Plugin
+		UpdateCenterURL          string
+		UpdateCenterDownloadURL  string
+		PluginCacheDir           string
+		PluginChecksums          map[string]string
+		ImageReferencePath       string
 	}{
 		JenkinsHomePath:          getJenkinsHomePath(jenkins),
 		InitConfigurationPath:    jenkinsInitConfigurationVolumePath,
 		BasePlugins:              jenkins.Spec.Master.BasePlugins,
-		UserPlugins:              jenkins.Spec.Master.Plugins,
-		InstallPluginsCommand:    installPluginsCommand,
+		UserPlugins:              userPlugins,
+		DependencyPlugins:        dependencyPlugins,
+		PluginChecksums:          pluginChecksums,
+		UpdateCenterURL:          strings.TrimSuffix(jenkins.Spec.Master.UpdateCenterURL, "/"),
+		UpdateCenterDownloadURL:  strings.TrimSuffix(jenkins.Spec.Master.UpdateCenterDownloadURL, "/"),
 		JenkinsScriptsVolumePath: JenkinsScriptsVolumePath,
 	}
 
+	if jenkins.Spec.Master.PluginCache != nil {
+		data.PluginCacheDir = pluginCacheVolumePath
+	}
+	if jenkins.Spec.Master.ReadOnlyRootFilesystem {
+		data.ImageReferencePath = imageReferencePath
+	}
+	if jenkins.Spec.Master.PluginBundle != nil {
+		data.InstallPluginsCommand = fmt.Sprintf("%s/%s", JenkinsScriptsVolumePath, installPluginsFromBundleCommand)
+	} else if jenkins.Spec.Master.PluginCache != nil || jenkins.Spec.Master.PluginChecksumVerification {
+		// the plugin cache and checksum verification are supported only by the script provided by the operator
+		data.InstallPluginsCommand = fmt.Sprintf("%s/%s", JenkinsScriptsVolumePath, installPluginsCommand)
+	} else {
+		data.InstallPluginsCommand = installPluginsCommand
+	}
+
 	output, err := render.Render(initBashTemplate, data)
 	if err != nil {
 		return nil, err
@@ -330,20 +503,31 @@ func getScriptsConfigMapName(jenkins *v1alpha2.Jenkins) string {
 }
 
 // NewScriptsConfigMap builds Kubernetes config map used to store scripts
-func NewScriptsConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) (*corev1.ConfigMap, error) {
+func NewScriptsConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, userPlugins, dependencyPlugins []v1alpha2.Plugin, pluginChecksums map[string]string) (*corev1.ConfigMap, error) {
 	meta.Name = getScriptsConfigMapName(jenkins)
 
-	initBashScript, err := buildInitBashScript(jenkins)
+	initBashScript, err := buildInitBashScript(jenkins, userPlugins, dependencyPlugins, pluginChecksums)
 	if err != nil {
 		return nil, err
 	}
 
-	return &corev1.ConfigMap{
+	veleroHookBashScript, err := buildVeleroHookBashScript()
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
 		Data: map[string]string{
 			InitScriptName:        *initBashScript,
 			installPluginsCommand: fmt.Sprintf(installPluginsBashFmt, getJenkinsHomePath(jenkins)),
+			VeleroHookScriptName:  veleroHookBashScript,
 		},
-	}, nil
+	}
+	if jenkins.Spec.Master.PluginBundle != nil {
+		configMap.Data[installPluginsFromBundleCommand] = fmt.Sprintf(installPluginsFromBundleBashFmt, getPluginBundlePath(jenkins), getJenkinsHomePath(jenkins))
+	}
+
+	return configMap, nil
 }
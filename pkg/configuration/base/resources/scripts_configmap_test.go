@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInitBashScript(t *testing.T) {
+	newJenkins := func(master v1alpha2.JenkinsMaster) *v1alpha2.Jenkins {
+		master.Containers = []v1alpha2.Container{{Name: JenkinsMasterContainerName}}
+		return &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: master}}
+	}
+
+	t.Run("default", func(t *testing.T) {
+		script, err := buildInitBashScript(newJenkins(v1alpha2.JenkinsMaster{}), nil, nil, nil)
+
+		require.NoError(t, err)
+		assert.Contains(t, *script, "  install-plugins.sh < /var/lib/jenkins/base-plugins\n")
+		assert.NotContains(t, *script, "PLUGIN_CACHE_DIR")
+		assert.NotContains(t, *script, "PLUGIN_CHECKSUMS_FILE")
+		assert.NotContains(t, *script, "/usr/share/jenkins/ref")
+	})
+	t.Run("plugin cache", func(t *testing.T) {
+		script, err := buildInitBashScript(newJenkins(v1alpha2.JenkinsMaster{PluginCache: &v1alpha2.PluginCache{}}), nil, nil, nil)
+
+		require.NoError(t, err)
+		assert.Contains(t, *script, "export PLUGIN_CACHE_DIR=\"/var/jenkins/plugin-cache\"\n")
+		assert.Contains(t, *script, "  /var/jenkins/scripts/install-plugins.sh < /var/lib/jenkins/base-plugins\n")
+	})
+	t.Run("plugin checksums", func(t *testing.T) {
+		checksums := map[string]string{"job-dsl": "def", "git": "abc"}
+
+		script, err := buildInitBashScript(newJenkins(v1alpha2.JenkinsMaster{PluginChecksumVerification: true}), nil, nil, checksums)
+
+		require.NoError(t, err)
+		assert.Contains(t, *script, "cat > /var/lib/jenkins/plugin-checksums << EOF\ngit:abc\njob-dsl:def\nEOF\n"+
+			"export PLUGIN_CHECKSUMS_FILE=\"/var/lib/jenkins/plugin-checksums\"\n")
+		assert.Contains(t, *script, "  /var/jenkins/scripts/install-plugins.sh < /var/lib/jenkins/base-plugins\n")
+	})
+	t.Run("read-only root filesystem", func(t *testing.T) {
+		script, err := buildInitBashScript(newJenkins(v1alpha2.JenkinsMaster{ReadOnlyRootFilesystem: true}), nil, nil, nil)
+
+		require.NoError(t, err)
+		assert.Contains(t, *script, "  cp -rn /usr/share/jenkins/ref/. \"${REF}\"/\n")
+	})
+}
@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// jenkinsUserID is the UID and GID of the jenkins user in the official Jenkins images
+	jenkinsUserID int64 = 1000
+
+	// capabilityAll drops all capabilities of the container
+	capabilityAll corev1.Capability = "ALL"
+)
+
+// IsRestrictedSecurityContextEnabled returns true when the Jenkins master pod should comply with the restricted
+// Pod Security Standard
+func IsRestrictedSecurityContextEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return !jenkins.Spec.Master.DisableRestrictedSecurityContext
+}
+
+// NewRestrictedPodSecurityContext returns the security context of the Jenkins master pod which runs Jenkins as
+// the jenkins user of the official Jenkins images
+func NewRestrictedPodSecurityContext() *corev1.PodSecurityContext {
+	return &corev1.PodSecurityContext{
+		RunAsNonRoot: pointer.BoolPtr(true),
+		RunAsUser:    pointer.Int64Ptr(jenkinsUserID),
+		RunAsGroup:   pointer.Int64Ptr(jenkinsUserID),
+		FSGroup:      pointer.Int64Ptr(jenkinsUserID),
+	}
+}
+
+// NewRestrictedSecurityContext returns the security context of containers of the Jenkins master pod compliant with
+// the restricted Pod Security Standard
+func NewRestrictedSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		RunAsNonRoot:             pointer.BoolPtr(true),
+		AllowPrivilegeEscalation: pointer.BoolPtr(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{capabilityAll},
+		},
+	}
+}
+
+// getSeccompAnnotations returns annotations which set the RuntimeDefault seccomp profile of the Jenkins master pod,
+// the seccomp profile set by the user in spec.master.annotations is kept
+func getSeccompAnnotations(jenkins *v1alpha2.Jenkins) map[string]string {
+	if !IsRestrictedSecurityContextEnabled(jenkins) {
+		return nil
+	}
+	if _, found := jenkins.Spec.Master.Annotations[corev1.SeccompPodAnnotationKey]; found {
+		return nil
+	}
+	return map[string]string{corev1.SeccompPodAnnotationKey: corev1.SeccompProfileRuntimeDefault}
+}
@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+)
+
+const (
+	// VeleroHookScriptName is the script name which is executed by Velero before and after the backup
+	VeleroHookScriptName = "velero-hook.sh"
+
+	veleroPreBackupHookContainerAnnotation  = "pre.hook.backup.velero.io/container"
+	veleroPreBackupHookCommandAnnotation    = "pre.hook.backup.velero.io/command"
+	veleroPreBackupHookOnErrorAnnotation    = "pre.hook.backup.velero.io/on-error"
+	veleroPostBackupHookContainerAnnotation = "post.hook.backup.velero.io/container"
+	veleroPostBackupHookCommandAnnotation   = "post.hook.backup.velero.io/command"
+)
+
+var veleroHookBashTemplate = template.Must(template.New(VeleroHookScriptName).Parse(`#!/usr/bin/env bash
+set -eo pipefail
+
+# Velero backup hook, puts Jenkins into the quiet mode before the backup
+# and cancels the quiet mode after the backup, usage: velero-hook.sh pre|post
+
+case "$1" in
+    pre) endpoint=quietDown ;;
+    post) endpoint=cancelQuietDown ;;
+    *) echo "Usage: $0 pre|post" && exit 1 ;;
+esac
+
+user="$(cat {{ .OperatorCredentialsPath }}/{{ .OperatorUserNameFile }})"
+token="$(cat {{ .OperatorCredentialsPath }}/{{ .OperatorTokenFile }})"
+
+curl -fsS -X POST -u "${user}:${token}" "http://localhost:{{ .HTTPPort }}/${endpoint}" > /dev/null
+if [[ "$1" == "pre" ]]; then
+    sync
+fi
+
+echo Done
+`))
+
+func buildVeleroHookBashScript() (string, error) {
+	data := struct {
+		OperatorCredentialsPath string
+		OperatorUserNameFile    string
+		OperatorTokenFile       string
+		HTTPPort                int32
+	}{
+		OperatorCredentialsPath: jenkinsOperatorCredentialsVolumePath,
+		OperatorUserNameFile:    OperatorCredentialsSecretUserNameKey,
+		OperatorTokenFile:       OperatorCredentialsSecretTokenKey,
+		HTTPPort:                constants.DefaultHTTPPortInt32,
+	}
+
+	return render.Render(veleroHookBashTemplate, data)
+}
+
+// getVeleroHookAnnotations returns Velero pre and post backup hook annotations for Jenkins master pod
+func getVeleroHookAnnotations(jenkins *v1alpha2.Jenkins) map[string]string {
+	script := fmt.Sprintf("%s/scripts/%s", getJenkinsHomePath(jenkins), VeleroHookScriptName)
+	preCommand, _ := json.Marshal([]string{script, "pre"})
+	postCommand, _ := json.Marshal([]string{script, "post"})
+
+	return map[string]string{
+		veleroPreBackupHookContainerAnnotation:  JenkinsMasterContainerName,
+		veleroPreBackupHookCommandAnnotation:    string(preCommand),
+		veleroPreBackupHookOnErrorAnnotation:    "Continue",
+		veleroPostBackupHookContainerAnnotation: JenkinsMasterContainerName,
+		veleroPostBackupHookCommandAnnotation:   string(postCommand),
+	}
+}
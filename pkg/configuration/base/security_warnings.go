@@ -0,0 +1,102 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultPluginSecurityWarningsInterval = 24 * time.Hour
+	pluginSecurityWarningsRetryInterval   = 10 * time.Minute
+
+	pluginSecurityWarningsFoundReason = "WarningsFound"
+	noPluginSecurityWarningsReason    = "NoWarnings"
+)
+
+// ensurePluginSecurityWarnings checks installed plugins against security warnings published in the update center
+// and returns the time after which the next check should be done
+func (r *ReconcileJenkinsBaseConfiguration) ensurePluginSecurityWarnings(jenkinsClient jenkinsclient.Jenkins) (time.Duration, error) {
+	settings := r.Configuration.Jenkins.Spec.Master.PluginSecurityWarnings
+	if settings == nil {
+		return 0, nil
+	}
+
+	interval := defaultPluginSecurityWarningsInterval
+	if settings.Interval != nil {
+		interval = settings.Interval.Duration
+	}
+	condition := configuration.GetCondition(r.Configuration.Jenkins.Status, v1alpha2.PluginSecurityWarningsCondition)
+	if condition != nil {
+		if sinceLastProbe := time.Since(condition.LastProbeTime.Time); sinceLastProbe < interval {
+			return interval - sinceLastProbe, nil
+		}
+	}
+
+	url := settings.UpdateCenterJSONURL
+	if len(url) == 0 {
+		url = getUpdateCenterJSONURL(r.Configuration.Jenkins.Spec.Master)
+	}
+	warnings, err := plugins.FetchSecurityWarnings(url, r.Configuration.GetProxySettings())
+	if err != nil {
+		return 0, err
+	}
+
+	installedPlugins, err := jenkinsClient.GetPlugins(fetchAllPlugins)
+	if err != nil {
+		return 0, stackerr.WithStack(err)
+	}
+
+	var affectedPlugins []string
+	for _, installedPlugin := range installedPlugins.Raw.Plugins {
+		plugin := plugins.Plugin{Name: installedPlugin.ShortName, Version: installedPlugin.Version}
+		for _, warning := range warnings {
+			if warning.Affects(plugin) {
+				affectedPlugins = append(affectedPlugins, fmt.Sprintf("%s %s", plugin, warning))
+			}
+		}
+	}
+	sort.Strings(affectedPlugins)
+
+	newCondition := v1alpha2.JenkinsCondition{
+		Type:   v1alpha2.PluginSecurityWarningsCondition,
+		Status: corev1.ConditionFalse,
+		Reason: noPluginSecurityWarningsReason,
+	}
+	if len(affectedPlugins) > 0 {
+		newCondition.Status = corev1.ConditionTrue
+		newCondition.Reason = pluginSecurityWarningsFoundReason
+		newCondition.Message = strings.Join(affectedPlugins, ", ")
+	}
+
+	if newCondition.Status == corev1.ConditionTrue && (condition == nil || condition.Message != newCondition.Message) {
+		message := "Installed plugins are affected by security warnings"
+		r.logger.V(log.VWarn).Info(fmt.Sprintf("%s: %s", message, newCondition.Message))
+		*r.Configuration.Notifications <- event.Event{
+			Jenkins: *r.Configuration.Jenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelWarning,
+			Reason:  reason.NewPluginSecurityWarnings(reason.OperatorSource, []string{message}, append([]string{message}, affectedPlugins...)...),
+		}
+	}
+
+	configuration.SetCondition(&r.Configuration.Jenkins.Status, newCondition)
+	if err := r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins); err != nil {
+		return 0, stackerr.WithStack(err)
+	}
+
+	return interval, nil
+}
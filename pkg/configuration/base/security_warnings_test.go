@@ -0,0 +1,122 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileJenkinsBaseConfiguration_ensurePluginSecurityWarnings(t *testing.T) {
+	log.SetupLogger(true)
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/update-center.actual.json", r.URL.Path)
+		_, _ = w.Write([]byte(`{"warnings": [{"id": "SECURITY-1234", "name": "git", "type": "plugin", "url": "https://jenkins.io/security/advisory/", "versions": [{"lastVersion": "4.2.2", "pattern": "4[.][0-2]([.-].*|)"}]}]}`))
+	}))
+	defer server.Close()
+	pluginsInJenkins := &gojenkins.Plugins{
+		Raw: &gojenkins.PluginResponse{
+			Plugins: []gojenkins.Plugin{
+				{ShortName: "git", Version: "4.2.2", Active: true, Enabled: true},
+				{ShortName: "job-dsl", Version: "1.77", Active: true, Enabled: true},
+			},
+		},
+	}
+	newJenkins := func() *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					UpdateCenterURL:        server.URL,
+					PluginSecurityWarnings: &v1alpha2.PluginSecurityWarnings{Interval: &metav1.Duration{Duration: time.Hour}},
+				},
+			},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		r := New(configuration.Configuration{Jenkins: &v1alpha2.Jenkins{}}, client.JenkinsAPIConnectionSettings{})
+
+		requeueAfter, err := r.ensurePluginSecurityWarnings(nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), requeueAfter)
+	})
+	t.Run("affected plugins", func(t *testing.T) {
+		jenkins := newJenkins()
+		fakeClient := fake.NewFakeClient(jenkins)
+		notifications := make(chan event.Event, 1)
+		r := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient, Notifications: &notifications}, client.JenkinsAPIConnectionSettings{})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(pluginsInJenkins, nil)
+
+		requeueAfter, err := r.ensurePluginSecurityWarnings(jenkinsClient)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, requeueAfter)
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, jenkins)
+		require.NoError(t, err)
+		require.Len(t, jenkins.Status.Conditions, 1)
+		condition := jenkins.Status.Conditions[0]
+		assert.Equal(t, v1alpha2.PluginSecurityWarningsCondition, condition.Type)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, "git:4.2.2 SECURITY-1234 https://jenkins.io/security/advisory/", condition.Message)
+		require.Len(t, notifications, 1)
+		notification := <-notifications
+		assert.Equal(t, v1alpha2.NotificationLevelWarning, notification.Level)
+
+		// the next check is done after interval
+		requeueAfter, err = r.ensurePluginSecurityWarnings(jenkinsClient)
+
+		require.NoError(t, err)
+		assert.True(t, requeueAfter > 0 && requeueAfter <= time.Hour)
+	})
+	t.Run("no affected plugins", func(t *testing.T) {
+		jenkins := newJenkins()
+		jenkins.Status.Conditions = []v1alpha2.JenkinsCondition{{
+			Type:          v1alpha2.PluginSecurityWarningsCondition,
+			Status:        corev1.ConditionTrue,
+			LastProbeTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		}}
+		fakeClient := fake.NewFakeClient(jenkins)
+		notifications := make(chan event.Event, 1)
+		r := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient, Notifications: &notifications}, client.JenkinsAPIConnectionSettings{})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(&gojenkins.Plugins{
+			Raw: &gojenkins.PluginResponse{Plugins: []gojenkins.Plugin{{ShortName: "git", Version: "4.3.0"}}},
+		}, nil)
+
+		_, err := r.ensurePluginSecurityWarnings(jenkinsClient)
+
+		require.NoError(t, err)
+		assert.Len(t, notifications, 0)
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, jenkins)
+		require.NoError(t, err)
+		require.Len(t, jenkins.Status.Conditions, 1)
+		assert.Equal(t, corev1.ConditionFalse, jenkins.Status.Conditions[0].Status)
+		assert.Equal(t, noPluginSecurityWarningsReason, jenkins.Status.Conditions[0].Reason)
+	})
+}
@@ -3,23 +3,45 @@ package base
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
 	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 
 	docker "github.com/docker/distribution/reference"
 	stackerr "github.com/pkg/errors"
+	"golang.org/x/net/http/httpguts"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// minTokenRotationInterval is the minimal interval of the operator API token rotation
+const minTokenRotationInterval = time.Hour
+
+// minAdminCredentialRotationInterval is the minimal interval of the operator credentials rotation
+const minAdminCredentialRotationInterval = time.Hour
+
+// maxScriptLogsLimit keeps the ConfigMap with script logs below the size limit of Kubernetes objects
+const maxScriptLogsLimit = 10
+
 var (
-	dockerImageRegexp = regexp.MustCompile(`^` + docker.TagRegexp.String() + `$`)
+	dockerImageRegexp       = regexp.MustCompile(`^` + docker.TagRegexp.String() + `$`)
+	monitoringPathRegex     = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	monitoringIntervalRegex = regexp.MustCompile(`^(|[0-9]+(ms|s|m|h))$`)
+	jenkinsTunnelRegex      = regexp.MustCompile(`^[A-Za-z0-9.-]+:[0-9]+$`)
 )
 
 // Validate validates Jenkins CR Spec.master section
@@ -44,7 +66,69 @@ func (r *ReconcileJenkinsBaseConfiguration) Validate(jenkins *v1alpha2.Jenkins)
 		}
 	}
 
-	if msg := r.validatePlugins(plugins.BasePlugins(), jenkins.Spec.Master.BasePlugins, jenkins.Spec.Master.Plugins); len(msg) > 0 {
+	userPlugins, msg, err := r.getUserPlugins()
+	if err != nil {
+		return nil, err
+	}
+	messages = append(messages, msg...)
+
+	if msg := r.validatePlugins(plugins.BasePlugins(), jenkins.Spec.Master.BasePlugins, userPlugins); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg, err := r.validatePluginBundle(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg, err := r.validatePluginCache(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := validateUpdateCenter(jenkins.Spec.Master); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := validateProxy(jenkins.Spec.Proxy); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := validateAuthProxy(jenkins.Spec.JenkinsAPISettings.AuthProxy); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := validateMonitoring(jenkins.Spec.Monitoring); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := validateScriptLogs(jenkins.Spec.ScriptLogs); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := validateSecurity(jenkins); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := validateAgents(jenkins.Spec.Agents, jenkins.Namespace); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if _, msg, err := r.resolvePluginDependencies(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg, err := r.validatePluginChecksumVerification(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := validateNotifications(jenkins.Spec.Notifications); len(msg) > 0 {
 		messages = append(messages, msg...)
 	}
 
@@ -63,10 +147,31 @@ func (r *ReconcileJenkinsBaseConfiguration) Validate(jenkins *v1alpha2.Jenkins)
 		messages = append(messages, msg...)
 	}
 
+	if jenkins.Spec.ReconcileInterval != nil && jenkins.Spec.ReconcileInterval.Duration < 0 {
+		messages = append(messages, fmt.Sprintf("spec.reconcileInterval '%s' must not be negative", jenkins.Spec.ReconcileInterval.Duration))
+	}
+
 	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy && jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.ServiceAccountAuthorizationStrategy {
 		messages = append(messages, fmt.Sprintf("unrecognized '%s' spec.jenkinsAPISettings.authorizationStrategy", jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy))
 	}
 
+	if interval := jenkins.Spec.JenkinsAPISettings.TokenRotationInterval; interval != nil && interval.Duration < minTokenRotationInterval {
+		messages = append(messages, fmt.Sprintf("spec.jenkinsAPISettings.tokenRotationInterval '%s' must be at least %s", interval.Duration, minTokenRotationInterval))
+	}
+
+	if rateLimit := jenkins.Spec.JenkinsAPISettings.RateLimit; rateLimit != nil && (rateLimit.RequestsPerSecond < 0 || rateLimit.Burst < 0) {
+		messages = append(messages, "spec.jenkinsAPISettings.rateLimit requestsPerSecond and burst must not be negative")
+	}
+
+	if sshCLI := jenkins.Spec.JenkinsAPISettings.SSHCLI; sshCLI != nil {
+		if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy {
+			messages = append(messages, fmt.Sprintf("spec.jenkinsAPISettings.sshCLI requires '%s' spec.jenkinsAPISettings.authorizationStrategy", v1alpha2.CreateUserAuthorizationStrategy))
+		}
+		if sshCLI.Port < 1 || sshCLI.Port > 65535 {
+			messages = append(messages, fmt.Sprintf("spec.jenkinsAPISettings.sshCLI.port '%d' must be between 1 and 65535", sshCLI.Port))
+		}
+	}
+
 	return messages, nil
 }
 
@@ -353,6 +458,385 @@ func (r *ReconcileJenkinsBaseConfiguration) validatePlugins(requiredBasePlugins
 	return messages
 }
 
+func (r *ReconcileJenkinsBaseConfiguration) validatePluginBundle() ([]string, error) {
+	bundle := r.Configuration.Jenkins.Spec.Master.PluginBundle
+	if bundle == nil {
+		return nil, nil
+	}
+
+	if (len(bundle.PersistentVolumeClaimName) > 0) == (len(bundle.Image) > 0) {
+		return []string{"spec.master.pluginBundle must set exactly one of persistentVolumeClaimName or image"}, nil
+	}
+
+	var messages []string
+	if r.Configuration.Jenkins.Spec.Master.DynamicPluginInstallation {
+		messages = append(messages, "spec.master.dynamicPluginInstallation can't be used together with spec.master.pluginBundle")
+	}
+	if len(bundle.Image) > 0 {
+		if !dockerImageRegexp.MatchString(bundle.Image) && !docker.ReferenceRegexp.MatchString(bundle.Image) {
+			messages = append(messages, fmt.Sprintf("spec.master.pluginBundle.image '%s' is invalid", bundle.Image))
+		}
+		return messages, nil
+	}
+
+	persistentVolumeClaim := &corev1.PersistentVolumeClaim{}
+	namespaceName := types.NamespacedName{Namespace: r.Configuration.Jenkins.Namespace, Name: bundle.PersistentVolumeClaimName}
+	err := r.Client.Get(context.TODO(), namespaceName, persistentVolumeClaim)
+	if err != nil && apierrors.IsNotFound(err) {
+		messages = append(messages, fmt.Sprintf("PersistentVolumeClaim '%s' configured in spec.master.pluginBundle not found", bundle.PersistentVolumeClaimName))
+	} else if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	return messages, nil
+}
+
+func (r *ReconcileJenkinsBaseConfiguration) validatePluginCache() ([]string, error) {
+	pluginCache := r.Configuration.Jenkins.Spec.Master.PluginCache
+	if pluginCache == nil {
+		return nil, nil
+	}
+
+	var messages []string
+	if r.Configuration.Jenkins.Spec.Master.PluginBundle != nil {
+		messages = append(messages, "spec.master.pluginCache can't be used together with spec.master.pluginBundle")
+	}
+	if len(pluginCache.PersistentVolumeClaimName) == 0 {
+		return messages, nil
+	}
+
+	persistentVolumeClaim := &corev1.PersistentVolumeClaim{}
+	namespaceName := types.NamespacedName{Namespace: r.Configuration.Jenkins.Namespace, Name: pluginCache.PersistentVolumeClaimName}
+	err := r.Client.Get(context.TODO(), namespaceName, persistentVolumeClaim)
+	if err != nil && apierrors.IsNotFound(err) {
+		messages = append(messages, fmt.Sprintf("PersistentVolumeClaim '%s' configured in spec.master.pluginCache not found", pluginCache.PersistentVolumeClaimName))
+	} else if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	return messages, nil
+}
+
+func (r *ReconcileJenkinsBaseConfiguration) validatePluginChecksumVerification() ([]string, error) {
+	master := r.Configuration.Jenkins.Spec.Master
+	if !master.PluginChecksumVerification {
+		return nil, nil
+	}
+
+	var messages []string
+	// transitive dependencies downloaded by the installation script aren't known to the operator
+	if master.PluginBundle == nil && master.PluginDependencyResolution == nil {
+		messages = append(messages, "spec.master.pluginChecksumVerification requires spec.master.pluginDependencyResolution to verify plugin dependencies")
+	}
+
+	_, msg, err := r.resolvePluginChecksums()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(messages, msg...), nil
+}
+
+func validateUpdateCenter(master v1alpha2.JenkinsMaster) []string {
+	var messages []string
+	urls := [][2]string{
+		{"spec.master.updateCenterURL", master.UpdateCenterURL},
+		{"spec.master.updateCenterDownloadURL", master.UpdateCenterDownloadURL},
+	}
+	if master.PluginDependencyResolution != nil {
+		urls = append(urls, [2]string{"spec.master.pluginDependencyResolution.pluginVersionsURL", master.PluginDependencyResolution.PluginVersionsURL})
+	}
+	for _, nameAndValue := range urls {
+		name, value := nameAndValue[0], nameAndValue[1]
+		if len(value) == 0 {
+			continue
+		}
+		parsedURL, err := url.Parse(value)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+			messages = append(messages, fmt.Sprintf("%s '%s' must be a valid http or https URL", name, value))
+		}
+	}
+
+	return messages
+}
+
+func validateProxy(proxy *v1alpha2.Proxy) []string {
+	if proxy == nil {
+		return nil
+	}
+
+	var messages []string
+	for _, nameAndValue := range [][2]string{{"spec.proxy.httpProxy", proxy.HTTPProxy}, {"spec.proxy.httpsProxy", proxy.HTTPSProxy}} {
+		name, value := nameAndValue[0], nameAndValue[1]
+		if len(value) == 0 {
+			continue
+		}
+		parsedURL, err := url.Parse(value)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+			messages = append(messages, fmt.Sprintf("%s '%s' must be a valid http or https URL", name, value))
+		}
+	}
+
+	return messages
+}
+
+func validateAuthProxy(authProxy *v1alpha2.AuthProxy) []string {
+	if authProxy == nil {
+		return nil
+	}
+
+	var messages []string
+	for i, header := range authProxy.Headers {
+		name := fmt.Sprintf("spec.jenkinsAPISettings.authProxy.headers[%d]", i)
+		if !httpguts.ValidHeaderFieldName(header.Name) {
+			messages = append(messages, fmt.Sprintf("%s.name '%s' is not a valid HTTP header name", name, header.Name))
+		}
+		if !httpguts.ValidHeaderFieldValue(header.Prefix) {
+			messages = append(messages, fmt.Sprintf("%s.prefix '%s' is not a valid HTTP header value", name, header.Prefix))
+		}
+		if len(header.ValueSecretKeySelector.Name) == 0 || len(header.ValueSecretKeySelector.Key) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.valueSecretKeySelector secret name and key must be set", name))
+		}
+	}
+
+	return messages
+}
+
+func validateMonitoring(monitoring *v1alpha2.Monitoring) []string {
+	if monitoring == nil || !monitoring.Enabled {
+		return nil
+	}
+
+	var messages []string
+	if !monitoringPathRegex.MatchString(monitoring.Path) {
+		messages = append(messages, fmt.Sprintf("spec.monitoring.path '%s' must contain only letters, digits, '_', '.' and '-'", monitoring.Path))
+	}
+	if !monitoringIntervalRegex.MatchString(monitoring.Interval) {
+		messages = append(messages, fmt.Sprintf("spec.monitoring.interval '%s' must be a duration, e.g. 30s", monitoring.Interval))
+	}
+
+	return messages
+}
+
+func validateScriptLogs(scriptLogs *v1alpha2.ScriptLogs) []string {
+	if scriptLogs == nil {
+		return nil
+	}
+	if scriptLogs.Limit < 0 || scriptLogs.Limit > maxScriptLogsLimit {
+		return []string{fmt.Sprintf("spec.scriptLogs.limit %d must be between 0 and %d", scriptLogs.Limit, maxScriptLogsLimit)}
+	}
+
+	return nil
+}
+
+func validateAgents(agents *v1alpha2.Agents, jenkinsNamespace string) []string {
+	if agents == nil {
+		return nil
+	}
+
+	var messages []string
+	if len(agents.Namespace) > 0 {
+		if errs := validation.IsDNS1123Label(agents.Namespace); len(errs) > 0 {
+			messages = append(messages, fmt.Sprintf("spec.agents.namespace '%s' is invalid: %s", agents.Namespace, strings.Join(errs, ", ")))
+		}
+		if agents.Namespace == jenkinsNamespace {
+			messages = append(messages, fmt.Sprintf("spec.agents.namespace '%s' must be different from the namespace of the Jenkins CR", agents.Namespace))
+		}
+		if agents.KubernetesCloud != nil && len(agents.KubernetesCloud.Namespace) > 0 && agents.KubernetesCloud.Namespace != agents.Namespace {
+			messages = append(messages, fmt.Sprintf("spec.agents.kubernetesCloud.namespace '%s' conflicts with spec.agents.namespace '%s'", agents.KubernetesCloud.Namespace, agents.Namespace))
+		}
+	}
+	if agents.KubernetesCloud == nil {
+		return messages
+	}
+
+	kubernetesCloud := agents.KubernetesCloud
+	if len(kubernetesCloud.JenkinsURL) > 0 {
+		parsedURL, err := url.Parse(kubernetesCloud.JenkinsURL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 ||
+			strings.ContainsAny(kubernetesCloud.JenkinsURL, "\"$\\ ") {
+			messages = append(messages, fmt.Sprintf("spec.agents.kubernetesCloud.jenkinsURL '%s' must be a valid http or https URL", kubernetesCloud.JenkinsURL))
+		}
+	}
+	if len(kubernetesCloud.JenkinsTunnel) > 0 && !jenkinsTunnelRegex.MatchString(kubernetesCloud.JenkinsTunnel) {
+		messages = append(messages, fmt.Sprintf("spec.agents.kubernetesCloud.jenkinsTunnel '%s' must be in host:port format", kubernetesCloud.JenkinsTunnel))
+	}
+	if len(kubernetesCloud.Namespace) > 0 {
+		if errs := validation.IsDNS1123Label(kubernetesCloud.Namespace); len(errs) > 0 {
+			messages = append(messages, fmt.Sprintf("spec.agents.kubernetesCloud.namespace '%s' is invalid: %s", kubernetesCloud.Namespace, strings.Join(errs, ", ")))
+		}
+	}
+	if len(kubernetesCloud.ServiceAccountName) > 0 {
+		if errs := validation.IsDNS1123Subdomain(kubernetesCloud.ServiceAccountName); len(errs) > 0 {
+			messages = append(messages, fmt.Sprintf("spec.agents.kubernetesCloud.serviceAccountName '%s' is invalid: %s", kubernetesCloud.ServiceAccountName, strings.Join(errs, ", ")))
+		}
+	}
+	if kubernetesCloud.ContainerCap < 0 {
+		messages = append(messages, fmt.Sprintf("spec.agents.kubernetesCloud.containerCap %d can't be negative", kubernetesCloud.ContainerCap))
+	}
+	if kubernetesCloud.MaxRequestsPerHost < 0 {
+		messages = append(messages, fmt.Sprintf("spec.agents.kubernetesCloud.maxRequestsPerHost %d can't be negative", kubernetesCloud.MaxRequestsPerHost))
+	}
+
+	return messages
+}
+
+func validateSecurity(jenkins *v1alpha2.Jenkins) []string {
+	security := jenkins.Spec.Security
+	if security == nil {
+		return nil
+	}
+
+	var messages []string
+	if rotation := security.AdminCredentialRotation; rotation != nil {
+		if rotation.Interval.Duration < minAdminCredentialRotationInterval {
+			messages = append(messages, fmt.Sprintf("spec.security.adminCredentialRotation.interval '%s' must be at least %s", rotation.Interval.Duration, minAdminCredentialRotationInterval))
+		}
+		if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy {
+			messages = append(messages, fmt.Sprintf("spec.security.adminCredentialRotation requires the '%s' spec.jenkinsAPISettings.authorizationStrategy", v1alpha2.CreateUserAuthorizationStrategy))
+		}
+	}
+	if provider := security.KubernetesCredentialsProvider; provider != nil && len(provider.LabelSelector) > 0 {
+		if _, err := labels.Parse(provider.LabelSelector); err != nil {
+			messages = append(messages, fmt.Sprintf("spec.security.kubernetesCredentialsProvider.labelSelector '%s' is invalid: %s", provider.LabelSelector, err))
+		} else if strings.ContainsAny(provider.LabelSelector, " \t\n") {
+			messages = append(messages, fmt.Sprintf("spec.security.kubernetesCredentialsProvider.labelSelector '%s' can't contain whitespaces", provider.LabelSelector))
+		}
+	}
+	if realm := security.Realm; realm != nil {
+		var realms []string
+		if realm.LDAP != nil {
+			realms = append(realms, "ldap")
+		}
+		if realm.OIDC != nil {
+			realms = append(realms, "oidc")
+		}
+		if len(realms) > 1 {
+			messages = append(messages, fmt.Sprintf("spec.security.realm can contain only one of %s", strings.Join(realms, ", ")))
+		}
+		// Jenkins doesn't accept API token of the operator user who doesn't exist in the external security realm
+		for _, name := range realms {
+			if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.ServiceAccountAuthorizationStrategy {
+				messages = append(messages, fmt.Sprintf("spec.security.realm.%s requires the '%s' spec.jenkinsAPISettings.authorizationStrategy", name, v1alpha2.ServiceAccountAuthorizationStrategy))
+			}
+		}
+	}
+	if security.FIPS {
+		messages = append(messages, validateFIPS(jenkins)...)
+	}
+	if resources.IsKubernetesCredentialsProviderEnabled(jenkins) && security.CredentialsSync != nil && security.CredentialsSync.Enabled {
+		messages = append(messages, "spec.security.credentialsSync and spec.security.kubernetesCredentialsProvider can't be enabled together")
+	}
+	if authorization := security.Authorization; authorization != nil {
+		messages = append(messages, validateAuthorizationRoles("spec.security.authorization.globalRoles", authorization.GlobalRoles, false)...)
+		messages = append(messages, validateAuthorizationRoles("spec.security.authorization.projectRoles", authorization.ProjectRoles, true)...)
+	}
+
+	return messages
+}
+
+// validateFIPS refuses configurations known to be non-compliant in FIPS mode, plugins are checked only by names
+// declared in the Jenkins CR, not by their dependencies
+func validateFIPS(jenkins *v1alpha2.Jenkins) []string {
+	var messages []string
+	for field, declaredPlugins := range map[string][]v1alpha2.Plugin{
+		"spec.master.basePlugins": jenkins.Spec.Master.BasePlugins,
+		"spec.master.plugins":     jenkins.Spec.Master.Plugins,
+	} {
+		for _, plugin := range declaredPlugins {
+			if reason := plugins.FIPSNonCompliantReason(plugin.Name); len(reason) > 0 {
+				messages = append(messages, fmt.Sprintf("%s '%s' isn't FIPS compliant, %s", field, plugin.Name, reason))
+			}
+		}
+	}
+	sort.Strings(messages)
+
+	// backup and restore are performed by containers of the Jenkins master pod, so their images are checked here too
+	for _, container := range jenkins.Spec.Master.Containers {
+		if isAlpineImage(container.Image) {
+			messages = append(messages, fmt.Sprintf("spec.master.containers[%s].image '%s' isn't FIPS compliant, Alpine Linux doesn't provide FIPS validated cryptography", container.Name, container.Image))
+		}
+	}
+	if isAlpineImage(jenkins.Spec.SeedAgent.Image) {
+		messages = append(messages, fmt.Sprintf("spec.seedAgent.image '%s' isn't FIPS compliant, Alpine Linux doesn't provide FIPS validated cryptography", jenkins.Spec.SeedAgent.Image))
+	}
+	if jenkins.Spec.JenkinsAPISettings.SSHCLI != nil {
+		messages = append(messages, "spec.jenkinsAPISettings.sshCLI isn't FIPS compliant, the operator user authenticates with the Ed25519 key")
+	}
+
+	return messages
+}
+
+func isAlpineImage(image string) bool {
+	return strings.Contains(strings.ToLower(image), "alpine")
+}
+
+var authorizationPermissionRegexp = regexp.MustCompile(`^[^/]+/[^/]+$`)
+
+// validateAuthorizationRoles validates roles of the role-strategy plugin, only project roles have the pattern of items
+func validateAuthorizationRoles(field string, roles []v1alpha2.AuthorizationRole, projectRoles bool) []string {
+	var messages []string
+	names := map[string]bool{}
+	for _, role := range roles {
+		if len(role.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("%s name can't be empty", field))
+		} else if names[role.Name] {
+			messages = append(messages, fmt.Sprintf("%s '%s' is duplicated", field, role.Name))
+		} else if !projectRoles && role.Name == constants.OperatorName {
+			messages = append(messages, fmt.Sprintf("%s '%s' is reserved for the operator", field, role.Name))
+		}
+		names[role.Name] = true
+
+		if projectRoles && len(role.Pattern) == 0 {
+			messages = append(messages, fmt.Sprintf("%s '%s' pattern can't be empty", field, role.Name))
+		} else if projectRoles {
+			if _, err := regexp.Compile(role.Pattern); err != nil {
+				messages = append(messages, fmt.Sprintf("%s '%s' pattern '%s' is invalid: %s", field, role.Name, role.Pattern, err))
+			}
+		} else if len(role.Pattern) > 0 {
+			messages = append(messages, fmt.Sprintf("%s '%s' can't have pattern, it's supported only by project roles", field, role.Name))
+		}
+
+		if len(role.Permissions) == 0 {
+			messages = append(messages, fmt.Sprintf("%s '%s' permissions can't be empty", field, role.Name))
+		}
+		for _, permission := range role.Permissions {
+			if !authorizationPermissionRegexp.MatchString(permission) {
+				messages = append(messages, fmt.Sprintf("%s '%s' permission '%s' is invalid, it must be in the <group>/<name> format, e.g. Job/Build", field, role.Name, permission))
+			}
+		}
+	}
+
+	return messages
+}
+
+func validateNotifications(notifications []v1alpha2.Notification) []string {
+	var messages []string
+	for _, notification := range notifications {
+		if len(notification.Template) > 0 {
+			if _, err := provider.ParseTemplate(notification.Template); err != nil {
+				messages = append(messages, fmt.Sprintf("Notification '%s' has invalid template: %s", notification.Name, err))
+			}
+		}
+		if notification.Filter == nil {
+			continue
+		}
+		for _, phase := range notification.Filter.Phases {
+			if phase != string(event.PhaseBase) && phase != string(event.PhaseUser) {
+				messages = append(messages, fmt.Sprintf("Notification '%s' filter has unrecognized phase '%s', must be '%s' or '%s'",
+					notification.Name, phase, event.PhaseBase, event.PhaseUser))
+			}
+		}
+		if notification.Filter.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(notification.Filter.Selector); err != nil {
+				messages = append(messages, fmt.Sprintf("Notification '%s' filter has invalid selector: %s", notification.Name, err))
+			}
+		}
+	}
+
+	return messages
+}
+
 func (r *ReconcileJenkinsBaseConfiguration) verifyBasePlugins(requiredBasePlugins []plugins.Plugin, basePlugins []v1alpha2.Plugin) []string {
 	var messages []string
 
@@ -406,5 +890,69 @@ func (r *ReconcileJenkinsBaseConfiguration) validateCustomization(customization
 		}
 	}
 
+	if _, err := groovy.SortConfigurations(customization.Configurations); err != nil {
+		messages = append(messages, fmt.Sprintf("%s.configurations are invalid: %s", name, err))
+	}
+
+	messages = append(messages, validateScriptExecution(customization.Execution, name+".execution")...)
+	if customization.Template != nil {
+		templateMessages, err := r.validateCustomizationTemplate(*customization.Template, name+".template")
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, templateMessages...)
+	}
+	for index, configMapRef := range customization.Configurations {
+		messages = append(messages, validateScriptExecution(configMapRef.Execution, fmt.Sprintf("%s.configurations[%d].execution", name, index))...)
+	}
+
 	return messages, nil
 }
+
+func (r *ReconcileJenkinsBaseConfiguration) validateCustomizationTemplate(template v1alpha2.CustomizationTemplate, name string) ([]string, error) {
+	var messages []string
+	namespace := r.Configuration.Jenkins.ObjectMeta.Namespace
+	for index, source := range template.ValuesFrom {
+		if (len(source.SecretName) > 0) == (len(source.ConfigMapName) > 0) {
+			messages = append(messages, fmt.Sprintf("%s.valuesFrom[%d] must set exactly one of secretName or configMapName", name, index))
+			continue
+		}
+
+		if len(source.SecretName) > 0 {
+			err := r.Client.Get(context.TODO(), types.NamespacedName{Name: source.SecretName, Namespace: namespace}, &corev1.Secret{})
+			if err != nil && apierrors.IsNotFound(err) {
+				messages = append(messages, fmt.Sprintf("Secret '%s' configured in %s.valuesFrom[%d] not found", source.SecretName, name, index))
+			} else if err != nil {
+				return nil, stackerr.WithStack(err)
+			}
+		} else {
+			err := r.Client.Get(context.TODO(), types.NamespacedName{Name: source.ConfigMapName, Namespace: namespace}, &corev1.ConfigMap{})
+			if err != nil && apierrors.IsNotFound(err) {
+				messages = append(messages, fmt.Sprintf("ConfigMap '%s' configured in %s.valuesFrom[%d] not found", source.ConfigMapName, name, index))
+			} else if err != nil {
+				return nil, stackerr.WithStack(err)
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+func validateScriptExecution(execution *v1alpha2.ScriptExecution, name string) []string {
+	var messages []string
+	if execution == nil {
+		return messages
+	}
+
+	if execution.Timeout != nil && execution.Timeout.Duration <= 0 {
+		messages = append(messages, fmt.Sprintf("%s.timeout must be positive", name))
+	}
+	if execution.Retries < 0 {
+		messages = append(messages, fmt.Sprintf("%s.retries can't be negative", name))
+	}
+	if execution.RetryInterval != nil && execution.RetryInterval.Duration < 0 {
+		messages = append(messages, fmt.Sprintf("%s.retryInterval can't be negative", name))
+	}
+
+	return messages
+}
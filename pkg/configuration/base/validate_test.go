@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/client"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -841,6 +843,300 @@ func TestValidateCustomization(t *testing.T) {
 
 		assert.Equal(t, got, []string{"ConfigMap 'configmap-name' configured in spec.groovyScripts.configurations[0] not found"})
 	})
+	t.Run("circular dependency between configmaps", func(t *testing.T) {
+		customization := v1alpha2.Customization{
+			Configurations: []v1alpha2.ConfigMapRef{
+				{Name: configMapName, DependsOn: []string{"other-configmap"}},
+				{Name: "other-configmap", DependsOn: []string{configMapName}},
+			},
+		}
+		fakeClient := fake.NewFakeClient()
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: jenkins,
+			Client:  fakeClient,
+		}, client.JenkinsAPIConnectionSettings{})
+		for _, name := range []string{configMapName, "other-configmap"} {
+			err := fakeClient.Create(context.TODO(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace}})
+			require.NoError(t, err)
+		}
+
+		got, err := baseReconcileLoop.validateCustomization(customization, "spec.groovyScripts")
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, got, []string{"spec.groovyScripts.configurations are invalid: circular dependency between ConfigMaps: configmap-name, other-configmap"})
+	})
+	t.Run("invalid template values sources", func(t *testing.T) {
+		customization := v1alpha2.Customization{
+			Configurations: []v1alpha2.ConfigMapRef{{Name: configMapName}},
+			Template: &v1alpha2.CustomizationTemplate{
+				ValuesFrom: []v1alpha2.TemplateValuesSource{
+					{},
+					{SecretName: secretName, ConfigMapName: configMapName},
+					{SecretName: "missing-secret"},
+					{ConfigMapName: "missing-configmap"},
+					{ConfigMapName: configMapName},
+				},
+			},
+		}
+		fakeClient := fake.NewFakeClient()
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: jenkins,
+			Client:  fakeClient,
+		}, client.JenkinsAPIConnectionSettings{})
+		err := fakeClient.Create(context.TODO(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: configMapName, Namespace: defaultNamespace}})
+		require.NoError(t, err)
+
+		got, err := baseReconcileLoop.validateCustomization(customization, "spec.groovyScripts")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"spec.groovyScripts.template.valuesFrom[0] must set exactly one of secretName or configMapName",
+			"spec.groovyScripts.template.valuesFrom[1] must set exactly one of secretName or configMapName",
+			"Secret 'missing-secret' configured in spec.groovyScripts.template.valuesFrom[2] not found",
+			"ConfigMap 'missing-configmap' configured in spec.groovyScripts.template.valuesFrom[3] not found",
+		}, got)
+	})
+}
+
+func TestValidatePluginBundle(t *testing.T) {
+	newBaseReconcileLoop := func(bundle *v1alpha2.PluginBundle, objects ...runtime.Object) *ReconcileJenkinsBaseConfiguration {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace},
+			Spec:       v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{PluginBundle: bundle}},
+		}
+		return New(configuration.Configuration{Jenkins: jenkins, Client: fake.NewFakeClient(objects...)}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		got, err := newBaseReconcileLoop(nil).validatePluginBundle()
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("both sources set", func(t *testing.T) {
+		got, err := newBaseReconcileLoop(&v1alpha2.PluginBundle{PersistentVolumeClaimName: "plugins", Image: "plugins:1.0"}).validatePluginBundle()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"spec.master.pluginBundle must set exactly one of persistentVolumeClaimName or image"}, got)
+	})
+	t.Run("valid image", func(t *testing.T) {
+		got, err := newBaseReconcileLoop(&v1alpha2.PluginBundle{Image: "registry.example.com/jenkins/plugins:1.0"}).validatePluginBundle()
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("persistent volume claim exists", func(t *testing.T) {
+		persistentVolumeClaim := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "plugins", Namespace: defaultNamespace}}
+
+		got, err := newBaseReconcileLoop(&v1alpha2.PluginBundle{PersistentVolumeClaimName: "plugins"}, persistentVolumeClaim).validatePluginBundle()
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("persistent volume claim not found", func(t *testing.T) {
+		got, err := newBaseReconcileLoop(&v1alpha2.PluginBundle{PersistentVolumeClaimName: "plugins"}).validatePluginBundle()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"PersistentVolumeClaim 'plugins' configured in spec.master.pluginBundle not found"}, got)
+	})
+}
+
+func TestValidatePluginCache(t *testing.T) {
+	newBaseReconcileLoop := func(master v1alpha2.JenkinsMaster, objects ...runtime.Object) *ReconcileJenkinsBaseConfiguration {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace},
+			Spec:       v1alpha2.JenkinsSpec{Master: master},
+		}
+		return New(configuration.Configuration{Jenkins: jenkins, Client: fake.NewFakeClient(objects...)}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("managed by operator", func(t *testing.T) {
+		got, err := newBaseReconcileLoop(v1alpha2.JenkinsMaster{PluginCache: &v1alpha2.PluginCache{}}).validatePluginCache()
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("persistent volume claim not found", func(t *testing.T) {
+		got, err := newBaseReconcileLoop(v1alpha2.JenkinsMaster{PluginCache: &v1alpha2.PluginCache{PersistentVolumeClaimName: "cache"}}).validatePluginCache()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"PersistentVolumeClaim 'cache' configured in spec.master.pluginCache not found"}, got)
+	})
+	t.Run("used together with plugin bundle", func(t *testing.T) {
+		persistentVolumeClaim := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: defaultNamespace}}
+		master := v1alpha2.JenkinsMaster{
+			PluginCache:  &v1alpha2.PluginCache{PersistentVolumeClaimName: "cache"},
+			PluginBundle: &v1alpha2.PluginBundle{Image: "plugins:1.0"},
+		}
+
+		got, err := newBaseReconcileLoop(master, persistentVolumeClaim).validatePluginCache()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"spec.master.pluginCache can't be used together with spec.master.pluginBundle"}, got)
+	})
+}
+
+func TestValidateUpdateCenter(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		assert.Nil(t, validateUpdateCenter(v1alpha2.JenkinsMaster{}))
+	})
+	t.Run("happy", func(t *testing.T) {
+		master := v1alpha2.JenkinsMaster{
+			UpdateCenterURL:            "https://updates.example.com",
+			UpdateCenterDownloadURL:    "http://mirror.example.com/jenkins",
+			PluginDependencyResolution: &v1alpha2.PluginDependencyResolution{},
+		}
+
+		assert.Nil(t, validateUpdateCenter(master))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		master := v1alpha2.JenkinsMaster{
+			UpdateCenterURL:            "updates.example.com",
+			UpdateCenterDownloadURL:    "ftp://mirror.example.com",
+			PluginDependencyResolution: &v1alpha2.PluginDependencyResolution{PluginVersionsURL: "https://"},
+		}
+
+		assert.Equal(t, []string{
+			"spec.master.updateCenterURL 'updates.example.com' must be a valid http or https URL",
+			"spec.master.updateCenterDownloadURL 'ftp://mirror.example.com' must be a valid http or https URL",
+			"spec.master.pluginDependencyResolution.pluginVersionsURL 'https://' must be a valid http or https URL",
+		}, validateUpdateCenter(master))
+	})
+}
+
+func TestValidateProxy(t *testing.T) {
+	assert.Nil(t, validateProxy(nil))
+	assert.Nil(t, validateProxy(&v1alpha2.Proxy{HTTPSProxy: "http://proxy.example.com:3128", NoProxy: "example.com"}))
+	assert.Equal(t, []string{
+		"spec.proxy.httpProxy 'proxy.example.com:3128' must be a valid http or https URL",
+		"spec.proxy.httpsProxy 'socks5://proxy.example.com' must be a valid http or https URL",
+	}, validateProxy(&v1alpha2.Proxy{HTTPProxy: "proxy.example.com:3128", HTTPSProxy: "socks5://proxy.example.com"}))
+}
+
+func TestValidateAuthProxy(t *testing.T) {
+	assert.Nil(t, validateAuthProxy(nil))
+	valueSecretKeySelector := v1alpha2.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth2-proxy"}, Key: "token"}
+	assert.Nil(t, validateAuthProxy(&v1alpha2.AuthProxy{Headers: []v1alpha2.AuthProxyHeader{
+		{Name: "Authorization", Prefix: "Bearer ", ValueSecretKeySelector: valueSecretKeySelector},
+	}}))
+	assert.Equal(t, []string{
+		"spec.jenkinsAPISettings.authProxy.headers[0].name 'X Token' is not a valid HTTP header name",
+		"spec.jenkinsAPISettings.authProxy.headers[1].valueSecretKeySelector secret name and key must be set",
+	}, validateAuthProxy(&v1alpha2.AuthProxy{Headers: []v1alpha2.AuthProxyHeader{
+		{Name: "X Token", ValueSecretKeySelector: valueSecretKeySelector},
+		{Name: "X-Token", ValueSecretKeySelector: v1alpha2.SecretKeySelector{Key: "token"}},
+	}}))
+}
+
+func TestValidateMonitoring(t *testing.T) {
+	assert.Nil(t, validateMonitoring(nil))
+	assert.Nil(t, validateMonitoring(&v1alpha2.Monitoring{Path: "/invalid/"}))
+	assert.Nil(t, validateMonitoring(&v1alpha2.Monitoring{Enabled: true, Path: "prometheus", Interval: "30s"}))
+	assert.Equal(t, []string{
+		"spec.monitoring.path 'metrics/jenkins' must contain only letters, digits, '_', '.' and '-'",
+		"spec.monitoring.interval '30' must be a duration, e.g. 30s",
+	}, validateMonitoring(&v1alpha2.Monitoring{Enabled: true, Path: "metrics/jenkins", Interval: "30"}))
+}
+
+func TestValidateScriptLogs(t *testing.T) {
+	assert.Nil(t, validateScriptLogs(nil))
+	assert.Nil(t, validateScriptLogs(&v1alpha2.ScriptLogs{Limit: 10}))
+	assert.Equal(t, []string{"spec.scriptLogs.limit 11 must be between 0 and 10"}, validateScriptLogs(&v1alpha2.ScriptLogs{Limit: 11}))
+}
+
+func TestValidateAgents(t *testing.T) {
+	assert.Nil(t, validateAgents(nil, "default"))
+	assert.Nil(t, validateAgents(&v1alpha2.Agents{KubernetesCloud: &v1alpha2.KubernetesCloud{
+		JenkinsURL:         "https://jenkins.example.com/jenkins",
+		JenkinsTunnel:      "jenkins-agents.example.com:50000",
+		Namespace:          "agents",
+		ServiceAccountName: "jenkins-agent",
+		ContainerCap:       10,
+		MaxRequestsPerHost: 64,
+	}}, "default"))
+	assert.Equal(t, []string{
+		"spec.agents.kubernetesCloud.jenkinsURL 'http://jenkins\"' must be a valid http or https URL",
+		"spec.agents.kubernetesCloud.jenkinsTunnel 'jenkins-agents' must be in host:port format",
+		"spec.agents.kubernetesCloud.namespace 'Agents' is invalid: a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+		"spec.agents.kubernetesCloud.containerCap -1 can't be negative",
+	}, validateAgents(&v1alpha2.Agents{KubernetesCloud: &v1alpha2.KubernetesCloud{
+		JenkinsURL:    "http://jenkins\"",
+		JenkinsTunnel: "jenkins-agents",
+		Namespace:     "Agents",
+		ContainerCap:  -1,
+	}}, "default"))
+	assert.Nil(t, validateAgents(&v1alpha2.Agents{
+		Namespace:       "jenkins-agents",
+		KubernetesCloud: &v1alpha2.KubernetesCloud{Namespace: "jenkins-agents"},
+	}, "default"))
+	assert.Equal(t, []string{
+		"spec.agents.namespace 'default' must be different from the namespace of the Jenkins CR",
+		"spec.agents.kubernetesCloud.namespace 'agents' conflicts with spec.agents.namespace 'default'",
+	}, validateAgents(&v1alpha2.Agents{
+		Namespace:       "default",
+		KubernetesCloud: &v1alpha2.KubernetesCloud{Namespace: "agents"},
+	}, "default"))
+}
+
+func TestValidateNotifications(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		notifications := []v1alpha2.Notification{
+			{Name: "slack", Template: "{{ .Title }}: {{ join .Messages \", \" }}"},
+			{Name: "pagerduty", Filter: &v1alpha2.NotificationFilter{
+				Phases:   []string{"base", "user"},
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "production"}},
+			}},
+		}
+
+		assert.Nil(t, validateNotifications(notifications))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		notifications := []v1alpha2.Notification{
+			{Name: "slack", Template: "{{ .Name "},
+			{Name: "pagerduty", Filter: &v1alpha2.NotificationFilter{
+				Phases: []string{"backup"},
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "environment", Operator: "invalid"},
+				}},
+			}},
+		}
+
+		messages := validateNotifications(notifications)
+
+		assert.Len(t, messages, 3)
+		assert.Contains(t, messages[0], "Notification 'slack' has invalid template")
+		assert.Equal(t, "Notification 'pagerduty' filter has unrecognized phase 'backup', must be 'base' or 'user'", messages[1])
+		assert.Contains(t, messages[2], "Notification 'pagerduty' filter has invalid selector")
+	})
+}
+
+func TestValidateScriptExecution(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		assert.Nil(t, validateScriptExecution(nil, "spec.groovyScripts.execution"))
+	})
+	t.Run("happy", func(t *testing.T) {
+		execution := &v1alpha2.ScriptExecution{
+			Timeout:       &metav1.Duration{Duration: time.Minute},
+			Retries:       3,
+			RetryInterval: &metav1.Duration{Duration: time.Second},
+		}
+
+		assert.Nil(t, validateScriptExecution(execution, "spec.groovyScripts.execution"))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		execution := &v1alpha2.ScriptExecution{
+			Timeout:       &metav1.Duration{},
+			Retries:       -1,
+			RetryInterval: &metav1.Duration{Duration: -time.Second},
+		}
+
+		assert.Equal(t, []string{
+			"spec.groovyScripts.execution.timeout must be positive",
+			"spec.groovyScripts.execution.retries can't be negative",
+			"spec.groovyScripts.execution.retryInterval can't be negative",
+		}, validateScriptExecution(execution, "spec.groovyScripts.execution"))
+	})
 }
 
 func TestValidateJenkinsMasterContainerCommand(t *testing.T) {
@@ -962,3 +1258,119 @@ func TestValidateJenkinsMasterContainerCommand(t *testing.T) {
 		assert.Len(t, got, 1)
 	})
 }
+
+func TestValidateSecurity(t *testing.T) {
+	newJenkins := func(strategy v1alpha2.AuthorizationStrategy, interval time.Duration) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: strategy},
+				Security: &v1alpha2.Security{
+					AdminCredentialRotation: &v1alpha2.AdminCredentialRotation{Interval: metav1.Duration{Duration: interval}},
+				},
+			},
+		}
+	}
+
+	assert.Nil(t, validateSecurity(&v1alpha2.Jenkins{}))
+	assert.Nil(t, validateSecurity(newJenkins(v1alpha2.CreateUserAuthorizationStrategy, 24*time.Hour)))
+	assert.Equal(t, []string{"spec.security.adminCredentialRotation.interval '30m0s' must be at least 1h0m0s"},
+		validateSecurity(newJenkins(v1alpha2.CreateUserAuthorizationStrategy, 30*time.Minute)))
+	assert.Equal(t, []string{"spec.security.adminCredentialRotation requires the 'createUser' spec.jenkinsAPISettings.authorizationStrategy"},
+		validateSecurity(newJenkins(v1alpha2.ServiceAccountAuthorizationStrategy, 24*time.Hour)))
+
+	newJenkinsWithProvider := func(labelSelector string, credentialsSync bool) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Security: &v1alpha2.Security{
+					CredentialsSync:               &v1alpha2.CredentialsSync{Enabled: credentialsSync},
+					KubernetesCredentialsProvider: &v1alpha2.KubernetesCredentialsProvider{Enabled: true, LabelSelector: labelSelector},
+				},
+			},
+		}
+	}
+	assert.Nil(t, validateSecurity(newJenkinsWithProvider("team=backend,tier!=test", false)))
+	assert.Len(t, validateSecurity(newJenkinsWithProvider("=backend", false)), 1)
+	assert.Equal(t, []string{"spec.security.kubernetesCredentialsProvider.labelSelector 'team in (a, b)' can't contain whitespaces"},
+		validateSecurity(newJenkinsWithProvider("team in (a, b)", false)))
+	assert.Equal(t, []string{"spec.security.credentialsSync and spec.security.kubernetesCredentialsProvider can't be enabled together"},
+		validateSecurity(newJenkinsWithProvider("", true)))
+
+	newJenkinsWithLDAP := func(strategy v1alpha2.AuthorizationStrategy) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: strategy},
+				Security: &v1alpha2.Security{
+					Realm: &v1alpha2.SecurityRealm{LDAP: &v1alpha2.LDAPSecurityRealm{Server: "ldaps://ldap.example.com"}},
+				},
+			},
+		}
+	}
+	assert.Nil(t, validateSecurity(newJenkinsWithLDAP(v1alpha2.ServiceAccountAuthorizationStrategy)))
+	assert.Equal(t, []string{"spec.security.realm.ldap requires the 'serviceAccount' spec.jenkinsAPISettings.authorizationStrategy"},
+		validateSecurity(newJenkinsWithLDAP(v1alpha2.CreateUserAuthorizationStrategy)))
+
+	jenkinsWithRealms := newJenkinsWithLDAP(v1alpha2.CreateUserAuthorizationStrategy)
+	jenkinsWithRealms.Spec.Security.Realm.OIDC = &v1alpha2.OIDCSecurityRealm{Issuer: "https://idp.example.com"}
+	assert.Equal(t, []string{
+		"spec.security.realm can contain only one of ldap, oidc",
+		"spec.security.realm.ldap requires the 'serviceAccount' spec.jenkinsAPISettings.authorizationStrategy",
+		"spec.security.realm.oidc requires the 'serviceAccount' spec.jenkinsAPISettings.authorizationStrategy",
+	}, validateSecurity(jenkinsWithRealms))
+
+	fipsJenkins := &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			JenkinsAPISettings: v1alpha2.JenkinsAPISettings{
+				AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy,
+				SSHCLI:                &v1alpha2.SSHCLI{Port: 2222},
+			},
+			Master: v1alpha2.JenkinsMaster{
+				BasePlugins: []v1alpha2.Plugin{{Name: "kubernetes", Version: "1.25.2"}},
+				Plugins:     []v1alpha2.Plugin{{Name: "ssh-slaves", Version: "1.31.2"}, {Name: "bouncycastle-api", Version: "2.18"}},
+				Containers: []v1alpha2.Container{
+					{Name: resources.JenkinsMasterContainerName, Image: "jenkins/jenkins:lts-rhel-ubi9-jdk17"},
+					{Name: "backup", Image: "example/backup:1.0-alpine"},
+				},
+			},
+			Security: &v1alpha2.Security{FIPS: true},
+		},
+	}
+	assert.Equal(t, []string{
+		"spec.master.plugins 'bouncycastle-api' isn't FIPS compliant, it registers the Bouncy Castle provider which isn't FIPS validated",
+		"spec.master.plugins 'ssh-slaves' isn't FIPS compliant, it connects agents with the Trilead SSH implementation with its own cryptography",
+		"spec.master.containers[backup].image 'example/backup:1.0-alpine' isn't FIPS compliant, Alpine Linux doesn't provide FIPS validated cryptography",
+		"spec.jenkinsAPISettings.sshCLI isn't FIPS compliant, the operator user authenticates with the Ed25519 key",
+	}, validateSecurity(fipsJenkins))
+
+	newJenkinsWithAuthorization := func(globalRoles, projectRoles []v1alpha2.AuthorizationRole) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Security: &v1alpha2.Security{
+					Authorization: &v1alpha2.Authorization{GlobalRoles: globalRoles, ProjectRoles: projectRoles},
+				},
+			},
+		}
+	}
+	assert.Nil(t, validateSecurity(newJenkinsWithAuthorization(
+		[]v1alpha2.AuthorizationRole{{Name: "admin", Permissions: []string{"Overall/Administer"}, Groups: []string{"admins"}}},
+		[]v1alpha2.AuthorizationRole{{Name: "team-a", Pattern: "team-a/.*", Permissions: []string{"Job/Build", "Job/Read"}, Users: []string{"alice"}}},
+	)))
+	assert.Equal(t, []string{
+		"spec.security.authorization.globalRoles 'jenkins-operator' is reserved for the operator",
+		"spec.security.authorization.globalRoles 'read' can't have pattern, it's supported only by project roles",
+		"spec.security.authorization.globalRoles 'read' is duplicated",
+		"spec.security.authorization.globalRoles 'read' permission 'Read' is invalid, it must be in the <group>/<name> format, e.g. Job/Build",
+		"spec.security.authorization.projectRoles 'team-a' pattern can't be empty",
+		"spec.security.authorization.projectRoles 'team-a' permissions can't be empty",
+		"spec.security.authorization.projectRoles 'team-b' pattern 'team-b/(' is invalid: error parsing regexp: missing closing ): `team-b/(`",
+	}, validateSecurity(newJenkinsWithAuthorization(
+		[]v1alpha2.AuthorizationRole{
+			{Name: "jenkins-operator", Permissions: []string{"Overall/Administer"}},
+			{Name: "read", Pattern: ".*", Permissions: []string{"Overall/Read"}},
+			{Name: "read", Permissions: []string{"Read"}},
+		},
+		[]v1alpha2.AuthorizationRole{
+			{Name: "team-a"},
+			{Name: "team-b", Pattern: "team-b/(", Permissions: []string{"Job/Read"}},
+		},
+	)))
+}
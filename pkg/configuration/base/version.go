@@ -0,0 +1,108 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	upgradeCheckInterval      = 24 * time.Hour
+	upgradeCheckRetryInterval = time.Hour
+
+	upgradeAvailableReason   = "NewerVersionReleased"
+	noUpgradeAvailableReason = "LatestVersion"
+
+	ltsReleaseLine    = "LTS"
+	weeklyReleaseLine = "weekly"
+)
+
+// ensureJenkinsVersion publishes the running version of Jenkins core in the status and checks whether a newer
+// version is released in the same release line, it returns the time after which the next check should be done
+func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsVersion(jenkinsClient jenkinsclient.Jenkins) (time.Duration, error) {
+	jenkins := r.Configuration.Jenkins
+	version := jenkinsClient.GetVersion()
+	if len(version) == 0 {
+		return 0, nil
+	}
+
+	versionChanged := jenkins.Status.Version != version
+	condition := configuration.GetCondition(jenkins.Status, v1alpha2.UpgradeAvailableCondition)
+	if !versionChanged && condition != nil {
+		if sinceLastProbe := time.Since(condition.LastProbeTime.Time); sinceLastProbe < upgradeCheckInterval {
+			return upgradeCheckInterval - sinceLastProbe, nil
+		}
+	}
+	jenkins.Status.Version = version
+
+	releaseLine := getReleaseLine(jenkins.Spec.Master, version)
+	latestVersion, err := plugins.FetchLatestCoreVersion(getCoreUpdateCenterJSONURL(jenkins.Spec.Master, releaseLine), r.Configuration.GetProxySettings())
+	if err != nil {
+		if versionChanged {
+			if updateErr := r.Client.Status().Update(context.TODO(), jenkins); updateErr != nil {
+				return 0, stackerr.WithStack(updateErr)
+			}
+		}
+		return 0, err
+	}
+
+	newCondition := v1alpha2.JenkinsCondition{
+		Type:    v1alpha2.UpgradeAvailableCondition,
+		Status:  corev1.ConditionFalse,
+		Reason:  noUpgradeAvailableReason,
+		Message: fmt.Sprintf("Jenkins %s is the latest %s version", version, releaseLine),
+	}
+	if plugins.CompareVersions(latestVersion, version) > 0 {
+		newCondition.Status = corev1.ConditionTrue
+		newCondition.Reason = upgradeAvailableReason
+		newCondition.Message = fmt.Sprintf("Jenkins %s is released in the %s release line, running %s", latestVersion, releaseLine, version)
+		if condition == nil || condition.Message != newCondition.Message {
+			r.logger.Info(newCondition.Message)
+		}
+	}
+
+	configuration.SetCondition(&jenkins.Status, newCondition)
+	if err := r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return 0, stackerr.WithStack(err)
+	}
+
+	return upgradeCheckInterval, nil
+}
+
+// getReleaseLine returns the LTS release line when the Jenkins master image is tagged as LTS or the version
+// has three components (e.g. 2.235.1), otherwise the weekly release line
+func getReleaseLine(master v1alpha2.JenkinsMaster, version string) string {
+	if len(master.Containers) > 0 {
+		image := master.Containers[0].Image
+		if tag := image[strings.LastIndex(image, "/")+1:]; strings.Contains(strings.ToLower(tag), "lts") {
+			return ltsReleaseLine
+		}
+	}
+	if len(strings.Split(version, ".")) > 2 {
+		return ltsReleaseLine
+	}
+
+	return weeklyReleaseLine
+}
+
+func getCoreUpdateCenterJSONURL(master v1alpha2.JenkinsMaster, releaseLine string) string {
+	updateCenterURL := master.UpdateCenterURL
+	if len(updateCenterURL) == 0 {
+		updateCenterURL = plugins.DefaultUpdateCenterURL
+	}
+	updateCenterURL = strings.TrimSuffix(updateCenterURL, "/")
+	if releaseLine == ltsReleaseLine {
+		updateCenterURL += plugins.StableUpdateCenterPath
+	}
+
+	return updateCenterURL + plugins.UpdateCenterJSONPath
+}
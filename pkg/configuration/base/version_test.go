@@ -0,0 +1,125 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileJenkinsBaseConfiguration_ensureJenkinsVersion(t *testing.T) {
+	log.SetupLogger(true)
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stable/update-center.actual.json", r.URL.Path)
+		_, _ = w.Write([]byte(`{"core": {"name": "core", "version": "2.235.2"}}`))
+	}))
+	defer server.Close()
+	newJenkins := func() *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					UpdateCenterURL: server.URL,
+					Containers:      []v1alpha2.Container{{Name: "jenkins-master", Image: "jenkins/jenkins:lts"}},
+				},
+			},
+		}
+	}
+
+	t.Run("upgrade available", func(t *testing.T) {
+		jenkins := newJenkins()
+		fakeClient := fake.NewFakeClient(jenkins)
+		r := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient}, client.JenkinsAPIConnectionSettings{})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetVersion().Return("2.235.1").Times(2)
+
+		requeueAfter, err := r.ensureJenkinsVersion(jenkinsClient)
+
+		require.NoError(t, err)
+		assert.Equal(t, upgradeCheckInterval, requeueAfter)
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, jenkins)
+		require.NoError(t, err)
+		assert.Equal(t, "2.235.1", jenkins.Status.Version)
+		condition := configuration.GetCondition(jenkins.Status, v1alpha2.UpgradeAvailableCondition)
+		require.NotNil(t, condition)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, upgradeAvailableReason, condition.Reason)
+		assert.Equal(t, "Jenkins 2.235.2 is released in the LTS release line, running 2.235.1", condition.Message)
+
+		// the next check is done after interval
+		requeueAfter, err = r.ensureJenkinsVersion(jenkinsClient)
+		require.NoError(t, err)
+		assert.True(t, requeueAfter > 0 && requeueAfter <= upgradeCheckInterval)
+	})
+	t.Run("latest version", func(t *testing.T) {
+		jenkins := newJenkins()
+		fakeClient := fake.NewFakeClient(jenkins)
+		r := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient}, client.JenkinsAPIConnectionSettings{})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetVersion().Return("2.235.2")
+
+		_, err := r.ensureJenkinsVersion(jenkinsClient)
+
+		require.NoError(t, err)
+		condition := configuration.GetCondition(jenkins.Status, v1alpha2.UpgradeAvailableCondition)
+		require.NotNil(t, condition)
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.Equal(t, noUpgradeAvailableReason, condition.Reason)
+	})
+	t.Run("version changed after the last check", func(t *testing.T) {
+		jenkins := newJenkins()
+		jenkins.Status.Version = "2.235.1"
+		configuration.SetCondition(&jenkins.Status, v1alpha2.JenkinsCondition{Type: v1alpha2.UpgradeAvailableCondition, Status: corev1.ConditionTrue})
+		fakeClient := fake.NewFakeClient(jenkins)
+		r := New(configuration.Configuration{Jenkins: jenkins, Client: fakeClient}, client.JenkinsAPIConnectionSettings{})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetVersion().Return("2.235.2")
+
+		_, err := r.ensureJenkinsVersion(jenkinsClient)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2.235.2", jenkins.Status.Version)
+		assert.False(t, configuration.IsConditionTrue(jenkins.Status, v1alpha2.UpgradeAvailableCondition))
+	})
+}
+
+func TestGetReleaseLine(t *testing.T) {
+	master := func(image string) v1alpha2.JenkinsMaster {
+		return v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Image: image}}}
+	}
+
+	assert.Equal(t, ltsReleaseLine, getReleaseLine(master("jenkins/jenkins:lts"), "2.235.1"))
+	assert.Equal(t, ltsReleaseLine, getReleaseLine(master("jenkins/jenkins:2.235.1-lts-alpine"), "2.235.1"))
+	assert.Equal(t, ltsReleaseLine, getReleaseLine(master("registry.example.com/jenkins:2.235.1"), "2.235.1"))
+	assert.Equal(t, weeklyReleaseLine, getReleaseLine(master("jenkins/jenkins:2.240"), "2.240"))
+	assert.Equal(t, weeklyReleaseLine, getReleaseLine(master("lts.example.com/jenkins:latest"), "2.240"))
+}
+
+func TestGetCoreUpdateCenterJSONURL(t *testing.T) {
+	assert.Equal(t, "https://updates.jenkins.io/stable/update-center.actual.json",
+		getCoreUpdateCenterJSONURL(v1alpha2.JenkinsMaster{}, ltsReleaseLine))
+	assert.Equal(t, "https://mirror.example.com/update-center.actual.json",
+		getCoreUpdateCenterJSONURL(v1alpha2.JenkinsMaster{UpdateCenterURL: "https://mirror.example.com/"}, weeklyReleaseLine))
+}
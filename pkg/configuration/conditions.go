@@ -0,0 +1,44 @@
+package configuration
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetCondition returns the condition of given type or nil if it's not set
+func GetCondition(status v1alpha2.JenkinsStatus, conditionType v1alpha2.JenkinsConditionType) *v1alpha2.JenkinsCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// SetCondition adds or updates the condition, the transition time is changed only when the status has changed.
+// It returns true when the status, reason or message of the condition has changed.
+func SetCondition(status *v1alpha2.JenkinsStatus, condition v1alpha2.JenkinsCondition) bool {
+	now := metav1.Now()
+	condition.LastProbeTime = now
+	condition.LastTransitionTime = now
+	if current := GetCondition(*status, condition.Type); current != nil {
+		changed := current.Status != condition.Status || current.Reason != condition.Reason || current.Message != condition.Message
+		if current.Status == condition.Status {
+			condition.LastTransitionTime = current.LastTransitionTime
+		}
+		*current = condition
+		return changed
+	}
+
+	status.Conditions = append(status.Conditions, condition)
+	return true
+}
+
+// IsConditionTrue returns true if the condition of given type is set and its status is True
+func IsConditionTrue(status v1alpha2.JenkinsStatus, conditionType v1alpha2.JenkinsConditionType) bool {
+	condition := GetCondition(status, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
@@ -0,0 +1,64 @@
+package configuration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCondition(t *testing.T) {
+	t.Run("new condition", func(t *testing.T) {
+		status := &v1alpha2.JenkinsStatus{}
+
+		changed := SetCondition(status, v1alpha2.JenkinsCondition{Type: v1alpha2.ReadyCondition, Status: corev1.ConditionFalse, Reason: "InProgress"})
+
+		assert.True(t, changed)
+		require.Len(t, status.Conditions, 1)
+		assert.False(t, status.Conditions[0].LastTransitionTime.IsZero())
+		assert.False(t, IsConditionTrue(*status, v1alpha2.ReadyCondition))
+	})
+	t.Run("same condition", func(t *testing.T) {
+		transitionTime := metav1.NewTime(metav1.Now().Add(-time.Hour))
+		status := &v1alpha2.JenkinsStatus{Conditions: []v1alpha2.JenkinsCondition{
+			{Type: v1alpha2.ReadyCondition, Status: corev1.ConditionTrue, Reason: "Reconciled", LastTransitionTime: transitionTime},
+		}}
+
+		changed := SetCondition(status, v1alpha2.JenkinsCondition{Type: v1alpha2.ReadyCondition, Status: corev1.ConditionTrue, Reason: "Reconciled"})
+
+		assert.False(t, changed)
+		assert.Equal(t, transitionTime, status.Conditions[0].LastTransitionTime)
+		assert.True(t, IsConditionTrue(*status, v1alpha2.ReadyCondition))
+	})
+	t.Run("changed message", func(t *testing.T) {
+		transitionTime := metav1.NewTime(metav1.Now().Add(-time.Hour))
+		status := &v1alpha2.JenkinsStatus{Conditions: []v1alpha2.JenkinsCondition{
+			{Type: v1alpha2.BackupHealthyCondition, Status: corev1.ConditionFalse, Message: "first", LastTransitionTime: transitionTime},
+		}}
+
+		changed := SetCondition(status, v1alpha2.JenkinsCondition{Type: v1alpha2.BackupHealthyCondition, Status: corev1.ConditionFalse, Message: "second"})
+
+		assert.True(t, changed)
+		assert.Equal(t, transitionTime, status.Conditions[0].LastTransitionTime)
+		assert.Equal(t, "second", status.Conditions[0].Message)
+	})
+	t.Run("changed status", func(t *testing.T) {
+		transitionTime := metav1.NewTime(metav1.Now().Add(-time.Hour))
+		status := &v1alpha2.JenkinsStatus{Conditions: []v1alpha2.JenkinsCondition{
+			{Type: v1alpha2.PluginSecurityWarningsCondition, Status: corev1.ConditionFalse},
+			{Type: v1alpha2.ReadyCondition, Status: corev1.ConditionFalse, LastTransitionTime: transitionTime},
+		}}
+
+		changed := SetCondition(status, v1alpha2.JenkinsCondition{Type: v1alpha2.ReadyCondition, Status: corev1.ConditionTrue})
+
+		assert.True(t, changed)
+		require.Len(t, status.Conditions, 2)
+		assert.NotEqual(t, transitionTime, status.Conditions[1].LastTransitionTime)
+		assert.True(t, IsConditionTrue(*status, v1alpha2.ReadyCondition))
+	})
+}
@@ -3,10 +3,15 @@ package configuration
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/audit"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
@@ -56,7 +61,13 @@ func (c *Configuration) RestartJenkinsMasterPod(reason reason.Reason) error {
 		Reason:  reason,
 	}
 
-	return stackerr.WithStack(c.Client.Delete(context.TODO(), currentJenkinsMasterPod))
+	if err = c.Client.Delete(context.TODO(), currentJenkinsMasterPod); err != nil {
+		return stackerr.WithStack(err)
+	}
+	jenkinsMasterPodRestartsTotal.WithLabelValues(c.Jenkins.Namespace, c.Jenkins.Name).Inc()
+	audit.Add(c.Client, c.Jenkins, audit.ActionPodRecreated,
+		fmt.Sprintf("Jenkins master pod '%s' has been deleted to recreate it: %s", currentJenkinsMasterPod.Name, strings.Join(reason.Short(), "; ")), "")
+	return nil
 }
 
 // GetJenkinsMasterPod gets the jenkins master pod.
@@ -97,6 +108,7 @@ func (c *Configuration) CreateResource(obj metav1.Object) error {
 	if err := controllerutil.SetControllerReference(c.Jenkins, obj, c.Scheme); err != nil {
 		return stackerr.WithStack(err)
 	}
+	SetContentHash(obj)
 
 	return c.Client.Create(context.TODO(), runtimeObj) // don't wrap error
 }
@@ -110,6 +122,7 @@ func (c *Configuration) UpdateResource(obj metav1.Object) error {
 
 	// set Jenkins instance as the owner and controller, don't check error(can be already set)
 	_ = controllerutil.SetControllerReference(c.Jenkins, obj, c.Scheme)
+	SetContentHash(obj)
 
 	return c.Client.Update(context.TODO(), runtimeObj) // don't wrap error
 }
@@ -123,6 +136,7 @@ func (c *Configuration) CreateOrUpdateResource(obj metav1.Object) error {
 
 	// set Jenkins instance as the owner and controller, don't check error(can be already set)
 	_ = controllerutil.SetControllerReference(c.Jenkins, obj, c.Scheme)
+	SetContentHash(obj)
 
 	err := c.Client.Create(context.TODO(), runtimeObj)
 	if err != nil && errors.IsAlreadyExists(err) {
@@ -183,13 +197,53 @@ func (c *Configuration) GetJenkinsClient() (jenkinsclient.Jenkins, error) {
 	case v1alpha2.ServiceAccountAuthorizationStrategy:
 		return c.GetJenkinsClientFromServiceAccount()
 	case v1alpha2.CreateUserAuthorizationStrategy:
-		return c.GetJenkinsClientFromSecret()
+		jenkinsClient, err := c.GetJenkinsClientFromSecret()
+		if err != nil {
+			return nil, err
+		}
+		return c.withSSHScriptExecution(jenkinsClient)
 	default:
 		return nil, stackerr.Errorf("unrecognized '%s' spec.jenkinsAPISettings.authorizationStrategy", c.Jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy)
 	}
 }
 
-func (c *Configuration) getJenkinsAPIUrl() (string, error) {
+// withSSHScriptExecution returns the Jenkins client which executes Groovy scripts by the Jenkins CLI over SSH when
+// spec.jenkinsAPISettings.sshCLI is set, the SSH server is reached directly by the IP of the Jenkins master pod
+func (c *Configuration) withSSHScriptExecution(jenkinsClient jenkinsclient.Jenkins) (jenkinsclient.Jenkins, error) {
+	sshCLI := c.Jenkins.Spec.JenkinsAPISettings.SSHCLI
+	if sshCLI == nil {
+		return jenkinsClient, nil
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(c.Jenkins), Namespace: c.Jenkins.ObjectMeta.Namespace}, credentialsSecret)
+	if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+	privateKey := credentialsSecret.Data[resources.OperatorCredentialsSecretSSHPrivateKeyKey]
+	if len(privateKey) == 0 {
+		return nil, stackerr.Errorf("SSH private key not found in Secret '%s'", credentialsSecret.Name)
+	}
+	jenkinsMasterPod, err := c.GetJenkinsMasterPod()
+	if err != nil {
+		return nil, err
+	}
+	instanceIdentity, err := jenkinsClient.GetInstanceIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return jenkinsclient.NewSSHScriptClient(jenkinsClient, jenkinsclient.SSHSettings{
+		Address:          net.JoinHostPort(jenkinsMasterPod.Status.PodIP, fmt.Sprint(sshCLI.Port)),
+		UserName:         string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey]),
+		PrivateKey:       privateKey,
+		InstanceIdentity: instanceIdentity,
+	})
+}
+
+// getJenkinsAPIUrl returns the Jenkins API URL, the TLS server name of settings is set when Jenkins is reached by
+// the port-forward
+func (c *Configuration) getJenkinsAPIUrl(settings *jenkinsclient.JenkinsAPIConnectionSettings) (string, error) {
 	var service corev1.Service
 
 	err := c.Client.Get(context.TODO(), types.NamespacedName{
@@ -204,12 +258,94 @@ func (c *Configuration) getJenkinsAPIUrl() (string, error) {
 	if prefix, ok := GetJenkinsOpts(*c.Jenkins)["prefix"]; ok {
 		jenkinsURL += prefix
 	}
+	if c.Jenkins.Spec.JenkinsAPISettings.PortForwardFallback && !isJenkinsAPIReachable(jenkinsURL) {
+		podPort := service.Spec.Ports[0].TargetPort.IntValue()
+		if podPort == 0 {
+			podPort = int(service.Spec.Ports[0].Port)
+		}
+		return c.getJenkinsAPIUrlByPortForward(jenkinsURL, podPort, settings)
+	}
 	return jenkinsURL, nil
 }
 
+// GetProxySettings returns the proxy settings of requests to Jenkins and the update center, spec.proxy of the CR
+// overrides the settings of the operator
+func (c *Configuration) GetProxySettings() jenkinsclient.ProxySettings {
+	if proxy := c.Jenkins.Spec.Proxy; proxy != nil {
+		return jenkinsclient.ProxySettings{HTTPProxy: proxy.HTTPProxy, HTTPSProxy: proxy.HTTPSProxy, NoProxy: proxy.NoProxy}
+	}
+	return c.JenkinsAPIConnectionSettings.Proxy
+}
+
+// getJenkinsAPIConnectionSettings returns the Jenkins API connection settings with the CA bundle and the client
+// certificate loaded from Secrets
+func (c *Configuration) getJenkinsAPIConnectionSettings() (jenkinsclient.JenkinsAPIConnectionSettings, error) {
+	settings := c.JenkinsAPIConnectionSettings
+	settings.Proxy = c.GetProxySettings()
+	if rateLimit := c.Jenkins.Spec.JenkinsAPISettings.RateLimit; rateLimit != nil {
+		settings.RateLimit = jenkinsclient.RateLimit{QPS: float32(rateLimit.RequestsPerSecond), Burst: int(rateLimit.Burst)}
+		if rateLimit.Burst == 0 {
+			settings.RateLimit.Burst = int(rateLimit.RequestsPerSecond)
+		}
+	}
+	if authProxy := c.Jenkins.Spec.JenkinsAPISettings.AuthProxy; authProxy != nil {
+		headers, err := c.getAuthProxyHeaders(*authProxy)
+		if err != nil {
+			return settings, err
+		}
+		settings.Headers = headers
+	}
+	if name := settings.TLS.CASecret; len(name.Name) > 0 {
+		secret := &corev1.Secret{}
+		if err := c.Client.Get(context.TODO(), name, secret); err != nil {
+			return settings, stackerr.Wrapf(err, "couldn't get the CA bundle Secret '%s' of Jenkins API", name)
+		}
+		caBundle, found := secret.Data[jenkinsclient.CASecretKey]
+		if !found {
+			return settings, stackerr.Errorf("the CA bundle Secret '%s' of Jenkins API doesn't contain the '%s' key", name, jenkinsclient.CASecretKey)
+		}
+		settings.TLS.CABundle = caBundle
+	}
+	if name := settings.TLS.ClientCertificateSecret; len(name.Name) > 0 {
+		secret := &corev1.Secret{}
+		if err := c.Client.Get(context.TODO(), name, secret); err != nil {
+			return settings, stackerr.Wrapf(err, "couldn't get the client certificate Secret '%s' of Jenkins API", name)
+		}
+		certificate, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return settings, stackerr.Wrapf(err, "invalid client certificate in Secret '%s' of Jenkins API", name)
+		}
+		settings.TLS.ClientCertificate = &certificate
+	}
+	return settings, nil
+}
+
+// getAuthProxyHeaders returns HTTP headers of the auth proxy in front of Jenkins with values read from Secrets
+func (c *Configuration) getAuthProxyHeaders(authProxy v1alpha2.AuthProxy) (http.Header, error) {
+	headers := http.Header{}
+	for _, header := range authProxy.Headers {
+		selector := header.ValueSecretKeySelector
+		secret := &corev1.Secret{}
+		if err := c.Client.Get(context.TODO(), types.NamespacedName{Namespace: c.Jenkins.Namespace, Name: selector.Name}, secret); err != nil {
+			return nil, stackerr.Wrapf(err, "couldn't get the Secret '%s' of the auth proxy header '%s'", selector.Name, header.Name)
+		}
+		value, found := secret.Data[selector.Key]
+		if !found || len(value) == 0 {
+			return nil, stackerr.Errorf("the auth proxy header '%s' is empty in Secret '%s/%s[%s]'", header.Name, c.Jenkins.Namespace, selector.Name, selector.Key)
+		}
+		headers.Set(header.Name, header.Prefix+strings.TrimSpace(string(value)))
+	}
+	return headers, nil
+}
+
 // GetJenkinsClientFromServiceAccount gets jenkins client from a serviceAccount.
 func (c *Configuration) GetJenkinsClientFromServiceAccount() (jenkinsclient.Jenkins, error) {
-	jenkinsAPIUrl, err := c.getJenkinsAPIUrl()
+	settings, err := c.getJenkinsAPIConnectionSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	jenkinsAPIUrl, err := c.getJenkinsAPIUrl(&settings)
 	if err != nil {
 		return nil, err
 	}
@@ -220,12 +356,16 @@ func (c *Configuration) GetJenkinsClientFromServiceAccount() (jenkinsclient.Jenk
 		return nil, err
 	}
 
-	return jenkinsclient.NewBearerTokenAuthorization(jenkinsAPIUrl, token.String())
+	return jenkinsclient.NewBearerTokenAuthorization(jenkinsAPIUrl, token.String(), settings)
 }
 
 // GetJenkinsClientFromSecret gets jenkins client from a secret.
 func (c *Configuration) GetJenkinsClientFromSecret() (jenkinsclient.Jenkins, error) {
-	jenkinsURL, err := c.getJenkinsAPIUrl()
+	settings, err := c.getJenkinsAPIConnectionSettings()
+	if err != nil {
+		return nil, err
+	}
+	jenkinsURL, err := c.getJenkinsAPIUrl(&settings)
 	if err != nil {
 		return nil, err
 	}
@@ -250,32 +390,160 @@ func (c *Configuration) GetJenkinsClientFromSecret() (jenkinsclient.Jenkins, err
 	if credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey] == nil ||
 		tokenCreationTimeBytes == nil || tokenCreationTime == nil ||
 		currentJenkinsMasterPod.ObjectMeta.CreationTimestamp.Time.UTC().After(tokenCreationTime.UTC()) {
-		userName := string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey])
-		jenkinsClient, err := jenkinsclient.NewUserAndPasswordAuthorization(
-			jenkinsURL,
-			userName,
-			string(credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey]))
+		token, err := c.generateOperatorToken(jenkinsURL, settings, credentialsSecret)
 		if err != nil {
 			return nil, err
 		}
-
-		token, err := jenkinsClient.GenerateToken(userName, "token")
-		if err != nil {
-			return nil, err
-		}
-
-		credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey] = []byte(token.GetToken())
-		now, _ := time.Now().UTC().MarshalText()
-		credentialsSecret.Data[resources.OperatorCredentialsSecretTokenCreationKey] = now
+		setOperatorToken(credentialsSecret, token)
 		err = c.UpdateResource(credentialsSecret)
 		if err != nil {
 			return nil, stackerr.WithStack(err)
 		}
+		audit.Add(c.Client, c.Jenkins, audit.ActionSecretRotated,
+			fmt.Sprintf("API token of the operator has been generated in Secret '%s'", credentialsSecret.Name), token.GetUUID())
+	} else if c.isOperatorTokenRotationDue(*tokenCreationTime) {
+		return c.rotateOperatorToken(jenkinsURL, settings, credentialsSecret)
 	}
 	return jenkinsclient.NewUserAndPasswordAuthorization(
 		jenkinsURL,
 		string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey]),
-		string(credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey]))
+		string(credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey]),
+		settings)
+}
+
+// generateOperatorToken generates the new API token of the operator user authenticated with its password
+func (c *Configuration) generateOperatorToken(jenkinsURL string, settings jenkinsclient.JenkinsAPIConnectionSettings, credentialsSecret *corev1.Secret) (*jenkinsclient.UserToken, error) {
+	userName := string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey])
+	jenkinsClient, err := jenkinsclient.NewUserAndPasswordAuthorization(
+		jenkinsURL,
+		userName,
+		string(credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey]),
+		settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return jenkinsClient.GenerateToken(userName, "token")
+}
+
+func setOperatorToken(credentialsSecret *corev1.Secret, token *jenkinsclient.UserToken) {
+	credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey] = []byte(token.GetToken())
+	credentialsSecret.Data[resources.OperatorCredentialsSecretTokenUUIDKey] = []byte(token.GetUUID())
+	now, _ := time.Now().UTC().MarshalText()
+	credentialsSecret.Data[resources.OperatorCredentialsSecretTokenCreationKey] = now
+}
+
+// isOperatorTokenRotationDue returns true if the API token of the operator user created at the given time is older
+// than spec.jenkinsAPISettings.tokenRotationInterval
+func (c *Configuration) isOperatorTokenRotationDue(tokenCreationTime time.Time) bool {
+	interval := c.Jenkins.Spec.JenkinsAPISettings.TokenRotationInterval
+	if interval == nil || interval.Duration <= 0 {
+		return false
+	}
+	return time.Since(tokenCreationTime) >= interval.Duration
+}
+
+// rotateOperatorToken replaces the API token of the operator user, the new token is verified before it's stored in
+// the operator credentials Secret and the old token is revoked after that
+func (c *Configuration) rotateOperatorToken(jenkinsURL string, settings jenkinsclient.JenkinsAPIConnectionSettings, credentialsSecret *corev1.Secret) (jenkinsclient.Jenkins, error) {
+	userName := string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey])
+	oldTokenUUID := string(credentialsSecret.Data[resources.OperatorCredentialsSecretTokenUUIDKey])
+
+	token, err := c.generateOperatorToken(jenkinsURL, settings, credentialsSecret)
+	if err != nil {
+		return nil, stackerr.WithMessage(err, "couldn't rotate API token of the operator")
+	}
+	// the client polls Jenkins authenticated with the new token
+	jenkinsClient, err := jenkinsclient.NewUserAndPasswordAuthorization(jenkinsURL, userName, token.GetToken(), settings)
+	if err != nil {
+		return nil, stackerr.WithMessage(err, "couldn't verify rotated API token of the operator")
+	}
+
+	setOperatorToken(credentialsSecret, token)
+	if err = c.UpdateResource(credentialsSecret); err != nil {
+		// the old token is still in use
+		_ = jenkinsClient.RevokeToken(userName, token.GetUUID())
+		return nil, stackerr.WithStack(err)
+	}
+	audit.Add(c.Client, c.Jenkins, audit.ActionSecretRotated,
+		fmt.Sprintf("API token of the operator has been rotated in Secret '%s'", credentialsSecret.Name), token.GetUUID())
+
+	// tokens generated before the UUID was stored in the Secret are revoked when the Jenkins master Pod is recreated
+	if len(oldTokenUUID) > 0 {
+		if err = jenkinsClient.RevokeToken(userName, oldTokenUUID); err != nil {
+			return nil, stackerr.WithMessage(err, "couldn't revoke old API token of the operator")
+		}
+	}
+	return jenkinsClient, nil
+}
+
+// setOperatorPasswordGroovyScriptFmt changes the password of the operator user in the Jenkins own user database
+const setOperatorPasswordGroovyScriptFmt = `import hudson.model.User
+import hudson.security.HudsonPrivateSecurityRealm
+
+def user = User.getById('%s', false)
+if (user == null) {
+    throw new IllegalStateException('The operator user does not exist')
+}
+user.addProperty(HudsonPrivateSecurityRealm.Details.fromPlainPassword('%s'))
+user.save()
+`
+
+// RotateOperatorCredentials replaces the password and the API token of the operator user, the new password is verified
+// by generating the new token with it before both are stored in the operator credentials Secret, the old token is
+// revoked after that. It returns the Jenkins client authenticated with the new token.
+func (c *Configuration) RotateOperatorCredentials(jenkinsClient jenkinsclient.Jenkins) (jenkinsclient.Jenkins, error) {
+	settings, err := c.getJenkinsAPIConnectionSettings()
+	if err != nil {
+		return nil, err
+	}
+	jenkinsURL, err := c.getJenkinsAPIUrl(&settings)
+	if err != nil {
+		return nil, err
+	}
+	credentialsSecret := &corev1.Secret{}
+	err = c.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(c.Jenkins), Namespace: c.Jenkins.ObjectMeta.Namespace}, credentialsSecret)
+	if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+	userName := string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey])
+	oldPassword := string(credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey])
+	oldTokenUUID := string(credentialsSecret.Data[resources.OperatorCredentialsSecretTokenUUIDKey])
+
+	password := resources.NewOperatorPassword()
+	if _, err = jenkinsClient.ExecuteScript(fmt.Sprintf(setOperatorPasswordGroovyScriptFmt, userName, password)); err != nil {
+		return nil, stackerr.WithMessage(err, "couldn't change password of the operator")
+	}
+	credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey] = []byte(password)
+	token, err := c.generateOperatorToken(jenkinsURL, settings, credentialsSecret)
+	if err != nil {
+		_, _ = jenkinsClient.ExecuteScript(fmt.Sprintf(setOperatorPasswordGroovyScriptFmt, userName, oldPassword))
+		return nil, stackerr.WithMessage(err, "couldn't verify rotated password of the operator")
+	}
+	newJenkinsClient, err := jenkinsclient.NewUserAndPasswordAuthorization(jenkinsURL, userName, token.GetToken(), settings)
+	if err != nil {
+		_, _ = jenkinsClient.ExecuteScript(fmt.Sprintf(setOperatorPasswordGroovyScriptFmt, userName, oldPassword))
+		return nil, stackerr.WithMessage(err, "couldn't verify rotated API token of the operator")
+	}
+
+	setOperatorToken(credentialsSecret, token)
+	now, _ := time.Now().UTC().MarshalText()
+	credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordCreationKey] = now
+	if err = c.UpdateResource(credentialsSecret); err != nil {
+		// the old credentials are still in use
+		_, _ = jenkinsClient.ExecuteScript(fmt.Sprintf(setOperatorPasswordGroovyScriptFmt, userName, oldPassword))
+		_ = newJenkinsClient.RevokeToken(userName, token.GetUUID())
+		return nil, stackerr.WithStack(err)
+	}
+	audit.Add(c.Client, c.Jenkins, audit.ActionSecretRotated,
+		fmt.Sprintf("Password and API token of the operator have been rotated in Secret '%s'", credentialsSecret.Name), token.GetUUID())
+
+	if len(oldTokenUUID) > 0 {
+		if err = newJenkinsClient.RevokeToken(userName, oldTokenUUID); err != nil {
+			return nil, stackerr.WithMessage(err, "couldn't revoke old API token of the operator")
+		}
+	}
+	return c.withSSHScriptExecution(newJenkinsClient)
 }
 
 // GetJenkinsOpts gets JENKINS_OPTS env parameter, parses it's values and returns it as a map`
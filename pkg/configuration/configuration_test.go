@@ -2,10 +2,14 @@ package configuration
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestGetJenkinsOpts(t *testing.T) {
@@ -142,3 +146,38 @@ func TestGetJenkinsOpts(t *testing.T) {
 		assert.Equal(t, opts["httpPort"], "--8080")
 	})
 }
+
+func TestConfiguration_isOperatorTokenRotationDue(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{}
+	configuration := Configuration{Jenkins: jenkins}
+	assert.False(t, configuration.isOperatorTokenRotationDue(time.Now().Add(-365*24*time.Hour)))
+
+	jenkins.Spec.JenkinsAPISettings.TokenRotationInterval = &metav1.Duration{Duration: 24 * time.Hour}
+	assert.False(t, configuration.isOperatorTokenRotationDue(time.Now().Add(-time.Hour)))
+	assert.True(t, configuration.isOperatorTokenRotationDue(time.Now().Add(-25*time.Hour)))
+}
+
+func TestConfiguration_getAuthProxyHeaders(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "oauth2-proxy"},
+		Data:       map[string][]byte{"token": []byte("sso-token\n"), "empty": {}},
+	}
+	configuration := Configuration{
+		Client:  fake.NewFakeClient(secret),
+		Jenkins: &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "jenkins"}},
+	}
+	header := func(key string) v1alpha2.AuthProxyHeader {
+		return v1alpha2.AuthProxyHeader{
+			Name:                   "Authorization",
+			Prefix:                 "Bearer ",
+			ValueSecretKeySelector: v1alpha2.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth2-proxy"}, Key: key},
+		}
+	}
+
+	headers, err := configuration.getAuthProxyHeaders(v1alpha2.AuthProxy{Headers: []v1alpha2.AuthProxyHeader{header("token")}})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer sso-token", headers.Get("Authorization"))
+
+	_, err = configuration.getAuthProxyHeaders(v1alpha2.AuthProxy{Headers: []v1alpha2.AuthProxyHeader{header("empty")}})
+	assert.EqualError(t, err, "the auth proxy header 'Authorization' is empty in Secret 'default/oauth2-proxy[empty]'")
+}
@@ -0,0 +1,319 @@
+package defaults
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	containerProbeURI      = "login"
+	containerProbePortName = "http"
+)
+
+// SetDefaults sets default values of unset fields of Jenkins CR, it returns true if the CR has been changed.
+// The service type of Jenkins master defaults to NodePort when useNodePort is true.
+func SetDefaults(jenkins *v1alpha2.Jenkins, useNodePort bool) (bool, error) {
+	changed := false
+	logger := log.Log.WithValues("cr", jenkins.Name).V(log.VDebug)
+
+	var jenkinsContainer v1alpha2.Container
+
+	if len(jenkins.Spec.Master.Containers) == 0 {
+		changed = true
+		jenkinsContainer = v1alpha2.Container{Name: resources.JenkinsMasterContainerName}
+	} else {
+		if jenkins.Spec.Master.Containers[0].Name != resources.JenkinsMasterContainerName {
+			return false, stackerr.Errorf("first container in spec.master.containers must be Jenkins container with name '%s', please correct CR", resources.JenkinsMasterContainerName)
+		}
+		jenkinsContainer = jenkins.Spec.Master.Containers[0]
+	}
+
+	if len(jenkinsContainer.Image) == 0 {
+		image := constants.DefaultJenkinsMasterImage
+		if resources.IsFIPSEnabled(jenkins) {
+			image = constants.DefaultFIPSJenkinsMasterImage
+		}
+		logger.Info("Setting default Jenkins master image: " + image)
+		changed = true
+		jenkinsContainer.Image = image
+		jenkinsContainer.ImagePullPolicy = corev1.PullAlways
+	}
+	if len(jenkinsContainer.ImagePullPolicy) == 0 {
+		logger.Info(fmt.Sprintf("Setting default Jenkins master image pull policy: %s", corev1.PullAlways))
+		changed = true
+		jenkinsContainer.ImagePullPolicy = corev1.PullAlways
+	}
+
+	if jenkinsContainer.ReadinessProbe == nil {
+		logger.Info("Setting default Jenkins readinessProbe")
+		changed = true
+		jenkinsContainer.ReadinessProbe = resources.NewSimpleProbe(containerProbeURI, containerProbePortName, corev1.URISchemeHTTP, 30)
+	}
+	if jenkinsContainer.LivenessProbe == nil {
+		logger.Info("Setting default Jenkins livenessProbe")
+		changed = true
+		jenkinsContainer.LivenessProbe = resources.NewProbe(containerProbeURI, containerProbePortName, corev1.URISchemeHTTP, 80, 5, 12)
+	}
+	if len(jenkinsContainer.Command) == 0 {
+		logger.Info("Setting default Jenkins container command")
+		changed = true
+		jenkinsContainer.Command = resources.GetJenkinsMasterContainerBaseCommand()
+	}
+	if isJavaOpsVariableNotSet(jenkinsContainer) {
+		logger.Info("Setting default Jenkins container JAVA_OPTS environment variable")
+		changed = true
+		jenkinsContainer.Env = append(jenkinsContainer.Env, corev1.EnvVar{
+			Name:  constants.JavaOpsVariableName,
+			Value: "-XX:+UnlockExperimentalVMOptions -XX:+UseCGroupMemoryLimitForHeap -XX:MaxRAMFraction=1 -Djenkins.install.runSetupWizard=false -Djava.awt.headless=true",
+		})
+	}
+	if len(jenkins.Spec.Master.BasePlugins) == 0 {
+		logger.Info("Setting default operator plugins")
+		changed = true
+		jenkins.Spec.Master.BasePlugins = basePlugins()
+	}
+	for _, plugin := range seedJobsPlugins(jenkins) {
+		if !isPluginSet(jenkins, plugin.Name) {
+			logger.Info(fmt.Sprintf("Adding '%s' plugin required by seed jobs to operator plugins", plugin.Name))
+			changed = true
+			jenkins.Spec.Master.BasePlugins = append(jenkins.Spec.Master.BasePlugins, v1alpha2.Plugin{Name: plugin.Name, Version: plugin.Version})
+		}
+	}
+	if monitoring := jenkins.Spec.Monitoring; monitoring != nil && monitoring.Enabled {
+		if !isPluginSet(jenkins, plugins.PrometheusPluginName) {
+			logger.Info(fmt.Sprintf("Adding '%s' plugin required by monitoring to operator plugins", plugins.PrometheusPluginName))
+			changed = true
+			prometheusPlugin := plugins.PrometheusPlugin()
+			jenkins.Spec.Master.BasePlugins = append(jenkins.Spec.Master.BasePlugins, v1alpha2.Plugin{Name: prometheusPlugin.Name, Version: prometheusPlugin.Version})
+		}
+		if len(monitoring.Path) == 0 {
+			logger.Info("Setting default Prometheus metrics path")
+			changed = true
+			monitoring.Path = resources.DefaultMonitoringPath
+		}
+	}
+	if security := jenkins.Spec.Security; security != nil && security.Realm != nil && security.Realm.LDAP != nil &&
+		!isPluginSet(jenkins, plugins.LDAPPluginName) {
+		logger.Info(fmt.Sprintf("Adding '%s' plugin required by LDAP security realm to operator plugins", plugins.LDAPPluginName))
+		changed = true
+		ldapPlugin := plugins.LDAPPlugin()
+		jenkins.Spec.Master.BasePlugins = append(jenkins.Spec.Master.BasePlugins, v1alpha2.Plugin{Name: ldapPlugin.Name, Version: ldapPlugin.Version})
+	}
+	if security := jenkins.Spec.Security; security != nil && security.Realm != nil && security.Realm.OIDC != nil &&
+		!isPluginSet(jenkins, plugins.OIDCPluginName) {
+		logger.Info(fmt.Sprintf("Adding '%s' plugin required by OpenID Connect security realm to operator plugins", plugins.OIDCPluginName))
+		changed = true
+		oidcPlugin := plugins.OIDCPlugin()
+		jenkins.Spec.Master.BasePlugins = append(jenkins.Spec.Master.BasePlugins, v1alpha2.Plugin{Name: oidcPlugin.Name, Version: oidcPlugin.Version})
+	}
+	if security := jenkins.Spec.Security; security != nil && security.Authorization != nil &&
+		!isPluginSet(jenkins, plugins.RoleStrategyPluginName) {
+		logger.Info(fmt.Sprintf("Adding '%s' plugin required by role-based authorization strategy to operator plugins", plugins.RoleStrategyPluginName))
+		changed = true
+		roleStrategyPlugin := plugins.RoleStrategyPlugin()
+		jenkins.Spec.Master.BasePlugins = append(jenkins.Spec.Master.BasePlugins, v1alpha2.Plugin{Name: roleStrategyPlugin.Name, Version: roleStrategyPlugin.Version})
+	}
+	if resources.IsRestrictedSecurityContextEnabled(jenkins) {
+		if jenkins.Spec.Master.SecurityContext == nil {
+			logger.Info("Setting default Jenkins master pod security context")
+			changed = true
+			jenkins.Spec.Master.SecurityContext = resources.NewRestrictedPodSecurityContext()
+		}
+		if jenkinsContainer.SecurityContext == nil {
+			logger.Info("Setting default Jenkins master container security context")
+			changed = true
+			jenkinsContainer.SecurityContext = resources.NewRestrictedSecurityContext()
+		}
+	}
+	if jenkins.Spec.Master.ReadOnlyRootFilesystem && !isReadOnlyRootFilesystemSet(jenkinsContainer.SecurityContext) {
+		logger.Info("Setting read-only root filesystem of Jenkins master container")
+		changed = true
+		if jenkinsContainer.SecurityContext == nil {
+			jenkinsContainer.SecurityContext = &corev1.SecurityContext{}
+		}
+		jenkinsContainer.SecurityContext.ReadOnlyRootFilesystem = pointer.BoolPtr(true)
+	}
+	if isResourceRequirementsNotSet(jenkinsContainer.Resources) {
+		logger.Info("Setting default Jenkins master container resource requirements")
+		changed = true
+		jenkinsContainer.Resources = resources.NewResourceRequirements("1", "500Mi", "1500m", "3Gi")
+	}
+	// the port is defaulted by the CRD, the type depends on the operator settings
+	var serviceType = corev1.ServiceTypeClusterIP
+	if useNodePort {
+		serviceType = corev1.ServiceTypeNodePort
+	}
+	if setDefaultsForService(&jenkins.Spec.Service, serviceType, constants.DefaultHTTPPortInt32) {
+		logger.Info("Setting default Jenkins master service")
+		changed = true
+	}
+	if setDefaultsForService(&jenkins.Spec.SlaveService, corev1.ServiceTypeClusterIP, constants.DefaultSlavePortInt32) {
+		logger.Info("Setting default Jenkins slave service")
+		changed = true
+	}
+	if len(jenkins.Spec.Master.Containers) > 1 {
+		for i, container := range jenkins.Spec.Master.Containers[1:] {
+			if setDefaultsForContainer(jenkins, container.Name, i+1) {
+				changed = true
+			}
+		}
+	}
+	if (len(jenkins.Spec.Backup.ContainerName) > 0 || len(jenkins.Spec.Backup.Destinations) > 0) && jenkins.Spec.Backup.Interval == 0 {
+		logger.Info("Setting default backup interval")
+		changed = true
+		jenkins.Spec.Backup.Interval = 30
+	}
+
+	if len(jenkins.Spec.Master.Containers) == 0 || len(jenkins.Spec.Master.Containers) == 1 {
+		jenkins.Spec.Master.Containers = []v1alpha2.Container{jenkinsContainer}
+	} else {
+		noJenkinsContainers := jenkins.Spec.Master.Containers[1:]
+		containers := []v1alpha2.Container{jenkinsContainer}
+		containers = append(containers, noJenkinsContainers...)
+		jenkins.Spec.Master.Containers = containers
+	}
+
+	if reflect.DeepEqual(jenkins.Spec.JenkinsAPISettings, v1alpha2.JenkinsAPISettings{}) {
+		logger.Info("Setting default Jenkins API settings")
+		changed = true
+		jenkins.Spec.JenkinsAPISettings = v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy}
+	}
+
+	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy == "" {
+		logger.Info("Setting default Jenkins API settings authorization strategy")
+		changed = true
+		jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy = v1alpha2.CreateUserAuthorizationStrategy
+	}
+
+	agentImage := constants.DefaultJenkinsAgentImage
+	if resources.IsFIPSEnabled(jenkins) {
+		agentImage = constants.DefaultFIPSJenkinsAgentImage
+	}
+	if len(jenkins.Spec.SeedJobs) > 0 && len(jenkins.Spec.SeedAgent.Image) == 0 {
+		logger.Info("Setting default Agent image: " + agentImage)
+		changed = true
+		jenkins.Spec.SeedAgent.Image = agentImage
+	}
+
+	if jenkins.Spec.Agents != nil {
+		for i, staticAgent := range jenkins.Spec.Agents.Static {
+			if len(staticAgent.Image) == 0 {
+				logger.Info(fmt.Sprintf("Setting default static agent '%s' image: %s", staticAgent.Name, agentImage))
+				changed = true
+				jenkins.Spec.Agents.Static[i].Image = agentImage
+			}
+			if staticAgent.Executors == 0 {
+				logger.Info(fmt.Sprintf("Setting default static agent '%s' executors: %d", staticAgent.Name, constants.DefaultStaticAgentExecutors))
+				changed = true
+				jenkins.Spec.Agents.Static[i].Executors = constants.DefaultStaticAgentExecutors
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+func isJavaOpsVariableNotSet(container v1alpha2.Container) bool {
+	for _, env := range container.Env {
+		if env.Name == constants.JavaOpsVariableName {
+			return false
+		}
+	}
+	return true
+}
+
+func setDefaultsForContainer(jenkins *v1alpha2.Jenkins, containerName string, containerIndex int) bool {
+	changed := false
+	logger := log.Log.WithValues("cr", jenkins.Name, "container", containerName).V(log.VDebug)
+
+	if len(jenkins.Spec.Master.Containers[containerIndex].ImagePullPolicy) == 0 {
+		logger.Info(fmt.Sprintf("Setting default container image pull policy: %s", corev1.PullAlways))
+		changed = true
+		jenkins.Spec.Master.Containers[containerIndex].ImagePullPolicy = corev1.PullAlways
+	}
+	if resources.IsRestrictedSecurityContextEnabled(jenkins) && jenkins.Spec.Master.Containers[containerIndex].SecurityContext == nil {
+		logger.Info("Setting default container security context")
+		changed = true
+		jenkins.Spec.Master.Containers[containerIndex].SecurityContext = resources.NewRestrictedSecurityContext()
+	}
+	if isResourceRequirementsNotSet(jenkins.Spec.Master.Containers[containerIndex].Resources) {
+		logger.Info("Setting default container resource requirements")
+		changed = true
+		jenkins.Spec.Master.Containers[containerIndex].Resources = resources.NewResourceRequirements("50m", "50Mi", "100m", "100Mi")
+	}
+	return changed
+}
+
+func setDefaultsForService(service *v1alpha2.Service, serviceType corev1.ServiceType, port int32) bool {
+	changed := false
+	if len(service.Type) == 0 {
+		changed = true
+		service.Type = serviceType
+	}
+	if service.Port == 0 {
+		changed = true
+		service.Port = port
+	}
+	return changed
+}
+
+func isResourceRequirementsNotSet(requirements corev1.ResourceRequirements) bool {
+	return reflect.DeepEqual(requirements, corev1.ResourceRequirements{})
+}
+
+func isReadOnlyRootFilesystemSet(securityContext *corev1.SecurityContext) bool {
+	return securityContext != nil && securityContext.ReadOnlyRootFilesystem != nil && *securityContext.ReadOnlyRootFilesystem
+}
+
+func basePlugins() (result []v1alpha2.Plugin) {
+	for _, value := range plugins.BasePlugins() {
+		result = append(result, v1alpha2.Plugin{Name: value.Name, Version: value.Version})
+	}
+	return
+}
+
+// seedJobsPlugins returns plugins required by credential types of seed jobs
+func seedJobsPlugins(jenkins *v1alpha2.Jenkins) []plugins.Plugin {
+	var bitbucket, gitHubApp bool
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		switch seedJob.JenkinsCredentialType {
+		case v1alpha2.BitbucketAppPasswordCredentialType, v1alpha2.BitbucketAPITokenCredentialType:
+			bitbucket = true
+		case v1alpha2.GitHubAppCredentialType:
+			gitHubApp = true
+		}
+	}
+
+	var result []plugins.Plugin
+	if bitbucket {
+		result = append(result, plugins.BitbucketBranchSourcePlugin())
+	}
+	if gitHubApp {
+		result = append(result, plugins.GitHubBranchSourcePlugin())
+	}
+	return result
+}
+
+func isPluginSet(jenkins *v1alpha2.Jenkins, name string) bool {
+	for _, plugin := range jenkins.Spec.Master.BasePlugins {
+		if plugin.Name == name {
+			return true
+		}
+	}
+	for _, plugin := range jenkins.Spec.Master.Plugins {
+		if plugin.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package defaults
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSetDefaults(t *testing.T) {
+	t.Run("empty CR", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{}
+
+		changed, err := SetDefaults(jenkins, true)
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		require.Len(t, jenkins.Spec.Master.Containers, 1)
+		assert.Equal(t, resources.JenkinsMasterContainerName, jenkins.Spec.Master.Containers[0].Name)
+		assert.Equal(t, constants.DefaultJenkinsMasterImage, jenkins.Spec.Master.Containers[0].Image)
+		assert.NotEmpty(t, jenkins.Spec.Master.BasePlugins)
+		assert.Equal(t, corev1.ServiceTypeNodePort, jenkins.Spec.Service.Type)
+		assert.Equal(t, v1alpha2.CreateUserAuthorizationStrategy, jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy)
+		assert.Equal(t, resources.NewRestrictedPodSecurityContext(), jenkins.Spec.Master.SecurityContext)
+
+		changed, err = SetDefaults(jenkins, true)
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("sidecar container and backup", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName}, {Name: "backup"}},
+				},
+				Backup: v1alpha2.Backup{ContainerName: "backup"},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		require.Len(t, jenkins.Spec.Master.Containers, 2)
+		assert.Equal(t, corev1.PullAlways, jenkins.Spec.Master.Containers[1].ImagePullPolicy)
+		assert.False(t, jenkins.Spec.Master.Containers[1].Resources.Requests.Cpu().IsZero())
+		assert.Equal(t, uint64(30), jenkins.Spec.Backup.Interval)
+		assert.Equal(t, corev1.ServiceTypeClusterIP, jenkins.Spec.Service.Type)
+	})
+	t.Run("service defaulted by CRD", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Service:      v1alpha2.Service{Port: constants.DefaultHTTPPortInt32},
+				SlaveService: v1alpha2.Service{Type: corev1.ServiceTypeNodePort},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha2.Service{Type: corev1.ServiceTypeNodePort, Port: constants.DefaultHTTPPortInt32}, jenkins.Spec.Service)
+		assert.Equal(t, v1alpha2.Service{Type: corev1.ServiceTypeNodePort, Port: constants.DefaultSlavePortInt32}, jenkins.Spec.SlaveService)
+	})
+	t.Run("restricted security context", func(t *testing.T) {
+		runAsUser := int64(2000)
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					SecurityContext: &corev1.PodSecurityContext{RunAsUser: &runAsUser},
+					Containers:      []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName}, {Name: "backup"}},
+				},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, &corev1.PodSecurityContext{RunAsUser: &runAsUser}, jenkins.Spec.Master.SecurityContext)
+		assert.Equal(t, resources.NewRestrictedSecurityContext(), jenkins.Spec.Master.Containers[0].SecurityContext)
+		assert.Equal(t, resources.NewRestrictedSecurityContext(), jenkins.Spec.Master.Containers[1].SecurityContext)
+	})
+	t.Run("restricted security context disabled", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{DisableRestrictedSecurityContext: true},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Nil(t, jenkins.Spec.Master.SecurityContext)
+		assert.Nil(t, jenkins.Spec.Master.Containers[0].SecurityContext)
+	})
+	t.Run("read-only root filesystem", func(t *testing.T) {
+		readOnlyRootFilesystem := false
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					ReadOnlyRootFilesystem:           true,
+					DisableRestrictedSecurityContext: true,
+					Containers: []v1alpha2.Container{{
+						Name:            resources.JenkinsMasterContainerName,
+						SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: &readOnlyRootFilesystem},
+					}},
+				},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		require.NotNil(t, jenkins.Spec.Master.Containers[0].SecurityContext.ReadOnlyRootFilesystem)
+		assert.True(t, *jenkins.Spec.Master.Containers[0].SecurityContext.ReadOnlyRootFilesystem)
+
+		changed, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("LDAP security realm", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Security: &v1alpha2.Security{
+					Realm: &v1alpha2.SecurityRealm{LDAP: &v1alpha2.LDAPSecurityRealm{Server: "ldaps://ldap.example.com"}},
+				},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, jenkins.Spec.Master.BasePlugins, v1alpha2.Plugin{Name: plugins.LDAPPluginName, Version: plugins.LDAPPlugin().Version})
+
+		changed, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("OIDC security realm", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Security: &v1alpha2.Security{
+					Realm: &v1alpha2.SecurityRealm{OIDC: &v1alpha2.OIDCSecurityRealm{Issuer: "https://idp.example.com"}},
+				},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, jenkins.Spec.Master.BasePlugins, v1alpha2.Plugin{Name: plugins.OIDCPluginName, Version: plugins.OIDCPlugin().Version})
+
+		changed, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("role-based authorization strategy", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Security: &v1alpha2.Security{Authorization: &v1alpha2.Authorization{}},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, jenkins.Spec.Master.BasePlugins, v1alpha2.Plugin{Name: plugins.RoleStrategyPluginName, Version: plugins.RoleStrategyPlugin().Version})
+
+		changed, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("FIPS images", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Security: &v1alpha2.Security{FIPS: true},
+				SeedJobs: []v1alpha2.SeedJob{{ID: "jenkins-operator"}},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, constants.DefaultFIPSJenkinsMasterImage, jenkins.Spec.Master.Containers[0].Image)
+		assert.Equal(t, constants.DefaultFIPSJenkinsAgentImage, jenkins.Spec.SeedAgent.Image)
+	})
+	t.Run("static agents", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Agents: &v1alpha2.Agents{Static: []v1alpha2.StaticAgent{
+					{Name: "docker"},
+					{Name: "maven", Executors: 4, Image: "jenkins/inbound-agent:4.3-4"},
+				}},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha2.StaticAgent{
+			{Name: "docker", Executors: constants.DefaultStaticAgentExecutors, Image: constants.DefaultJenkinsAgentImage},
+			{Name: "maven", Executors: 4, Image: "jenkins/inbound-agent:4.3-4"},
+		}, jenkins.Spec.Agents.Static)
+	})
+	t.Run("invalid first container", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: "backup"}}},
+			},
+		}
+
+		_, err := SetDefaults(jenkins, false)
+
+		assert.Error(t, err)
+	})
+}
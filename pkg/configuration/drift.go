@@ -0,0 +1,80 @@
+package configuration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ContentHashAnnotation holds the hash of the content of the resource written by the operator,
+// it's used to detect manual changes of resources managed by the operator
+const ContentHashAnnotation = "jenkins.io/content-hash"
+
+// serviceContent are fields of Service managed by the operator, other fields are defaulted by Kubernetes
+type serviceContent struct {
+	Type                     corev1.ServiceType
+	Selector                 map[string]string
+	LoadBalancerIP           string
+	LoadBalancerSourceRanges []string
+	Ports                    []int32
+}
+
+// ContentHash returns the hash of the content of the resource managed by the operator,
+// it returns false if changes of the resource kind aren't detected.
+func ContentHash(obj metav1.Object) (string, bool) {
+	var content interface{}
+	switch resource := obj.(type) {
+	case *corev1.ConfigMap:
+		content = []interface{}{resource.Data, resource.BinaryData}
+	case *corev1.Service:
+		service := serviceContent{
+			Type:                     resource.Spec.Type,
+			Selector:                 resource.Spec.Selector,
+			LoadBalancerIP:           resource.Spec.LoadBalancerIP,
+			LoadBalancerSourceRanges: resource.Spec.LoadBalancerSourceRanges,
+		}
+		for _, port := range resource.Spec.Ports {
+			service.Ports = append(service.Ports, port.Port)
+		}
+		content = service
+	default:
+		return "", false
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", false
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), true
+}
+
+// SetContentHash sets the hash of the content of the resource in its annotations.
+func SetContentHash(obj metav1.Object) {
+	hash, ok := ContentHash(obj)
+	if !ok {
+		return
+	}
+
+	// annotations may be shared with the Jenkins CR, e.g. annotations of services
+	annotations := map[string]string{}
+	for key, value := range obj.GetAnnotations() {
+		annotations[key] = value
+	}
+	annotations[ContentHashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+}
+
+// IsDrifted returns true if the resource has been changed since the operator wrote it.
+func IsDrifted(obj metav1.Object) bool {
+	expected, found := obj.GetAnnotations()[ContentHashAnnotation]
+	if !found {
+		return false
+	}
+
+	hash, ok := ContentHash(obj)
+	return ok && hash != expected
+}
@@ -0,0 +1,51 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsDrifted(t *testing.T) {
+	t.Run("config map", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{Data: map[string]string{"key": "value"}}
+		SetContentHash(configMap)
+		assert.False(t, IsDrifted(configMap))
+
+		configMap.Data["key"] = "changed"
+		assert.True(t, IsDrifted(configMap))
+	})
+	t.Run("service", func(t *testing.T) {
+		annotations := map[string]string{"team": "a"}
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeClusterIP,
+				Selector: map[string]string{"app": "jenkins-operator"},
+				Ports:    []corev1.ServicePort{{Port: 8080}},
+			},
+		}
+		SetContentHash(service)
+		assert.NotContains(t, annotations, ContentHashAnnotation)
+
+		// fields defaulted by Kubernetes
+		service.Spec.ClusterIP = "10.0.0.1"
+		service.Spec.Ports[0].NodePort = 30000
+		assert.False(t, IsDrifted(service))
+
+		service.Spec.Selector = map[string]string{"app": "other"}
+		assert.True(t, IsDrifted(service))
+	})
+	t.Run("without annotation", func(t *testing.T) {
+		assert.False(t, IsDrifted(&corev1.ConfigMap{Data: map[string]string{"key": "value"}}))
+	})
+	t.Run("unsupported kind", func(t *testing.T) {
+		secret := &corev1.Secret{Data: map[string][]byte{"key": []byte("value")}}
+		SetContentHash(secret)
+
+		assert.NotContains(t, secret.Annotations, ContentHashAnnotation)
+		assert.False(t, IsDrifted(secret))
+	})
+}
@@ -0,0 +1,17 @@
+package configuration
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var jenkinsMasterPodRestartsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "jenkins_operator",
+	Subsystem: "jenkins_master",
+	Name:      "pod_restarts_total",
+	Help:      "Number of Jenkins master pods deleted by the operator to recreate them.",
+}, []string{"namespace", "jenkins"})
+
+func init() {
+	metrics.Registry.MustRegister(jenkinsMasterPodRestartsTotal)
+}
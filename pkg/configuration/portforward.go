@@ -0,0 +1,179 @@
+package configuration
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const (
+	// jenkinsAPIDialTimeout is the timeout of the connection to the Jenkins API which checks if it's reachable
+	jenkinsAPIDialTimeout = 3 * time.Second
+	// portForwardReadyTimeout is the timeout of starting the port-forward to the Jenkins master Pod
+	portForwardReadyTimeout = 10 * time.Second
+)
+
+// portForward is the running port-forward from the local port of the operator to the Jenkins master Pod
+type portForward struct {
+	podUID    types.UID
+	localPort uint16
+	stopChan  chan struct{}
+	doneChan  chan struct{}
+}
+
+var (
+	portForwardsMutex sync.Mutex
+	// portForwards are shared by reconcile loops of the same Jenkins, the key is the Jenkins master Pod
+	portForwards = map[types.NamespacedName]*portForward{}
+)
+
+func (p *portForward) isRunning() bool {
+	select {
+	case <-p.doneChan:
+		return false
+	default:
+		return true
+	}
+}
+
+// isJenkinsAPIReachable returns true if the operator can open the TCP connection to the Jenkins API
+func isJenkinsAPIReachable(jenkinsURL string) bool {
+	parsedURL, err := url.Parse(jenkinsURL)
+	if err != nil {
+		return false
+	}
+	port := parsedURL.Port()
+	if len(port) == 0 {
+		port = "80"
+		if parsedURL.Scheme == "https" {
+			port = "443"
+		}
+	}
+	connection, err := net.DialTimeout("tcp", net.JoinHostPort(parsedURL.Hostname(), port), jenkinsAPIDialTimeout)
+	if err != nil {
+		return false
+	}
+	_ = connection.Close()
+	return true
+}
+
+// getJenkinsAPIUrlByPortForward returns the Jenkins API URL with the host replaced by the local address of the
+// port-forward to the Jenkins master Pod, the port-forward is started when it isn't running yet
+func (c *Configuration) getJenkinsAPIUrlByPortForward(jenkinsURL string, podPort int, settings *jenkinsclient.JenkinsAPIConnectionSettings) (string, error) {
+	pod, err := c.GetJenkinsMasterPod()
+	if err != nil {
+		return "", stackerr.WithStack(err)
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return "", stackerr.Errorf("couldn't port-forward to Jenkins master Pod '%s/%s' in '%s' phase", pod.Namespace, pod.Name, pod.Status.Phase)
+	}
+
+	portForwardsMutex.Lock()
+	defer portForwardsMutex.Unlock()
+
+	key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	forward, found := portForwards[key]
+	if found && forward.podUID != pod.UID {
+		close(forward.stopChan)
+		found = false
+	}
+	if !found || !forward.isRunning() {
+		forward, err = c.startPortForward(pod, podPort)
+		if err != nil {
+			delete(portForwards, key)
+			return "", err
+		}
+		portForwards[key] = forward
+	}
+
+	return getLocalJenkinsAPIUrl(jenkinsURL, forward.localPort, settings)
+}
+
+// getLocalJenkinsAPIUrl returns the Jenkins API URL with the host replaced by the local address of the port-forward,
+// the original hostname is still verified in the Jenkins certificate
+func getLocalJenkinsAPIUrl(jenkinsURL string, localPort uint16, settings *jenkinsclient.JenkinsAPIConnectionSettings) (string, error) {
+	parsedURL, err := url.Parse(jenkinsURL)
+	if err != nil {
+		return "", stackerr.WithStack(err)
+	}
+	if parsedURL.Scheme == jenkinsclient.HTTPSScheme && len(settings.TLS.ServerName) == 0 {
+		settings.TLS.ServerName = parsedURL.Hostname()
+	}
+	parsedURL.Host = net.JoinHostPort("127.0.0.1", fmt.Sprint(localPort))
+	return parsedURL.String(), nil
+}
+
+// StopPortForward stops the port-forward to the master Pod of the deleted Jenkins
+func StopPortForward(jenkins types.NamespacedName) {
+	portForwardsMutex.Lock()
+	defer portForwardsMutex.Unlock()
+
+	key := types.NamespacedName{
+		Namespace: jenkins.Namespace,
+		Name:      resources.GetJenkinsMasterPodName(&v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: jenkins.Name}}),
+	}
+	if forward, found := portForwards[key]; found {
+		close(forward.stopChan)
+		delete(portForwards, key)
+	}
+}
+
+func (c *Configuration) startPortForward(pod *corev1.Pod, podPort int) (*portForward, error) {
+	req := c.ClientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(c.Config)
+	if err != nil {
+		return nil, stackerr.Wrap(err, "couldn't create port-forward round tripper")
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	forward := &portForward{podUID: pod.UID, stopChan: make(chan struct{}), doneChan: make(chan struct{})}
+	readyChan := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", podPort)},
+		forward.stopChan, readyChan, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		return nil, stackerr.Wrap(err, "couldn't create port-forward")
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- forwarder.ForwardPorts()
+		close(forward.doneChan)
+	}()
+
+	select {
+	case <-readyChan:
+	case err = <-errChan:
+		return nil, stackerr.Wrapf(err, "couldn't port-forward to Jenkins master Pod '%s/%s'", pod.Namespace, pod.Name)
+	case <-time.After(portForwardReadyTimeout):
+		close(forward.stopChan)
+		return nil, stackerr.Errorf("timeout while waiting for port-forward to Jenkins master Pod '%s/%s'", pod.Namespace, pod.Name)
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil || len(ports) == 0 {
+		close(forward.stopChan)
+		return nil, stackerr.Errorf("couldn't get local port of port-forward to Jenkins master Pod '%s/%s'", pod.Namespace, pod.Name)
+	}
+	forward.localPort = ports[0].Local
+	return forward, nil
+}
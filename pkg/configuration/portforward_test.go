@@ -0,0 +1,83 @@
+package configuration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestIsJenkinsAPIReachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	jenkinsURL := server.URL + "/jenkins"
+
+	assert.True(t, isJenkinsAPIReachable(jenkinsURL))
+
+	server.Close()
+	assert.False(t, isJenkinsAPIReachable(jenkinsURL))
+	assert.False(t, isJenkinsAPIReachable("://invalid"))
+}
+
+func TestGetLocalJenkinsAPIUrl(t *testing.T) {
+	t.Run("http", func(t *testing.T) {
+		settings := jenkinsclient.JenkinsAPIConnectionSettings{}
+
+		jenkinsURL, err := getLocalJenkinsAPIUrl("http://jenkins-operator-http-jenkins.default:8080/jenkins", 41234, &settings)
+
+		require.NoError(t, err)
+		assert.Equal(t, "http://127.0.0.1:41234/jenkins", jenkinsURL)
+		assert.Empty(t, settings.TLS.ServerName)
+	})
+	t.Run("https keeps the hostname for TLS verification", func(t *testing.T) {
+		settings := jenkinsclient.JenkinsAPIConnectionSettings{Scheme: jenkinsclient.HTTPSScheme}
+
+		jenkinsURL, err := getLocalJenkinsAPIUrl("https://jenkins-operator-http-jenkins.default:8443", 41234, &settings)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://127.0.0.1:41234", jenkinsURL)
+		assert.Equal(t, "jenkins-operator-http-jenkins.default", settings.TLS.ServerName)
+	})
+	t.Run("https with the server name set", func(t *testing.T) {
+		settings := jenkinsclient.JenkinsAPIConnectionSettings{TLS: jenkinsclient.TLSSettings{ServerName: "jenkins.example.com"}}
+
+		_, err := getLocalJenkinsAPIUrl("https://jenkins-operator-http-jenkins.default:8443", 41234, &settings)
+
+		require.NoError(t, err)
+		assert.Equal(t, "jenkins.example.com", settings.TLS.ServerName)
+	})
+}
+
+func TestStopPortForward(t *testing.T) {
+	key := types.NamespacedName{Namespace: "default", Name: "jenkins-example"}
+	forward := &portForward{stopChan: make(chan struct{}), doneChan: make(chan struct{})}
+	other := &portForward{stopChan: make(chan struct{}), doneChan: make(chan struct{})}
+	otherKey := types.NamespacedName{Namespace: "other", Name: "jenkins-example"}
+	portForwardsMutex.Lock()
+	portForwards[key] = forward
+	portForwards[otherKey] = other
+	portForwardsMutex.Unlock()
+	defer StopPortForward(types.NamespacedName{Namespace: "other", Name: "example"})
+
+	StopPortForward(types.NamespacedName{Namespace: "default", Name: "example"})
+
+	assert.NotContains(t, portForwards, key)
+	assert.Contains(t, portForwards, otherKey)
+	select {
+	case <-forward.stopChan:
+	default:
+		assert.Fail(t, "port-forward hasn't been stopped")
+	}
+	select {
+	case <-other.stopChan:
+		assert.Fail(t, "port-forward of another Jenkins has been stopped")
+	default:
+	}
+
+	// the port-forward of the Jenkins which has been deleted again is already stopped
+	StopPortForward(types.NamespacedName{Namespace: "default", Name: "example"})
+}
@@ -0,0 +1,2 @@
+// Package agents implements static agents registered in Jenkins and run as Deployments by the operator
+package agents
@@ -0,0 +1,326 @@
+package agents
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/go-logr/logr"
+	stackerr "github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	configurationType = "user-static-agents"
+	scriptSource      = "static-agents"
+	scriptName        = "static-agents.groovy"
+
+	// StaticAgentLabelKey is the label of static agent pods with the name of the agent
+	StaticAgentLabelKey = "jenkins-static-agent"
+	// SecretKey is the data key of the Secret with the JNLP secret of the static agent
+	SecretKey = "secret"
+
+	staticAgentAppLabelValue = "jenkins-operator-static-agent"
+	staticAgentDescription   = "The jenkins-operator generated static agent"
+	homeVolumeName           = "home"
+	homeVolumePath           = "/home/jenkins/agent"
+)
+
+var staticAgentsGroovyScriptTemplate = template.Must(template.New(scriptName).Parse(`
+import hudson.model.Node
+import hudson.slaves.DumbSlave
+import hudson.slaves.JNLPLauncher
+import jenkins.model.Jenkins
+
+def jenkins = Jenkins.instance
+def upsertAgent = { String name, int executors, String labels ->
+    def existing = jenkins.getNode(name)
+    if (existing != null && existing.numExecutors == executors && existing.labelString == labels) {
+        return
+    }
+    println "${existing == null ? 'Creating' : 'Updating'} agent '${name}'"
+    def agent = new DumbSlave(name, '{{ .RemoteFS }}', new JNLPLauncher(true))
+    agent.nodeDescription = '{{ .Description }}'
+    agent.numExecutors = executors
+    agent.labelString = labels
+    agent.mode = Node.Mode.NORMAL
+    jenkins.addNode(agent)
+}
+{{ range .Agents }}
+upsertAgent('{{ .Name }}', {{ .Executors }}, '{{ .Labels }}')
+{{- end }}
+{{ range .Stale }}
+if (jenkins.getNode('{{ . }}') != null) {
+    println "Removing agent '{{ . }}'"
+    jenkins.removeNode(jenkins.getNode('{{ . }}'))
+}
+{{- end }}
+`))
+
+// StaticAgents registers static agents in Jenkins and runs them as Deployments
+type StaticAgents interface {
+	Ensure(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+}
+
+type staticAgents struct {
+	configuration.Configuration
+	jenkinsClient jenkinsclient.Jenkins
+	logger        logr.Logger
+}
+
+// New creates StaticAgents client
+func New(jenkinsClient jenkinsclient.Jenkins, config configuration.Configuration) StaticAgents {
+	return &staticAgents{
+		Configuration: config,
+		jenkinsClient: jenkinsClient,
+		logger:        log.Log.WithValues("cr", config.Jenkins.Name),
+	}
+}
+
+// node is the Jenkins node of the static agent
+type node struct {
+	Name      string
+	Executors int
+	Labels    string
+}
+
+// Ensure creates and updates Jenkins nodes of spec.agents.static, stores their JNLP secrets in Secrets and runs
+// the agents as Deployments, nodes and Deployments of removed static agents are deleted
+func (s *staticAgents) Ensure(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
+	desired := getStaticAgents(jenkins)
+	if len(desired) == 0 && len(jenkins.Status.CreatedStaticAgents) == 0 {
+		return false, nil
+	}
+
+	names := map[string]bool{}
+	var createdStaticAgents []string
+	var nodes []node
+	for _, staticAgent := range desired {
+		names[staticAgent.Name] = true
+		createdStaticAgents = append(createdStaticAgents, staticAgent.Name)
+		nodes = append(nodes, node{Name: staticAgent.Name, Executors: staticAgent.Executors, Labels: strings.Join(staticAgent.Labels, " ")})
+	}
+	var stale []string
+	for _, name := range jenkins.Status.CreatedStaticAgents {
+		if !names[name] {
+			stale = append(stale, name)
+		}
+	}
+
+	groovyScript, err := staticAgentsGroovyScript(nodes, stale)
+	if err != nil {
+		return true, err
+	}
+
+	groovyClient := groovy.New(s.jenkinsClient, s.Client, jenkins, configurationType, jenkins.Spec.GroovyScripts.Customization)
+	requeue, err = groovyClient.EnsureSingle(scriptSource, scriptName, nodesHash(nodes), groovyScript)
+	if err != nil || requeue {
+		return requeue, err
+	}
+
+	for _, staticAgent := range desired {
+		if err = s.ensureStaticAgent(jenkins, staticAgent); err != nil {
+			return true, err
+		}
+	}
+	for _, name := range stale {
+		s.logger.Info(fmt.Sprintf("Deleting static agent '%s'", name))
+		meta := metav1.ObjectMeta{Name: GetStaticAgentResourceName(jenkins, name), Namespace: jenkins.Namespace}
+		for _, object := range []runtime.Object{&appsv1.Deployment{ObjectMeta: meta}, &corev1.Secret{ObjectMeta: meta}} {
+			if err = s.Client.Delete(context.TODO(), object); err != nil && !apierrors.IsNotFound(err) {
+				return true, stackerr.WithStack(err)
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(createdStaticAgents, jenkins.Status.CreatedStaticAgents) {
+		jenkins.Status.CreatedStaticAgents = createdStaticAgents
+		return true, stackerr.WithStack(s.Client.Status().Update(context.TODO(), jenkins))
+	}
+
+	return false, nil
+}
+
+// ensureStaticAgent stores the JNLP secret of the static agent node in the Secret and runs the agent as the Deployment
+func (s *staticAgents) ensureStaticAgent(jenkins *v1alpha2.Jenkins, staticAgent v1alpha2.StaticAgent) error {
+	nodeSecret, err := s.jenkinsClient.GetNodeSecret(staticAgent.Name)
+	if err != nil {
+		return err
+	}
+
+	meta := resources.NewResourceObjectMeta(jenkins)
+	meta.Name = GetStaticAgentResourceName(jenkins, staticAgent.Name)
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: meta,
+		Data: map[string][]byte{
+			SecretKey: []byte(nodeSecret),
+		},
+	}
+	if err = s.CreateOrUpdateResource(secret); err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	deployment, err := staticAgentDeployment(jenkins, staticAgent)
+	if err != nil {
+		return err
+	}
+	return stackerr.WithStack(s.CreateOrUpdateResource(deployment))
+}
+
+func getStaticAgents(jenkins *v1alpha2.Jenkins) []v1alpha2.StaticAgent {
+	if jenkins.Spec.Agents == nil {
+		return nil
+	}
+	return jenkins.Spec.Agents.Static
+}
+
+// GetStaticAgentResourceName returns name of the Deployment and Secret of the static agent
+func GetStaticAgentResourceName(jenkins *v1alpha2.Jenkins, agentName string) string {
+	return fmt.Sprintf("%s-static-agent-%s-%s", constants.OperatorName, jenkins.Name, agentName)
+}
+
+// staticAgentDeployment returns the Deployment of the static agent scheduled like the Jenkins master pod on Linux nodes
+func staticAgentDeployment(jenkins *v1alpha2.Jenkins, staticAgent v1alpha2.StaticAgent) (*appsv1.Deployment, error) {
+	jenkinsSlavesServiceFQDN, err := resources.GetJenkinsSlavesServiceFQDN(jenkins)
+	if err != nil {
+		return nil, err
+	}
+	jenkinsHTTPServiceFQDN, err := resources.GetJenkinsHTTPServiceFQDN(jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeSelector := map[string]string{}
+	for key, value := range jenkins.Spec.Master.NodeSelector {
+		nodeSelector[key] = value
+	}
+	if _, ok := nodeSelector[corev1.LabelOSStable]; !ok {
+		nodeSelector[corev1.LabelOSStable] = string(v1alpha2.AgentOSLinux)
+	}
+	podLabels := map[string]string{
+		constants.LabelAppKey:       staticAgentAppLabelValue,
+		constants.LabelJenkinsCRKey: jenkins.Name,
+		StaticAgentLabelKey:         staticAgent.Name,
+	}
+	name := GetStaticAgentResourceName(jenkins, staticAgent.Name)
+	replicas := int32(1)
+
+	meta := resources.NewResourceObjectMeta(jenkins)
+	meta.Name = name
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			// the agent can't connect twice with the same name, so the old pod is stopped first
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					NodeSelector:     nodeSelector,
+					Tolerations:      jenkins.Spec.Master.Tolerations,
+					ImagePullSecrets: jenkins.Spec.Master.ImagePullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:      "jnlp",
+							Image:     staticAgent.Image,
+							Resources: staticAgent.Resources,
+							Env: []corev1.EnvVar{
+								{
+									Name:  "JENKINS_TUNNEL",
+									Value: fmt.Sprintf("%s:%d", jenkinsSlavesServiceFQDN, jenkins.Spec.SlaveService.Port),
+								},
+								{
+									Name: "JENKINS_SECRET",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: name},
+											Key:                  SecretKey,
+										},
+									},
+								},
+								{
+									Name:  "JENKINS_AGENT_NAME",
+									Value: staticAgent.Name,
+								},
+								{
+									Name:  "JENKINS_URL",
+									Value: fmt.Sprintf("http://%s:%d", jenkinsHTTPServiceFQDN, jenkins.Spec.Service.Port),
+								},
+								{
+									Name:  "JENKINS_AGENT_WORKDIR",
+									Value: homeVolumePath,
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      homeVolumeName,
+									MountPath: homeVolumePath,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: homeVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func staticAgentsGroovyScript(nodes []node, stale []string) (string, error) {
+	data := struct {
+		RemoteFS    string
+		Description string
+		Agents      []node
+		Stale       []string
+	}{
+		RemoteFS:    homeVolumePath,
+		Description: staticAgentDescription,
+		Agents:      nodes,
+		Stale:       stale,
+	}
+
+	return render.Render(staticAgentsGroovyScriptTemplate, data)
+}
+
+// nodesHash is calculated only from the desired nodes, so the script isn't executed again after the stale nodes
+// have been removed
+func nodesHash(nodes []node) string {
+	hash := sha256.New()
+	for _, node := range nodes {
+		hash.Write([]byte(fmt.Sprintf("%+v", node)))
+	}
+	return base64.URLEncoding.EncodeToString(hash.Sum(nil))
+}
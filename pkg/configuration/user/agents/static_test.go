@@ -0,0 +1,145 @@
+package agents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func jenkinsWithStaticAgents(staticAgents ...v1alpha2.StaticAgent) *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		TypeMeta:   v1alpha2.JenkinsTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				NodeSelector: map[string]string{"pool": "jenkins"},
+			},
+			Service:      v1alpha2.Service{Port: 8080},
+			SlaveService: v1alpha2.Service{Port: 50000},
+			Agents:       &v1alpha2.Agents{Static: staticAgents},
+		},
+	}
+}
+
+func newStaticAgents(jenkinsClient jenkinsclient.Jenkins, k8sClient client.Client, jenkins *v1alpha2.Jenkins) StaticAgents {
+	return New(jenkinsClient, configuration.Configuration{Client: k8sClient, Jenkins: jenkins, Scheme: scheme.Scheme})
+}
+
+func TestStaticAgentsGroovyScript(t *testing.T) {
+	script, err := staticAgentsGroovyScript([]node{{Name: "docker", Executors: 2, Labels: "docker linux"}}, []string{"removed"})
+
+	require.NoError(t, err)
+	assert.Contains(t, script, "new DumbSlave(name, '/home/jenkins/agent', new JNLPLauncher(true))")
+	assert.Contains(t, script, "upsertAgent('docker', 2, 'docker linux')")
+	assert.Contains(t, script, "jenkins.removeNode(jenkins.getNode('removed'))")
+	assert.NotContains(t, script, "jenkins.getNode('docker')")
+}
+
+func TestStaticAgentDeployment(t *testing.T) {
+	staticAgent := v1alpha2.StaticAgent{
+		Name:      "docker",
+		Executors: 2,
+		Image:     "jenkins/inbound-agent:4.3-4",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+	}
+	jenkins := jenkinsWithStaticAgents(staticAgent)
+
+	deployment, err := staticAgentDeployment(jenkins, staticAgent)
+
+	require.NoError(t, err)
+	assert.Equal(t, "jenkins-operator-static-agent-jenkins-docker", deployment.Name)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, map[string]string{"pool": "jenkins", "kubernetes.io/os": "linux"}, podSpec.NodeSelector)
+	assert.Equal(t, map[string]string{"pool": "jenkins"}, jenkins.Spec.Master.NodeSelector)
+	assert.Equal(t, map[string]string{"app": "jenkins-operator-static-agent", "jenkins-cr": "jenkins", "jenkins-static-agent": "docker"},
+		deployment.Spec.Template.Labels)
+	container := podSpec.Containers[0]
+	assert.Equal(t, staticAgent.Image, container.Image)
+	assert.Equal(t, staticAgent.Resources, container.Resources)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "JENKINS_AGENT_NAME", Value: "docker"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "JENKINS_TUNNEL", Value: "jenkins-operator-slave-jenkins.default.svc.cluster.local:50000"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "JENKINS_SECRET", ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: deployment.Name}, Key: SecretKey},
+	}})
+}
+
+func TestEnsure(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	t.Run("no static agents", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithStaticAgents()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+
+		requeue, err := newStaticAgents(jenkinsClient, fake.NewFakeClient(), jenkins).Ensure(jenkins)
+
+		assert.NoError(t, err)
+		assert.False(t, requeue)
+	})
+	t.Run("registers static agents and removes stale ones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithStaticAgents(v1alpha2.StaticAgent{Name: "docker", Labels: []string{"docker"}, Executors: 2, Image: "jenkins/inbound-agent"})
+		jenkins.Status.CreatedStaticAgents = []string{"docker", "removed"}
+		staleMeta := metav1.ObjectMeta{Name: GetStaticAgentResourceName(jenkins, "removed"), Namespace: "default"}
+		fakeClient := fake.NewFakeClient(&appsv1.Deployment{ObjectMeta: staleMeta}, &corev1.Secret{ObjectMeta: staleMeta})
+		require.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).DoAndReturn(func(script string) (string, error) {
+			assert.Contains(t, script, "upsertAgent('docker', 2, 'docker')")
+			assert.Contains(t, script, "jenkins.getNode('removed')")
+			return "", nil
+		})
+		jenkinsClient.EXPECT().GetNodeSecret("docker").Return("jnlp-secret", nil).Times(2)
+
+		requeue, err := newStaticAgents(jenkinsClient, fakeClient, jenkins).Ensure(jenkins)
+
+		assert.NoError(t, err)
+		assert.True(t, requeue)
+
+		requeue, err = newStaticAgents(jenkinsClient, fakeClient, jenkins).Ensure(jenkins)
+
+		assert.NoError(t, err)
+		assert.True(t, requeue)
+		name := types.NamespacedName{Name: GetStaticAgentResourceName(jenkins, "docker"), Namespace: "default"}
+		secret := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(context.TODO(), name, secret))
+		assert.Equal(t, "jnlp-secret", string(secret.Data[SecretKey]))
+		require.NoError(t, fakeClient.Get(context.TODO(), name, &appsv1.Deployment{}))
+		staleName := types.NamespacedName{Name: staleMeta.Name, Namespace: "default"}
+		assert.True(t, apierrors.IsNotFound(fakeClient.Get(context.TODO(), staleName, &appsv1.Deployment{})))
+		assert.True(t, apierrors.IsNotFound(fakeClient.Get(context.TODO(), staleName, &corev1.Secret{})))
+		updated := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, updated))
+		assert.Equal(t, []string{"docker"}, updated.Status.CreatedStaticAgents)
+
+		requeue, err = newStaticAgents(jenkinsClient, fakeClient, updated).Ensure(updated)
+
+		assert.NoError(t, err)
+		assert.False(t, requeue)
+	})
+}
@@ -0,0 +1,43 @@
+package agents
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var labelRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// Validate verifies spec.agents.static of the Jenkins CR
+func Validate(jenkins v1alpha2.Jenkins) []string {
+	var messages []string
+	names := map[string]bool{}
+	for _, staticAgent := range getStaticAgents(&jenkins) {
+		prefix := fmt.Sprintf("spec.agents.static '%s'", staticAgent.Name)
+		if errs := validation.IsDNS1123Label(staticAgent.Name); len(errs) > 0 {
+			messages = append(messages, fmt.Sprintf("%s name is invalid: %s", prefix, strings.Join(errs, ", ")))
+		}
+		if staticAgent.Name == seedjobs.AgentName {
+			messages = append(messages, fmt.Sprintf("%s name is reserved for the seed job agent", prefix))
+		}
+		if names[staticAgent.Name] {
+			messages = append(messages, fmt.Sprintf("%s name is not unique", prefix))
+		}
+		names[staticAgent.Name] = true
+
+		for _, label := range staticAgent.Labels {
+			if !labelRegex.MatchString(label) {
+				messages = append(messages, fmt.Sprintf("%s label '%s' can contain only alphanumeric characters, '.', '_' and '-'", prefix, label))
+			}
+		}
+		if staticAgent.Executors < 0 {
+			messages = append(messages, fmt.Sprintf("%s executors %d can't be negative", prefix, staticAgent.Executors))
+		}
+	}
+	return messages
+}
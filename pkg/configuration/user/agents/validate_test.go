@@ -0,0 +1,46 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		jenkins := jenkinsWithStaticAgents(
+			v1alpha2.StaticAgent{Name: "docker", Labels: []string{"docker", "linux_x86-64.v2"}, Executors: 2},
+			v1alpha2.StaticAgent{Name: "maven"},
+		)
+
+		assert.Nil(t, Validate(*jenkins))
+	})
+	t.Run("no agents", func(t *testing.T) {
+		assert.Nil(t, Validate(v1alpha2.Jenkins{}))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		jenkins := jenkinsWithStaticAgents(
+			v1alpha2.StaticAgent{Name: "seed-job-agent"},
+			v1alpha2.StaticAgent{Name: "docker", Labels: []string{"docker linux", "it's"}, Executors: -1},
+			v1alpha2.StaticAgent{Name: "docker"},
+		)
+
+		assert.Equal(t, []string{
+			"spec.agents.static 'seed-job-agent' name is reserved for the seed job agent",
+			"spec.agents.static 'docker' label 'docker linux' can contain only alphanumeric characters, '.', '_' and '-'",
+			"spec.agents.static 'docker' label 'it's' can contain only alphanumeric characters, '.', '_' and '-'",
+			"spec.agents.static 'docker' executors -1 can't be negative",
+			"spec.agents.static 'docker' name is not unique",
+		}, Validate(*jenkins))
+	})
+	t.Run("invalid name", func(t *testing.T) {
+		jenkins := jenkinsWithStaticAgents(v1alpha2.StaticAgent{Name: "Docker"})
+
+		messages := Validate(*jenkins)
+
+		assert.Len(t, messages, 1)
+		assert.Contains(t, messages[0], "spec.agents.static 'Docker' name is invalid")
+	})
+}
@@ -0,0 +1,416 @@
+package casc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	agentPodTemplatesConfigurationType = "user-casc-agent-pod-templates"
+	agentPodTemplatesSource            = "jenkinsagentpodtemplates"
+	agentPodTemplatesScriptName        = "agent-pod-templates.yaml"
+
+	// jnlpContainerName is the name of the inbound agent container of the agent pod
+	jnlpContainerName = "jnlp"
+	// windowsContainerCommand and windowsContainerArgs keep containers of Windows agent pods running, Windows
+	// images don't have the cat and sleep commands used for Linux containers
+	windowsContainerCommand = "powershell"
+	windowsContainerArgs    = "Start-Sleep 999999"
+)
+
+// EnsureAgentPodTemplates applies JenkinsAgentPodTemplates bound to Jenkins as pod templates of the kubernetes cloud
+// by Configuration as Code, pod templates of deleted JenkinsAgentPodTemplates are removed
+func (c *configurationAsCode) EnsureAgentPodTemplates(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
+	podTemplates, err := c.getAgentPodTemplates(jenkins)
+	if err != nil {
+		return true, err
+	}
+	if len(podTemplates) == 0 && !isConfigurationTypeApplied(jenkins, agentPodTemplatesConfigurationType) {
+		return false, nil
+	}
+
+	configuration, err := agentPodTemplatesConfiguration(jenkins, podTemplates)
+	if err != nil {
+		return true, err
+	}
+
+	return c.ensureConfiguration(jenkins, agentPodTemplatesConfigurationType, agentPodTemplatesSource, agentPodTemplatesScriptName, configuration)
+}
+
+// getAgentPodTemplates returns JenkinsAgentPodTemplates bound to Jenkins sorted by name
+func (c *configurationAsCode) getAgentPodTemplates(jenkins *v1alpha2.Jenkins) ([]v1alpha2.JenkinsAgentPodTemplate, error) {
+	podTemplateList := &v1alpha2.JenkinsAgentPodTemplateList{}
+	if err := c.k8sClient.List(context.TODO(), podTemplateList, k8s.InNamespace(jenkins.Namespace)); err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	var podTemplates []v1alpha2.JenkinsAgentPodTemplate
+	for _, podTemplate := range podTemplateList.Items {
+		if podTemplate.Spec.JenkinsRef.Name == jenkins.Name {
+			podTemplates = append(podTemplates, podTemplate)
+		}
+	}
+	sort.Slice(podTemplates, func(i, j int) bool {
+		return podTemplates[i].Name < podTemplates[j].Name
+	})
+	return podTemplates, nil
+}
+
+func isConfigurationTypeApplied(jenkins *v1alpha2.Jenkins, configurationType string) bool {
+	for _, script := range jenkins.Status.AppliedGroovyScripts {
+		if script.ConfigurationType == configurationType {
+			return true
+		}
+	}
+	return false
+}
+
+// agentPodTemplatesConfiguration returns Configuration as Code of the kubernetes cloud with the pod templates,
+// Configuration as Code replaces the whole cloud so it contains also the settings applied by the base configuration
+func agentPodTemplatesConfiguration(jenkins *v1alpha2.Jenkins, podTemplates []v1alpha2.JenkinsAgentPodTemplate) (map[string]interface{}, error) {
+	kubernetesCloud, err := resources.GetKubernetesCloudSettings(jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []interface{}{}
+	for _, podTemplate := range podTemplates {
+		templates = append(templates, agentPodTemplateConfiguration(podTemplate, kubernetesCloud))
+	}
+
+	cloud := map[string]interface{}{
+		"name":               resources.KubernetesCloudName,
+		"serverUrl":          resources.KubernetesCloudServerURL,
+		"namespace":          kubernetesCloud.Namespace,
+		"jenkinsUrl":         kubernetesCloud.JenkinsURL,
+		"jenkinsTunnel":      kubernetesCloud.JenkinsTunnel,
+		"retentionTimeout":   resources.KubernetesCloudRetentionTimeout,
+		"maxRequestsPerHost": kubernetesCloud.MaxRequestsPerHost,
+		"templates":          templates,
+	}
+	if kubernetesCloud.ContainerCap > 0 {
+		cloud["containerCap"] = kubernetesCloud.ContainerCap
+	}
+
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"clouds": []interface{}{
+				map[string]interface{}{
+					"kubernetes": cloud,
+				},
+			},
+		},
+	}, nil
+}
+
+// agentPodTemplateConfiguration returns Configuration as Code of the pod template, the ServiceAccount of the
+// kubernetes cloud settings is used when the pod template doesn't set its own
+func agentPodTemplateConfiguration(podTemplate v1alpha2.JenkinsAgentPodTemplate, kubernetesCloud resources.KubernetesCloudSettings) map[string]interface{} {
+	spec := podTemplate.Spec
+	configuration := map[string]interface{}{
+		"name": podTemplate.Name,
+	}
+	serviceAccountName := spec.ServiceAccountName
+	if len(serviceAccountName) == 0 {
+		serviceAccountName = kubernetesCloud.ServiceAccountName
+	}
+	optional := map[string]string{
+		"label":             strings.Join(spec.Labels, " "),
+		"serviceAccount":    serviceAccountName,
+		"nodeSelector":      nodeSelectorConfiguration(agentNodeSelector(spec)),
+		"yaml":              spec.Yaml,
+		"yamlMergeStrategy": string(spec.YamlMergeStrategy),
+	}
+	for key, value := range optional {
+		if len(value) > 0 {
+			configuration[key] = escapeConfigurationAsCodeValue(value)
+		}
+	}
+	if spec.IdleMinutes > 0 {
+		configuration["idleMinutes"] = spec.IdleMinutes
+	}
+	if spec.InstanceCap > 0 {
+		configuration["instanceCap"] = spec.InstanceCap
+	}
+
+	if agentContainers := agentPodTemplateContainers(spec); len(agentContainers) > 0 {
+		var containers []interface{}
+		for _, container := range agentContainers {
+			containers = append(containers, agentContainerConfiguration(container))
+		}
+		configuration["containers"] = containers
+	}
+	if len(spec.Volumes) > 0 {
+		var volumes []interface{}
+		for _, volume := range spec.Volumes {
+			volumes = append(volumes, agentVolumeConfiguration(volume))
+		}
+		configuration["volumes"] = volumes
+	}
+
+	return configuration
+}
+
+// agentNodeSelector returns the node selector of the pod template with the kubernetes.io/os and kubernetes.io/arch
+// labels of the OS and Arch
+func agentNodeSelector(spec v1alpha2.JenkinsAgentPodTemplateSpec) map[string]string {
+	nodeSelector := map[string]string{}
+	for key, value := range spec.NodeSelector {
+		nodeSelector[key] = value
+	}
+	if len(spec.OS) > 0 {
+		nodeSelector[corev1.LabelOSStable] = string(spec.OS)
+	}
+	if len(spec.Arch) > 0 {
+		nodeSelector[corev1.LabelArchStable] = spec.Arch
+	}
+	return nodeSelector
+}
+
+// agentPodTemplateContainers returns containers of the pod template, Windows pod templates get the Windows inbound
+// agent container unless they define the jnlp container and their containers sleep in PowerShell without a command
+func agentPodTemplateContainers(spec v1alpha2.JenkinsAgentPodTemplateSpec) []v1alpha2.AgentContainer {
+	if spec.OS != v1alpha2.AgentOSWindows {
+		return spec.Containers
+	}
+
+	var containers []v1alpha2.AgentContainer
+	hasJNLPContainer := false
+	for _, container := range spec.Containers {
+		if container.Name == jnlpContainerName {
+			hasJNLPContainer = true
+		} else if len(container.Command) == 0 {
+			container.Command = windowsContainerCommand
+			container.Args = windowsContainerArgs
+		}
+		containers = append(containers, container)
+	}
+	if !hasJNLPContainer {
+		jnlpContainer := v1alpha2.AgentContainer{
+			Name:  jnlpContainerName,
+			Image: constants.DefaultWindowsJenkinsAgentImage,
+		}
+		containers = append([]v1alpha2.AgentContainer{jnlpContainer}, containers...)
+	}
+	return containers
+}
+
+// nodeSelectorConfiguration returns the node selector in key=value,key=value format sorted by key
+func nodeSelectorConfiguration(nodeSelector map[string]string) string {
+	var selectors []string
+	for key, value := range nodeSelector {
+		selectors = append(selectors, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(selectors)
+	return strings.Join(selectors, ",")
+}
+
+func agentContainerConfiguration(container v1alpha2.AgentContainer) map[string]interface{} {
+	configuration := map[string]interface{}{
+		"name":  container.Name,
+		"image": escapeConfigurationAsCodeValue(container.Image),
+	}
+	optional := map[string]string{
+		"command":               container.Command,
+		"args":                  container.Args,
+		"workingDir":            container.WorkingDir,
+		"resourceRequestCpu":    quantity(container.Resources.Requests, corev1.ResourceCPU),
+		"resourceRequestMemory": quantity(container.Resources.Requests, corev1.ResourceMemory),
+		"resourceLimitCpu":      quantity(container.Resources.Limits, corev1.ResourceCPU),
+		"resourceLimitMemory":   quantity(container.Resources.Limits, corev1.ResourceMemory),
+	}
+	for key, value := range optional {
+		if len(value) > 0 {
+			configuration[key] = escapeConfigurationAsCodeValue(value)
+		}
+	}
+	if container.AlwaysPullImage {
+		configuration["alwaysPullImage"] = true
+	}
+	if container.TTYEnabled {
+		configuration["ttyEnabled"] = true
+	}
+
+	if len(container.Env) > 0 {
+		var envVars []interface{}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				envVars = append(envVars, map[string]interface{}{
+					"secretEnvVar": map[string]interface{}{
+						"key":        env.Name,
+						"secretName": env.ValueFrom.SecretKeyRef.Name,
+						"secretKey":  env.ValueFrom.SecretKeyRef.Key,
+						"optional":   env.ValueFrom.SecretKeyRef.Optional != nil && *env.ValueFrom.SecretKeyRef.Optional,
+					},
+				})
+				continue
+			}
+			envVars = append(envVars, map[string]interface{}{
+				"envVar": map[string]interface{}{
+					"key":   env.Name,
+					"value": escapeConfigurationAsCodeValue(env.Value),
+				},
+			})
+		}
+		configuration["envVars"] = envVars
+	}
+
+	return configuration
+}
+
+func quantity(resources corev1.ResourceList, name corev1.ResourceName) string {
+	value, ok := resources[name]
+	if !ok {
+		return ""
+	}
+	return value.String()
+}
+
+func agentVolumeConfiguration(volume v1alpha2.AgentVolume) map[string]interface{} {
+	switch {
+	case volume.EmptyDir != nil:
+		return map[string]interface{}{
+			"emptyDirVolume": map[string]interface{}{
+				"mountPath": volume.MountPath,
+				"memory":    volume.EmptyDir.Memory,
+			},
+		}
+	case volume.Secret != nil:
+		return map[string]interface{}{
+			"secretVolume": map[string]interface{}{
+				"mountPath":  volume.MountPath,
+				"secretName": volume.Secret.SecretName,
+			},
+		}
+	case volume.ConfigMap != nil:
+		return map[string]interface{}{
+			"configMapVolume": map[string]interface{}{
+				"mountPath":     volume.MountPath,
+				"configMapName": volume.ConfigMap.ConfigMapName,
+			},
+		}
+	default:
+		return map[string]interface{}{
+			"persistentVolumeClaim": map[string]interface{}{
+				"mountPath": volume.MountPath,
+				"claimName": volume.PersistentVolumeClaim.ClaimName,
+				"readOnly":  volume.PersistentVolumeClaim.ReadOnly,
+			},
+		}
+	}
+}
+
+// validateAgentPodTemplates verifies JenkinsAgentPodTemplates bound to Jenkins
+func (c *configurationAsCode) validateAgentPodTemplates(jenkins v1alpha2.Jenkins) ([]string, error) {
+	podTemplates, err := c.getAgentPodTemplates(&jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+	for _, podTemplate := range podTemplates {
+		messages = append(messages, validateAgentPodTemplate(podTemplate)...)
+	}
+	return messages, nil
+}
+
+func validateAgentPodTemplate(podTemplate v1alpha2.JenkinsAgentPodTemplate) []string {
+	var messages []string
+	prefix := fmt.Sprintf("JenkinsAgentPodTemplate '%s'", podTemplate.Name)
+	spec := podTemplate.Spec
+
+	for _, label := range spec.Labels {
+		if len(label) == 0 || strings.ContainsAny(label, " \t\n") {
+			messages = append(messages, fmt.Sprintf("%s label '%s' can't be empty or contain whitespaces", prefix, label))
+		}
+	}
+
+	names := map[string]bool{}
+	for _, container := range spec.Containers {
+		if errs := validation.IsDNS1123Label(container.Name); len(errs) > 0 {
+			messages = append(messages, fmt.Sprintf("%s container name '%s' is invalid: %s", prefix, container.Name, strings.Join(errs, ", ")))
+		}
+		if names[container.Name] {
+			messages = append(messages, fmt.Sprintf("%s container name '%s' is not unique", prefix, container.Name))
+		}
+		names[container.Name] = true
+		if len(container.Image) == 0 {
+			messages = append(messages, fmt.Sprintf("%s container '%s' image can't be empty", prefix, container.Name))
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && (env.ValueFrom.SecretKeyRef == nil || len(env.Value) > 0) {
+				messages = append(messages, fmt.Sprintf("%s container '%s' env '%s' supports only value or valueFrom.secretKeyRef", prefix, container.Name, env.Name))
+			}
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		if !strings.HasPrefix(volume.MountPath, "/") {
+			messages = append(messages, fmt.Sprintf("%s volume mountPath '%s' must be an absolute path", prefix, volume.MountPath))
+		}
+		sources := 0
+		for _, set := range []bool{volume.EmptyDir != nil, volume.Secret != nil, volume.ConfigMap != nil, volume.PersistentVolumeClaim != nil} {
+			if set {
+				sources++
+			}
+		}
+		if sources != 1 {
+			messages = append(messages, fmt.Sprintf("%s volume '%s' must have exactly one of emptyDir, secret, configMap or persistentVolumeClaim", prefix, volume.MountPath))
+		}
+	}
+
+	switch spec.OS {
+	case "", v1alpha2.AgentOSLinux, v1alpha2.AgentOSWindows:
+	default:
+		messages = append(messages, fmt.Sprintf("%s os '%s' is invalid, supported operating systems: %s, %s",
+			prefix, spec.OS, v1alpha2.AgentOSLinux, v1alpha2.AgentOSWindows))
+	}
+	if spec.OS == v1alpha2.AgentOSWindows && len(spec.Arch) > 0 && spec.Arch != "amd64" {
+		messages = append(messages, fmt.Sprintf("%s arch '%s' isn't supported by Windows nodes, only amd64 is supported", prefix, spec.Arch))
+	}
+	if value, ok := spec.NodeSelector[corev1.LabelOSStable]; ok && len(spec.OS) > 0 && value != string(spec.OS) {
+		messages = append(messages, fmt.Sprintf("%s nodeSelector '%s=%s' conflicts with os '%s'", prefix, corev1.LabelOSStable, value, spec.OS))
+	}
+	if value, ok := spec.NodeSelector[corev1.LabelArchStable]; ok && len(spec.Arch) > 0 && value != spec.Arch {
+		messages = append(messages, fmt.Sprintf("%s nodeSelector '%s=%s' conflicts with arch '%s'", prefix, corev1.LabelArchStable, value, spec.Arch))
+	}
+
+	switch spec.YamlMergeStrategy {
+	case "", v1alpha2.YamlMergeStrategyOverride, v1alpha2.YamlMergeStrategyMerge:
+	default:
+		messages = append(messages, fmt.Sprintf("%s yamlMergeStrategy '%s' is invalid, supported strategies: %s, %s",
+			prefix, spec.YamlMergeStrategy, v1alpha2.YamlMergeStrategyOverride, v1alpha2.YamlMergeStrategyMerge))
+	}
+	if len(spec.Yaml) > 0 {
+		if err := validatePodYaml(spec.Yaml); err != nil {
+			messages = append(messages, fmt.Sprintf("%s yaml isn't a valid pod definition: %s", prefix, err))
+		}
+	}
+
+	return messages
+}
+
+// validatePodYaml checks whether the YAML is a pod definition without unknown fields
+func validatePodYaml(podYaml string) error {
+	content, err := yaml.ToJSON([]byte(podYaml))
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(&corev1.Pod{})
+}
@@ -0,0 +1,339 @@
+package casc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func jenkinsForAgentPodTemplates() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Service:      v1alpha2.Service{Port: 8080},
+			SlaveService: v1alpha2.Service{Port: 50000},
+		},
+	}
+}
+
+func agentPodTemplate(name, jenkinsName string) *v1alpha2.JenkinsAgentPodTemplate {
+	return &v1alpha2.JenkinsAgentPodTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha2.JenkinsAgentPodTemplateSpec{
+			JenkinsRef: v1alpha2.JenkinsRef{Name: jenkinsName},
+			Labels:     []string{name},
+			Containers: []v1alpha2.AgentContainer{{Name: name, Image: name + ":latest", Command: "sleep", Args: "99999"}},
+		},
+	}
+}
+
+// decodeConfiguration returns Configuration as Code applied by the groovy script
+func decodeConfiguration(t *testing.T, script string) map[string]interface{} {
+	matches := regexp.MustCompile(`decode\('([^']+)'\)`).FindStringSubmatch(script)
+	require.Len(t, matches, 2)
+	content, err := base64.StdEncoding.DecodeString(matches[1])
+	require.NoError(t, err)
+	configuration := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(content, &configuration))
+	return configuration
+}
+
+func kubernetesCloudTemplates(t *testing.T, configuration map[string]interface{}) []interface{} {
+	clouds := configuration["jenkins"].(map[string]interface{})["clouds"].([]interface{})
+	require.Len(t, clouds, 1)
+	return clouds[0].(map[string]interface{})["kubernetes"].(map[string]interface{})["templates"].([]interface{})
+}
+
+func TestAgentPodTemplateConfiguration(t *testing.T) {
+	t.Run("all fields", func(t *testing.T) {
+		optional := true
+		podTemplate := agentPodTemplate("maven", "jenkins")
+		podTemplate.Spec.Labels = []string{"maven", "java"}
+		podTemplate.Spec.ServiceAccountName = "agent"
+		podTemplate.Spec.NodeSelector = map[string]string{"pool": "agents", "kubernetes.io/os": "linux"}
+		podTemplate.Spec.IdleMinutes = 5
+		podTemplate.Spec.InstanceCap = 10
+		podTemplate.Spec.Yaml = "spec:\n  hostname: ${HOSTNAME}\n"
+		podTemplate.Spec.YamlMergeStrategy = v1alpha2.YamlMergeStrategyMerge
+		podTemplate.Spec.Containers[0].TTYEnabled = true
+		podTemplate.Spec.Containers[0].Env = []corev1.EnvVar{
+			{Name: "MAVEN_OPTS", Value: "-Xmx512m"},
+			{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "maven"}, Key: "token", Optional: &optional,
+			}}},
+		}
+		podTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}
+		podTemplate.Spec.Volumes = []v1alpha2.AgentVolume{
+			{MountPath: "/root/.m2", EmptyDir: &v1alpha2.AgentEmptyDirVolume{}},
+			{MountPath: "/etc/settings", ConfigMap: &v1alpha2.AgentConfigMapVolume{ConfigMapName: "settings"}},
+			{MountPath: "/etc/certs", Secret: &v1alpha2.AgentSecretVolume{SecretName: "certs"}},
+			{MountPath: "/cache", PersistentVolumeClaim: &v1alpha2.AgentPersistentVolumeClaimVolume{ClaimName: "cache", ReadOnly: true}},
+		}
+
+		configuration := agentPodTemplateConfiguration(*podTemplate, resources.KubernetesCloudSettings{ServiceAccountName: "default-agent"})
+
+		assert.Equal(t, map[string]interface{}{
+			"name":              "maven",
+			"label":             "maven java",
+			"serviceAccount":    "agent",
+			"nodeSelector":      "kubernetes.io/os=linux,pool=agents",
+			"idleMinutes":       5,
+			"instanceCap":       10,
+			"yaml":              "spec:\n  hostname: ^${HOSTNAME}\n",
+			"yamlMergeStrategy": "merge",
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":                  "maven",
+					"image":                 "maven:latest",
+					"command":               "sleep",
+					"args":                  "99999",
+					"ttyEnabled":            true,
+					"resourceRequestCpu":    "500m",
+					"resourceRequestMemory": "512Mi",
+					"resourceLimitMemory":   "1Gi",
+					"envVars": []interface{}{
+						map[string]interface{}{"envVar": map[string]interface{}{"key": "MAVEN_OPTS", "value": "-Xmx512m"}},
+						map[string]interface{}{"secretEnvVar": map[string]interface{}{"key": "TOKEN", "secretName": "maven", "secretKey": "token", "optional": true}},
+					},
+				},
+			},
+			"volumes": []interface{}{
+				map[string]interface{}{"emptyDirVolume": map[string]interface{}{"mountPath": "/root/.m2", "memory": false}},
+				map[string]interface{}{"configMapVolume": map[string]interface{}{"mountPath": "/etc/settings", "configMapName": "settings"}},
+				map[string]interface{}{"secretVolume": map[string]interface{}{"mountPath": "/etc/certs", "secretName": "certs"}},
+				map[string]interface{}{"persistentVolumeClaim": map[string]interface{}{"mountPath": "/cache", "claimName": "cache", "readOnly": true}},
+			},
+		}, configuration)
+	})
+	t.Run("windows", func(t *testing.T) {
+		podTemplate := agentPodTemplate("dotnet", "jenkins")
+		podTemplate.Spec.OS = v1alpha2.AgentOSWindows
+		podTemplate.Spec.Arch = "amd64"
+		podTemplate.Spec.NodeSelector = map[string]string{"pool": "windows"}
+		podTemplate.Spec.Containers = []v1alpha2.AgentContainer{
+			{Name: "dotnet", Image: "mcr.microsoft.com/dotnet/sdk:6.0-windowsservercore-ltsc2019"},
+			{Name: "nuget", Image: "nuget:latest", Command: "cmd", Args: "/c ping -t localhost"},
+		}
+
+		configuration := agentPodTemplateConfiguration(*podTemplate, resources.KubernetesCloudSettings{})
+
+		assert.Equal(t, "kubernetes.io/arch=amd64,kubernetes.io/os=windows,pool=windows", configuration["nodeSelector"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "jnlp", "image": constants.DefaultWindowsJenkinsAgentImage},
+			map[string]interface{}{"name": "dotnet", "image": "mcr.microsoft.com/dotnet/sdk:6.0-windowsservercore-ltsc2019", "command": "powershell", "args": "Start-Sleep 999999"},
+			map[string]interface{}{"name": "nuget", "image": "nuget:latest", "command": "cmd", "args": "/c ping -t localhost"},
+		}, configuration["containers"])
+		assert.Empty(t, podTemplate.Spec.Containers[0].Command)
+	})
+	t.Run("windows with jnlp container", func(t *testing.T) {
+		podTemplate := agentPodTemplate("dotnet", "jenkins")
+		podTemplate.Spec.OS = v1alpha2.AgentOSWindows
+		podTemplate.Spec.Containers = []v1alpha2.AgentContainer{{Name: "jnlp", Image: "jenkins/inbound-agent:windowsservercore-1809"}}
+
+		configuration := agentPodTemplateConfiguration(*podTemplate, resources.KubernetesCloudSettings{})
+
+		assert.Equal(t, "kubernetes.io/os=windows", configuration["nodeSelector"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "jnlp", "image": "jenkins/inbound-agent:windowsservercore-1809"},
+		}, configuration["containers"])
+	})
+	t.Run("linux arm64", func(t *testing.T) {
+		podTemplate := agentPodTemplate("maven", "jenkins")
+		podTemplate.Spec.OS = v1alpha2.AgentOSLinux
+		podTemplate.Spec.Arch = "arm64"
+
+		configuration := agentPodTemplateConfiguration(*podTemplate, resources.KubernetesCloudSettings{})
+
+		assert.Equal(t, "kubernetes.io/arch=arm64,kubernetes.io/os=linux", configuration["nodeSelector"])
+		assert.Len(t, configuration["containers"], 1)
+	})
+	t.Run("kubernetes cloud", func(t *testing.T) {
+		jenkins := jenkinsForAgentPodTemplates()
+
+		configuration, err := agentPodTemplatesConfiguration(jenkins, nil)
+
+		require.NoError(t, err)
+		cloud := configuration["jenkins"].(map[string]interface{})["clouds"].([]interface{})[0].(map[string]interface{})["kubernetes"]
+		assert.Equal(t, map[string]interface{}{
+			"name":               "kubernetes",
+			"serverUrl":          "https://kubernetes.default.svc.cluster.local:443",
+			"namespace":          "default",
+			"jenkinsUrl":         "http://jenkins-operator-http-jenkins.default.svc.cluster.local:8080",
+			"jenkinsTunnel":      "jenkins-operator-slave-jenkins.default.svc.cluster.local:50000",
+			"retentionTimeout":   15,
+			"maxRequestsPerHost": 32,
+			"templates":          []interface{}{},
+		}, cloud)
+	})
+	t.Run("kubernetes cloud overrides", func(t *testing.T) {
+		jenkins := jenkinsForAgentPodTemplates()
+		jenkins.Spec.Agents = &v1alpha2.Agents{KubernetesCloud: &v1alpha2.KubernetesCloud{
+			JenkinsURL:         "https://jenkins.example.com",
+			JenkinsTunnel:      "jenkins-agents.example.com:50000",
+			Namespace:          "agents",
+			ServiceAccountName: "agent",
+			ContainerCap:       10,
+			MaxRequestsPerHost: 64,
+		}}
+		podTemplate := agentPodTemplate("maven", "jenkins")
+
+		configuration, err := agentPodTemplatesConfiguration(jenkins, []v1alpha2.JenkinsAgentPodTemplate{*podTemplate})
+
+		require.NoError(t, err)
+		cloud := configuration["jenkins"].(map[string]interface{})["clouds"].([]interface{})[0].(map[string]interface{})["kubernetes"].(map[string]interface{})
+		assert.Equal(t, "agents", cloud["namespace"])
+		assert.Equal(t, "https://jenkins.example.com", cloud["jenkinsUrl"])
+		assert.Equal(t, "jenkins-agents.example.com:50000", cloud["jenkinsTunnel"])
+		assert.Equal(t, 10, cloud["containerCap"])
+		assert.Equal(t, 64, cloud["maxRequestsPerHost"])
+		assert.Equal(t, "agent", cloud["templates"].([]interface{})[0].(map[string]interface{})["serviceAccount"])
+	})
+}
+
+func TestEnsureAgentPodTemplates(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	t.Run("no pod templates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsForAgentPodTemplates()
+		fakeClient := fake.NewFakeClient(agentPodTemplate("other", "other-jenkins"))
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+
+		requeue, err := New(jenkinsClient, fakeClient, jenkins).EnsureAgentPodTemplates(jenkins)
+
+		assert.NoError(t, err)
+		assert.False(t, requeue)
+	})
+	t.Run("applies bound pod templates and removes deleted ones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsForAgentPodTemplates()
+		maven := agentPodTemplate("maven", "jenkins")
+		fakeClient := fake.NewFakeClient(maven, agentPodTemplate("golang", "jenkins"), agentPodTemplate("other", "other-jenkins"))
+		require.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).DoAndReturn(func(script string) (string, error) {
+			templates := kubernetesCloudTemplates(t, decodeConfiguration(t, script))
+			require.Len(t, templates, 2)
+			assert.Equal(t, "golang", templates[0].(map[string]interface{})["name"])
+			assert.Equal(t, "maven", templates[1].(map[string]interface{})["name"])
+			return "", nil
+		})
+
+		requeue, err := New(jenkinsClient, fakeClient, jenkins).EnsureAgentPodTemplates(jenkins)
+
+		assert.NoError(t, err)
+		assert.True(t, requeue)
+
+		requeue, err = New(jenkinsClient, fakeClient, jenkins).EnsureAgentPodTemplates(jenkins)
+
+		assert.NoError(t, err)
+		assert.False(t, requeue)
+
+		require.NoError(t, fakeClient.Delete(context.TODO(), maven))
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, jenkins))
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).DoAndReturn(func(script string) (string, error) {
+			templates := kubernetesCloudTemplates(t, decodeConfiguration(t, script))
+			require.Len(t, templates, 1)
+			assert.Equal(t, "golang", templates[0].(map[string]interface{})["name"])
+			return "", nil
+		})
+
+		requeue, err = New(jenkinsClient, fakeClient, jenkins).EnsureAgentPodTemplates(jenkins)
+
+		assert.NoError(t, err)
+		assert.True(t, requeue)
+	})
+}
+
+func TestValidateAgentPodTemplates(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	t.Run("happy", func(t *testing.T) {
+		jenkins := jenkinsForAgentPodTemplates()
+		podTemplate := agentPodTemplate("maven", "jenkins")
+		podTemplate.Spec.Volumes = []v1alpha2.AgentVolume{{MountPath: "/root/.m2", EmptyDir: &v1alpha2.AgentEmptyDirVolume{}}}
+		podTemplate.Spec.Yaml = "apiVersion: v1\nkind: Pod\nspec:\n  nodeSelector:\n    pool: agents\n"
+		podTemplate.Spec.YamlMergeStrategy = v1alpha2.YamlMergeStrategyOverride
+
+		messages, err := New(nil, fake.NewFakeClient(podTemplate), jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Nil(t, messages)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		jenkins := jenkinsForAgentPodTemplates()
+		podTemplate := agentPodTemplate("maven", "jenkins")
+		podTemplate.Spec.Labels = []string{"maven java"}
+		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers,
+			v1alpha2.AgentContainer{Name: "maven", Env: []corev1.EnvVar{{Name: "POD", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}}}})
+		podTemplate.Spec.Volumes = []v1alpha2.AgentVolume{
+			{MountPath: "cache"},
+			{MountPath: "/data", EmptyDir: &v1alpha2.AgentEmptyDirVolume{}, Secret: &v1alpha2.AgentSecretVolume{SecretName: "data"}},
+		}
+		podTemplate.Spec.Yaml = "spec:\n  unknown: true\n"
+		podTemplate.Spec.YamlMergeStrategy = "replace"
+		fakeClient := fake.NewFakeClient(podTemplate, agentPodTemplate("INVALID", "other-jenkins"))
+
+		messages, err := New(nil, fakeClient, jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"JenkinsAgentPodTemplate 'maven' label 'maven java' can't be empty or contain whitespaces",
+			"JenkinsAgentPodTemplate 'maven' container name 'maven' is not unique",
+			"JenkinsAgentPodTemplate 'maven' container 'maven' image can't be empty",
+			"JenkinsAgentPodTemplate 'maven' container 'maven' env 'POD' supports only value or valueFrom.secretKeyRef",
+			"JenkinsAgentPodTemplate 'maven' volume mountPath 'cache' must be an absolute path",
+			"JenkinsAgentPodTemplate 'maven' volume 'cache' must have exactly one of emptyDir, secret, configMap or persistentVolumeClaim",
+			"JenkinsAgentPodTemplate 'maven' volume '/data' must have exactly one of emptyDir, secret, configMap or persistentVolumeClaim",
+			"JenkinsAgentPodTemplate 'maven' yamlMergeStrategy 'replace' is invalid, supported strategies: override, merge",
+			"JenkinsAgentPodTemplate 'maven' yaml isn't a valid pod definition: json: unknown field \"unknown\"",
+		}, messages)
+	})
+	t.Run("invalid os and arch", func(t *testing.T) {
+		jenkins := jenkinsForAgentPodTemplates()
+		windows := agentPodTemplate("dotnet", "jenkins")
+		windows.Spec.OS = v1alpha2.AgentOSWindows
+		windows.Spec.Arch = "arm64"
+		windows.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux", "kubernetes.io/arch": "amd64"}
+		unknown := agentPodTemplate("maven", "jenkins")
+		unknown.Spec.OS = "darwin"
+		fakeClient := fake.NewFakeClient(windows, unknown)
+
+		messages, err := New(nil, fakeClient, jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"JenkinsAgentPodTemplate 'dotnet' arch 'arm64' isn't supported by Windows nodes, only amd64 is supported",
+			"JenkinsAgentPodTemplate 'dotnet' nodeSelector 'kubernetes.io/os=linux' conflicts with os 'windows'",
+			"JenkinsAgentPodTemplate 'dotnet' nodeSelector 'kubernetes.io/arch=amd64' conflicts with arch 'arm64'",
+			"JenkinsAgentPodTemplate 'maven' os 'darwin' is invalid, supported operating systems: linux, windows",
+		}, messages)
+	})
+}
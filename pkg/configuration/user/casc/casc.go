@@ -5,11 +5,16 @@ import (
 	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/awssecrets"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/vault"
 
+	"github.com/go-logr/logr"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const groovyUtf8MaxStringLength = 65535
@@ -17,16 +22,30 @@ const groovyUtf8MaxStringLength = 65535
 // ConfigurationAsCode defines client for configurationAsCode
 type ConfigurationAsCode interface {
 	Ensure(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+	Changes() ([]string, error)
+	EnsureGitRepositories(jenkins *v1alpha2.Jenkins) (reconcile.Result, error)
+	EnsureRemoteURLs(jenkins *v1alpha2.Jenkins) (reconcile.Result, error)
+	EnsureSecurity(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+	EnsureAgentPodTemplates(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+	Validate(jenkins v1alpha2.Jenkins) ([]string, error)
 }
 
 type configurationAsCode struct {
-	groovyClient *groovy.Groovy
+	groovyClient  *groovy.Groovy
+	jenkinsClient jenkinsclient.Jenkins
+	k8sClient     k8s.Client
+	logger        logr.Logger
 }
 
 // New creates new instance of ConfigurationAsCode
 func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, jenkins *v1alpha2.Jenkins) ConfigurationAsCode {
 	return &configurationAsCode{
-		groovyClient: groovy.New(jenkinsClient, k8sClient, jenkins, "user-casc", jenkins.Spec.ConfigurationAsCode.Customization),
+		groovyClient: groovy.New(jenkinsClient, k8sClient, jenkins, "user-casc", jenkins.Spec.ConfigurationAsCode.Customization).
+			WithPlaceholderResolver(vault.New(k8sClient, jenkins).Resolve).
+			WithPlaceholderResolver(awssecrets.New(jenkins).Resolve),
+		jenkinsClient: jenkinsClient,
+		k8sClient:     k8sClient,
+		logger:        log.Log.WithValues("cr", jenkins.Name),
 	}
 }
 
@@ -37,11 +56,20 @@ func (c *configurationAsCode) Ensure(jenkins *v1alpha2.Jenkins) (requeue bool, e
 		return requeue, err
 	}
 
-	return c.groovyClient.Ensure(func(name string) bool {
-		return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
-	}, func(groovyScript string) string {
-		return fmt.Sprintf(applyConfigurationAsCodeGroovyScriptFmt, prepareScript(groovyScript))
-	})
+	return c.groovyClient.Ensure(isConfigurationAsCodeFile, applyConfigurationAsCodeGroovyScript)
+}
+
+// Changes returns messages about already applied YAML files which have changed
+func (c *configurationAsCode) Changes() ([]string, error) {
+	return c.groovyClient.Changes(isConfigurationAsCodeFile, applyConfigurationAsCodeGroovyScript)
+}
+
+func isConfigurationAsCodeFile(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
+func applyConfigurationAsCodeGroovyScript(configuration string) string {
+	return fmt.Sprintf(applyConfigurationAsCodeGroovyScriptFmt, prepareScript(configuration))
 }
 
 const applyConfigurationAsCodeGroovyScriptFmt = `
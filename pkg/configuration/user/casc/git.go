@@ -0,0 +1,375 @@
+package casc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/audit"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	gitRepositoryConfigurationType = "user-casc-git"
+	gitRepositoryScriptName        = "git-repository.groovy"
+
+	// UsernameSecretKey is key for username in Git repository credentials secret
+	UsernameSecretKey = "username"
+	// PasswordSecretKey is key for password or access token in Git repository credentials secret
+	PasswordSecretKey = "password"
+	// PrivateKeySecretKey is key for SSH private key in Git repository credentials secret
+	PrivateKeySecretKey = "privateKey"
+
+	defaultGitRepositoryRef          = "master"
+	defaultGitRepositoryPollInterval = 5 * time.Minute
+)
+
+// gitCommitRegex matches the line with resolved commit SHA printed by the Git repository Groovy script
+var gitCommitRegex = regexp.MustCompile(`(?m)^COMMIT=([0-9a-f]{40})\s*$`)
+
+var gitRepositoryGroovyScriptTemplate = template.Must(template.New(gitRepositoryScriptName).Parse(`
+import io.jenkins.plugins.casc.ConfigurationAsCode
+import io.jenkins.plugins.casc.yaml.YamlSource
+import jenkins.model.Jenkins
+
+def decode = { String value -> new String(Base64.getDecoder().decode(value), 'UTF-8') }
+def url = decode('{{ .URL }}')
+def ref = decode('{{ .Ref }}')
+def path = decode('{{ .Path }}')
+def lastCommit = '{{ .LastCommit }}'
+
+def repositoryDir = new File(Jenkins.get().getRootDir(), 'casc-git/{{ .Name }}')
+repositoryDir.mkdirs()
+def gitEnv = System.getenv().collect { key, value -> "${key}=${value}".toString() }
+def gitConfig = []
+def keyFile = null
+{{- if .PrivateKey }}
+keyFile = File.createTempFile('casc-git', '.key')
+keyFile.setReadable(false, false)
+keyFile.setReadable(true, true)
+keyFile.text = decode('{{ .PrivateKey }}')
+gitEnv.add("GIT_SSH_COMMAND=ssh -i ${keyFile.absolutePath} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null".toString())
+{{- end }}
+{{- if .Username }}
+def basicAuth = Base64.getEncoder().encodeToString((decode('{{ .Username }}') + ':' + decode('{{ .Password }}')).getBytes('UTF-8'))
+gitConfig = ['-c', "http.extraHeader=Authorization: Basic ${basicAuth}".toString()]
+{{- end }}
+
+def git = { List<String> args ->
+    def process = (['git'] + gitConfig + args).execute(gitEnv, repositoryDir)
+    def out = new StringBuffer()
+    def err = new StringBuffer()
+    process.waitForProcessOutput(out, err)
+    if (process.exitValue() != 0) {
+        throw new Exception("git ${args.join(' ')} failed: ${err}")
+    }
+    return out.toString().trim()
+}
+
+try {
+    if (!new File(repositoryDir, '.git').exists()) {
+        git(['init', '-q'])
+        git(['remote', 'add', 'origin', url])
+    } else {
+        git(['remote', 'set-url', 'origin', url])
+    }
+    git(['fetch', '-q', '--depth', '1', 'origin', ref])
+    git(['checkout', '-q', '-f', 'FETCH_HEAD'])
+    def commit = git(['rev-parse', 'HEAD'])
+
+    if (commit != lastCommit) {
+        def configDir = new File(repositoryDir, path)
+        if (!configDir.isDirectory()) {
+            throw new Exception("Directory '${path}' not found in ${url} at ${commit}")
+        }
+        def files = []
+        configDir.eachFileRecurse(groovy.io.FileType.FILES) {
+            if (it.name.endsWith('.yaml') || it.name.endsWith('.yml')) {
+                files << it
+            }
+        }
+        files.sort { it.path }.each {
+            println "Applying ${it.path - repositoryDir.path} from ${commit}"
+            ConfigurationAsCode.get().configureWith(YamlSource.of(it.toPath()))
+        }
+    }
+    println "COMMIT=${commit}"
+} finally {
+    keyFile?.delete()
+}
+`))
+
+// EnsureGitRepositories fetches Git repositories with Configuration as Code YAML files and applies them in Jenkins
+// when the resolved commit has changed, it returns result with the time of the next poll
+func (c *configurationAsCode) EnsureGitRepositories(jenkins *v1alpha2.Jenkins) (reconcile.Result, error) {
+	var statuses []v1alpha2.ConfigurationAsCodeGitRepositoryStatus
+	var requeueAfter time.Duration
+	changed := len(jenkins.Status.ConfigurationAsCodeGitRepositories) != len(jenkins.Spec.ConfigurationAsCode.GitRepositories)
+
+	for _, repository := range jenkins.Spec.ConfigurationAsCode.GitRepositories {
+		secret := &corev1.Secret{}
+		if len(repository.Credentials.Name) > 0 {
+			err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: repository.Credentials.Name, Namespace: jenkins.Namespace}, secret)
+			if err != nil {
+				return reconcile.Result{}, stackerr.WithStack(err)
+			}
+		}
+
+		status := findGitRepositoryStatus(jenkins.Status.ConfigurationAsCodeGitRepositories, repository.Name)
+		hash := gitRepositoryHash(repository, *secret)
+		pollInterval := gitRepositoryPollInterval(repository)
+		if status.Hash == hash && status.LastPollTime != nil {
+			if nextPoll := time.Until(status.LastPollTime.Add(pollInterval)); nextPoll > 0 {
+				statuses = append(statuses, status)
+				requeueAfter = minDuration(requeueAfter, nextPoll)
+				continue
+			}
+		}
+
+		lastCommit := status.Commit
+		if status.Hash != hash {
+			lastCommit = ""
+		}
+		script, err := gitRepositoryGroovyScript(repository, *secret, lastCommit)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		c.logger.V(log.VDebug).Info(fmt.Sprintf("Polling Configuration as Code Git repository '%s'", repository.Name))
+		start := time.Now()
+		logs, err := c.jenkinsClient.ExecuteScript(script)
+		execution := groovy.Execution{
+			ConfigurationType: gitRepositoryConfigurationType,
+			Source:            repository.Name,
+			Name:              gitRepositoryScriptName,
+			Duration:          time.Since(start),
+			Logs:              logs,
+			Err:               err,
+		}
+		if err != nil {
+			groovy.RecordExecution(c.k8sClient, jenkins, execution)
+			if groovyErr, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed); ok {
+				groovyErr.ConfigurationType = gitRepositoryConfigurationType
+				groovyErr.Name = gitRepositoryScriptName
+				groovyErr.Source = repository.Name
+				groovyErr.Logs = logs
+				c.logger.V(log.VWarn).Info(fmt.Sprintf("Configuration as Code Git repository '%s' groovy script execution failed, logs :\n%s", repository.Name, logs))
+			}
+			return reconcile.Result{}, err
+		}
+
+		matches := gitCommitRegex.FindStringSubmatch(logs)
+		if len(matches) != 2 {
+			return reconcile.Result{}, stackerr.Errorf("couldn't resolve commit of Configuration as Code Git repository '%s', logs '%s'", repository.Name, logs)
+		}
+		// polls which don't apply any change aren't kept in script logs
+		if matches[1] != lastCommit {
+			groovy.RecordExecution(c.k8sClient, jenkins, execution)
+			c.logger.Info(fmt.Sprintf("Configuration as Code Git repository '%s' commit '%s' has been applied", repository.Name, matches[1]))
+			audit.Add(c.k8sClient, jenkins, audit.ActionGroovyScriptExecuted,
+				fmt.Sprintf("Configuration as Code Git repository '%s' has been applied", repository.Name), matches[1])
+		} else {
+			groovy.ObserveExecution(jenkins, execution)
+		}
+
+		now := metav1.Now()
+		statuses = append(statuses, v1alpha2.ConfigurationAsCodeGitRepositoryStatus{
+			Name:         repository.Name,
+			Commit:       matches[1],
+			Hash:         hash,
+			LastPollTime: &now,
+		})
+		requeueAfter = minDuration(requeueAfter, pollInterval)
+		changed = true
+	}
+
+	if changed {
+		jenkins.Status.ConfigurationAsCodeGitRepositories = statuses
+		if err := c.k8sClient.Status().Update(context.TODO(), jenkins); err != nil {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// Validate verifies Configuration as Code reload strategy, Git repositories, remote URLs, security settings and
+// JenkinsAgentPodTemplates
+func (c *configurationAsCode) Validate(jenkins v1alpha2.Jenkins) ([]string, error) {
+	var messages []string
+	switch jenkins.Spec.ConfigurationAsCode.ReloadStrategy {
+	case "", v1alpha2.ReloadConfigurationAsCodeReloadStrategy, v1alpha2.RestartConfigurationAsCodeReloadStrategy:
+	default:
+		messages = append(messages, fmt.Sprintf("spec.configurationAsCode.reloadStrategy '%s' is invalid, supported strategies: %s, %s",
+			jenkins.Spec.ConfigurationAsCode.ReloadStrategy, v1alpha2.ReloadConfigurationAsCodeReloadStrategy, v1alpha2.RestartConfigurationAsCodeReloadStrategy))
+	}
+
+	names := map[string]bool{}
+	for _, repository := range jenkins.Spec.ConfigurationAsCode.GitRepositories {
+		if errs := validation.IsDNS1123Label(repository.Name); len(errs) > 0 {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.gitRepositories name '%s' is invalid: %s", repository.Name, strings.Join(errs, ", ")))
+		}
+		if names[repository.Name] {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.gitRepositories name '%s' is not unique", repository.Name))
+		}
+		names[repository.Name] = true
+
+		if len(repository.URL) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.gitRepositories '%s' url can't be empty", repository.Name))
+		}
+		if strings.Contains(repository.Path, "..") {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.gitRepositories '%s' path can't contain '..'", repository.Name))
+		}
+		if repository.PollInterval != nil && repository.PollInterval.Duration < time.Minute {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.gitRepositories '%s' pollInterval must be at least 1m", repository.Name))
+		}
+
+		if len(repository.Credentials.Name) == 0 {
+			continue
+		}
+		secret := &corev1.Secret{}
+		err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: repository.Credentials.Name, Namespace: jenkins.Namespace}, secret)
+		if err != nil && apierrors.IsNotFound(err) {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.gitRepositories '%s' secret '%s' not found", repository.Name, repository.Credentials.Name))
+			continue
+		} else if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+		_, hasPrivateKey := secret.Data[PrivateKeySecretKey]
+		_, hasUsername := secret.Data[UsernameSecretKey]
+		_, hasPassword := secret.Data[PasswordSecretKey]
+		if !hasPrivateKey && (!hasUsername || !hasPassword) {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.gitRepositories '%s' secret '%s' must contain '%s' and '%s' or '%s' keys",
+				repository.Name, repository.Credentials.Name, UsernameSecretKey, PasswordSecretKey, PrivateKeySecretKey))
+		}
+	}
+
+	remoteURLMessages, err := c.validateRemoteURLs(jenkins)
+	if err != nil {
+		return nil, err
+	}
+	messages = append(messages, remoteURLMessages...)
+
+	securityMessages, err := c.validateSecurity(jenkins)
+	if err != nil {
+		return nil, err
+	}
+	messages = append(messages, securityMessages...)
+
+	agentPodTemplateMessages, err := c.validateAgentPodTemplates(jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(messages, agentPodTemplateMessages...), nil
+}
+
+func gitRepositoryGroovyScript(repository v1alpha2.ConfigurationAsCodeGitRepository, secret corev1.Secret, lastCommit string) (string, error) {
+	ref := repository.Ref
+	if len(ref) == 0 {
+		ref = defaultGitRepositoryRef
+	}
+	path := strings.Trim(repository.Path, "/")
+	if len(path) == 0 {
+		path = "."
+	}
+
+	data := struct {
+		Name       string
+		URL        string
+		Ref        string
+		Path       string
+		LastCommit string
+		Username   string
+		Password   string
+		PrivateKey string
+	}{
+		Name:       repository.Name,
+		URL:        encode([]byte(repository.URL)),
+		Ref:        encode([]byte(ref)),
+		Path:       encode([]byte(path)),
+		LastCommit: lastCommit,
+		PrivateKey: encode(secret.Data[PrivateKeySecretKey]),
+	}
+	if len(data.PrivateKey) == 0 {
+		data.Username = encode(secret.Data[UsernameSecretKey])
+		data.Password = encode(secret.Data[PasswordSecretKey])
+	}
+
+	return render.Render(gitRepositoryGroovyScriptTemplate, data)
+}
+
+func gitRepositoryHash(repository v1alpha2.ConfigurationAsCodeGitRepository, secret corev1.Secret) string {
+	toCalculate := map[string]string{
+		"url":  repository.URL,
+		"ref":  repository.Ref,
+		"path": repository.Path,
+	}
+	for key, value := range secret.Data {
+		toCalculate["secret-"+key] = string(value)
+	}
+	return calculateHash(toCalculate)
+}
+
+func gitRepositoryPollInterval(repository v1alpha2.ConfigurationAsCodeGitRepository) time.Duration {
+	if repository.PollInterval == nil {
+		return defaultGitRepositoryPollInterval
+	}
+	return repository.PollInterval.Duration
+}
+
+func findGitRepositoryStatus(statuses []v1alpha2.ConfigurationAsCodeGitRepositoryStatus, name string) v1alpha2.ConfigurationAsCodeGitRepositoryStatus {
+	for _, status := range statuses {
+		if status.Name == name {
+			return status
+		}
+	}
+	return v1alpha2.ConfigurationAsCodeGitRepositoryStatus{Name: name}
+}
+
+func calculateHash(data map[string]string) string {
+	hash := sha256.New()
+
+	var keys []string
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte(data[key]))
+	}
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
+
+func minDuration(current, next time.Duration) time.Duration {
+	if current == 0 || next < current {
+		return next
+	}
+	return current
+}
+
+func encode(value []byte) string {
+	if len(value) == 0 {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(value)
+}
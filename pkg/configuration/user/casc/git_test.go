@@ -0,0 +1,177 @@
+package casc
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const commit = "0123456789abcdef0123456789abcdef01234567"
+
+func jenkinsWithGitRepository() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			ConfigurationAsCode: v1alpha2.ConfigurationAsCode{
+				GitRepositories: []v1alpha2.ConfigurationAsCodeGitRepository{
+					{
+						Name: "casc",
+						URL:  "https://github.com/example/jenkins-casc.git",
+						Ref:  "main",
+						Path: "jenkins/",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGitRepositoryGroovyScript(t *testing.T) {
+	repository := jenkinsWithGitRepository().Spec.ConfigurationAsCode.GitRepositories[0]
+	encoded := func(value string) string {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	}
+
+	t.Run("username and password", func(t *testing.T) {
+		secret := corev1.Secret{Data: map[string][]byte{UsernameSecretKey: []byte("user"), PasswordSecretKey: []byte("token")}}
+
+		script, err := gitRepositoryGroovyScript(repository, secret, commit)
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "def url = decode('"+encoded(repository.URL)+"')")
+		assert.Contains(t, script, "def ref = decode('"+encoded("main")+"')")
+		assert.Contains(t, script, "def path = decode('"+encoded("jenkins")+"')")
+		assert.Contains(t, script, "def lastCommit = '"+commit+"'")
+		assert.Contains(t, script, "http.extraHeader=Authorization: Basic")
+		assert.NotContains(t, script, "GIT_SSH_COMMAND")
+	})
+	t.Run("private key", func(t *testing.T) {
+		secret := corev1.Secret{Data: map[string][]byte{PrivateKeySecretKey: []byte("key")}}
+
+		script, err := gitRepositoryGroovyScript(repository, secret, "")
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "keyFile.text = decode('"+encoded("key")+"')")
+		assert.Contains(t, script, "GIT_SSH_COMMAND")
+		assert.NotContains(t, script, "http.extraHeader")
+	})
+}
+
+func TestEnsureGitRepositories(t *testing.T) {
+	t.Run("applies repository and stores commit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithGitRepository()
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		assert.NoError(t, err)
+		fakeClient := fake.NewFakeClient()
+		err = fakeClient.Create(context.TODO(), jenkins)
+		assert.NoError(t, err)
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).Return("Applying /jenkins/jenkins.yaml\nCOMMIT="+commit+"\n", nil)
+
+		result, err := New(jenkinsClient, fakeClient, jenkins).EnsureGitRepositories(jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, defaultGitRepositoryPollInterval, result.RequeueAfter)
+		updated := &v1alpha2.Jenkins{}
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, updated)
+		assert.NoError(t, err)
+		assert.Len(t, updated.Status.ConfigurationAsCodeGitRepositories, 1)
+		assert.Equal(t, commit, updated.Status.ConfigurationAsCodeGitRepositories[0].Commit)
+	})
+	t.Run("skips repository until poll interval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithGitRepository()
+		repository := jenkins.Spec.ConfigurationAsCode.GitRepositories[0]
+		lastPollTime := metav1.NewTime(time.Now().Add(-time.Minute))
+		jenkins.Status.ConfigurationAsCodeGitRepositories = []v1alpha2.ConfigurationAsCodeGitRepositoryStatus{
+			{Name: "casc", Commit: commit, Hash: gitRepositoryHash(repository, corev1.Secret{}), LastPollTime: &lastPollTime},
+		}
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+
+		result, err := New(jenkinsClient, fake.NewFakeClient(), jenkins).EnsureGitRepositories(jenkins)
+
+		assert.NoError(t, err)
+		assert.True(t, result.RequeueAfter > 3*time.Minute && result.RequeueAfter <= 4*time.Minute)
+	})
+	t.Run("commit not found in logs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithGitRepository()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).Return("", nil)
+
+		_, err := New(jenkinsClient, fake.NewFakeClient(), jenkins).EnsureGitRepositories(jenkins)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateGitRepositories(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+	t.Run("happy", func(t *testing.T) {
+		jenkins := jenkinsWithGitRepository()
+		jenkins.Spec.ConfigurationAsCode.GitRepositories[0].Credentials.Name = "git"
+		fakeClient := fake.NewFakeClient()
+		err := fakeClient.Create(context.TODO(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "git", Namespace: "default"},
+			Data:       map[string][]byte{PrivateKeySecretKey: []byte("key")},
+		})
+		assert.NoError(t, err)
+
+		messages, err := New(nil, fakeClient, jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Nil(t, messages)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		jenkins := jenkinsWithGitRepository()
+		jenkins.Spec.ConfigurationAsCode.GitRepositories = append(jenkins.Spec.ConfigurationAsCode.GitRepositories,
+			v1alpha2.ConfigurationAsCodeGitRepository{
+				Name:         "casc",
+				Path:         "../secrets",
+				PollInterval: &metav1.Duration{Duration: time.Second},
+				Credentials:  v1alpha2.SecretRef{Name: "git"},
+			})
+
+		messages, err := New(nil, fake.NewFakeClient(), jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"spec.configurationAsCode.gitRepositories name 'casc' is not unique",
+			"spec.configurationAsCode.gitRepositories 'casc' url can't be empty",
+			"spec.configurationAsCode.gitRepositories 'casc' path can't contain '..'",
+			"spec.configurationAsCode.gitRepositories 'casc' pollInterval must be at least 1m",
+			"spec.configurationAsCode.gitRepositories 'casc' secret 'git' not found",
+		}, messages)
+	})
+	t.Run("invalid reload strategy", func(t *testing.T) {
+		jenkins := jenkinsWithGitRepository()
+		jenkins.Spec.ConfigurationAsCode.ReloadStrategy = "recreate"
+
+		messages, err := New(nil, fake.NewFakeClient(), jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"spec.configurationAsCode.reloadStrategy 'recreate' is invalid, supported strategies: reload, restart"}, messages)
+	})
+}
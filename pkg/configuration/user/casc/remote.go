@@ -0,0 +1,244 @@
+package casc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"text/template"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/audit"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	remoteURLConfigurationType = "user-casc-remote-url"
+	remoteURLScriptName        = "remote-url.groovy"
+
+	// TokenSecretKey is key for bearer token in remote URL credentials secret
+	TokenSecretKey = "token"
+
+	defaultRemoteURLPollInterval = 5 * time.Minute
+)
+
+// contentHashRegex matches the line with SHA-256 hash of the YAML file printed by the remote URL Groovy script
+var contentHashRegex = regexp.MustCompile(`(?m)^CONTENT_HASH=([0-9a-f]{64})\s*$`)
+
+var remoteURLGroovyScriptTemplate = template.Must(template.New(remoteURLScriptName).Parse(`
+import io.jenkins.plugins.casc.ConfigurationAsCode
+import io.jenkins.plugins.casc.yaml.YamlSource
+import java.security.MessageDigest
+
+def decode = { String value -> new String(Base64.getDecoder().decode(value), 'UTF-8') }
+def url = decode('{{ .URL }}')
+def lastContentHash = '{{ .LastContentHash }}'
+
+def connection = new URL(url).openConnection()
+connection.setConnectTimeout(30000)
+connection.setReadTimeout(60000)
+{{- if .Username }}
+connection.setRequestProperty('Authorization', 'Basic ' + Base64.getEncoder().encodeToString((decode('{{ .Username }}') + ':' + decode('{{ .Password }}')).getBytes('UTF-8')))
+{{- else if .Token }}
+connection.setRequestProperty('Authorization', 'Bearer ' + decode('{{ .Token }}'))
+{{- end }}
+if (connection.responseCode != 200) {
+    throw new Exception("GET ${url} failed with status code ${connection.responseCode}")
+}
+def content = connection.inputStream.bytes
+def contentHash = MessageDigest.getInstance('SHA-256').digest(content).encodeHex().toString()
+
+if (contentHash != lastContentHash) {
+    println "Applying ${url}"
+    ConfigurationAsCode.get().configureWith(YamlSource.of(new ByteArrayInputStream(content)))
+}
+println "CONTENT_HASH=${contentHash}"
+`))
+
+// EnsureRemoteURLs downloads Configuration as Code YAML files from remote URLs and applies them in Jenkins
+// when the content has changed, it returns result with the time of the next poll
+func (c *configurationAsCode) EnsureRemoteURLs(jenkins *v1alpha2.Jenkins) (reconcile.Result, error) {
+	var statuses []v1alpha2.ConfigurationAsCodeRemoteURLStatus
+	var requeueAfter time.Duration
+	changed := len(jenkins.Status.ConfigurationAsCodeRemoteURLs) != len(jenkins.Spec.ConfigurationAsCode.RemoteURLs)
+
+	for _, remoteURL := range jenkins.Spec.ConfigurationAsCode.RemoteURLs {
+		secret := &corev1.Secret{}
+		if len(remoteURL.Credentials.Name) > 0 {
+			err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: remoteURL.Credentials.Name, Namespace: jenkins.Namespace}, secret)
+			if err != nil {
+				return reconcile.Result{}, stackerr.WithStack(err)
+			}
+		}
+
+		status := findRemoteURLStatus(jenkins.Status.ConfigurationAsCodeRemoteURLs, remoteURL.URL)
+		hash := remoteURLHash(remoteURL, *secret)
+		pollInterval := remoteURLPollInterval(remoteURL)
+		if status.Hash == hash && status.LastPollTime != nil {
+			if nextPoll := time.Until(status.LastPollTime.Add(pollInterval)); nextPoll > 0 {
+				statuses = append(statuses, status)
+				requeueAfter = minDuration(requeueAfter, nextPoll)
+				continue
+			}
+		}
+
+		lastContentHash := status.ContentHash
+		if status.Hash != hash {
+			lastContentHash = ""
+		}
+		script, err := remoteURLGroovyScript(remoteURL, *secret, lastContentHash)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		c.logger.V(log.VDebug).Info(fmt.Sprintf("Polling Configuration as Code remote URL '%s'", remoteURL.URL))
+		start := time.Now()
+		logs, err := c.jenkinsClient.ExecuteScript(script)
+		execution := groovy.Execution{
+			ConfigurationType: remoteURLConfigurationType,
+			Source:            remoteURL.URL,
+			Name:              remoteURLScriptName,
+			Duration:          time.Since(start),
+			Logs:              logs,
+			Err:               err,
+		}
+		if err != nil {
+			groovy.RecordExecution(c.k8sClient, jenkins, execution)
+			if groovyErr, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed); ok {
+				groovyErr.ConfigurationType = remoteURLConfigurationType
+				groovyErr.Name = remoteURLScriptName
+				groovyErr.Source = remoteURL.URL
+				groovyErr.Logs = logs
+				c.logger.V(log.VWarn).Info(fmt.Sprintf("Configuration as Code remote URL '%s' groovy script execution failed, logs :\n%s", remoteURL.URL, logs))
+			}
+			return reconcile.Result{}, err
+		}
+
+		matches := contentHashRegex.FindStringSubmatch(logs)
+		if len(matches) != 2 {
+			return reconcile.Result{}, stackerr.Errorf("couldn't resolve content hash of Configuration as Code remote URL '%s', logs '%s'", remoteURL.URL, logs)
+		}
+		// polls which don't apply any change aren't kept in script logs
+		if matches[1] != lastContentHash {
+			groovy.RecordExecution(c.k8sClient, jenkins, execution)
+			c.logger.Info(fmt.Sprintf("Configuration as Code remote URL '%s' has been applied", remoteURL.URL))
+			audit.Add(c.k8sClient, jenkins, audit.ActionGroovyScriptExecuted,
+				fmt.Sprintf("Configuration as Code remote URL '%s' has been applied", remoteURL.URL), matches[1])
+		} else {
+			groovy.ObserveExecution(jenkins, execution)
+		}
+
+		now := metav1.Now()
+		statuses = append(statuses, v1alpha2.ConfigurationAsCodeRemoteURLStatus{
+			URL:          remoteURL.URL,
+			ContentHash:  matches[1],
+			Hash:         hash,
+			LastPollTime: &now,
+		})
+		requeueAfter = minDuration(requeueAfter, pollInterval)
+		changed = true
+	}
+
+	if changed {
+		jenkins.Status.ConfigurationAsCodeRemoteURLs = statuses
+		if err := c.k8sClient.Status().Update(context.TODO(), jenkins); err != nil {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (c *configurationAsCode) validateRemoteURLs(jenkins v1alpha2.Jenkins) ([]string, error) {
+	var messages []string
+	urls := map[string]bool{}
+	for _, remoteURL := range jenkins.Spec.ConfigurationAsCode.RemoteURLs {
+		parsed, err := url.Parse(remoteURL.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.remoteURLs url '%s' must be a valid http:// or https:// URL", remoteURL.URL))
+		}
+		if urls[remoteURL.URL] {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.remoteURLs url '%s' is not unique", remoteURL.URL))
+		}
+		urls[remoteURL.URL] = true
+		if remoteURL.PollInterval != nil && remoteURL.PollInterval.Duration < time.Minute {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.remoteURLs '%s' pollInterval must be at least 1m", remoteURL.URL))
+		}
+
+		if len(remoteURL.Credentials.Name) == 0 {
+			continue
+		}
+		secret := &corev1.Secret{}
+		err = c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: remoteURL.Credentials.Name, Namespace: jenkins.Namespace}, secret)
+		if err != nil && apierrors.IsNotFound(err) {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.remoteURLs '%s' secret '%s' not found", remoteURL.URL, remoteURL.Credentials.Name))
+			continue
+		} else if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+		_, hasToken := secret.Data[TokenSecretKey]
+		_, hasUsername := secret.Data[UsernameSecretKey]
+		_, hasPassword := secret.Data[PasswordSecretKey]
+		if !hasToken && (!hasUsername || !hasPassword) {
+			messages = append(messages, fmt.Sprintf("spec.configurationAsCode.remoteURLs '%s' secret '%s' must contain '%s' and '%s' or '%s' keys",
+				remoteURL.URL, remoteURL.Credentials.Name, UsernameSecretKey, PasswordSecretKey, TokenSecretKey))
+		}
+	}
+
+	return messages, nil
+}
+
+func remoteURLGroovyScript(remoteURL v1alpha2.ConfigurationAsCodeRemoteURL, secret corev1.Secret, lastContentHash string) (string, error) {
+	data := struct {
+		URL             string
+		LastContentHash string
+		Username        string
+		Password        string
+		Token           string
+	}{
+		URL:             encode([]byte(remoteURL.URL)),
+		LastContentHash: lastContentHash,
+		Username:        encode(secret.Data[UsernameSecretKey]),
+		Password:        encode(secret.Data[PasswordSecretKey]),
+		Token:           encode(secret.Data[TokenSecretKey]),
+	}
+
+	return render.Render(remoteURLGroovyScriptTemplate, data)
+}
+
+func remoteURLHash(remoteURL v1alpha2.ConfigurationAsCodeRemoteURL, secret corev1.Secret) string {
+	toCalculate := map[string]string{
+		"url": remoteURL.URL,
+	}
+	for key, value := range secret.Data {
+		toCalculate["secret-"+key] = string(value)
+	}
+	return calculateHash(toCalculate)
+}
+
+func remoteURLPollInterval(remoteURL v1alpha2.ConfigurationAsCodeRemoteURL) time.Duration {
+	if remoteURL.PollInterval == nil {
+		return defaultRemoteURLPollInterval
+	}
+	return remoteURL.PollInterval.Duration
+}
+
+func findRemoteURLStatus(statuses []v1alpha2.ConfigurationAsCodeRemoteURLStatus, address string) v1alpha2.ConfigurationAsCodeRemoteURLStatus {
+	for _, status := range statuses {
+		if status.URL == address {
+			return status
+		}
+	}
+	return v1alpha2.ConfigurationAsCodeRemoteURLStatus{URL: address}
+}
@@ -0,0 +1,166 @@
+package casc
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var contentHash = strings.Repeat("0123456789abcdef", 4)
+
+func jenkinsWithRemoteURL() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			ConfigurationAsCode: v1alpha2.ConfigurationAsCode{
+				RemoteURLs: []v1alpha2.ConfigurationAsCodeRemoteURL{
+					{URL: "https://config.example.com/jenkins/jenkins.yaml"},
+				},
+			},
+		},
+	}
+}
+
+func TestRemoteURLGroovyScript(t *testing.T) {
+	remoteURL := jenkinsWithRemoteURL().Spec.ConfigurationAsCode.RemoteURLs[0]
+	encoded := func(value string) string {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	}
+
+	t.Run("username and password", func(t *testing.T) {
+		secret := corev1.Secret{Data: map[string][]byte{UsernameSecretKey: []byte("user"), PasswordSecretKey: []byte("password")}}
+
+		script, err := remoteURLGroovyScript(remoteURL, secret, contentHash)
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "def url = decode('"+encoded(remoteURL.URL)+"')")
+		assert.Contains(t, script, "def lastContentHash = '"+contentHash+"'")
+		assert.Contains(t, script, "'Basic ' + Base64.getEncoder().encodeToString((decode('"+encoded("user")+"') + ':' + decode('"+encoded("password")+"'))")
+		assert.NotContains(t, script, "Bearer")
+	})
+	t.Run("token", func(t *testing.T) {
+		secret := corev1.Secret{Data: map[string][]byte{TokenSecretKey: []byte("token")}}
+
+		script, err := remoteURLGroovyScript(remoteURL, secret, "")
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "'Bearer ' + decode('"+encoded("token")+"')")
+		assert.NotContains(t, script, "Basic")
+	})
+	t.Run("without credentials", func(t *testing.T) {
+		script, err := remoteURLGroovyScript(remoteURL, corev1.Secret{}, "")
+
+		assert.NoError(t, err)
+		assert.NotContains(t, script, "Authorization")
+	})
+}
+
+func TestEnsureRemoteURLs(t *testing.T) {
+	t.Run("applies remote URL and stores content hash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithRemoteURL()
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		assert.NoError(t, err)
+		fakeClient := fake.NewFakeClient()
+		err = fakeClient.Create(context.TODO(), jenkins)
+		assert.NoError(t, err)
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).Return("Applying https://config.example.com/jenkins/jenkins.yaml\nCONTENT_HASH="+contentHash+"\n", nil)
+
+		result, err := New(jenkinsClient, fakeClient, jenkins).EnsureRemoteURLs(jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, defaultRemoteURLPollInterval, result.RequeueAfter)
+		updated := &v1alpha2.Jenkins{}
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, updated)
+		assert.NoError(t, err)
+		assert.Len(t, updated.Status.ConfigurationAsCodeRemoteURLs, 1)
+		assert.Equal(t, contentHash, updated.Status.ConfigurationAsCodeRemoteURLs[0].ContentHash)
+	})
+	t.Run("skips remote URL until poll interval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithRemoteURL()
+		remoteURL := jenkins.Spec.ConfigurationAsCode.RemoteURLs[0]
+		lastPollTime := metav1.NewTime(time.Now().Add(-time.Minute))
+		jenkins.Status.ConfigurationAsCodeRemoteURLs = []v1alpha2.ConfigurationAsCodeRemoteURLStatus{
+			{URL: remoteURL.URL, ContentHash: contentHash, Hash: remoteURLHash(remoteURL, corev1.Secret{}), LastPollTime: &lastPollTime},
+		}
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+
+		result, err := New(jenkinsClient, fake.NewFakeClient(), jenkins).EnsureRemoteURLs(jenkins)
+
+		assert.NoError(t, err)
+		assert.True(t, result.RequeueAfter > 3*time.Minute && result.RequeueAfter <= 4*time.Minute)
+	})
+	t.Run("content hash not found in logs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithRemoteURL()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).Return("", nil)
+
+		_, err := New(jenkinsClient, fake.NewFakeClient(), jenkins).EnsureRemoteURLs(jenkins)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateRemoteURLs(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+	t.Run("happy", func(t *testing.T) {
+		jenkins := jenkinsWithRemoteURL()
+		jenkins.Spec.ConfigurationAsCode.RemoteURLs[0].Credentials.Name = "config"
+		fakeClient := fake.NewFakeClient()
+		err := fakeClient.Create(context.TODO(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+			Data:       map[string][]byte{TokenSecretKey: []byte("token")},
+		})
+		assert.NoError(t, err)
+
+		messages, err := New(nil, fakeClient, jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Nil(t, messages)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		jenkins := jenkinsWithRemoteURL()
+		jenkins.Spec.ConfigurationAsCode.RemoteURLs = append(jenkins.Spec.ConfigurationAsCode.RemoteURLs,
+			v1alpha2.ConfigurationAsCodeRemoteURL{URL: "https://config.example.com/jenkins/jenkins.yaml"},
+			v1alpha2.ConfigurationAsCodeRemoteURL{
+				URL:          "file:///var/jenkins/jenkins.yaml",
+				PollInterval: &metav1.Duration{Duration: time.Second},
+				Credentials:  v1alpha2.SecretRef{Name: "config"},
+			})
+
+		messages, err := New(nil, fake.NewFakeClient(), jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"spec.configurationAsCode.remoteURLs url 'https://config.example.com/jenkins/jenkins.yaml' is not unique",
+			"spec.configurationAsCode.remoteURLs url 'file:///var/jenkins/jenkins.yaml' must be a valid http:// or https:// URL",
+			"spec.configurationAsCode.remoteURLs 'file:///var/jenkins/jenkins.yaml' pollInterval must be at least 1m",
+			"spec.configurationAsCode.remoteURLs 'file:///var/jenkins/jenkins.yaml' secret 'config' not found",
+		}, messages)
+	})
+}
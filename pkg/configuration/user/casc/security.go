@@ -0,0 +1,444 @@
+package casc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	securityConfigurationType = "user-casc-security"
+	securitySource            = "spec.security"
+	securityScriptName        = "security.yaml"
+
+	// BindDNSecretKey is key for DN of the user which searches in LDAP in the LDAP bind secret
+	BindDNSecretKey = "bindDN"
+	// BindPasswordSecretKey is key for password of the user which searches in LDAP in the LDAP bind secret
+	BindPasswordSecretKey = "bindPassword"
+	// ClientSecretSecretKey is key for secret of Jenkins client registered in the OpenID Connect identity provider
+	ClientSecretSecretKey = "clientSecret"
+
+	defaultLDAPPort  = "389"
+	defaultLDAPSPort = "636"
+	ldapDialTimeout  = 5 * time.Second
+
+	defaultOIDCScopes        = "openid email profile"
+	defaultOIDCUserNameClaim = "sub"
+	oidcWellKnownPath        = "/.well-known/openid-configuration"
+
+	operatorAdminRoleName = constants.OperatorName
+	administerPermission  = "Overall/Administer"
+)
+
+// dialLDAPServer checks whether the LDAP server accepts connections, it's replaced in tests
+var dialLDAPServer = func(address string) error {
+	connection, err := net.DialTimeout("tcp", address, ldapDialTimeout)
+	if err != nil {
+		return err
+	}
+	return connection.Close()
+}
+
+var configurationGroovyScriptTemplate = template.Must(template.New("configuration").Parse(`
+import io.jenkins.plugins.casc.ConfigurationAsCode
+import io.jenkins.plugins.casc.yaml.YamlSource
+
+def configuration = Base64.getDecoder().decode('{{ .Configuration }}')
+ConfigurationAsCode.get().configureWith(YamlSource.of(new ByteArrayInputStream(configuration)))
+`))
+
+// EnsureSecurity applies the security settings from spec.security in Jenkins by Configuration as Code
+func (c *configurationAsCode) EnsureSecurity(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
+	configuration, err := c.securityConfiguration(jenkins)
+	if err != nil || configuration == nil {
+		return false, err
+	}
+
+	return c.ensureConfiguration(jenkins, securityConfigurationType, securitySource, securityScriptName, configuration)
+}
+
+// ensureConfiguration applies Configuration as Code generated by the operator, it's applied again only when it changes
+func (c *configurationAsCode) ensureConfiguration(jenkins *v1alpha2.Jenkins, configurationType, source, name string, configuration map[string]interface{}) (requeue bool, err error) {
+	// the configuration is JSON which is a valid YAML, JSON takes care of escaping
+	content, err := json.Marshal(configuration)
+	if err != nil {
+		return true, stackerr.WithStack(err)
+	}
+	groovyScript, err := render.Render(configurationGroovyScriptTemplate, struct{ Configuration string }{Configuration: encode(content)})
+	if err != nil {
+		return true, err
+	}
+
+	hash := sha256.Sum256(content)
+	groovyClient := groovy.New(c.jenkinsClient, c.k8sClient, jenkins, configurationType, jenkins.Spec.ConfigurationAsCode.Customization)
+	return groovyClient.EnsureSingle(source, name, base64.URLEncoding.EncodeToString(hash[:]), groovyScript)
+}
+
+// securityConfiguration returns Configuration as Code of spec.security, it's nil when there is nothing to configure
+func (c *configurationAsCode) securityConfiguration(jenkins *v1alpha2.Jenkins) (map[string]interface{}, error) {
+	security := jenkins.Spec.Security
+	if security == nil {
+		return nil, nil
+	}
+
+	configuration := map[string]interface{}{}
+	if security.Realm != nil && security.Realm.LDAP != nil {
+		ldapConfiguration, err := c.ldapConfiguration(jenkins.Namespace, *security.Realm.LDAP)
+		if err != nil {
+			return nil, err
+		}
+		configuration["securityRealm"] = map[string]interface{}{
+			"ldap": map[string]interface{}{
+				"configurations": []interface{}{ldapConfiguration},
+			},
+		}
+	} else if security.Realm != nil && security.Realm.OIDC != nil {
+		oidcConfiguration, err := c.oidcConfiguration(jenkins)
+		if err != nil {
+			return nil, err
+		}
+		configuration["securityRealm"] = map[string]interface{}{
+			"oic": oidcConfiguration,
+		}
+	}
+	if security.Authorization != nil {
+		authorizationConfiguration, err := c.authorizationConfiguration(jenkins)
+		if err != nil {
+			return nil, err
+		}
+		configuration["authorizationStrategy"] = map[string]interface{}{
+			"roleBased": authorizationConfiguration,
+		}
+	}
+	if len(configuration) == 0 {
+		return nil, nil
+	}
+
+	return map[string]interface{}{
+		"jenkins": configuration,
+	}, nil
+}
+
+func (c *configurationAsCode) ldapConfiguration(namespace string, ldap v1alpha2.LDAPSecurityRealm) (map[string]interface{}, error) {
+	configuration := map[string]interface{}{
+		"server": escapeConfigurationAsCodeValue(ldap.Server),
+	}
+	optional := map[string]string{
+		"rootDN":            ldap.RootDN,
+		"userSearchBase":    ldap.UserSearchBase,
+		"userSearch":        ldap.UserSearch,
+		"groupSearchBase":   ldap.GroupSearchBase,
+		"groupSearchFilter": ldap.GroupSearchFilter,
+	}
+	for key, value := range optional {
+		if len(value) > 0 {
+			configuration[key] = escapeConfigurationAsCodeValue(value)
+		}
+	}
+
+	if ldap.GroupMembershipStrategy == v1alpha2.FromUserRecordLDAPGroupMembershipStrategy {
+		attribute := ldap.GroupMembershipAttribute
+		if len(attribute) == 0 {
+			attribute = "memberOf"
+		}
+		configuration["groupMembershipStrategy"] = map[string]interface{}{
+			"fromUserRecord": map[string]interface{}{"attributeName": escapeConfigurationAsCodeValue(attribute)},
+		}
+	} else {
+		configuration["groupMembershipStrategy"] = map[string]interface{}{
+			"fromGroupSearch": map[string]interface{}{"filter": escapeConfigurationAsCodeValue(ldap.GroupMembershipFilter)},
+		}
+	}
+
+	if len(ldap.BindSecret.Name) > 0 {
+		secret := &corev1.Secret{}
+		err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: ldap.BindSecret.Name, Namespace: namespace}, secret)
+		if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+		configuration["managerDN"] = escapeConfigurationAsCodeValue(string(secret.Data[BindDNSecretKey]))
+		configuration["managerPasswordSecret"] = escapeConfigurationAsCodeValue(string(secret.Data[BindPasswordSecretKey]))
+	}
+
+	return configuration, nil
+}
+
+// oidcConfiguration returns configuration of the oic-auth plugin, the escape hatch is always enabled, so the break-glass
+// admin can log in and fix Jenkins when the identity provider doesn't work
+func (c *configurationAsCode) oidcConfiguration(jenkins *v1alpha2.Jenkins) (map[string]interface{}, error) {
+	oidc := jenkins.Spec.Security.Realm.OIDC
+
+	clientSecret := &corev1.Secret{}
+	err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: oidc.ClientSecret.Name, Namespace: jenkins.Namespace}, clientSecret)
+	if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+	breakGlassSecret, err := c.getBreakGlassSecret(jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := defaultOIDCScopes
+	if len(oidc.Scopes) > 0 {
+		scopes = strings.Join(oidc.Scopes, " ")
+	}
+	userNameClaim := oidc.UserNameClaim
+	if len(userNameClaim) == 0 {
+		userNameClaim = defaultOIDCUserNameClaim
+	}
+
+	configuration := map[string]interface{}{
+		"automanualconfigure":             "auto",
+		"wellKnownOpenIDConfigurationUrl": escapeConfigurationAsCodeValue(strings.TrimSuffix(oidc.Issuer, "/") + oidcWellKnownPath),
+		"clientId":                        escapeConfigurationAsCodeValue(oidc.ClientID),
+		"clientSecret":                    escapeConfigurationAsCodeValue(string(clientSecret.Data[ClientSecretSecretKey])),
+		"scopes":                          escapeConfigurationAsCodeValue(scopes),
+		"userNameField":                   escapeConfigurationAsCodeValue(userNameClaim),
+		"escapeHatchEnabled":              true,
+		"escapeHatchUsername":             escapeConfigurationAsCodeValue(string(breakGlassSecret.Data[resources.BreakGlassSecretUserNameKey])),
+		"escapeHatchSecret":               escapeConfigurationAsCodeValue(string(breakGlassSecret.Data[resources.BreakGlassSecretPasswordKey])),
+	}
+	if len(oidc.GroupsClaim) > 0 {
+		configuration["groupsFieldName"] = escapeConfigurationAsCodeValue(oidc.GroupsClaim)
+	}
+
+	return configuration, nil
+}
+
+func (c *configurationAsCode) getBreakGlassSecret(jenkins *v1alpha2.Jenkins) (*corev1.Secret, error) {
+	breakGlassSecret := &corev1.Secret{}
+	err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: resources.GetBreakGlassSecretName(jenkins), Namespace: jenkins.Namespace}, breakGlassSecret)
+	if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+	return breakGlassSecret, nil
+}
+
+// authorizationConfiguration returns configuration of the role-strategy plugin, the operator user and the break-glass
+// admin are always assigned to the operator role with the administer permission, so the user roles can't lock them out
+func (c *configurationAsCode) authorizationConfiguration(jenkins *v1alpha2.Jenkins) (map[string]interface{}, error) {
+	var operatorAssignments []string
+	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy == v1alpha2.CreateUserAuthorizationStrategy {
+		operatorAssignments = append(operatorAssignments, resources.OperatorUserName)
+	}
+	if realm := jenkins.Spec.Security.Realm; realm != nil && realm.OIDC != nil {
+		breakGlassSecret, err := c.getBreakGlassSecret(jenkins)
+		if err != nil {
+			return nil, err
+		}
+		operatorAssignments = append(operatorAssignments, string(breakGlassSecret.Data[resources.BreakGlassSecretUserNameKey]))
+	}
+
+	var globalRoles []interface{}
+	if len(operatorAssignments) > 0 {
+		globalRoles = append(globalRoles, authorizationRoleConfiguration(v1alpha2.AuthorizationRole{
+			Name:        operatorAdminRoleName,
+			Permissions: []string{administerPermission},
+			Users:       operatorAssignments,
+		}))
+	}
+	for _, role := range jenkins.Spec.Security.Authorization.GlobalRoles {
+		globalRoles = append(globalRoles, authorizationRoleConfiguration(role))
+	}
+	var projectRoles []interface{}
+	for _, role := range jenkins.Spec.Security.Authorization.ProjectRoles {
+		projectRoles = append(projectRoles, authorizationRoleConfiguration(role))
+	}
+
+	roles := map[string]interface{}{}
+	if len(globalRoles) > 0 {
+		roles["global"] = globalRoles
+	}
+	if len(projectRoles) > 0 {
+		roles["items"] = projectRoles
+	}
+	return map[string]interface{}{
+		"roles": roles,
+	}, nil
+}
+
+// authorizationRoleConfiguration returns the role of the role-strategy plugin, the plugin doesn't distinguish users
+// and groups, so both are assigned by their names
+func authorizationRoleConfiguration(role v1alpha2.AuthorizationRole) map[string]interface{} {
+	permissions := make([]string, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		permissions = append(permissions, escapeConfigurationAsCodeValue(permission))
+	}
+	assignments := make([]string, 0, len(role.Users)+len(role.Groups))
+	for _, user := range role.Users {
+		assignments = append(assignments, escapeConfigurationAsCodeValue(user))
+	}
+	for _, group := range role.Groups {
+		assignments = append(assignments, escapeConfigurationAsCodeValue(group))
+	}
+
+	configuration := map[string]interface{}{
+		"name":        escapeConfigurationAsCodeValue(role.Name),
+		"permissions": permissions,
+		"assignments": assignments,
+	}
+	if len(role.Pattern) > 0 {
+		configuration["pattern"] = escapeConfigurationAsCodeValue(role.Pattern)
+	}
+	return configuration
+}
+
+// escapeConfigurationAsCodeValue prevents Configuration as Code from resolving ${...} in values as variables
+func escapeConfigurationAsCodeValue(value string) string {
+	return strings.Replace(value, "${", "^${", -1)
+}
+
+func (c *configurationAsCode) validateSecurity(jenkins v1alpha2.Jenkins) ([]string, error) {
+	security := jenkins.Spec.Security
+	if security == nil || security.Realm == nil {
+		return nil, nil
+	}
+
+	var messages []string
+	if security.Realm.LDAP != nil {
+		ldapMessages, err := c.validateLDAP(jenkins.Namespace, *security.Realm.LDAP)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, ldapMessages...)
+	}
+	if security.Realm.OIDC != nil {
+		oidcMessages, err := c.validateOIDC(jenkins.Namespace, *security.Realm.OIDC)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, oidcMessages...)
+	}
+
+	return messages, nil
+}
+
+func (c *configurationAsCode) validateLDAP(namespace string, ldap v1alpha2.LDAPSecurityRealm) ([]string, error) {
+	var messages []string
+	switch ldap.GroupMembershipStrategy {
+	case "", v1alpha2.FromGroupSearchLDAPGroupMembershipStrategy, v1alpha2.FromUserRecordLDAPGroupMembershipStrategy:
+	default:
+		messages = append(messages, fmt.Sprintf("spec.security.realm.ldap.groupMembershipStrategy '%s' is invalid, supported strategies: %s, %s",
+			ldap.GroupMembershipStrategy, v1alpha2.FromGroupSearchLDAPGroupMembershipStrategy, v1alpha2.FromUserRecordLDAPGroupMembershipStrategy))
+	}
+
+	if len(ldap.BindSecret.Name) > 0 {
+		secret := &corev1.Secret{}
+		err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: ldap.BindSecret.Name, Namespace: namespace}, secret)
+		if err != nil && apierrors.IsNotFound(err) {
+			messages = append(messages, fmt.Sprintf("spec.security.realm.ldap.bindSecret '%s' not found", ldap.BindSecret.Name))
+		} else if err != nil {
+			return nil, stackerr.WithStack(err)
+		} else if len(secret.Data[BindDNSecretKey]) == 0 || len(secret.Data[BindPasswordSecretKey]) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.security.realm.ldap.bindSecret '%s' must contain '%s' and '%s' keys",
+				ldap.BindSecret.Name, BindDNSecretKey, BindPasswordSecretKey))
+		}
+	}
+
+	servers := strings.Fields(ldap.Server)
+	if len(servers) == 0 {
+		messages = append(messages, "spec.security.realm.ldap.server can't be empty")
+	}
+	for _, server := range servers {
+		address, err := ldapServerAddress(server)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("spec.security.realm.ldap.server '%s' is invalid: %s", server, err))
+			continue
+		}
+		if err = dialLDAPServer(address); err != nil {
+			messages = append(messages, fmt.Sprintf("spec.security.realm.ldap.server '%s' is unreachable: %s", server, err))
+		}
+	}
+
+	return messages, nil
+}
+
+func (c *configurationAsCode) validateOIDC(namespace string, oidc v1alpha2.OIDCSecurityRealm) ([]string, error) {
+	var messages []string
+	issuer, err := url.Parse(oidc.Issuer)
+	if err != nil || issuer.Scheme != "https" || len(issuer.Host) == 0 {
+		messages = append(messages, fmt.Sprintf("spec.security.realm.oidc.issuer '%s' is invalid, it must be an https URL", oidc.Issuer))
+	}
+	if len(oidc.ClientID) == 0 {
+		messages = append(messages, "spec.security.realm.oidc.clientID can't be empty")
+	}
+	if len(oidc.ClientSecret.Name) == 0 {
+		messages = append(messages, "spec.security.realm.oidc.clientSecret.name can't be empty")
+	}
+
+	secretKeys := []struct {
+		field string
+		name  string
+		keys  []string
+	}{
+		{field: "clientSecret", name: oidc.ClientSecret.Name, keys: []string{ClientSecretSecretKey}},
+		// the break-glass secret is generated by the operator when it's not set
+		{field: "breakGlassSecret", name: oidc.BreakGlassSecret.Name, keys: []string{resources.BreakGlassSecretUserNameKey, resources.BreakGlassSecretPasswordKey}},
+	}
+	for _, secretKey := range secretKeys {
+		if len(secretKey.name) == 0 {
+			continue
+		}
+		secret := &corev1.Secret{}
+		err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: secretKey.name, Namespace: namespace}, secret)
+		if err != nil && apierrors.IsNotFound(err) {
+			messages = append(messages, fmt.Sprintf("spec.security.realm.oidc.%s '%s' not found", secretKey.field, secretKey.name))
+			continue
+		} else if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+		for _, key := range secretKey.keys {
+			if len(secret.Data[key]) == 0 {
+				messages = append(messages, fmt.Sprintf("spec.security.realm.oidc.%s '%s' must contain '%s' key", secretKey.field, secretKey.name, key))
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+// ldapServerAddress returns host:port of the LDAP server in the ldap plugin format, e.g. ldaps://ldap.example.com,
+// ldap.example.com:389 or ldap.example.com
+func ldapServerAddress(server string) (string, error) {
+	if !strings.Contains(server, "://") {
+		server = "ldap://" + server
+	}
+	parsed, err := url.Parse(server)
+	if err != nil {
+		return "", err
+	}
+
+	port := defaultLDAPPort
+	switch parsed.Scheme {
+	case "ldap":
+	case "ldaps":
+		port = defaultLDAPSPort
+	default:
+		return "", stackerr.Errorf("unsupported scheme '%s', supported schemes: ldap, ldaps", parsed.Scheme)
+	}
+	if len(parsed.Hostname()) == 0 {
+		return "", stackerr.New("host can't be empty")
+	}
+	if len(parsed.Port()) > 0 {
+		port = parsed.Port()
+	}
+	return net.JoinHostPort(parsed.Hostname(), port), nil
+}
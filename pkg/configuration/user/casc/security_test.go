@@ -0,0 +1,311 @@
+package casc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func jenkinsWithLDAPRealm() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.ServiceAccountAuthorizationStrategy},
+			Security: &v1alpha2.Security{
+				Realm: &v1alpha2.SecurityRealm{
+					LDAP: &v1alpha2.LDAPSecurityRealm{
+						Server:                "ldaps://ldap.example.com",
+						RootDN:                "dc=example,dc=com",
+						BindSecret:            v1alpha2.SecretRef{Name: "ldap"},
+						UserSearchBase:        "ou=people",
+						GroupMembershipFilter: "(member={0})",
+					},
+				},
+			},
+		},
+	}
+}
+
+func ldapBindSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "default"},
+		Data: map[string][]byte{
+			BindDNSecretKey:       []byte("cn=jenkins,dc=example,dc=com"),
+			BindPasswordSecretKey: []byte("pa${ss}word"),
+		},
+	}
+}
+
+func jenkinsWithOIDCRealm() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.ServiceAccountAuthorizationStrategy},
+			Security: &v1alpha2.Security{
+				Realm: &v1alpha2.SecurityRealm{
+					OIDC: &v1alpha2.OIDCSecurityRealm{
+						Issuer:       "https://idp.example.com/realms/jenkins/",
+						ClientID:     "jenkins",
+						ClientSecret: v1alpha2.SecretRef{Name: "oidc"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func oidcSecrets() []runtime.Object {
+	return []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: "default"},
+			Data:       map[string][]byte{ClientSecretSecretKey: []byte("client-secret")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins-operator-break-glass-jenkins", Namespace: "default"},
+			Data: map[string][]byte{
+				resources.BreakGlassSecretUserNameKey: []byte(resources.BreakGlassUserName),
+				resources.BreakGlassSecretPasswordKey: []byte("break-glass-password"),
+			},
+		},
+	}
+}
+
+func TestSecurityConfiguration(t *testing.T) {
+	t.Run("LDAP from group search", func(t *testing.T) {
+		jenkins := jenkinsWithLDAPRealm()
+
+		configuration, err := New(nil, fake.NewFakeClient(ldapBindSecret()), jenkins).(*configurationAsCode).securityConfiguration(jenkins)
+
+		require.NoError(t, err)
+		content, err := json.Marshal(configuration)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"jenkins":{"securityRealm":{"ldap":{"configurations":[{
+			"server":"ldaps://ldap.example.com",
+			"rootDN":"dc=example,dc=com",
+			"userSearchBase":"ou=people",
+			"managerDN":"cn=jenkins,dc=example,dc=com",
+			"managerPasswordSecret":"pa^${ss}word",
+			"groupMembershipStrategy":{"fromGroupSearch":{"filter":"(member={0})"}}
+		}]}}}}`, string(content))
+	})
+	t.Run("LDAP from user record without bind secret", func(t *testing.T) {
+		jenkins := jenkinsWithLDAPRealm()
+		jenkins.Spec.Security.Realm.LDAP = &v1alpha2.LDAPSecurityRealm{
+			Server:                  "ldap.${DOMAIN}",
+			UserSearch:              "sAMAccountName={0}",
+			GroupMembershipStrategy: v1alpha2.FromUserRecordLDAPGroupMembershipStrategy,
+		}
+
+		configuration, err := New(nil, fake.NewFakeClient(), jenkins).(*configurationAsCode).securityConfiguration(jenkins)
+
+		require.NoError(t, err)
+		content, err := json.Marshal(configuration)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"jenkins":{"securityRealm":{"ldap":{"configurations":[{
+			"server":"ldap.^${DOMAIN}",
+			"userSearch":"sAMAccountName={0}",
+			"groupMembershipStrategy":{"fromUserRecord":{"attributeName":"memberOf"}}
+		}]}}}}`, string(content))
+	})
+	t.Run("OIDC with defaults", func(t *testing.T) {
+		jenkins := jenkinsWithOIDCRealm()
+
+		configuration, err := New(nil, fake.NewFakeClient(oidcSecrets()...), jenkins).(*configurationAsCode).securityConfiguration(jenkins)
+
+		require.NoError(t, err)
+		content, err := json.Marshal(configuration)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"jenkins":{"securityRealm":{"oic":{
+			"automanualconfigure":"auto",
+			"wellKnownOpenIDConfigurationUrl":"https://idp.example.com/realms/jenkins/.well-known/openid-configuration",
+			"clientId":"jenkins",
+			"clientSecret":"client-secret",
+			"scopes":"openid email profile",
+			"userNameField":"sub",
+			"escapeHatchEnabled":true,
+			"escapeHatchUsername":"break-glass-admin",
+			"escapeHatchSecret":"break-glass-password"
+		}}}}`, string(content))
+	})
+	t.Run("OIDC with claims and scopes", func(t *testing.T) {
+		jenkins := jenkinsWithOIDCRealm()
+		jenkins.Spec.Security.Realm.OIDC.Scopes = []string{"openid", "groups"}
+		jenkins.Spec.Security.Realm.OIDC.UserNameClaim = "preferred_username"
+		jenkins.Spec.Security.Realm.OIDC.GroupsClaim = "groups"
+
+		configuration, err := New(nil, fake.NewFakeClient(oidcSecrets()...), jenkins).(*configurationAsCode).securityConfiguration(jenkins)
+
+		require.NoError(t, err)
+		oic := configuration["jenkins"].(map[string]interface{})["securityRealm"].(map[string]interface{})["oic"].(map[string]interface{})
+		assert.Equal(t, "openid groups", oic["scopes"])
+		assert.Equal(t, "preferred_username", oic["userNameField"])
+		assert.Equal(t, "groups", oic["groupsFieldName"])
+	})
+	t.Run("authorization with createUser strategy", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy},
+				Security: &v1alpha2.Security{
+					Authorization: &v1alpha2.Authorization{
+						GlobalRoles: []v1alpha2.AuthorizationRole{
+							{Name: "read", Permissions: []string{"Overall/Read"}, Groups: []string{"developers"}},
+						},
+						ProjectRoles: []v1alpha2.AuthorizationRole{
+							{Name: "team-a", Pattern: "team-a/.*", Permissions: []string{"Job/Build", "Job/Read"}, Users: []string{"alice"}, Groups: []string{"team-a"}},
+						},
+					},
+				},
+			},
+		}
+
+		configuration, err := New(nil, fake.NewFakeClient(), jenkins).(*configurationAsCode).securityConfiguration(jenkins)
+
+		require.NoError(t, err)
+		content, err := json.Marshal(configuration)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"jenkins":{"authorizationStrategy":{"roleBased":{"roles":{
+			"global":[
+				{"name":"jenkins-operator","permissions":["Overall/Administer"],"assignments":["jenkins-operator"]},
+				{"name":"read","permissions":["Overall/Read"],"assignments":["developers"]}
+			],
+			"items":[
+				{"name":"team-a","pattern":"team-a/.*","permissions":["Job/Build","Job/Read"],"assignments":["alice","team-a"]}
+			]
+		}}}}}`, string(content))
+	})
+	t.Run("authorization with OIDC break-glass admin", func(t *testing.T) {
+		jenkins := jenkinsWithOIDCRealm()
+		jenkins.Spec.Security.Authorization = &v1alpha2.Authorization{}
+
+		configuration, err := New(nil, fake.NewFakeClient(oidcSecrets()...), jenkins).(*configurationAsCode).securityConfiguration(jenkins)
+
+		require.NoError(t, err)
+		content, err := json.Marshal(configuration["jenkins"].(map[string]interface{})["authorizationStrategy"])
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"roleBased":{"roles":{"global":[
+			{"name":"jenkins-operator","permissions":["Overall/Administer"],"assignments":["break-glass-admin"]}
+		]}}}`, string(content))
+	})
+	t.Run("no security realm", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{}
+
+		configuration, err := New(nil, fake.NewFakeClient(), jenkins).(*configurationAsCode).securityConfiguration(jenkins)
+
+		assert.NoError(t, err)
+		assert.Nil(t, configuration)
+	})
+}
+
+func TestEnsureSecurity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jenkins := jenkinsWithLDAPRealm()
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+	fakeClient := fake.NewFakeClient(ldapBindSecret())
+	require.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+
+	jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+	jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).DoAndReturn(func(script string) (string, error) {
+		matches := regexp.MustCompile(`decode\('([^']+)'\)`).FindStringSubmatch(script)
+		require.Len(t, matches, 2)
+		configuration, err := base64.StdEncoding.DecodeString(matches[1])
+		require.NoError(t, err)
+		assert.Contains(t, string(configuration), `"server":"ldaps://ldap.example.com"`)
+		return "", nil
+	})
+
+	requeue, err := New(jenkinsClient, fakeClient, jenkins).EnsureSecurity(jenkins)
+
+	assert.NoError(t, err)
+	assert.True(t, requeue)
+
+	requeue, err = New(jenkinsClient, fakeClient, jenkins).EnsureSecurity(jenkins)
+
+	assert.NoError(t, err)
+	assert.False(t, requeue)
+}
+
+func TestValidateSecurity(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+	defer func(dial func(address string) error) { dialLDAPServer = dial }(dialLDAPServer)
+
+	t.Run("happy", func(t *testing.T) {
+		var dialed []string
+		dialLDAPServer = func(address string) error {
+			dialed = append(dialed, address)
+			return nil
+		}
+		jenkins := jenkinsWithLDAPRealm()
+		jenkins.Spec.Security.Realm.LDAP.Server = "ldaps://ldap.example.com ldap2.example.com:1389"
+
+		messages, err := New(nil, fake.NewFakeClient(ldapBindSecret()), jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Nil(t, messages)
+		assert.Equal(t, []string{"ldap.example.com:636", "ldap2.example.com:1389"}, dialed)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		dialLDAPServer = func(address string) error {
+			return errors.New("connection refused")
+		}
+		jenkins := jenkinsWithLDAPRealm()
+		jenkins.Spec.Security.Realm.LDAP.Server = "ldaps://ldap.example.com http://ldap.example.com"
+		jenkins.Spec.Security.Realm.LDAP.GroupMembershipStrategy = "fromDirectory"
+
+		messages, err := New(nil, fake.NewFakeClient(), jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"spec.security.realm.ldap.groupMembershipStrategy 'fromDirectory' is invalid, supported strategies: fromGroupSearch, fromUserRecord",
+			"spec.security.realm.ldap.bindSecret 'ldap' not found",
+			"spec.security.realm.ldap.server 'ldaps://ldap.example.com' is unreachable: connection refused",
+			"spec.security.realm.ldap.server 'http://ldap.example.com' is invalid: unsupported scheme 'http', supported schemes: ldap, ldaps",
+		}, messages)
+	})
+	t.Run("OIDC happy", func(t *testing.T) {
+		jenkins := jenkinsWithOIDCRealm()
+
+		messages, err := New(nil, fake.NewFakeClient(oidcSecrets()...), jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Nil(t, messages)
+	})
+	t.Run("OIDC invalid", func(t *testing.T) {
+		jenkins := jenkinsWithOIDCRealm()
+		jenkins.Spec.Security.Realm.OIDC.Issuer = "http://idp.example.com"
+		jenkins.Spec.Security.Realm.OIDC.BreakGlassSecret = v1alpha2.SecretRef{Name: "break-glass"}
+		fakeClient := fake.NewFakeClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "break-glass", Namespace: "default"},
+			Data:       map[string][]byte{resources.BreakGlassSecretUserNameKey: []byte("admin")},
+		})
+
+		messages, err := New(nil, fakeClient, jenkins).Validate(*jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"spec.security.realm.oidc.issuer 'http://idp.example.com' is invalid, it must be an https URL",
+			"spec.security.realm.oidc.clientSecret 'oidc' not found",
+			"spec.security.realm.oidc.breakGlassSecret 'break-glass' must contain 'password' key",
+		}, messages)
+	})
+}
@@ -0,0 +1,265 @@
+package credentials
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sort"
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/go-logr/logr"
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	configurationType = "user-credentials"
+	scriptSource      = "kubernetes-secrets"
+	scriptName        = "credentials.groovy"
+
+	// TypeLabelName is the Secret label with the type of Jenkins credential, it differs from the label of
+	// kubernetes-credentials-provider-plugin, so the plugin doesn't create the same credentials
+	TypeLabelName = "jenkins.io/operator-credentials-type"
+	// DescriptionAnnotationName is the Secret annotation with the description of Jenkins credential
+	DescriptionAnnotationName = "jenkins.io/credentials-description"
+
+	// UsernamePasswordCredentialType is the Jenkins username with password credential
+	UsernamePasswordCredentialType = "usernamePassword"
+	// SecretTextCredentialType is the Jenkins secret text credential
+	SecretTextCredentialType = "secretText"
+	// BasicSSHUserPrivateKeyCredentialType is the Jenkins SSH username with private key credential
+	BasicSSHUserPrivateKeyCredentialType = "basicSSHUserPrivateKey"
+	// CertificateCredentialType is the Jenkins certificate credential
+	CertificateCredentialType = "certificate"
+
+	// UsernameSecretKey is username data key in Kubernetes secret used to create Jenkins username/password and SSH credentials
+	UsernameSecretKey = "username"
+	// PasswordSecretKey is password data key in Kubernetes secret used to create Jenkins username/password and
+	// certificate credentials
+	PasswordSecretKey = "password"
+	// TextSecretKey is text data key in Kubernetes secret used to create Jenkins secret text credential
+	TextSecretKey = "text"
+	// PrivateKeySecretKey is private key data key in Kubernetes secret used to create Jenkins SSH credential
+	PrivateKeySecretKey = "privateKey"
+	// PassphraseSecretKey is optional private key passphrase data key in Kubernetes secret used to create Jenkins SSH credential
+	PassphraseSecretKey = "passphrase"
+	// CertificateSecretKey is PKCS#12 keystore data key in Kubernetes secret used to create Jenkins certificate credential
+	CertificateSecretKey = "certificate"
+)
+
+// requiredSecretKeys contains data keys which must be set in Secrets of the supported credential types
+var requiredSecretKeys = map[string][]string{
+	UsernamePasswordCredentialType:       {UsernameSecretKey, PasswordSecretKey},
+	SecretTextCredentialType:             {TextSecretKey},
+	BasicSSHUserPrivateKeyCredentialType: {UsernameSecretKey, PrivateKeySecretKey},
+	CertificateCredentialType:            {CertificateSecretKey},
+}
+
+var credentialsGroovyScriptTemplate = template.Must(template.New(scriptName).Parse(`
+import com.cloudbees.jenkins.plugins.sshcredentials.impl.BasicSSHUserPrivateKey
+import com.cloudbees.plugins.credentials.CredentialsScope
+import com.cloudbees.plugins.credentials.SecretBytes
+import com.cloudbees.plugins.credentials.SystemCredentialsProvider
+import com.cloudbees.plugins.credentials.domains.Domain
+import com.cloudbees.plugins.credentials.impl.CertificateCredentialsImpl
+import com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl
+import hudson.util.Secret
+import org.jenkinsci.plugins.plaincredentials.impl.StringCredentialsImpl
+
+def decode = { String value -> new String(Base64.getDecoder().decode(value), 'UTF-8') }
+def credentialsStore = SystemCredentialsProvider.getInstance().getStore()
+def upsertCredentials = { credentials ->
+    def existing = credentialsStore.getCredentials(Domain.global()).find { it.id == credentials.id }
+    if (existing == null) {
+        println "Creating credentials '${credentials.id}'"
+        credentialsStore.addCredentials(Domain.global(), credentials)
+    } else {
+        println "Updating credentials '${credentials.id}'"
+        credentialsStore.updateCredentials(Domain.global(), existing, credentials)
+    }
+}
+{{ range .Credentials }}
+{{- if eq .Type "usernamePassword" }}
+upsertCredentials(new UsernamePasswordCredentialsImpl(CredentialsScope.GLOBAL, '{{ .ID }}', decode('{{ .Description }}'), decode('{{ .Username }}'), decode('{{ .Password }}')))
+{{- else if eq .Type "secretText" }}
+upsertCredentials(new StringCredentialsImpl(CredentialsScope.GLOBAL, '{{ .ID }}', decode('{{ .Description }}'), Secret.fromString(decode('{{ .Text }}'))))
+{{- else if eq .Type "basicSSHUserPrivateKey" }}
+upsertCredentials(new BasicSSHUserPrivateKey(CredentialsScope.GLOBAL, '{{ .ID }}', decode('{{ .Username }}'), new BasicSSHUserPrivateKey.DirectEntryPrivateKeySource(decode('{{ .PrivateKey }}')), decode('{{ .Passphrase }}'), decode('{{ .Description }}')))
+{{- else if eq .Type "certificate" }}
+upsertCredentials(new CertificateCredentialsImpl(CredentialsScope.GLOBAL, '{{ .ID }}', decode('{{ .Description }}'), decode('{{ .Password }}'), new CertificateCredentialsImpl.UploadedKeyStoreSource(SecretBytes.fromBytes(Base64.getDecoder().decode('{{ .Certificate }}')))))
+{{- end }}
+{{- end }}
+{{ range .Stale }}
+credentialsStore.getCredentials(Domain.global()).findAll { it.id == '{{ . }}' }.each {
+    println "Removing credentials '${it.id}'"
+    credentialsStore.removeCredentials(Domain.global(), it)
+}
+{{- end }}
+`))
+
+// Credentials synchronizes Kubernetes Secrets to Jenkins credentials
+type Credentials interface {
+	Ensure(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+}
+
+type credentials struct {
+	jenkinsClient jenkinsclient.Jenkins
+	k8sClient     k8s.Client
+	logger        logr.Logger
+}
+
+// New creates Credentials client
+func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, jenkins *v1alpha2.Jenkins) Credentials {
+	return &credentials{
+		jenkinsClient: jenkinsClient,
+		k8sClient:     k8sClient,
+		logger:        log.Log.WithValues("cr", jenkins.Name),
+	}
+}
+
+// IsEnabled returns true when Secrets are synchronized to Jenkins credentials
+func IsEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Security != nil && jenkins.Spec.Security.CredentialsSync != nil && jenkins.Spec.Security.CredentialsSync.Enabled
+}
+
+// credential is Jenkins credential created from Kubernetes Secret, all fields except ID and Type are base64 encoded
+type credential struct {
+	ID          string
+	Type        string
+	Description string
+	Username    string
+	Password    string
+	Text        string
+	PrivateKey  string
+	Passphrase  string
+	Certificate string
+}
+
+// Ensure creates and updates Jenkins credentials according to Secrets labeled with jenkins.io/operator-credentials-type and
+// removes credentials created from Secrets which don't exist anymore
+func (c *credentials) Ensure(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
+	if !IsEnabled(jenkins) && len(jenkins.Status.SyncedCredentials) == 0 {
+		return false, nil
+	}
+
+	var desired []credential
+	if IsEnabled(jenkins) {
+		desired, err = c.desiredCredentials(jenkins.Namespace)
+		if err != nil {
+			return true, err
+		}
+	}
+
+	ids := map[string]bool{}
+	var syncedCredentials []string
+	for _, credential := range desired {
+		ids[credential.ID] = true
+		syncedCredentials = append(syncedCredentials, credential.ID)
+	}
+	var stale []string
+	for _, id := range jenkins.Status.SyncedCredentials {
+		if !ids[id] {
+			stale = append(stale, id)
+		}
+	}
+
+	groovyScript, err := credentialsGroovyScript(desired, stale)
+	if err != nil {
+		return true, err
+	}
+
+	groovyClient := groovy.New(c.jenkinsClient, c.k8sClient, jenkins, configurationType, jenkins.Spec.GroovyScripts.Customization)
+	requeue, err = groovyClient.EnsureSingle(scriptSource, scriptName, credentialsHash(desired), groovyScript)
+	if err != nil || !requeue {
+		return requeue, err
+	}
+
+	if !reflect.DeepEqual(syncedCredentials, jenkins.Status.SyncedCredentials) {
+		c.logger.Info(fmt.Sprintf("Jenkins credentials have been synchronized with Secrets, credentials '%v'", syncedCredentials))
+		jenkins.Status.SyncedCredentials = syncedCredentials
+		if err = c.k8sClient.Status().Update(context.TODO(), jenkins); err != nil {
+			return true, stackerr.WithStack(err)
+		}
+	}
+
+	return true, nil
+}
+
+// desiredCredentials returns Jenkins credentials of Secrets labeled with jenkins.io/operator-credentials-type sorted by id,
+// Secrets with credential types unsupported by the operator are skipped
+func (c *credentials) desiredCredentials(namespace string) ([]credential, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.k8sClient.List(context.TODO(), secrets, k8s.InNamespace(namespace)); err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	var desired []credential
+	for _, secret := range secrets.Items {
+		credentialType, labeled := secret.Labels[TypeLabelName]
+		if !labeled {
+			continue
+		}
+		keys, supported := requiredSecretKeys[credentialType]
+		if !supported {
+			c.logger.V(log.VDebug).Info(fmt.Sprintf("Skipping Secret '%s' with unsupported credential type '%s'", secret.Name, credentialType))
+			continue
+		}
+		for _, key := range keys {
+			if len(secret.Data[key]) == 0 {
+				return nil, stackerr.Errorf("secret '%s' with credential type '%s' must contain '%s' key", secret.Name, credentialType, key)
+			}
+		}
+
+		desired = append(desired, credential{
+			ID:          secret.Name,
+			Type:        credentialType,
+			Description: encode([]byte(secret.Annotations[DescriptionAnnotationName])),
+			Username:    encode(secret.Data[UsernameSecretKey]),
+			Password:    encode(secret.Data[PasswordSecretKey]),
+			Text:        encode(secret.Data[TextSecretKey]),
+			PrivateKey:  encode(secret.Data[PrivateKeySecretKey]),
+			Passphrase:  encode(secret.Data[PassphraseSecretKey]),
+			Certificate: encode(secret.Data[CertificateSecretKey]),
+		})
+	}
+
+	sort.Slice(desired, func(i, j int) bool {
+		return desired[i].ID < desired[j].ID
+	})
+	return desired, nil
+}
+
+func credentialsGroovyScript(desired []credential, stale []string) (string, error) {
+	data := struct {
+		Credentials []credential
+		Stale       []string
+	}{
+		Credentials: desired,
+		Stale:       stale,
+	}
+
+	return render.Render(credentialsGroovyScriptTemplate, data)
+}
+
+// credentialsHash is calculated only from the desired credentials, so the script isn't executed again after the stale
+// credentials have been removed
+func credentialsHash(desired []credential) string {
+	hash := sha256.New()
+	for _, credential := range desired {
+		hash.Write([]byte(fmt.Sprintf("%+v", credential)))
+	}
+	return base64.URLEncoding.EncodeToString(hash.Sum(nil))
+}
+
+func encode(value []byte) string {
+	return base64.StdEncoding.EncodeToString(value)
+}
@@ -0,0 +1,159 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func jenkinsWithCredentialsSync(enabled bool) *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Security: &v1alpha2.Security{
+				CredentialsSync: &v1alpha2.CredentialsSync{Enabled: enabled},
+			},
+		},
+	}
+}
+
+func credentialsSecret(name, credentialType string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Labels:      map[string]string{TypeLabelName: credentialType},
+			Annotations: map[string]string{DescriptionAnnotationName: name + " description"},
+		},
+		Data: map[string][]byte{},
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return secret
+}
+
+func TestCredentialsGroovyScript(t *testing.T) {
+	desired := []credential{
+		{ID: "certificate", Type: CertificateCredentialType, Password: encode([]byte("password")), Certificate: encode([]byte("keystore"))},
+		{ID: "secret-text", Type: SecretTextCredentialType, Text: encode([]byte("text"))},
+		{ID: "ssh", Type: BasicSSHUserPrivateKeyCredentialType, Username: encode([]byte("git")), PrivateKey: encode([]byte("key"))},
+		{ID: "user", Type: UsernamePasswordCredentialType, Username: encode([]byte("user")), Password: encode([]byte("password"))},
+	}
+
+	script, err := credentialsGroovyScript(desired, []string{"removed"})
+
+	require.NoError(t, err)
+	assert.Contains(t, script, "new CertificateCredentialsImpl(CredentialsScope.GLOBAL, 'certificate', decode(''), decode('"+encode([]byte("password"))+"'), "+
+		"new CertificateCredentialsImpl.UploadedKeyStoreSource(SecretBytes.fromBytes(Base64.getDecoder().decode('"+encode([]byte("keystore"))+"'))))")
+	assert.Contains(t, script, "new StringCredentialsImpl(CredentialsScope.GLOBAL, 'secret-text', decode(''), Secret.fromString(decode('"+encode([]byte("text"))+"')))")
+	assert.Contains(t, script, "new BasicSSHUserPrivateKey(CredentialsScope.GLOBAL, 'ssh', decode('"+encode([]byte("git"))+"'), "+
+		"new BasicSSHUserPrivateKey.DirectEntryPrivateKeySource(decode('"+encode([]byte("key"))+"')), decode(''), decode(''))")
+	assert.Contains(t, script, "new UsernamePasswordCredentialsImpl(CredentialsScope.GLOBAL, 'user', decode(''), decode('"+encode([]byte("user"))+"'), decode('"+encode([]byte("password"))+"'))")
+	assert.Contains(t, script, "findAll { it.id == 'removed' }")
+}
+
+func TestEnsure(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	t.Run("disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+
+		requeue, err := New(jenkinsClient, fake.NewFakeClient(), jenkins).Ensure(jenkins)
+
+		assert.NoError(t, err)
+		assert.False(t, requeue)
+	})
+	t.Run("synchronizes labeled secrets and removes stale credentials", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithCredentialsSync(true)
+		jenkins.Status.SyncedCredentials = []string{"removed", "user"}
+		fakeClient := fake.NewFakeClient(
+			credentialsSecret("user", UsernamePasswordCredentialType, map[string]string{UsernameSecretKey: "user", PasswordSecretKey: "password"}),
+			credentialsSecret("token", SecretTextCredentialType, map[string]string{TextSecretKey: "token"}),
+			credentialsSecret("aws", "aws", map[string]string{"accessKeyID": "id"}),
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "not-labeled", Namespace: "default"}},
+		)
+		require.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).DoAndReturn(func(script string) (string, error) {
+			assert.Contains(t, script, "'token'")
+			assert.Contains(t, script, "'user'")
+			assert.NotContains(t, script, "'aws'")
+			assert.NotContains(t, script, "'not-labeled'")
+			assert.Contains(t, script, "findAll { it.id == 'removed' }")
+			assert.NotContains(t, script, "findAll { it.id == 'user' }")
+			return "", nil
+		})
+
+		requeue, err := New(jenkinsClient, fakeClient, jenkins).Ensure(jenkins)
+
+		assert.NoError(t, err)
+		assert.True(t, requeue)
+		updated := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, updated))
+		assert.Equal(t, []string{"token", "user"}, updated.Status.SyncedCredentials)
+
+		requeue, err = New(jenkinsClient, fakeClient, updated).Ensure(updated)
+
+		assert.NoError(t, err)
+		assert.False(t, requeue)
+	})
+	t.Run("removes credentials when disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithCredentialsSync(false)
+		jenkins.Status.SyncedCredentials = []string{"user"}
+		fakeClient := fake.NewFakeClient(
+			credentialsSecret("user", UsernamePasswordCredentialType, map[string]string{UsernameSecretKey: "user", PasswordSecretKey: "password"}),
+		)
+		require.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).DoAndReturn(func(script string) (string, error) {
+			assert.NotContains(t, script, "upsertCredentials(new")
+			assert.Contains(t, script, "findAll { it.id == 'user' }")
+			return "", nil
+		})
+
+		requeue, err := New(jenkinsClient, fakeClient, jenkins).Ensure(jenkins)
+
+		assert.NoError(t, err)
+		assert.True(t, requeue)
+		assert.Empty(t, jenkins.Status.SyncedCredentials)
+	})
+	t.Run("missing secret key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsWithCredentialsSync(true)
+		fakeClient := fake.NewFakeClient(
+			credentialsSecret("ssh", BasicSSHUserPrivateKeyCredentialType, map[string]string{UsernameSecretKey: "git"}),
+		)
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+
+		_, err := New(jenkinsClient, fakeClient, jenkins).Ensure(jenkins)
+
+		assert.EqualError(t, err, "secret 'ssh' with credential type 'basicSSHUserPrivateKey' must contain 'privateKey' key")
+	})
+}
@@ -0,0 +1,2 @@
+// Package credentials synchronizes Kubernetes Secrets to Jenkins credentials
+package credentials
@@ -4,16 +4,21 @@ import (
 	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/awssecrets"
 
 	"github.com/go-logr/logr"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/agents"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/credentials"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
 	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+	"github.com/jenkinsci/kubernetes-operator/pkg/vault"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -49,13 +54,58 @@ func (r *reconcileUserConfiguration) ReconcileCasc() (reconcile.Result, error) {
 		return result, nil
 	}
 
-	return reconcile.Result{}, nil
+	configurationAsCodeClient := casc.New(r.jenkinsClient, r.Client, r.Configuration.Jenkins)
+	requeue, err := configurationAsCodeClient.EnsureSecurity(r.Configuration.Jenkins)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	requeue, err = configurationAsCodeClient.EnsureAgentPodTemplates(r.Configuration.Jenkins)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	gitResult, err := configurationAsCodeClient.EnsureGitRepositories(r.Configuration.Jenkins)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	remoteURLResult, err := configurationAsCodeClient.EnsureRemoteURLs(r.Configuration.Jenkins)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if gitResult.RequeueAfter == 0 || (remoteURLResult.RequeueAfter > 0 && remoteURLResult.RequeueAfter < gitResult.RequeueAfter) {
+		return remoteURLResult, nil
+	}
+	return gitResult, nil
 }
 
 // Reconcile it's a main reconciliation loop for user supplied configuration
 func (r *reconcileUserConfiguration) ReconcileOthers() (reconcile.Result, error) {
 	backupAndRestore := backuprestore.New(r.Configuration, r.logger)
 
+	requeue, err := credentials.New(r.jenkinsClient, r.Client, r.Configuration.Jenkins).Ensure(r.Configuration.Jenkins)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	requeue, err = agents.New(r.jenkinsClient, r.Configuration).Ensure(r.Configuration.Jenkins)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
 	result, err := r.ensureSeedJobs()
 	if err != nil {
 		return reconcile.Result{}, err
@@ -64,6 +114,10 @@ func (r *reconcileUserConfiguration) ReconcileOthers() (reconcile.Result, error)
 		return result, nil
 	}
 
+	if err := backupAndRestore.RestoreDryRun(); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if err := backupAndRestore.Restore(r.jenkinsClient); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -92,6 +146,16 @@ func (r *reconcileUserConfiguration) ensureSeedJobs() (reconcile.Result, error)
 
 func (r *reconcileUserConfiguration) ensureCasc(jenkinsClient jenkinsclient.Jenkins) (reconcile.Result, error) {
 	configurationAsCodeClient := casc.New(jenkinsClient, r.Client, r.Configuration.Jenkins)
+	if r.Configuration.Jenkins.Spec.ConfigurationAsCode.ReloadStrategy == v1alpha2.RestartConfigurationAsCodeReloadStrategy {
+		changes, err := configurationAsCodeClient.Changes()
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(changes) > 0 {
+			return reconcile.Result{Requeue: true}, r.Configuration.RestartJenkinsMasterPod(reason.NewPodRestart(reason.OperatorSource, changes))
+		}
+	}
+
 	requeue, err := configurationAsCodeClient.Ensure(r.Configuration.Jenkins)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -100,7 +164,9 @@ func (r *reconcileUserConfiguration) ensureCasc(jenkinsClient jenkinsclient.Jenk
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	groovyClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, "user-groovy", r.Configuration.Jenkins.Spec.GroovyScripts.Customization)
+	groovyClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, "user-groovy", r.Configuration.Jenkins.Spec.GroovyScripts.Customization).
+		WithPlaceholderResolver(vault.New(r.Client, r.Configuration.Jenkins).Resolve).
+		WithPlaceholderResolver(awssecrets.New(r.Configuration.Jenkins).Resolve)
 	requeue, err = groovyClient.WaitForSecretSynchronization(resources.GroovyScriptsSecretVolumePath)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -0,0 +1,41 @@
+package seedjobs
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// buildResultFailure is the result of the failed Jenkins build
+const buildResultFailure = "FAILURE"
+
+var seedJobFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "jenkins_operator",
+	Subsystem: "seed_job",
+	Name:      "failures_total",
+	Help:      "Number of failed seed job builds observed by the operator.",
+}, []string{"namespace", "jenkins", "seed_job"})
+
+func init() {
+	metrics.Registry.MustRegister(seedJobFailuresTotal)
+}
+
+// observeSeedJobFailures counts failed builds of seed jobs which aren't recorded in the previous statuses yet
+func observeSeedJobFailures(jenkins v1alpha2.Jenkins, previous, current []v1alpha2.SeedJobStatus) {
+	recorded := map[string]int64{}
+	for _, status := range previous {
+		if status.LastBuildResult == buildResultFailure {
+			recorded[status.ID] = status.LastBuildNumber
+		}
+	}
+	for _, status := range current {
+		if status.LastBuildResult != buildResultFailure {
+			continue
+		}
+		if buildNumber, found := recorded[status.ID]; found && buildNumber == status.LastBuildNumber {
+			continue
+		}
+		seedJobFailuresTotal.WithLabelValues(jenkins.Namespace, jenkins.Name, status.ID).Inc()
+	}
+}
@@ -0,0 +1,36 @@
+package seedjobs
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObserveSeedJobFailures(t *testing.T) {
+	jenkins := v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "jenkins"}}
+	failures := func(seedJob string) float64 {
+		return testutil.ToFloat64(seedJobFailuresTotal.WithLabelValues("ns", "jenkins", seedJob))
+	}
+
+	observeSeedJobFailures(jenkins, nil, []v1alpha2.SeedJobStatus{
+		{ID: "failed", LastBuildNumber: 1, LastBuildResult: buildResultFailure},
+		{ID: "succeeded", LastBuildNumber: 1, LastBuildResult: "SUCCESS"},
+	})
+	assert.Equal(t, float64(1), failures("failed"))
+	assert.Equal(t, float64(0), failures("succeeded"))
+
+	// the same failed build is counted once
+	observeSeedJobFailures(jenkins,
+		[]v1alpha2.SeedJobStatus{{ID: "failed", LastBuildNumber: 1, LastBuildResult: buildResultFailure}},
+		[]v1alpha2.SeedJobStatus{{ID: "failed", LastBuildNumber: 1, LastBuildResult: buildResultFailure}})
+	assert.Equal(t, float64(1), failures("failed"))
+
+	observeSeedJobFailures(jenkins,
+		[]v1alpha2.SeedJobStatus{{ID: "failed", LastBuildNumber: 1, LastBuildResult: buildResultFailure}},
+		[]v1alpha2.SeedJobStatus{{ID: "failed", LastBuildNumber: 2, LastBuildResult: buildResultFailure}})
+	assert.Equal(t, float64(2), failures("failed"))
+}
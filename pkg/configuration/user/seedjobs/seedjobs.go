@@ -3,9 +3,13 @@ package seedjobs
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"net/url"
 	"reflect"
+	"strings"
 	"text/template"
 
 	"github.com/go-logr/logr"
@@ -34,16 +38,39 @@ const (
 	PasswordSecretKey = "password"
 	// PrivateKeySecretKey is private key data key in Kubernetes secret used to create Jenkins SSH credential
 	PrivateKeySecretKey = "privateKey"
+	// PassphraseSecretKey is optional private key passphrase data key in Kubernetes secret used to create Jenkins SSH credential
+	PassphraseSecretKey = "passphrase"
+	// AppIDSecretKey is GitHub App ID data key in Kubernetes secret used to create Jenkins GitHub App credential
+	AppIDSecretKey = "appId"
+	// OwnerSecretKey is GitHub App installation owner data key in Kubernetes secret used to create Jenkins GitHub App credential
+	OwnerSecretKey = "owner"
+	// TokenSecretKey is token data key in Kubernetes secret used to create Jenkins GitLab and Bitbucket token credentials
+	TokenSecretKey = "token"
+
+	// gitLabTokenUsername is the username used to clone GitLab repository over HTTPS with the token
+	gitLabTokenUsername = "oauth2"
+	// bitbucketTokenUsername is the default username used to clone Bitbucket repository over HTTPS with the token
+	bitbucketTokenUsername = "x-token-auth"
+
+	// gitLabAPITokenCredentialIDSuffix is the suffix of Jenkins GitLab API token credential ID created from
+	// the gitlabApiToken secret, the credential ID without suffix is used to clone the repository
+	gitLabAPITokenCredentialIDSuffix = "-gitlab-api-token"
 
 	// JenkinsCredentialTypeLabelName is label for kubernetes-credentials-provider-plugin which determine Jenkins
 	// credential type
 	JenkinsCredentialTypeLabelName = "jenkins.io/credentials-type"
 
+	// RebuildSeedJobAnnotation is the Jenkins CR annotation with the seed job id which should be built immediately,
+	// the annotation is removed after the build is scheduled
+	RebuildSeedJobAnnotation = "jenkins.io/rebuild-seed-job"
+
 	// AgentName is the name of seed job agent
 	AgentName = "seed-job-agent"
 
 	creatingGroovyScriptName = "seed-job-groovy-script.groovy"
 
+	defaultMultibranchScriptPath = "Jenkinsfile"
+
 	homeVolumeName = "home"
 	homeVolumePath = "/home/jenkins/agent"
 
@@ -73,6 +100,45 @@ import com.cloudbees.jenkins.GitHubPushTrigger;
 {{ if .BitbucketPushTrigger }}
 import com.cloudbees.jenkins.plugins.BitBucketTrigger;
 {{ end }}
+{{ if .Credential.Password }}
+import com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl;
+{{ end }}
+{{ if .Credential.AppID }}
+import org.jenkinsci.plugins.github_branch_source.GitHubAppCredentials;
+{{ end }}
+{{ if .BitbucketEndpoint }}
+import com.cloudbees.jenkins.plugins.bitbucket.endpoints.BitbucketCloudEndpoint;
+import com.cloudbees.jenkins.plugins.bitbucket.endpoints.BitbucketEndpointConfiguration;
+import com.cloudbees.jenkins.plugins.bitbucket.endpoints.BitbucketServerEndpoint;
+{{ end }}
+{{ if .GitLabServerURL }}
+import com.dabsquared.gitlabjenkins.connection.GitLabApiTokenImpl;
+import com.dabsquared.gitlabjenkins.connection.GitLabConnection;
+import com.dabsquared.gitlabjenkins.connection.GitLabConnectionConfig;
+import com.dabsquared.gitlabjenkins.connection.GitLabConnectionProperty;
+{{ end }}
+{{ if .Multibranch }}
+import com.cloudbees.hudson.plugins.folder.computed.DefaultOrphanedItemStrategy;
+import com.cloudbees.hudson.plugins.folder.computed.PeriodicFolderTrigger;
+import jenkins.branch.BranchSource;
+import jenkins.scm.impl.trait.WildcardSCMHeadFilterTrait;
+import org.jenkinsci.plugins.workflow.multibranch.WorkflowBranchProjectFactory;
+import org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject;
+{{ if .Multibranch.DiscoverPullRequests }}
+import org.jenkinsci.plugins.github_branch_source.BranchDiscoveryTrait;
+import org.jenkinsci.plugins.github_branch_source.GitHubSCMSource;
+import org.jenkinsci.plugins.github_branch_source.OriginPullRequestDiscoveryTrait;
+import org.jenkinsci.plugins.github_branch_source.TagDiscoveryTrait;
+{{ else }}
+import jenkins.plugins.git.GitSCMSource;
+import jenkins.plugins.git.traits.BranchDiscoveryTrait;
+import jenkins.plugins.git.traits.TagDiscoveryTrait;
+{{ end }}
+{{ end }}
+{{ if .GitLabPushTrigger }}
+import com.dabsquared.gitlabjenkins.GitLabPushTrigger;
+import com.dabsquared.gitlabjenkins.trigger.filter.BranchFilterType;
+{{ end }}
 import hudson.model.FreeStyleProject;
 import hudson.model.labels.LabelAtom;
 import hudson.plugins.git.BranchSpec;
@@ -92,7 +158,88 @@ Jenkins jenkins = Jenkins.instance
 
 def jobDslSeedName = "{{ .ID }}-{{ .SeedJobSuffix }}";
 def jobRef = jenkins.getItem(jobDslSeedName)
+{{ if .OperatorManagedCredential }}
+def decode = { String value -> new String(Base64.getDecoder().decode(value), 'UTF-8') }
+def credentialsStore = SystemCredentialsProvider.getInstance().getStore()
+def upsertCredentials = { credentials ->
+    def existing = credentialsStore.getCredentials(Domain.global()).find { it.id == credentials.id }
+    if (existing == null) {
+        credentialsStore.addCredentials(Domain.global(), credentials)
+    } else {
+        credentialsStore.updateCredentials(Domain.global(), existing, credentials)
+    }
+}
+{{ end }}
+{{ if .Credential.Password }}
+upsertCredentials(new UsernamePasswordCredentialsImpl(CredentialsScope.GLOBAL, "{{ .CredentialID }}", "Credentials of {{ .ID }} seed job", decode('{{ .Credential.Username }}'), decode('{{ .Credential.Password }}')))
+{{ end }}
+{{ if .Credential.AppID }}
+def gitHubAppPrivateKey = decode('{{ .Credential.PrivateKey }}')
+def gitHubAppCredentials = new GitHubAppCredentials(CredentialsScope.GLOBAL, "{{ .CredentialID }}", "GitHub App of {{ .ID }} seed job", decode('{{ .Credential.AppID }}'), Secret.fromString(gitHubAppPrivateKey))
+{{ if .Credential.Owner }}
+gitHubAppCredentials.setOwner(decode('{{ .Credential.Owner }}'))
+{{ end }}
+upsertCredentials(gitHubAppCredentials)
+{{ end }}
+{{ if .BitbucketEndpoint }}
+{{ if .BitbucketServerURL }}
+def bitbucketEndpoint = new BitbucketServerEndpoint("{{ .ID }}", "{{ .BitbucketServerURL }}", {{ .BitbucketManageHooks }}, "{{ .CredentialID }}")
+{{ else }}
+def bitbucketEndpoint = new BitbucketCloudEndpoint({{ .BitbucketManageHooks }}, "{{ .CredentialID }}")
+{{ end }}
+BitbucketEndpointConfiguration.get().updateEndpoint(bitbucketEndpoint)
+{{ end }}
+{{ if .GitLabServerURL }}
+def gitLabToken = decode('{{ .Credential.Password }}')
+def gitLabServerURL = decode('{{ .GitLabServerURL }}')
+upsertCredentials(new GitLabApiTokenImpl(CredentialsScope.GLOBAL, "{{ .GitLabAPITokenCredentialID }}", "GitLab API token of {{ .ID }} seed job", Secret.fromString(gitLabToken)))
+
+def gitLabConnectionName = "{{ .ID }}"
+def gitLabConnectionConfig = jenkins.getDescriptorByType(GitLabConnectionConfig)
+def gitLabConnections = gitLabConnectionConfig.getConnections().findAll { it.getName() != gitLabConnectionName }
+gitLabConnections.add(new GitLabConnection(gitLabConnectionName, gitLabServerURL, "{{ .GitLabAPITokenCredentialID }}", "autodetect", false, 10, 10))
+gitLabConnectionConfig.setConnections(gitLabConnections)
+gitLabConnectionConfig.save()
+{{ end }}
 
+{{ if .Multibranch }}
+def multibranchRef = jenkins.getItem("{{ .ID }}")
+if (multibranchRef == null) {
+        multibranchRef = jenkins.createProject(WorkflowMultiBranchProject, "{{ .ID }}")
+}
+multibranchRef.setDisplayName("Multibranch Pipeline from {{ .ID }}")
+
+{{ if .Multibranch.DiscoverPullRequests }}
+def scmSource = new GitHubSCMSource("{{ .GitHubRepositoryOwner }}", "{{ .GitHubRepository }}")
+def scmTraits = [new BranchDiscoveryTrait(1), new OriginPullRequestDiscoveryTrait(1)]
+{{ else }}
+def scmSource = new GitSCMSource("{{ .RepositoryURL }}")
+def scmTraits = [new BranchDiscoveryTrait()]
+{{ end }}
+scmSource.setId("{{ .ID }}")
+scmSource.setCredentialsId("{{ .CredentialID }}")
+{{ if .Multibranch.DiscoverTags }}
+scmTraits.add(new TagDiscoveryTrait())
+{{ end }}
+scmTraits.add(new WildcardSCMHeadFilterTrait("{{ .MultibranchIncludeBranches }}", "{{ .Multibranch.ExcludeBranches }}"))
+scmSource.setTraits(scmTraits)
+multibranchRef.setSourcesList([new BranchSource(scmSource)])
+
+def projectFactory = new WorkflowBranchProjectFactory()
+projectFactory.setScriptPath("{{ .MultibranchScriptPath }}")
+multibranchRef.setProjectFactory(projectFactory)
+multibranchRef.setOrphanedItemStrategy(new DefaultOrphanedItemStrategy(true, "{{ .MultibranchDaysToKeep }}", "{{ .MultibranchNumToKeep }}"))
+
+def periodicFolderTrigger = multibranchRef.getTrigger(PeriodicFolderTrigger)
+if (periodicFolderTrigger != null) {
+        multibranchRef.removeTrigger(periodicFolderTrigger)
+}
+{{ if .Multibranch.ScanInterval }}
+multibranchRef.addTrigger(new PeriodicFolderTrigger("{{ .Multibranch.ScanInterval }}"))
+{{ end }}
+multibranchRef.save()
+multibranchRef.scheduleBuild2(0)
+{{ else }}
 def repoList = GitSCM.createRepoList("{{ .RepositoryURL }}", "{{ .CredentialID }}")
 def gitExtensions = [new CloneOption(true, true, ";", 10), new CleanBeforeCheckout()]
 def scm = new GitSCM(
@@ -126,8 +273,12 @@ jobRef.getBuildersList().add(new Shell("{{ .ExecuteShell }}"))
 jobRef.getBuildersList().add(executeDslScripts)
 jobRef.setDisplayName("Seed Job from {{ .ID }}")
 jobRef.setScm(scm)
+{{ if .GitLabServerURL }}
+jobRef.removeProperty(GitLabConnectionProperty)
+jobRef.addProperty(new GitLabConnectionProperty(gitLabConnectionName))
+{{ end }}
 
-
+jobRef.getTriggers().keySet().toList().each { jobRef.removeTrigger(it) }
 {{ if .PollSCM }}
 jobRef.addTrigger(new SCMTrigger("{{ .PollSCM }}"))
 {{ end }}
@@ -140,11 +291,52 @@ jobRef.addTrigger(new GitHubPushTrigger())
 jobRef.addTrigger(new BitBucketTrigger())
 {{ end }}
 
+{{ if .GitLabPushTrigger }}
+def gitLabPushTrigger = new GitLabPushTrigger()
+gitLabPushTrigger.setTriggerOnPush(true)
+gitLabPushTrigger.setBranchFilterType(BranchFilterType.All)
+jobRef.addTrigger(gitLabPushTrigger)
+{{ end }}
+
+{{ if .GitLabRegisterWebhook }}
+def jenkinsURL = JenkinsLocationConfiguration.get().getUrl()
+if (jenkinsURL == null) {
+    throw new Exception("Jenkins URL is not configured, can't register GitLab web hook of {{ .ID }} seed job")
+}
+def gitLabHookURL = jenkinsURL + (jenkinsURL.endsWith("/") ? "" : "/") + "project/" + jobDslSeedName
+def gitLabHooksURL = gitLabServerURL + '/api/v4/projects/{{ .GitLabProject }}/hooks'
+def gitLabHookRegistered = false
+// hooks are paginated, the header of the next page is empty on the last one
+def gitLabHooksPage = "1"
+while (gitLabHooksPage && !gitLabHookRegistered) {
+    def gitLabHooksConnection = new URL(gitLabHooksURL + "?per_page=100&page=" + gitLabHooksPage).openConnection()
+    gitLabHooksConnection.setRequestProperty("PRIVATE-TOKEN", gitLabToken)
+    def gitLabHooks = new groovy.json.JsonSlurper().parse(gitLabHooksConnection.getInputStream())
+    gitLabHookRegistered = gitLabHooks.any { it.url == gitLabHookURL }
+    gitLabHooksPage = gitLabHooksConnection.getHeaderField("X-Next-Page")
+}
+if (!gitLabHookRegistered) {
+    def gitLabHookConnection = new URL(gitLabHooksURL).openConnection()
+    gitLabHookConnection.setRequestMethod("POST")
+    gitLabHookConnection.setDoOutput(true)
+    gitLabHookConnection.setRequestProperty("PRIVATE-TOKEN", gitLabToken)
+    gitLabHookConnection.setRequestProperty("Content-Type", "application/json")
+    gitLabHookConnection.getOutputStream().withWriter { it << groovy.json.JsonOutput.toJson([url: gitLabHookURL, push_events: true]) }
+    if (gitLabHookConnection.getResponseCode() != 201) {
+        throw new Exception("Couldn't register GitLab web hook of {{ .ID }} seed job, response code: " + gitLabHookConnection.getResponseCode())
+    }
+}
+{{ end }}
+
 {{ if .BuildPeriodically }}
 jobRef.addTrigger(new TimerTrigger("{{ .BuildPeriodically }}"))
 {{ end}}
 jobRef.setAssignedLabel(new LabelAtom("{{ .AgentName }}"))
+jobRef.save()
+{{ if not .WebhookOnly }}
 jenkins.getQueue().schedule(jobRef)
+{{ end }}
+{{ end }}
 `))
 
 // SeedJobs defines client interface to SeedJobs
@@ -152,8 +344,11 @@ type SeedJobs interface {
 	EnsureSeedJobs(jenkins *v1alpha2.Jenkins) (done bool, err error)
 	waitForSeedJobAgent(agentName string) (requeue bool, err error)
 	createJobs(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+	seedJobStatuses(jenkins v1alpha2.Jenkins) ([]v1alpha2.SeedJobStatus, error)
+	rebuildSeedJob(jenkins *v1alpha2.Jenkins) error
 	ensureLabelsForSecrets(jenkins v1alpha2.Jenkins) error
 	credentialValue(namespace string, seedJob v1alpha2.SeedJob) (string, error)
+	operatorManagedCredential(namespace string, seedJob v1alpha2.SeedJob) (operatorManagedCredential, error)
 	getAllSeedJobIDs(jenkins v1alpha2.Jenkins) []string
 	isRecreatePodNeeded(jenkins v1alpha2.Jenkins) bool
 	createAgent(jenkinsClient jenkinsclient.Jenkins, k8sClient client.Client, jenkinsManifest *v1alpha2.Jenkins, namespace string, agentName string) error
@@ -161,6 +356,11 @@ type SeedJobs interface {
 	validateSchedule(job v1alpha2.SeedJob, str string, key string) []string
 	validateGitHubPushTrigger(jenkins v1alpha2.Jenkins) []string
 	validateBitbucketPushTrigger(jenkins v1alpha2.Jenkins) []string
+	validateWebhookOnly(seedJob v1alpha2.SeedJob) []string
+	validateSeedAgentImagePullSecrets(jenkins v1alpha2.Jenkins) ([]string, error)
+	validateGitLab(jenkins v1alpha2.Jenkins, seedJob v1alpha2.SeedJob) []string
+	validateBitbucket(jenkins v1alpha2.Jenkins, seedJob v1alpha2.SeedJob) []string
+	validateMultibranch(jenkins v1alpha2.Jenkins, seedJob v1alpha2.SeedJob) []string
 	validateIfIDIsUnique(seedJobs []v1alpha2.SeedJob) []string
 }
 
@@ -230,15 +430,59 @@ func (s *seedJobs) EnsureSeedJobs(jenkins *v1alpha2.Jenkins) (done bool, err err
 		return false, nil
 	}
 
+	if err = s.rebuildSeedJob(jenkins); err != nil {
+		return false, err
+	}
+
 	seedJobIDs := s.getAllSeedJobIDs(*jenkins)
 	if !reflect.DeepEqual(seedJobIDs, jenkins.Status.CreatedSeedJobs) {
 		jenkins.Status.CreatedSeedJobs = seedJobIDs
-		return false, stackerr.WithStack(s.Client.Update(context.TODO(), jenkins))
+		return false, stackerr.WithStack(s.Client.Status().Update(context.TODO(), jenkins))
+	}
+
+	seedJobStatuses, err := s.seedJobStatuses(*jenkins)
+	if err != nil {
+		return false, err
+	}
+	if !reflect.DeepEqual(seedJobStatuses, jenkins.Status.SeedJobs) {
+		observeSeedJobFailures(*jenkins, jenkins.Status.SeedJobs, seedJobStatuses)
+		jenkins.Status.SeedJobs = seedJobStatuses
+		if err = s.Client.Status().Update(context.TODO(), jenkins); err != nil {
+			return false, stackerr.WithStack(err)
+		}
 	}
 
 	return true, nil
 }
 
+// rebuildSeedJob schedules build of the seed job requested by RebuildSeedJobAnnotation and removes the annotation
+func (s *seedJobs) rebuildSeedJob(jenkins *v1alpha2.Jenkins) error {
+	id, ok := jenkins.Annotations[RebuildSeedJobAnnotation]
+	if !ok {
+		return nil
+	}
+
+	found := false
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		if seedJob.ID != id {
+			continue
+		}
+		found = true
+
+		if _, err := s.jenkinsClient.BuildJob(seedJobName(seedJob)); err != nil {
+			return stackerr.Wrapf(err, "couldn't build seed job '%s'", id)
+		}
+		s.logger.Info(fmt.Sprintf("Seed job '%s' build has been scheduled", id))
+	}
+	if !found {
+		s.logger.Info(fmt.Sprintf("Seed job '%s' from '%s' annotation not found, skipping", id, RebuildSeedJobAnnotation))
+	}
+
+	before := jenkins.DeepCopy()
+	delete(jenkins.Annotations, RebuildSeedJobAnnotation)
+	return stackerr.WithStack(s.Client.Patch(context.TODO(), jenkins, client.MergeFrom(before)))
+}
+
 func (s *seedJobs) waitForSeedJobAgent(agentName string) (requeue bool, err error) {
 	agent := appsv1.Deployment{}
 	err = s.Client.Get(context.TODO(), types.NamespacedName{Name: agentDeploymentName(*s.Jenkins, agentName), Namespace: s.Jenkins.Namespace}, &agent)
@@ -266,7 +510,12 @@ func (s *seedJobs) createJobs(jenkins *v1alpha2.Jenkins) (requeue bool, err erro
 			return true, err
 		}
 
-		groovyScript, err := seedJobCreatingGroovyScript(seedJob)
+		credential, err := s.operatorManagedCredential(jenkins.Namespace, seedJob)
+		if err != nil {
+			return true, err
+		}
+
+		groovyScript, err := seedJobCreatingGroovyScript(seedJob, credential)
 		if err != nil {
 			return true, err
 		}
@@ -292,9 +541,14 @@ func (s *seedJobs) createJobs(jenkins *v1alpha2.Jenkins) (requeue bool, err erro
 // Operator will able to watch any changes made to them
 func (s *seedJobs) ensureLabelsForSecrets(jenkins v1alpha2.Jenkins) error {
 	for _, seedJob := range jenkins.Spec.SeedJobs {
-		if seedJob.JenkinsCredentialType == v1alpha2.BasicSSHCredentialType || seedJob.JenkinsCredentialType == v1alpha2.UsernamePasswordCredentialType {
+		if seedJob.JenkinsCredentialType == v1alpha2.BasicSSHCredentialType ||
+			seedJob.JenkinsCredentialType == v1alpha2.UsernamePasswordCredentialType ||
+			isOperatorManagedCredentialType(seedJob.JenkinsCredentialType) {
 			requiredLabels := resources.BuildLabelsForWatchedResources(jenkins)
-			requiredLabels[JenkinsCredentialTypeLabelName] = string(seedJob.JenkinsCredentialType)
+			// operator managed credentials are created by the seed job Groovy script, not by kubernetes-credentials-provider-plugin
+			if !isOperatorManagedCredentialType(seedJob.JenkinsCredentialType) {
+				requiredLabels[JenkinsCredentialTypeLabelName] = string(seedJob.JenkinsCredentialType)
+			}
 
 			secret := &corev1.Secret{}
 			namespaceName := types.NamespacedName{Namespace: jenkins.ObjectMeta.Namespace, Name: seedJob.CredentialID}
@@ -316,7 +570,15 @@ func (s *seedJobs) ensureLabelsForSecrets(jenkins v1alpha2.Jenkins) error {
 }
 
 func (s *seedJobs) credentialValue(namespace string, seedJob v1alpha2.SeedJob) (string, error) {
-	if seedJob.JenkinsCredentialType == v1alpha2.BasicSSHCredentialType || seedJob.JenkinsCredentialType == v1alpha2.UsernamePasswordCredentialType {
+	if isOperatorManagedCredentialType(seedJob.JenkinsCredentialType) {
+		credential, err := s.operatorManagedCredential(namespace, seedJob)
+		if err != nil {
+			return "", err
+		}
+		return credential.Username + credential.Password + credential.AppID + credential.PrivateKey + credential.Owner, nil
+	}
+	if seedJob.JenkinsCredentialType == v1alpha2.BasicSSHCredentialType ||
+		seedJob.JenkinsCredentialType == v1alpha2.UsernamePasswordCredentialType {
 		secret := &corev1.Secret{}
 		namespaceName := types.NamespacedName{Namespace: namespace, Name: seedJob.CredentialID}
 		err := s.Client.Get(context.TODO(), namespaceName, secret)
@@ -325,13 +587,87 @@ func (s *seedJobs) credentialValue(namespace string, seedJob v1alpha2.SeedJob) (
 		}
 
 		if seedJob.JenkinsCredentialType == v1alpha2.BasicSSHCredentialType {
-			return string(secret.Data[PrivateKeySecretKey]), nil
+			return string(secret.Data[PrivateKeySecretKey]) + string(secret.Data[PassphraseSecretKey]), nil
 		}
 		return string(secret.Data[UsernameSecretKey]) + string(secret.Data[PasswordSecretKey]), nil
 	}
 	return "", nil
 }
 
+// operatorManagedCredential is Jenkins credential created by the seed job Groovy script, it's either username &
+// password or GitHub App credential
+type operatorManagedCredential struct {
+	// Username and Password are base64 encoded, so they can be safely rendered in the Groovy script
+	Username string
+	Password string
+
+	// AppID, PrivateKey and Owner are base64 encoded too, PrivateKey is GitHub App private key in PKCS#8 format
+	AppID      string
+	PrivateKey string
+	Owner      string
+}
+
+// isOperatorManagedCredentialType returns true for credential types which are created by the seed job Groovy script
+// instead of kubernetes-credentials-provider-plugin
+func isOperatorManagedCredentialType(credentialType v1alpha2.JenkinsCredentialType) bool {
+	return credentialType == v1alpha2.GitLabAPITokenCredentialType ||
+		credentialType == v1alpha2.BitbucketAppPasswordCredentialType ||
+		credentialType == v1alpha2.BitbucketAPITokenCredentialType ||
+		credentialType == v1alpha2.GitHubAppCredentialType
+}
+
+func isBitbucketCredentialType(credentialType v1alpha2.JenkinsCredentialType) bool {
+	return credentialType == v1alpha2.BitbucketAppPasswordCredentialType ||
+		credentialType == v1alpha2.BitbucketAPITokenCredentialType
+}
+
+func (s *seedJobs) operatorManagedCredential(namespace string, seedJob v1alpha2.SeedJob) (operatorManagedCredential, error) {
+	if !isOperatorManagedCredentialType(seedJob.JenkinsCredentialType) {
+		return operatorManagedCredential{}, nil
+	}
+
+	secret := &corev1.Secret{}
+	namespaceName := types.NamespacedName{Namespace: namespace, Name: seedJob.CredentialID}
+	err := s.Client.Get(context.TODO(), namespaceName, secret)
+	if err != nil {
+		return operatorManagedCredential{}, stackerr.WithStack(err)
+	}
+
+	switch seedJob.JenkinsCredentialType {
+	case v1alpha2.GitLabAPITokenCredentialType:
+		return newUsernamePasswordCredential(gitLabTokenUsername, secret.Data[TokenSecretKey]), nil
+	case v1alpha2.BitbucketAPITokenCredentialType:
+		username := string(secret.Data[UsernameSecretKey])
+		if len(username) == 0 {
+			username = bitbucketTokenUsername
+		}
+		return newUsernamePasswordCredential(username, secret.Data[TokenSecretKey]), nil
+	case v1alpha2.GitHubAppCredentialType:
+		privateKey, err := convertPrivateKeyToPKCS8(string(secret.Data[PrivateKeySecretKey]))
+		if err != nil {
+			return operatorManagedCredential{}, stackerr.Wrapf(err, "invalid GitHub App private key in secret '%s'", seedJob.CredentialID)
+		}
+		credential := operatorManagedCredential{
+			AppID:      base64.StdEncoding.EncodeToString(secret.Data[AppIDSecretKey]),
+			PrivateKey: base64.StdEncoding.EncodeToString([]byte(privateKey)),
+		}
+		if owner := secret.Data[OwnerSecretKey]; len(owner) > 0 {
+			credential.Owner = base64.StdEncoding.EncodeToString(owner)
+		}
+		return credential, nil
+	default:
+		return newUsernamePasswordCredential(string(secret.Data[UsernameSecretKey]), secret.Data[PasswordSecretKey]), nil
+	}
+}
+
+func newUsernamePasswordCredential(username string, password []byte) operatorManagedCredential {
+	credential := operatorManagedCredential{Username: base64.StdEncoding.EncodeToString([]byte(username))}
+	if len(password) > 0 {
+		credential.Password = base64.StdEncoding.EncodeToString(password)
+	}
+	return credential
+}
+
 func (s *seedJobs) getAllSeedJobIDs(jenkins v1alpha2.Jenkins) []string {
 	var ids []string
 	for _, seedJob := range jenkins.Spec.SeedJobs {
@@ -393,10 +729,36 @@ func (s *seedJobs) createAgent(jenkinsClient jenkinsclient.Jenkins, k8sClient cl
 	return nil
 }
 
+// seedJobName returns name of the Jenkins job created by the seed job
+func seedJobName(seedJob v1alpha2.SeedJob) string {
+	if seedJob.Multibranch != nil {
+		return seedJob.ID
+	}
+	return fmt.Sprintf("%s-%s", seedJob.ID, constants.SeedJobSuffix)
+}
+
 func agentDeploymentName(jenkins v1alpha2.Jenkins, agentName string) string {
 	return fmt.Sprintf("%s-%s", agentName, jenkins.Name)
 }
 
+// seedAgentNodeSelector returns the node selector of the seed agent pod which defaults to spec.master.nodeSelector,
+// the seed agent image runs only on Linux nodes so it's scheduled there unless the OS is selected explicitly
+func seedAgentNodeSelector(jenkins *v1alpha2.Jenkins) map[string]string {
+	source := jenkins.Spec.SeedAgent.NodeSelector
+	if len(source) == 0 {
+		source = jenkins.Spec.Master.NodeSelector
+	}
+
+	nodeSelector := map[string]string{}
+	for key, value := range source {
+		nodeSelector[key] = value
+	}
+	if _, ok := nodeSelector[corev1.LabelOSStable]; !ok {
+		nodeSelector[corev1.LabelOSStable] = string(v1alpha2.AgentOSLinux)
+	}
+	return nodeSelector
+}
+
 func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName string, secret string) (*appsv1.Deployment, error) {
 	jenkinsSlavesServiceFQDN, err := resources.GetJenkinsSlavesServiceFQDN(jenkins)
 	if err != nil {
@@ -406,6 +768,45 @@ func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName stri
 	if err != nil {
 		return nil, err
 	}
+
+	seedAgent := jenkins.Spec.SeedAgent
+	nodeSelector := seedAgentNodeSelector(jenkins)
+	tolerations := seedAgent.Tolerations
+	if len(tolerations) == 0 {
+		tolerations = jenkins.Spec.Master.Tolerations
+	}
+	imagePullSecrets := seedAgent.ImagePullSecrets
+	if len(imagePullSecrets) == 0 {
+		imagePullSecrets = jenkins.Spec.Master.ImagePullSecrets
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      homeVolumeName,
+			MountPath: homeVolumePath,
+		},
+		{
+			Name:      workspaceVolumeName,
+			MountPath: workspaceVolumePath,
+		},
+	}
+	volumeMounts = append(volumeMounts, seedAgent.VolumeMounts...)
+	volumes := []corev1.Volume{
+		{
+			Name: homeVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: workspaceVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	volumes = append(volumes, seedAgent.Volumes...)
+
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      agentDeploymentName(*jenkins, agentName),
@@ -424,13 +825,15 @@ func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName stri
 		Spec: appsv1.DeploymentSpec{
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
-					NodeSelector:     jenkins.Spec.Master.NodeSelector,
-					Tolerations:      jenkins.Spec.Master.Tolerations,
-					ImagePullSecrets: jenkins.Spec.Master.ImagePullSecrets,
+					NodeSelector:       nodeSelector,
+					Tolerations:        tolerations,
+					ImagePullSecrets:   imagePullSecrets,
+					ServiceAccountName: seedAgent.ServiceAccountName,
 					Containers: []corev1.Container{
 						{
-							Name:  "jnlp",
-							Image: jenkins.Spec.SeedAgent.Image,
+							Name:      "jnlp",
+							Image:     seedAgent.Image,
+							Resources: seedAgent.Resources,
 							Env: []corev1.EnvVar{
 								{
 									Name: "JENKINS_TUNNEL",
@@ -458,32 +861,10 @@ func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName stri
 									Value: homeVolumePath,
 								},
 							},
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      homeVolumeName,
-									MountPath: homeVolumePath,
-								},
-								{
-									Name:      workspaceVolumeName,
-									MountPath: workspaceVolumePath,
-								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: homeVolumeName,
-							VolumeSource: corev1.VolumeSource{
-								EmptyDir: &corev1.EmptyDirVolumeSource{},
-							},
-						},
-						{
-							Name: workspaceVolumeName,
-							VolumeSource: corev1.VolumeSource{
-								EmptyDir: &corev1.EmptyDirVolumeSource{},
-							},
+							VolumeMounts: volumeMounts,
 						},
 					},
+					Volumes: volumes,
 				},
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
@@ -500,41 +881,116 @@ func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName stri
 	}, nil
 }
 
-func seedJobCreatingGroovyScript(seedJob v1alpha2.SeedJob) (string, error) {
+func seedJobCreatingGroovyScript(seedJob v1alpha2.SeedJob, credential operatorManagedCredential) (string, error) {
+	var gitLabProject, gitHubRepositoryOwner, gitHubRepository string
+	if seedJob.Multibranch != nil && seedJob.Multibranch.DiscoverPullRequests {
+		path, err := repositoryPath(seedJob.RepositoryURL)
+		if err != nil {
+			return "", err
+		}
+		parts := strings.SplitN(path, "/", 2)
+		gitHubRepositoryOwner, gitHubRepository = parts[0], parts[1]
+	}
+	multibranchScriptPath, multibranchIncludeBranches := defaultMultibranchScriptPath, "*"
+	var multibranchDaysToKeep, multibranchNumToKeep string
+	if seedJob.Multibranch != nil {
+		if len(seedJob.Multibranch.ScriptPath) > 0 {
+			multibranchScriptPath = seedJob.Multibranch.ScriptPath
+		}
+		if len(seedJob.Multibranch.IncludeBranches) > 0 {
+			multibranchIncludeBranches = seedJob.Multibranch.IncludeBranches
+		}
+		if seedJob.Multibranch.OrphanedItemDaysToKeep > 0 {
+			multibranchDaysToKeep = fmt.Sprintf("%d", seedJob.Multibranch.OrphanedItemDaysToKeep)
+		}
+		if seedJob.Multibranch.OrphanedItemNumToKeep > 0 {
+			multibranchNumToKeep = fmt.Sprintf("%d", seedJob.Multibranch.OrphanedItemNumToKeep)
+		}
+	}
+
+	// the URL is base64 encoded, so it can be safely rendered in the Groovy script
+	var gitLabServerURL string
+	if len(seedJob.GitLabServerURL) > 0 {
+		gitLabServerURL = base64.StdEncoding.EncodeToString([]byte(strings.TrimSuffix(seedJob.GitLabServerURL, "/")))
+	}
+	if seedJob.GitLabRegisterWebhook {
+		project, err := repositoryPath(seedJob.RepositoryURL)
+		if err != nil {
+			return "", err
+		}
+		gitLabProject = url.PathEscape(project)
+	}
+
 	data := struct {
-		ID                    string
-		CredentialID          string
-		Targets               string
-		ExecuteShell          string
-		RepositoryBranch      string
-		RepositoryURL         string
-		BitbucketPushTrigger  bool
-		GitHubPushTrigger     bool
-		BuildPeriodically     string
-		PollSCM               string
-		IgnoreMissingFiles    bool
-		AdditionalClasspath   string
-		FailOnMissingPlugin   bool
-		UnstableOnDeprecation bool
-		SeedJobSuffix         string
-		AgentName             string
+		ID                         string
+		CredentialID               string
+		Targets                    string
+		ExecuteShell               string
+		RepositoryBranch           string
+		RepositoryURL              string
+		BitbucketPushTrigger       bool
+		GitHubPushTrigger          bool
+		GitLabPushTrigger          bool
+		GitLabServerURL            string
+		GitLabRegisterWebhook      bool
+		BitbucketEndpoint          bool
+		BitbucketServerURL         string
+		BitbucketManageHooks       bool
+		Multibranch                *v1alpha2.MultibranchPipeline
+		MultibranchScriptPath      string
+		MultibranchIncludeBranches string
+		MultibranchDaysToKeep      string
+		MultibranchNumToKeep       string
+		GitHubRepositoryOwner      string
+		GitHubRepository           string
+		OperatorManagedCredential  bool
+		Credential                 operatorManagedCredential
+		GitLabAPITokenCredentialID string
+		GitLabProject              string
+		BuildPeriodically          string
+		WebhookOnly                bool
+		PollSCM                    string
+		IgnoreMissingFiles         bool
+		AdditionalClasspath        string
+		FailOnMissingPlugin        bool
+		UnstableOnDeprecation      bool
+		SeedJobSuffix              string
+		AgentName                  string
 	}{
-		ID:                    seedJob.ID,
-		CredentialID:          seedJob.CredentialID,
-		Targets:               seedJob.Targets,
-		ExecuteShell:          seedJob.ExecuteShell,
-		RepositoryBranch:      seedJob.RepositoryBranch,
-		RepositoryURL:         seedJob.RepositoryURL,
-		BitbucketPushTrigger:  seedJob.BitbucketPushTrigger,
-		GitHubPushTrigger:     seedJob.GitHubPushTrigger,
-		BuildPeriodically:     seedJob.BuildPeriodically,
-		PollSCM:               seedJob.PollSCM,
-		IgnoreMissingFiles:    seedJob.IgnoreMissingFiles,
-		AdditionalClasspath:   seedJob.AdditionalClasspath,
-		FailOnMissingPlugin:   seedJob.FailOnMissingPlugin,
-		UnstableOnDeprecation: seedJob.UnstableOnDeprecation,
-		SeedJobSuffix:         constants.SeedJobSuffix,
-		AgentName:             AgentName,
+		ID:                         seedJob.ID,
+		CredentialID:               seedJob.CredentialID,
+		Targets:                    seedJob.Targets,
+		ExecuteShell:               seedJob.ExecuteShell,
+		RepositoryBranch:           seedJob.RepositoryBranch,
+		RepositoryURL:              seedJob.RepositoryURL,
+		BitbucketPushTrigger:       seedJob.BitbucketPushTrigger,
+		GitHubPushTrigger:          seedJob.GitHubPushTrigger,
+		GitLabPushTrigger:          seedJob.GitLabPushTrigger,
+		GitLabServerURL:            gitLabServerURL,
+		GitLabRegisterWebhook:      seedJob.GitLabRegisterWebhook,
+		BitbucketEndpoint:          isBitbucketCredentialType(seedJob.JenkinsCredentialType),
+		BitbucketServerURL:         strings.TrimSuffix(seedJob.BitbucketServerURL, "/"),
+		BitbucketManageHooks:       seedJob.BitbucketManageHooks,
+		Multibranch:                seedJob.Multibranch,
+		MultibranchScriptPath:      multibranchScriptPath,
+		MultibranchIncludeBranches: multibranchIncludeBranches,
+		MultibranchDaysToKeep:      multibranchDaysToKeep,
+		MultibranchNumToKeep:       multibranchNumToKeep,
+		GitHubRepositoryOwner:      gitHubRepositoryOwner,
+		GitHubRepository:           gitHubRepository,
+		OperatorManagedCredential:  isOperatorManagedCredentialType(seedJob.JenkinsCredentialType),
+		Credential:                 credential,
+		GitLabAPITokenCredentialID: seedJob.CredentialID + gitLabAPITokenCredentialIDSuffix,
+		GitLabProject:              gitLabProject,
+		BuildPeriodically:          seedJob.BuildPeriodically,
+		WebhookOnly:                seedJob.WebhookOnly,
+		PollSCM:                    seedJob.PollSCM,
+		IgnoreMissingFiles:         seedJob.IgnoreMissingFiles,
+		AdditionalClasspath:        seedJob.AdditionalClasspath,
+		FailOnMissingPlugin:        seedJob.FailOnMissingPlugin,
+		UnstableOnDeprecation:      seedJob.UnstableOnDeprecation,
+		SeedJobSuffix:              constants.SeedJobSuffix,
+		AgentName:                  AgentName,
 	}
 
 	output, err := render.Render(seedJobGroovyScriptTemplate, data)
@@ -544,3 +1000,53 @@ func seedJobCreatingGroovyScript(seedJob v1alpha2.SeedJob) (string, error) {
 
 	return output, nil
 }
+
+// repositoryPath returns repository path with owner or namespace from repository URL, for example
+// git@gitlab.com:group/project.git and https://gitlab.com/group/project.git return group/project
+func repositoryPath(repositoryURL string) (string, error) {
+	var path string
+	if strings.Contains(repositoryURL, "://") {
+		parsed, err := url.Parse(repositoryURL)
+		if err != nil {
+			return "", stackerr.WithStack(err)
+		}
+		path = parsed.Path
+	} else if index := strings.Index(repositoryURL, ":"); index >= 0 {
+		path = repositoryURL[index+1:]
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if !strings.Contains(path, "/") {
+		return "", stackerr.Errorf("couldn't get repository path from repository URL '%s'", repositoryURL)
+	}
+	return path, nil
+}
+
+// convertPrivateKeyToPKCS8 converts PEM encoded RSA private key to PKCS#8 format required by GitHub App credential,
+// GitHub generates private keys in PKCS#1 format
+func convertPrivateKeyToPKCS8(privateKey string) (string, error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return "", stackerr.New("failed to decode PEM block")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+			return "", stackerr.WithStack(err)
+		}
+		return privateKey, nil
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return "", stackerr.WithStack(err)
+		}
+		pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return "", stackerr.WithStack(err)
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})), nil
+	default:
+		return "", stackerr.Errorf("unsupported PEM block type '%s'", block.Type)
+	}
+}
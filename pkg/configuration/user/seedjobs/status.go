@@ -0,0 +1,54 @@
+package seedjobs
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	stackerr "github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// seedJobStatuses collects the latest build results of seed jobs from Jenkins
+func (s *seedJobs) seedJobStatuses(jenkins v1alpha2.Jenkins) ([]v1alpha2.SeedJobStatus, error) {
+	var statuses []v1alpha2.SeedJobStatus
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		status := v1alpha2.SeedJobStatus{ID: seedJob.ID}
+		// multibranch pipeline is a folder, its branch jobs are built instead
+		if seedJob.Multibranch != nil {
+			statuses = append(statuses, status)
+			continue
+		}
+
+		jobName := seedJobName(seedJob)
+		job, err := s.jenkinsClient.GetJob(jobName)
+		if jenkinsclient.IsNotFoundError(err) {
+			statuses = append(statuses, status)
+			continue
+		} else if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+
+		if job.Raw.LastBuild.Number == 0 {
+			statuses = append(statuses, status)
+			continue
+		}
+
+		build, err := s.jenkinsClient.GetBuild(jobName, job.Raw.LastBuild.Number)
+		if jenkinsclient.IsNotFoundError(err) {
+			statuses = append(statuses, status)
+			continue
+		} else if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+
+		status.LastBuildNumber = build.GetBuildNumber()
+		status.LastBuildResult = build.GetResult()
+		status.LastBuildTime = &metav1.Time{Time: build.GetTimestamp()}
+		if len(build.GetUrl()) > 0 {
+			status.LastBuildConsoleURL = build.GetUrl() + "console"
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
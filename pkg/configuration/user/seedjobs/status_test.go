@@ -0,0 +1,82 @@
+package seedjobs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+func TestSeedJobStatuses(t *testing.T) {
+	buildTime := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	jenkins := v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			SeedJobs: []v1alpha2.SeedJob{
+				{ID: "built"},
+				{ID: "not-built"},
+				{ID: "not-created"},
+				{ID: "multibranch", Multibranch: &v1alpha2.MultibranchPipeline{}},
+			},
+		},
+	}
+
+	t.Run("happy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetJob("built-job-dsl-seed").Return(&gojenkins.Job{
+			Raw: &gojenkins.JobResponse{LastBuild: gojenkins.JobBuild{Number: 3}},
+		}, nil)
+		jenkinsClient.EXPECT().GetBuild("built-job-dsl-seed", int64(3)).Return(&gojenkins.Build{
+			Raw: &gojenkins.BuildResponse{
+				Number:    3,
+				Result:    "FAILURE",
+				Timestamp: buildTime.UnixNano() / int64(time.Millisecond),
+				URL:       "http://jenkins/job/built-job-dsl-seed/3/",
+			},
+		}, nil)
+		jenkinsClient.EXPECT().GetJob("not-built-job-dsl-seed").Return(&gojenkins.Job{
+			Raw: &gojenkins.JobResponse{},
+		}, nil)
+		jenkinsClient.EXPECT().GetJob("not-created-job-dsl-seed").Return(nil, errors.New("404"))
+
+		seedJobsClient := New(jenkinsClient, configuration.Configuration{ClientSet: kubernetes.Clientset{}, Jenkins: &jenkins})
+		statuses, err := seedJobsClient.seedJobStatuses(jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []v1alpha2.SeedJobStatus{
+			{
+				ID:                  "built",
+				LastBuildNumber:     3,
+				LastBuildResult:     "FAILURE",
+				LastBuildTime:       &metav1.Time{Time: buildTime.Local()},
+				LastBuildConsoleURL: "http://jenkins/job/built-job-dsl-seed/3/console",
+			},
+			{ID: "not-built"},
+			{ID: "not-created"},
+			{ID: "multibranch"},
+		}, statuses)
+	})
+	t.Run("Jenkins API error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetJob("built-job-dsl-seed").Return(nil, errors.New("500"))
+
+		seedJobsClient := New(jenkinsClient, configuration.Configuration{ClientSet: kubernetes.Clientset{}, Jenkins: &jenkins})
+		_, err := seedJobsClient.seedJobStatuses(jenkins)
+
+		assert.Error(t, err)
+	})
+}
@@ -1,20 +1,28 @@
 package seedjobs
 
 import (
+	"bytes"
 	"context"
-	"crypto/x509"
+	"encoding/binary"
 	"encoding/pem"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 	stackerr "github.com/pkg/errors"
 	"github.com/robfig/cron"
+	"golang.org/x/crypto/ssh"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 )
 
+// scanIntervalRegex matches the multibranch pipeline periodic scan interval, for example 30m, 1h or 1d
+var scanIntervalRegex = regexp.MustCompile(`^[1-9][0-9]*[mhd]$`)
+
 // ValidateSeedJobs verify seed jobs configuration
 func (s *seedJobs) ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error) {
 	var messages []string
@@ -23,6 +31,12 @@ func (s *seedJobs) ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error)
 		messages = append(messages, msg...)
 	}
 
+	msg, err := s.validateSeedAgentImagePullSecrets(jenkins)
+	if err != nil {
+		return nil, err
+	}
+	messages = append(messages, msg...)
+
 	for _, seedJob := range jenkins.Spec.SeedJobs {
 		if len(seedJob.ID) == 0 {
 			messages = append(messages, fmt.Sprintf("seedJob `%s` id can't be empty", seedJob.ID))
@@ -36,7 +50,7 @@ func (s *seedJobs) ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error)
 			messages = append(messages, fmt.Sprintf("seedJob `%s` repository URL branch can't be empty", seedJob.ID))
 		}
 
-		if len(seedJob.Targets) == 0 {
+		if len(seedJob.Targets) == 0 && seedJob.Multibranch == nil {
 			messages = append(messages, fmt.Sprintf("seedJob `%s` targets can't be empty", seedJob.ID))
 		}
 
@@ -45,7 +59,8 @@ func (s *seedJobs) ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error)
 		}
 
 		if (seedJob.JenkinsCredentialType == v1alpha2.BasicSSHCredentialType ||
-			seedJob.JenkinsCredentialType == v1alpha2.UsernamePasswordCredentialType) && len(seedJob.CredentialID) == 0 {
+			seedJob.JenkinsCredentialType == v1alpha2.UsernamePasswordCredentialType ||
+			isOperatorManagedCredentialType(seedJob.JenkinsCredentialType)) && len(seedJob.CredentialID) == 0 {
 			messages = append(messages, fmt.Sprintf("seedJob `%s` credential ID can't be empty", seedJob.ID))
 		}
 
@@ -56,6 +71,7 @@ func (s *seedJobs) ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error)
 
 		if seedJob.JenkinsCredentialType == v1alpha2.BasicSSHCredentialType ||
 			seedJob.JenkinsCredentialType == v1alpha2.UsernamePasswordCredentialType ||
+			isOperatorManagedCredentialType(seedJob.JenkinsCredentialType) ||
 			seedJob.JenkinsCredentialType == v1alpha2.ExternalCredentialType {
 			secret := &v1.Secret{}
 			namespaceName := types.NamespacedName{Namespace: jenkins.Namespace, Name: seedJob.CredentialID}
@@ -73,13 +89,32 @@ func (s *seedJobs) ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error)
 					}
 				}
 			}
-			if seedJob.JenkinsCredentialType == v1alpha2.UsernamePasswordCredentialType {
+			if seedJob.JenkinsCredentialType == v1alpha2.UsernamePasswordCredentialType ||
+				seedJob.JenkinsCredentialType == v1alpha2.BitbucketAppPasswordCredentialType {
 				if msg := validateUsernamePasswordSecret(*secret); len(msg) > 0 {
 					for _, m := range msg {
 						messages = append(messages, fmt.Sprintf("seedJob `%s` %s", seedJob.ID, m))
 					}
 				}
 			}
+			if seedJob.JenkinsCredentialType == v1alpha2.GitLabAPITokenCredentialType ||
+				seedJob.JenkinsCredentialType == v1alpha2.BitbucketAPITokenCredentialType {
+				if msg := validateTokenSecret(*secret); len(msg) > 0 {
+					for _, m := range msg {
+						messages = append(messages, fmt.Sprintf("seedJob `%s` %s", seedJob.ID, m))
+					}
+				}
+			}
+			if seedJob.JenkinsCredentialType == v1alpha2.GitHubAppCredentialType {
+				if msg := validateGitHubAppSecret(*secret); len(msg) > 0 {
+					for _, m := range msg {
+						messages = append(messages, fmt.Sprintf("seedJob `%s` %s", seedJob.ID, m))
+					}
+				}
+				if err := checkPluginExists(jenkins, plugins.GitHubBranchSourcePluginName); err != nil {
+					messages = append(messages, fmt.Sprintf("seedJob `%s` GitHub App credential type cannot be used: %s", seedJob.ID, err))
+				}
+			}
 		}
 
 		if len(seedJob.BuildPeriodically) > 0 {
@@ -113,11 +148,57 @@ func (s *seedJobs) ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error)
 				}
 			}
 		}
+
+		if msg := s.validateGitLab(jenkins, seedJob); len(msg) > 0 {
+			for _, m := range msg {
+				messages = append(messages, fmt.Sprintf("seedJob `%s` %s", seedJob.ID, m))
+			}
+		}
+
+		if msg := s.validateBitbucket(jenkins, seedJob); len(msg) > 0 {
+			for _, m := range msg {
+				messages = append(messages, fmt.Sprintf("seedJob `%s` %s", seedJob.ID, m))
+			}
+		}
+
+		if seedJob.WebhookOnly {
+			if msg := s.validateWebhookOnly(seedJob); len(msg) > 0 {
+				for _, m := range msg {
+					messages = append(messages, fmt.Sprintf("seedJob `%s` %s", seedJob.ID, m))
+				}
+			}
+		}
+
+		if msg := s.validateMultibranch(jenkins, seedJob); len(msg) > 0 {
+			for _, m := range msg {
+				messages = append(messages, fmt.Sprintf("seedJob `%s` %s", seedJob.ID, m))
+			}
+		}
 	}
 
 	return messages, nil
 }
 
+func (s *seedJobs) validateSeedAgentImagePullSecrets(jenkins v1alpha2.Jenkins) ([]string, error) {
+	var messages []string
+	for _, sr := range jenkins.Spec.SeedAgent.ImagePullSecrets {
+		secret := &v1.Secret{}
+		err := s.Client.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: sr.Name}, secret)
+		if err != nil && apierrors.IsNotFound(err) {
+			messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.seedAgent.imagePullSecrets not found", sr.Name))
+			continue
+		} else if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+
+		if secret.Type != v1.SecretTypeDockerConfigJson && secret.Type != v1.SecretTypeDockercfg {
+			messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.seedAgent.imagePullSecrets must be '%s' or '%s' type",
+				sr.Name, v1.SecretTypeDockerConfigJson, v1.SecretTypeDockercfg))
+		}
+	}
+	return messages, nil
+}
+
 func (s *seedJobs) validateSchedule(job v1alpha2.SeedJob, str string, key string) []string {
 	var messages []string
 	_, err := cron.Parse(str)
@@ -143,6 +224,119 @@ func (s *seedJobs) validateBitbucketPushTrigger(jenkins v1alpha2.Jenkins) []stri
 	return messages
 }
 
+func (s *seedJobs) validateWebhookOnly(seedJob v1alpha2.SeedJob) []string {
+	var messages []string
+	if !seedJob.GitHubPushTrigger && !seedJob.BitbucketPushTrigger && !seedJob.GitLabPushTrigger {
+		messages = append(messages, "webhookOnly requires githubPushTrigger, bitbucketPushTrigger or gitlabPushTrigger")
+	}
+	if len(seedJob.BuildPeriodically) > 0 || len(seedJob.PollSCM) > 0 {
+		messages = append(messages, "webhookOnly cannot be set together with buildPeriodically or pollSCM")
+	}
+	return messages
+}
+
+func (s *seedJobs) validateGitLab(jenkins v1alpha2.Jenkins, seedJob v1alpha2.SeedJob) []string {
+	var messages []string
+	if seedJob.GitLabPushTrigger && len(seedJob.GitLabServerURL) == 0 {
+		messages = append(messages, "gitlabPushTrigger cannot be enabled: gitlabServerUrl is not set")
+	}
+	if seedJob.GitLabRegisterWebhook && !seedJob.GitLabPushTrigger {
+		messages = append(messages, "gitlabRegisterWebhook cannot be enabled: gitlabPushTrigger is not enabled")
+	}
+	if seedJob.GitLabRegisterWebhook {
+		if _, err := repositoryPath(seedJob.RepositoryURL); err != nil {
+			messages = append(messages, fmt.Sprintf("gitlabRegisterWebhook cannot be enabled: %s", err))
+		}
+	}
+	if len(seedJob.GitLabServerURL) > 0 {
+		if !strings.HasPrefix(seedJob.GitLabServerURL, "http://") && !strings.HasPrefix(seedJob.GitLabServerURL, "https://") {
+			messages = append(messages, fmt.Sprintf("gitlabServerUrl '%s' must start with http:// or https://", seedJob.GitLabServerURL))
+		}
+		if seedJob.JenkinsCredentialType != v1alpha2.GitLabAPITokenCredentialType {
+			messages = append(messages, fmt.Sprintf("gitlabServerUrl requires '%s' credential type", v1alpha2.GitLabAPITokenCredentialType))
+		}
+		if err := checkPluginExists(jenkins, "gitlab-plugin"); err != nil {
+			messages = append(messages, fmt.Sprintf("gitlabServerUrl cannot be set: %s", err))
+		}
+	}
+	return messages
+}
+
+func (s *seedJobs) validateBitbucket(jenkins v1alpha2.Jenkins, seedJob v1alpha2.SeedJob) []string {
+	var messages []string
+	if !isBitbucketCredentialType(seedJob.JenkinsCredentialType) {
+		if len(seedJob.BitbucketServerURL) > 0 || seedJob.BitbucketManageHooks {
+			messages = append(messages, fmt.Sprintf("bitbucketServerUrl and bitbucketManageHooks require '%s' or '%s' credential type",
+				v1alpha2.BitbucketAppPasswordCredentialType, v1alpha2.BitbucketAPITokenCredentialType))
+		}
+		return messages
+	}
+
+	if len(seedJob.BitbucketServerURL) > 0 &&
+		!strings.HasPrefix(seedJob.BitbucketServerURL, "http://") && !strings.HasPrefix(seedJob.BitbucketServerURL, "https://") {
+		messages = append(messages, fmt.Sprintf("bitbucketServerUrl '%s' must start with http:// or https://", seedJob.BitbucketServerURL))
+	}
+	if err := checkPluginExists(jenkins, plugins.BitbucketBranchSourcePluginName); err != nil {
+		messages = append(messages, fmt.Sprintf("Bitbucket credential type cannot be used: %s", err))
+	}
+	return messages
+}
+
+func (s *seedJobs) validateMultibranch(jenkins v1alpha2.Jenkins, seedJob v1alpha2.SeedJob) []string {
+	var messages []string
+	multibranch := seedJob.Multibranch
+	if multibranch == nil {
+		return messages
+	}
+
+	unsupported := map[string]bool{
+		"targets":              len(seedJob.Targets) > 0,
+		"buildPeriodically":    len(seedJob.BuildPeriodically) > 0,
+		"pollSCM":              len(seedJob.PollSCM) > 0,
+		"githubPushTrigger":    seedJob.GitHubPushTrigger,
+		"bitbucketPushTrigger": seedJob.BitbucketPushTrigger,
+		"gitlabPushTrigger":    seedJob.GitLabPushTrigger,
+	}
+	for _, key := range []string{"targets", "buildPeriodically", "pollSCM", "githubPushTrigger", "bitbucketPushTrigger", "gitlabPushTrigger"} {
+		if unsupported[key] {
+			messages = append(messages, fmt.Sprintf("%s cannot be set together with multibranch", key))
+		}
+	}
+
+	values := map[string]string{
+		"scriptPath":      multibranch.ScriptPath,
+		"includeBranches": multibranch.IncludeBranches,
+		"excludeBranches": multibranch.ExcludeBranches,
+	}
+	for _, key := range []string{"scriptPath", "includeBranches", "excludeBranches"} {
+		if strings.ContainsAny(values[key], "\"$\\\n") {
+			messages = append(messages, fmt.Sprintf("multibranch %s '%s' contains invalid characters", key, values[key]))
+		}
+	}
+
+	if len(multibranch.ScanInterval) > 0 && !scanIntervalRegex.MatchString(multibranch.ScanInterval) {
+		messages = append(messages, fmt.Sprintf("multibranch scanInterval '%s' is invalid, expected number followed by m, h or d, for example 1d", multibranch.ScanInterval))
+	}
+	if multibranch.OrphanedItemDaysToKeep < 0 || multibranch.OrphanedItemNumToKeep < 0 {
+		messages = append(messages, "multibranch orphanedItemDaysToKeep and orphanedItemNumToKeep can't be negative")
+	}
+
+	if multibranch.DiscoverPullRequests {
+		if !strings.Contains(seedJob.RepositoryURL, "github.com") {
+			messages = append(messages, "multibranch discoverPullRequests is supported only for GitHub repositories")
+		} else if _, err := repositoryPath(seedJob.RepositoryURL); err != nil {
+			messages = append(messages, fmt.Sprintf("multibranch discoverPullRequests cannot be enabled: %s", err))
+		}
+		if seedJob.JenkinsCredentialType == v1alpha2.BasicSSHCredentialType {
+			messages = append(messages, fmt.Sprintf("multibranch discoverPullRequests cannot be used with '%s' credential type", v1alpha2.BasicSSHCredentialType))
+		}
+		if err := checkPluginExists(jenkins, plugins.GitHubBranchSourcePluginName); err != nil {
+			messages = append(messages, fmt.Sprintf("multibranch discoverPullRequests cannot be enabled: %s", err))
+		}
+	}
+	return messages
+}
+
 func checkPluginExists(jenkins v1alpha2.Jenkins, name string) error {
 	exists := false
 	for _, plugin := range jenkins.Spec.Master.BasePlugins {
@@ -193,7 +387,7 @@ func validateBasicSSHSecret(secret v1.Secret) []string {
 	if len(string(privateKey)) == 0 {
 		messages = append(messages, fmt.Sprintf("required data '%s' not found in secret '%s'", PrivateKeySecretKey, secret.ObjectMeta.Name))
 	}
-	if err := validatePrivateKey(string(privateKey)); err != nil {
+	if err := validatePrivateKey(string(privateKey), string(secret.Data[PassphraseSecretKey])); err != nil {
 		messages = append(messages, fmt.Sprintf("private key '%s' invalid in secret '%s': %s", PrivateKeySecretKey, secret.ObjectMeta.Name, err))
 	}
 
@@ -220,21 +414,84 @@ func validateUsernamePasswordSecret(secret v1.Secret) []string {
 	return messages
 }
 
-func validatePrivateKey(privateKey string) error {
+func validateTokenSecret(secret v1.Secret) []string {
+	var messages []string
+	token, exists := secret.Data[TokenSecretKey]
+	if !exists {
+		messages = append(messages, fmt.Sprintf("required data '%s' not found in secret '%s'", TokenSecretKey, secret.ObjectMeta.Name))
+	}
+	if len(token) == 0 {
+		messages = append(messages, fmt.Sprintf("required data '%s' is empty in secret '%s'", TokenSecretKey, secret.ObjectMeta.Name))
+	}
+
+	return messages
+}
+
+func validateGitHubAppSecret(secret v1.Secret) []string {
+	var messages []string
+	appID, exists := secret.Data[AppIDSecretKey]
+	if !exists {
+		messages = append(messages, fmt.Sprintf("required data '%s' not found in secret '%s'", AppIDSecretKey, secret.ObjectMeta.Name))
+	} else if _, err := strconv.ParseUint(string(appID), 10, 64); err != nil {
+		messages = append(messages, fmt.Sprintf("data '%s' must be a number in secret '%s'", AppIDSecretKey, secret.ObjectMeta.Name))
+	}
+
+	privateKey, exists := secret.Data[PrivateKeySecretKey]
+	if !exists {
+		messages = append(messages, fmt.Sprintf("required data '%s' not found in secret '%s'", PrivateKeySecretKey, secret.ObjectMeta.Name))
+	} else if _, err := convertPrivateKeyToPKCS8(string(privateKey)); err != nil {
+		messages = append(messages, fmt.Sprintf("private key '%s' invalid in secret '%s': %s", PrivateKeySecretKey, secret.ObjectMeta.Name, err))
+	}
+
+	return messages
+}
+
+// validatePrivateKey checks that the SSH private key can be parsed, encrypted keys are decrypted with the passphrase
+func validatePrivateKey(privateKey, passphrase string) error {
 	block, _ := pem.Decode([]byte(privateKey))
 	if block == nil {
 		return stackerr.New("failed to decode PEM block")
 	}
+	if isEncryptedOpenSSHPrivateKey(block) {
+		if len(passphrase) == 0 {
+			return stackerr.Errorf("private key is encrypted but '%s' is not set", PassphraseSecretKey)
+		}
+		// the SSH library can't decrypt keys in the OpenSSH format, so the passphrase is verified by Jenkins
+		return nil
+	}
 
-	priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
-	if err != nil {
+	_, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if _, ok := err.(*ssh.PassphraseMissingError); ok {
+		if len(passphrase) == 0 {
+			return stackerr.Errorf("private key is encrypted but '%s' is not set", PassphraseSecretKey)
+		}
+		_, err = ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
 		return stackerr.WithStack(err)
 	}
-
-	err = priv.Validate()
 	if err != nil {
 		return stackerr.WithStack(err)
 	}
+	if len(passphrase) > 0 {
+		return stackerr.Errorf("'%s' is set but private key is not encrypted", PassphraseSecretKey)
+	}
 
 	return nil
 }
+
+// isEncryptedOpenSSHPrivateKey returns true for the private key in the OpenSSH format which is encrypted with a cipher
+// other than none, see https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.key
+func isEncryptedOpenSSHPrivateKey(block *pem.Block) bool {
+	const magic = "openssh-key-v1\x00"
+	if block.Type != "OPENSSH PRIVATE KEY" || !bytes.HasPrefix(block.Bytes, []byte(magic)) {
+		return false
+	}
+	rest := block.Bytes[len(magic):]
+	if len(rest) < 4 {
+		return false
+	}
+	length := binary.BigEndian.Uint32(rest)
+	if uint64(len(rest)-4) < uint64(length) {
+		return false
+	}
+	return string(rest[4:4+length]) != "none"
+}
@@ -2,8 +2,12 @@ package user
 
 import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/awssecrets"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/agents"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+	"github.com/jenkinsci/kubernetes-operator/pkg/vault"
 )
 
 // Validate validates Jenkins CR Spec section
@@ -13,6 +17,25 @@ func (r *reconcileUserConfiguration) Validate(jenkins *v1alpha2.Jenkins) ([]stri
 		return msg, nil
 	}
 
+	configurationAsCode := casc.New(r.jenkinsClient, r.Client, jenkins)
+	messages, err := configurationAsCode.Validate(*jenkins)
+	if err != nil || len(messages) > 0 {
+		return messages, err
+	}
+
+	messages, err = vault.Validate(r.Client, *jenkins)
+	if err != nil || len(messages) > 0 {
+		return messages, err
+	}
+
+	if messages := awssecrets.Validate(*jenkins); len(messages) > 0 {
+		return messages, nil
+	}
+
+	if messages := agents.Validate(*jenkins); len(messages) > 0 {
+		return messages, nil
+	}
+
 	seedJobs := seedjobs.New(r.jenkinsClient, r.Configuration)
 	return seedJobs.ValidateSeedJobs(*jenkins)
 }
@@ -17,4 +17,13 @@ const (
 	JavaOpsVariableName = "JAVA_OPTS"
 	// DefaultJenkinsAgentImage is the default Jenkins agent docker image
 	DefaultJenkinsAgentImage = "jenkins/inbound-agent:latest"
+	// DefaultFIPSJenkinsMasterImage is the default Jenkins master docker image in FIPS mode, it's based on UBI with
+	// Red Hat OpenJDK which uses FIPS validated cryptography when the node runs in FIPS mode
+	DefaultFIPSJenkinsMasterImage = "jenkins/jenkins:lts-rhel-ubi9-jdk17"
+	// DefaultFIPSJenkinsAgentImage is the default Jenkins agent docker image in FIPS mode
+	DefaultFIPSJenkinsAgentImage = "jenkins/inbound-agent:latest-rhel-ubi9-jdk17"
+	// DefaultWindowsJenkinsAgentImage is the default Jenkins agent docker image of Windows agent pods
+	DefaultWindowsJenkinsAgentImage = "jenkins/inbound-agent:windowsservercore-ltsc2019"
+	// DefaultStaticAgentExecutors is the default number of executors of static agents
+	DefaultStaticAgentExecutors = 1
 )
@@ -0,0 +1,107 @@
+package jenkins
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// reconcileBackoffBaseDelay is the delay of requeue after the first failed reconcile loop
+	reconcileBackoffBaseDelay = time.Second
+	// reconcileBackoffMaxDelay is the maximal delay of requeue after failed reconcile loops
+	reconcileBackoffMaxDelay = 5 * time.Minute
+	// reconcileBackoffJitter is the maximal fraction of the delay added randomly to spread requeues of many CRs
+	reconcileBackoffJitter = 0.1
+)
+
+type reconcileError struct {
+	err     error
+	counter uint64
+	// failures is the number of consecutive failed reconcile loops regardless of the error
+	failures uint64
+}
+
+// reconcileBackoff is thread-safe store of failed reconcile loops of Jenkins CRs which drives exponential
+// backoff of requeues, the state of the CR is reset by the first successful reconcile loop.
+type reconcileBackoff struct {
+	mutex     sync.Mutex
+	errors    map[types.NamespacedName]reconcileError
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	jitter    float64
+}
+
+func newReconcileBackoff(baseDelay, maxDelay time.Duration, jitter float64) *reconcileBackoff {
+	return &reconcileBackoff{
+		errors:    map[types.NamespacedName]reconcileError{},
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+		jitter:    jitter,
+	}
+}
+
+// failed records the failed reconcile loop of the CR and returns its state with the delay of the next requeue.
+func (b *reconcileBackoff) failed(name types.NamespacedName, err error) (reconcileError, time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	lastErrors, found := b.errors[name]
+	if found && err.Error() == lastErrors.err.Error() {
+		lastErrors.counter++
+	} else {
+		lastErrors.counter = 1
+		lastErrors.err = err
+	}
+	lastErrors.failures++
+	b.errors[name] = lastErrors
+
+	delay := b.delay(lastErrors.failures)
+	observeReconcileBackoff(name, lastErrors.failures, delay)
+
+	return lastErrors, delay
+}
+
+// unavailable records the reconcile loop of the CR failed because Jenkins is temporarily unavailable and returns
+// the delay of the next requeue, it doesn't count against the limit of the same errors.
+func (b *reconcileBackoff) unavailable(name types.NamespacedName) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	lastErrors := b.errors[name]
+	lastErrors.failures++
+	b.errors[name] = lastErrors
+
+	delay := b.delay(lastErrors.failures)
+	observeReconcileBackoff(name, lastErrors.failures, delay)
+
+	return delay
+}
+
+// succeeded resets the state of the CR.
+func (b *reconcileBackoff) succeeded(name types.NamespacedName) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, found := b.errors[name]; found {
+		delete(b.errors, name)
+		resetReconcileBackoff(name)
+	}
+}
+
+func (b *reconcileBackoff) delay(failures uint64) time.Duration {
+	delay := b.baseDelay
+	for i := uint64(1); i < failures && delay < b.maxDelay; i++ {
+		delay *= 2
+	}
+	if b.jitter > 0 {
+		delay += time.Duration(rand.Float64() * b.jitter * float64(delay))
+	}
+	if delay > b.maxDelay {
+		delay = b.maxDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,82 @@
+package jenkins
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcileBackoff(t *testing.T) {
+	first := types.NamespacedName{Namespace: "default", Name: "jenkins"}
+	second := types.NamespacedName{Namespace: "other", Name: "jenkins"}
+
+	t.Run("exponential delay with cap", func(t *testing.T) {
+		backoff := newReconcileBackoff(time.Second, 10*time.Second, 0)
+
+		var delays []time.Duration
+		for i := 0; i < 6; i++ {
+			_, delay := backoff.failed(first, errors.New("error"))
+			delays = append(delays, delay)
+		}
+
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}, delays)
+	})
+	t.Run("counter of the same error", func(t *testing.T) {
+		backoff := newReconcileBackoff(time.Second, time.Minute, 0)
+
+		backoff.failed(first, errors.New("error"))
+		lastErrors, _ := backoff.failed(first, errors.New("error"))
+		assert.Equal(t, uint64(2), lastErrors.counter)
+		assert.Equal(t, uint64(2), lastErrors.failures)
+
+		lastErrors, delay := backoff.failed(first, errors.New("other error"))
+		assert.Equal(t, uint64(1), lastErrors.counter)
+		assert.Equal(t, uint64(3), lastErrors.failures)
+		assert.Equal(t, 4*time.Second, delay)
+	})
+	t.Run("unavailable Jenkins doesn't count as the same error", func(t *testing.T) {
+		backoff := newReconcileBackoff(time.Second, time.Minute, 0)
+
+		backoff.failed(first, errors.New("error"))
+		delay := backoff.unavailable(first)
+		lastErrors, _ := backoff.failed(first, errors.New("error"))
+
+		assert.Equal(t, 2*time.Second, delay)
+		assert.Equal(t, uint64(2), lastErrors.counter)
+		assert.Equal(t, uint64(3), lastErrors.failures)
+	})
+	t.Run("CRs with the same name in different namespaces", func(t *testing.T) {
+		backoff := newReconcileBackoff(time.Second, time.Minute, 0)
+
+		backoff.failed(first, errors.New("error"))
+		backoff.failed(first, errors.New("error"))
+		lastErrors, delay := backoff.failed(second, errors.New("error"))
+
+		assert.Equal(t, uint64(1), lastErrors.counter)
+		assert.Equal(t, time.Second, delay)
+	})
+	t.Run("reset on success", func(t *testing.T) {
+		backoff := newReconcileBackoff(time.Second, time.Minute, 0)
+
+		backoff.failed(first, errors.New("error"))
+		backoff.failed(first, errors.New("error"))
+		backoff.succeeded(first)
+		lastErrors, delay := backoff.failed(first, errors.New("error"))
+
+		assert.Equal(t, uint64(1), lastErrors.counter)
+		assert.Equal(t, time.Second, delay)
+		assert.Len(t, backoff.errors, 1)
+	})
+	t.Run("jitter", func(t *testing.T) {
+		backoff := newReconcileBackoff(10*time.Second, time.Minute, 0.5)
+
+		for i := 0; i < 10; i++ {
+			delay := backoff.delay(1)
+			assert.True(t, delay >= 10*time.Second && delay <= 15*time.Second, delay.String())
+		}
+		assert.Equal(t, time.Minute, backoff.delay(10))
+	})
+}
@@ -0,0 +1,115 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	conditionReasonReconciled                     = "Reconciled"
+	conditionReasonInProgress                     = "InProgress"
+	conditionReasonProvisioning                   = "Provisioning"
+	conditionReasonValidationFailed               = "ValidationFailed"
+	conditionReasonReconcileFailed                = "ReconcileFailed"
+	conditionReasonJenkinsUnavailable             = "JenkinsUnavailable"
+	conditionReasonBaseConfigurationNotReconciled = "BaseConfigurationNotReconciled"
+	conditionReasonUserConfigurationNotReconciled = "UserConfigurationNotReconciled"
+	conditionReasonPaused                         = "Paused"
+	conditionReasonResumed                        = "Resumed"
+)
+
+func newCondition(conditionType v1alpha2.JenkinsConditionType, status corev1.ConditionStatus, reason, message string) v1alpha2.JenkinsCondition {
+	return v1alpha2.JenkinsCondition{Type: conditionType, Status: status, Reason: reason, Message: message}
+}
+
+// notReadyConditions returns conditions of the phase which isn't reconciled and of the whole Jenkins
+func notReadyConditions(phaseCondition v1alpha2.JenkinsConditionType, reason, message string) []v1alpha2.JenkinsCondition {
+	readyReason := conditionReasonBaseConfigurationNotReconciled
+	if phaseCondition == v1alpha2.UserConfigurationReconciledCondition {
+		readyReason = conditionReasonUserConfigurationNotReconciled
+	}
+
+	return []v1alpha2.JenkinsCondition{
+		newCondition(phaseCondition, corev1.ConditionFalse, reason, message),
+		newCondition(v1alpha2.ReadyCondition, corev1.ConditionFalse, readyReason, message),
+	}
+}
+
+// jenkinsURL returns the URL of Jenkins HTTP service inside the cluster
+func jenkinsURL(jenkins *v1alpha2.Jenkins) string {
+	fqdn, err := resources.GetJenkinsHTTPServiceFQDN(jenkins)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("http://%s:%d", fqdn, jenkins.Spec.Service.Port)
+}
+
+// updateConditions sets conditions, the phase and the observed generation in the status of Jenkins CR, the CR is
+// updated only when any of them has changed
+func (r *ReconcileJenkins) updateConditions(jenkins *v1alpha2.Jenkins, conditions ...v1alpha2.JenkinsCondition) error {
+	changed := false
+	if jenkins.Status.ObservedGeneration != jenkins.Generation {
+		jenkins.Status.ObservedGeneration = jenkins.Generation
+		changed = true
+	}
+	for _, condition := range conditions {
+		if configuration.SetCondition(&jenkins.Status, condition) {
+			changed = true
+		}
+	}
+	if r.updatePhase(jenkins) {
+		changed = true
+	}
+	if url := jenkinsURL(jenkins); jenkins.Status.URL != url {
+		jenkins.Status.URL = url
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	return errors.WithStack(r.client.Status().Update(context.TODO(), jenkins))
+}
+
+func (r *ReconcileJenkins) updateUserConfigurationInProgress(jenkins *v1alpha2.Jenkins) error {
+	conditions := notReadyConditions(v1alpha2.UserConfigurationReconciledCondition, conditionReasonInProgress, "User configuration is being reconciled")
+	return r.updateConditions(jenkins, conditions...)
+}
+
+// updateConditionsAfterError sets the phase condition to False after the failed reconcile loop, the error of
+// the update is only logged because the reconcile loop is retried anyway
+func (r *ReconcileJenkins) updateConditionsAfterError(jenkins *v1alpha2.Jenkins, phaseCondition v1alpha2.JenkinsConditionType, reconcileErr error) {
+	if apierrors.IsConflict(reconcileErr) {
+		return
+	}
+	reason := conditionReasonReconcileFailed
+	if jenkinsclient.IsTransientError(reconcileErr) {
+		reason = conditionReasonJenkinsUnavailable
+	}
+	conditions := notReadyConditions(phaseCondition, reason, reconcileErr.Error())
+	if err := r.updateConditions(jenkins, conditions...); err != nil {
+		logx.WithValues("cr", jenkins.Name).V(log.VDebug).Info(fmt.Sprintf("Failed to update conditions: %s", err))
+	}
+}
+
+// updateObservedGeneration marks Jenkins as not ready when its spec has changed since the last reconcile loop,
+// Ready is set back to True once the new generation is reconciled
+func (r *ReconcileJenkins) updateObservedGeneration(jenkins *v1alpha2.Jenkins) error {
+	if jenkins.Status.ObservedGeneration == jenkins.Generation || !configuration.IsConditionTrue(jenkins.Status, v1alpha2.ReadyCondition) {
+		return nil
+	}
+
+	message := fmt.Sprintf("Generation %d is being reconciled", jenkins.Generation)
+	return r.updateConditions(jenkins, newCondition(v1alpha2.ReadyCondition, corev1.ConditionFalse, conditionReasonInProgress, message))
+}
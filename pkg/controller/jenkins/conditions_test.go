@@ -0,0 +1,54 @@
+package jenkins
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpdateConditions(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+	fakeClient := fake.NewFakeClient(jenkins)
+	r := &ReconcileJenkins{client: fakeClient}
+
+	err = r.updateConditions(jenkins, notReadyConditions(v1alpha2.UserConfigurationReconciledCondition, conditionReasonValidationFailed, "invalid")...)
+	require.NoError(t, err)
+
+	stored := &v1alpha2.Jenkins{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "jenkins", Namespace: "default"}, stored)
+	require.NoError(t, err)
+	phaseCondition := configuration.GetCondition(stored.Status, v1alpha2.UserConfigurationReconciledCondition)
+	require.NotNil(t, phaseCondition)
+	assert.Equal(t, corev1.ConditionFalse, phaseCondition.Status)
+	assert.Equal(t, conditionReasonValidationFailed, phaseCondition.Reason)
+	assert.Equal(t, "invalid", phaseCondition.Message)
+	readyCondition := configuration.GetCondition(stored.Status, v1alpha2.ReadyCondition)
+	require.NotNil(t, readyCondition)
+	assert.Equal(t, corev1.ConditionFalse, readyCondition.Status)
+	assert.Equal(t, conditionReasonUserConfigurationNotReconciled, readyCondition.Reason)
+
+	resourceVersion := stored.ResourceVersion
+	err = r.updateConditions(stored, notReadyConditions(v1alpha2.UserConfigurationReconciledCondition, conditionReasonValidationFailed, "invalid")...)
+	require.NoError(t, err)
+	assert.Equal(t, resourceVersion, stored.ResourceVersion, "unchanged conditions shouldn't update the CR")
+
+	err = r.updateConditions(stored, newCondition(v1alpha2.ReadyCondition, corev1.ConditionTrue, conditionReasonReconciled, ""))
+	require.NoError(t, err)
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "jenkins", Namespace: "default"}, stored)
+	require.NoError(t, err)
+	assert.True(t, configuration.IsConditionTrue(stored.Status, v1alpha2.ReadyCondition))
+	assert.Equal(t, v1alpha2.JenkinsPhaseReady, stored.Status.Phase)
+	assert.Equal(t, "http://jenkins-operator-http-jenkins.default.svc.cluster.local:0", stored.Status.URL)
+}
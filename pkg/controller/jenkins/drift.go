@@ -0,0 +1,111 @@
+package jenkins
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	k8sevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// resourceDrift is thread-safe store of manual changes of resources managed by the operator,
+// the changes are reported by the next reconcile loop of the owner Jenkins CR which reverts them.
+type resourceDrift struct {
+	mutex   sync.Mutex
+	changes map[types.NamespacedName][]string
+}
+
+func newResourceDrift() *resourceDrift {
+	return &resourceDrift{changes: map[types.NamespacedName][]string{}}
+}
+
+func (d *resourceDrift) add(name types.NamespacedName, message string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, change := range d.changes[name] {
+		if change == message {
+			return
+		}
+	}
+	d.changes[name] = append(d.changes[name], message)
+}
+
+// pop returns and forgets the changes of resources owned by the Jenkins CR.
+func (d *resourceDrift) pop(name types.NamespacedName) []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	changes := d.changes[name]
+	delete(d.changes, name)
+	return changes
+}
+
+// reportResourceDrift notifies about manual changes of resources owned by the Jenkins CR.
+func (r *ReconcileJenkins) reportResourceDrift(jenkins *v1alpha2.Jenkins) {
+	changes := r.resourceDrift.pop(types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name})
+	if len(changes) == 0 {
+		return
+	}
+
+	for _, change := range changes {
+		logx.WithValues("cr", jenkins.Name).Info(fmt.Sprintf("%s, reverting it", change))
+	}
+	*r.notificationEvents <- event.Event{
+		Jenkins: *jenkins,
+		Phase:   event.PhaseBase,
+		Level:   v1alpha2.NotificationLevelWarning,
+		Reason:  reason.NewResourceDrift(reason.HumanSource, changes),
+	}
+}
+
+// enqueueRequestForDrift enqueues the owner Jenkins CR of resources managed by the operator when they
+// are changed or deleted manually.
+type enqueueRequestForDrift struct {
+	kind  string
+	drift *resourceDrift
+}
+
+func (e *enqueueRequestForDrift) Create(k8sevent.CreateEvent, workqueue.RateLimitingInterface) {}
+
+func (e *enqueueRequestForDrift) Update(evt k8sevent.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if !configuration.IsDrifted(evt.MetaNew) {
+		return
+	}
+	if owner := getJenkinsOwner(evt.MetaNew); owner != nil {
+		e.drift.add(*owner, fmt.Sprintf("%s '%s' has been changed manually", e.kind, evt.MetaNew.GetName()))
+		q.Add(reconcile.Request{NamespacedName: *owner})
+	}
+}
+
+func (e *enqueueRequestForDrift) Delete(evt k8sevent.DeleteEvent, q workqueue.RateLimitingInterface) {
+	if owner := getJenkinsOwner(evt.Meta); owner != nil {
+		e.drift.add(*owner, fmt.Sprintf("%s '%s' has been deleted", e.kind, evt.Meta.GetName()))
+		q.Add(reconcile.Request{NamespacedName: *owner})
+	}
+}
+
+func (e *enqueueRequestForDrift) Generic(k8sevent.GenericEvent, workqueue.RateLimitingInterface) {}
+
+// getJenkinsOwner returns the name of the Jenkins CR which controls the resource.
+func getJenkinsOwner(object metav1.Object) *types.NamespacedName {
+	owner := metav1.GetControllerOf(object)
+	if owner == nil || owner.Kind != v1alpha2.Kind {
+		return nil
+	}
+	groupVersion, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil || groupVersion.Group != v1alpha2.SchemeGroupVersion.Group {
+		return nil
+	}
+
+	return &types.NamespacedName{Namespace: object.GetNamespace(), Name: owner.Name}
+}
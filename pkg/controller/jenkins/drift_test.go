@@ -0,0 +1,85 @@
+package jenkins
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	k8sevent "sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestEnqueueRequestForDrift(t *testing.T) {
+	controller := true
+	newConfigMap := func(value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "jenkins-operator-scripts-example",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: v1alpha2.SchemeGroupVersion.String(),
+					Kind:       v1alpha2.Kind,
+					Name:       "example",
+					Controller: &controller,
+				}},
+			},
+			Data: map[string]string{"key": value},
+		}
+	}
+	jenkins := types.NamespacedName{Namespace: "default", Name: "example"}
+
+	t.Run("changed by the operator", func(t *testing.T) {
+		drift := newResourceDrift()
+		handler := &enqueueRequestForDrift{kind: ConfigMapKind, drift: drift}
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		configMap := newConfigMap("value")
+		configuration.SetContentHash(configMap)
+
+		handler.Update(k8sevent.UpdateEvent{MetaOld: configMap, MetaNew: configMap}, queue)
+
+		assert.Equal(t, 0, queue.Len())
+		assert.Empty(t, drift.pop(jenkins))
+	})
+	t.Run("changed manually", func(t *testing.T) {
+		drift := newResourceDrift()
+		handler := &enqueueRequestForDrift{kind: ConfigMapKind, drift: drift}
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		configMap := newConfigMap("value")
+		configuration.SetContentHash(configMap)
+		changed := configMap.DeepCopy()
+		changed.Data["key"] = "changed"
+
+		handler.Update(k8sevent.UpdateEvent{MetaOld: configMap, MetaNew: changed}, queue)
+		handler.Update(k8sevent.UpdateEvent{MetaOld: configMap, MetaNew: changed}, queue)
+
+		assert.Equal(t, 1, queue.Len())
+		assert.Equal(t, []string{"ConfigMap 'jenkins-operator-scripts-example' has been changed manually"}, drift.pop(jenkins))
+		assert.Empty(t, drift.pop(jenkins))
+	})
+	t.Run("deleted", func(t *testing.T) {
+		drift := newResourceDrift()
+		handler := &enqueueRequestForDrift{kind: ConfigMapKind, drift: drift}
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+		handler.Delete(k8sevent.DeleteEvent{Meta: newConfigMap("value")}, queue)
+
+		assert.Equal(t, 1, queue.Len())
+		assert.Equal(t, []string{"ConfigMap 'jenkins-operator-scripts-example' has been deleted"}, drift.pop(jenkins))
+	})
+	t.Run("not owned by Jenkins", func(t *testing.T) {
+		drift := newResourceDrift()
+		handler := &enqueueRequestForDrift{kind: ConfigMapKind, drift: drift}
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		configMap := newConfigMap("value")
+		configMap.OwnerReferences = nil
+
+		handler.Delete(k8sevent.DeleteEvent{Meta: configMap}, queue)
+
+		assert.Equal(t, 0, queue.Len())
+	})
+}
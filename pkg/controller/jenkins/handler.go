@@ -1,16 +1,20 @@
 package jenkins
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/credentials"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -75,6 +79,67 @@ func (e *enqueueRequestForJenkins) getOwnerReconcileRequests(object metav1.Objec
 	return nil
 }
 
+// credentialsSecretMapper maps Secrets labeled with jenkins.io/operator-credentials-type to Jenkins CRs in the same namespace
+// which synchronize Secrets to Jenkins credentials
+func credentialsSecretMapper(k8sClient k8s.Client) handler.ToRequestsFunc {
+	return func(object handler.MapObject) []reconcile.Request {
+		if _, labeled := object.Meta.GetLabels()[credentials.TypeLabelName]; !labeled {
+			return nil
+		}
+
+		jenkinsList := &v1alpha2.JenkinsList{}
+		if err := k8sClient.List(context.TODO(), jenkinsList, k8s.InNamespace(object.Meta.GetNamespace())); err != nil {
+			log.Log.V(log.VWarn).Info(fmt.Sprintf("Couldn't list Jenkins CRs in namespace '%s': %s", object.Meta.GetNamespace(), err))
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range jenkinsList.Items {
+			jenkins := &jenkinsList.Items[i]
+			if credentials.IsEnabled(jenkins) || len(jenkins.Status.SyncedCredentials) > 0 {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name}})
+			}
+		}
+		return requests
+	}
+}
+
+// referencedSecretMapper maps Secrets to Jenkins CRs in the same namespace which apply their content to Jenkins or
+// pass it to environment variables of Jenkins master containers, so rotated Secrets are propagated to Jenkins
+func referencedSecretMapper(k8sClient k8s.Client) handler.ToRequestsFunc {
+	return func(object handler.MapObject) []reconcile.Request {
+		jenkinsList := &v1alpha2.JenkinsList{}
+		if err := k8sClient.List(context.TODO(), jenkinsList, k8s.InNamespace(object.Meta.GetNamespace())); err != nil {
+			log.Log.V(log.VWarn).Info(fmt.Sprintf("Couldn't list Jenkins CRs in namespace '%s': %s", object.Meta.GetNamespace(), err))
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range jenkinsList.Items {
+			jenkins := &jenkinsList.Items[i]
+			names := append(resources.GetConfigurationSecretNames(jenkins), resources.GetJenkinsMasterEnvSecretNames(jenkins)...)
+			for _, name := range names {
+				if name == object.Meta.GetName() {
+					log.Log.WithValues("cr", jenkins.Name).V(log.VDebug).Info(fmt.Sprintf("Referenced Secret '%s' has changed", name))
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name}})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
+// agentPodTemplateMapper maps JenkinsAgentPodTemplates to the Jenkins CR which they are bound to
+func agentPodTemplateMapper(object handler.MapObject) []reconcile.Request {
+	podTemplate, ok := object.Object.(*v1alpha2.JenkinsAgentPodTemplate)
+	if !ok || len(podTemplate.Spec.JenkinsRef.Name) == 0 {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: podTemplate.Namespace, Name: podTemplate.Spec.JenkinsRef.Name}}}
+}
+
 type jenkinsDecorator struct {
 	handler handler.EventHandler
 }
@@ -4,68 +4,75 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
-	"reflect"
+	"strings"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base"
-	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/defaults"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user"
-	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
-	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-type reconcileError struct {
-	err     error
-	counter uint64
-}
-
 const (
-	APIVersion             = "core/v1"
-	PodKind                = "Pod"
-	SecretKind             = "Secret"
-	ConfigMapKind          = "ConfigMap"
-	containerProbeURI      = "login"
-	containerProbePortName = "http"
+	APIVersion    = "core/v1"
+	PodKind       = "Pod"
+	SecretKind    = "Secret"
+	ConfigMapKind = "ConfigMap"
+	ServiceKind   = "Service"
+
+	// PausedAnnotation set to "true" on Jenkins CR pauses its reconciliation, e.g. for manual maintenance in Jenkins
+	PausedAnnotation = "jenkins.io/paused"
+	// LogLevelAnnotation set to "debug" on Jenkins CR enables debug logs of its reconciliation
+	LogLevelAnnotation = "jenkins.io/log-level"
+	// SupportBundleAnnotation set to "true" on Jenkins CR collects its support bundle, the annotation is removed then
+	SupportBundleAnnotation = "jenkins.io/support-bundle"
 )
 
-var reconcileErrors = map[string]reconcileError{}
 var logx = log.Log
 var _ reconcile.Reconciler = &ReconcileJenkins{}
 
 // Add creates a newReconcilierConfiguration Jenkins Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
-func Add(mgr manager.Manager, jenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings, clientSet kubernetes.Clientset, config rest.Config, notificationEvents *chan event.Event) error {
-	reconciler := newReconciler(mgr, jenkinsAPIConnectionSettings, clientSet, config, notificationEvents)
-	return add(mgr, reconciler)
+func Add(mgr manager.Manager, jenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings, clientSet kubernetes.Clientset, config rest.Config, notificationEvents *chan event.Event, options Options) error {
+	reconciler := newReconciler(mgr, jenkinsAPIConnectionSettings, clientSet, config, notificationEvents, options)
+	return add(mgr, reconciler, options.NamespaceFilter)
 }
 
 // add adds a newReconcilierConfiguration Controller to mgr with r as the reconcile.Reconciler.
-func add(mgr manager.Manager, r reconcile.Reconciler) error {
+func add(mgr manager.Manager, r *ReconcileJenkins, namespaceFilter NamespaceFilter) error {
 	// Create a newReconcilierConfiguration controller
 	c, err := controller.New("jenkins-controller", mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
+	var predicates []predicate.Predicate
+	if !namespaceFilter.IsEmpty() {
+		predicates = append(predicates, namespaceFilter.predicate(mgr.GetClient()))
+	}
+
 	// Watch for changes to primary resource Jenkins
 	decorator := jenkinsDecorator{handler: &handler.EnqueueRequestForObject{}}
-	err = c.Watch(&source.Kind{Type: &v1alpha2.Jenkins{}}, &decorator)
+	err = c.Watch(&source.Kind{Type: &v1alpha2.Jenkins{}}, &decorator, predicates...)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -76,7 +83,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	err = c.Watch(podResource, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &v1alpha2.Jenkins{},
-	})
+	}, predicates...)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -85,19 +92,53 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	err = c.Watch(secretResource, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &v1alpha2.Jenkins{},
-	})
+	}, predicates...)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
 	jenkinsHandler := &enqueueRequestForJenkins{}
-	err = c.Watch(secretResource, jenkinsHandler)
+	err = c.Watch(secretResource, jenkinsHandler, predicates...)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
 	configMapResource := &source.Kind{Type: &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: ConfigMapKind}}}
-	err = c.Watch(configMapResource, jenkinsHandler)
+	err = c.Watch(configMapResource, jenkinsHandler, predicates...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Watch for changes of Secrets synchronized to Jenkins credentials
+	err = c.Watch(secretResource, &handler.EnqueueRequestsFromMapFunc{ToRequests: credentialsSecretMapper(mgr.GetClient())}, predicates...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Watch for changes of Secrets referenced by the Jenkins CR, e.g. rotated passwords
+	err = c.Watch(secretResource, &handler.EnqueueRequestsFromMapFunc{ToRequests: referencedSecretMapper(mgr.GetClient())}, predicates...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Watch for changes of JenkinsAgentPodTemplates applied to the bound Jenkins
+	agentPodTemplateResource := &source.Kind{Type: &v1alpha2.JenkinsAgentPodTemplate{}}
+	err = c.Watch(agentPodTemplateResource, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(agentPodTemplateMapper)}, predicates...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Watch for manual changes of resources managed by the operator and revert them immediately
+	serviceResource := &source.Kind{Type: &corev1.Service{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: ServiceKind}}}
+	err = c.Watch(serviceResource, &enqueueRequestForDrift{kind: ServiceKind, drift: r.resourceDrift}, predicates...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = c.Watch(configMapResource, &enqueueRequestForDrift{kind: ConfigMapKind, drift: r.resourceDrift}, predicates...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = c.Watch(secretResource, &enqueueRequestForDrift{kind: SecretKind, drift: r.resourceDrift}, predicates...)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -111,24 +152,22 @@ func (r *ReconcileJenkins) Reconcile(request reconcile.Request) (reconcile.Resul
 	logger.V(log.VDebug).Info("Reconciling Jenkins")
 
 	result, jenkins, err := r.reconcile(request)
+	if err != nil {
+		observeReconcileError(request.NamespacedName, err)
+	}
+	r.updateInstanceHealth(request.NamespacedName, jenkins, err)
 	if err != nil && apierrors.IsConflict(err) {
 		return reconcile.Result{Requeue: true}, nil
-	} else if err != nil {
-		lastErrors, found := reconcileErrors[request.Name]
-		if found {
-			if err.Error() == lastErrors.err.Error() {
-				lastErrors.counter++
-			} else {
-				lastErrors.counter = 1
-				lastErrors.err = err
-			}
-		} else {
-			lastErrors = reconcileError{
-				err:     err,
-				counter: 1,
-			}
+	} else if err != nil && jenkinsclient.IsTransientError(err) {
+		// Jenkins which is busy or restarting doesn't count against the reconcile fail limit
+		delay := r.reconcileBackoff.unavailable(request.NamespacedName)
+		if retryAfter := jenkinsclient.GetRetryAfter(err); retryAfter > delay {
+			delay = retryAfter
 		}
-		reconcileErrors[request.Name] = lastErrors
+		logger.V(log.VWarn).Info(fmt.Sprintf("Jenkins API is temporarily unavailable, retrying in %s: %s", delay.Round(time.Millisecond), err))
+		return reconcile.Result{Requeue: true, RequeueAfter: delay}, nil
+	} else if err != nil {
+		lastErrors, delay := r.reconcileBackoff.failed(request.NamespacedName, err)
 		if lastErrors.counter >= reconcileFailLimit {
 			if log.Debug {
 				logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed %d times with the same error, giving up: %+v", reconcileFailLimit, err))
@@ -167,14 +206,30 @@ func (r *ReconcileJenkins) Reconcile(request reconcile.Request) (reconcile.Resul
 			}
 			return reconcile.Result{Requeue: false}, nil
 		}
-		return reconcile.Result{Requeue: true}, nil
+		return reconcile.Result{Requeue: true, RequeueAfter: delay}, nil
 	}
-	if result.Requeue && result.RequeueAfter == 0 {
-		result.RequeueAfter = time.Duration(rand.Intn(10)) * time.Millisecond
+	r.reconcileBackoff.succeeded(request.NamespacedName)
+	if result.Requeue && result.RequeueAfter == 0 && r.intervals.MaxRequeueDelay > 0 {
+		result.RequeueAfter = time.Duration(rand.Int63n(int64(r.intervals.MaxRequeueDelay)))
 	}
 	return result, nil
 }
 
+// updateInstanceHealth records the result of the reconcile loop served on the instances health endpoint, conflicts
+// are retried immediately so they aren't reported
+func (r *ReconcileJenkins) updateInstanceHealth(name types.NamespacedName, jenkins *v1alpha2.Jenkins, err error) {
+	if jenkins == nil {
+		if err == nil {
+			r.instances.Delete(name)
+		}
+		return
+	}
+	if apierrors.IsConflict(err) {
+		return
+	}
+	r.instances.Set(jenkins, err)
+}
+
 func (r *ReconcileJenkins) reconcile(request reconcile.Request) (reconcile.Result, *v1alpha2.Jenkins, error) {
 	logger := logx.WithValues("cr", request.Name)
 	// Fetch the Jenkins instance
@@ -186,21 +241,39 @@ func (r *ReconcileJenkins) reconcile(request reconcile.Request) (reconcile.Resul
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			r.resourceDrift.pop(request.NamespacedName)
+			configuration.StopPortForward(request.NamespacedName)
+			_ = log.SetCRLevel(request.Name, log.LevelInfo)
+			log.DeleteCRLogs(request.Name)
 			return reconcile.Result{}, nil, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, nil, errors.WithStack(err)
 	}
-	var requeue bool
-	requeue, err = r.setDefaults(jenkins)
-	if err != nil {
+	if err = log.SetCRLevel(jenkins.Name, jenkins.Annotations[LogLevelAnnotation]); err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Invalid '%s' annotation: %s", LogLevelAnnotation, err))
+	}
+	if !r.shard.Owns(jenkins) {
+		logger.V(log.VDebug).Info("Jenkins is reconciled by another shard of the operator")
+		return reconcile.Result{}, nil, nil
+	}
+	// the support bundle is collected also when the reconciliation is paused
+	if err = r.ensureSupportBundle(jenkins); err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
-	if requeue {
-		return reconcile.Result{Requeue: true}, jenkins, nil
+	paused, err := r.ensurePaused(jenkins)
+	if err != nil || paused {
+		return reconcile.Result{}, jenkins, err
 	}
+	r.reportResourceDrift(jenkins)
 
-	requeue, err = r.handleDeprecatedData(jenkins)
+	// defaults are persisted by the mutating admission webhook, they're set only in memory without it
+	// to not rewrite the spec of the CR
+	if _, err = defaults.SetDefaults(jenkins, r.jenkinsAPIConnectionSettings.UseNodePort); err != nil {
+		return reconcile.Result{}, jenkins, err
+	}
+
+	requeue, err := r.handleDeprecatedData(jenkins)
 	if err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
@@ -208,6 +281,11 @@ func (r *ReconcileJenkins) reconcile(request reconcile.Request) (reconcile.Resul
 		return reconcile.Result{Requeue: true}, jenkins, nil
 	}
 
+	err = r.updateObservedGeneration(jenkins)
+	if err != nil {
+		return reconcile.Result{}, jenkins, err
+	}
+
 	config := r.newReconcilierConfiguration(jenkins)
 	// Reconcile base configuration
 	baseConfiguration := base.New(config, r.jenkinsAPIConnectionSettings)
@@ -229,26 +307,43 @@ func (r *ReconcileJenkins) reconcile(request reconcile.Request) (reconcile.Resul
 		for _, msg := range baseMessages {
 			logger.V(log.VWarn).Info(msg)
 		}
-		return reconcile.Result{}, jenkins, nil // don't requeue
+		conditions := notReadyConditions(v1alpha2.BaseConfigurationReconciledCondition, conditionReasonValidationFailed, strings.Join(baseMessages, "; "))
+		return reconcile.Result{}, jenkins, r.updateConditions(jenkins, conditions...) // don't requeue
 	}
 
 	var result reconcile.Result
 	var jenkinsClient jenkinsclient.Jenkins
+	started := time.Now()
 	result, jenkinsClient, err = baseConfiguration.Reconcile()
+	observeReconcilePhase(jenkins, reconcilePhaseBase, started)
 	if err != nil {
+		r.updateConditionsAfterError(jenkins, v1alpha2.BaseConfigurationReconciledCondition, err)
 		return reconcile.Result{}, jenkins, err
 	}
-	if result.Requeue {
-		return result, jenkins, nil
-	}
-	if jenkinsClient == nil {
+	if result.Requeue || jenkinsClient == nil {
+		conditions := notReadyConditions(v1alpha2.BaseConfigurationReconciledCondition, conditionReasonInProgress, "Base configuration is being reconciled")
+		if jenkinsClient == nil {
+			conditions = notReadyConditions(v1alpha2.BaseConfigurationReconciledCondition, conditionReasonProvisioning, "Jenkins master is being provisioned")
+		}
+		if err = r.updateConditions(jenkins, conditions...); err != nil {
+			return reconcile.Result{}, jenkins, err
+		}
+		if result.Requeue {
+			return result, jenkins, nil
+		}
 		return reconcile.Result{Requeue: false}, jenkins, nil
 	}
+	err = r.updateConditions(jenkins, newCondition(v1alpha2.BaseConfigurationReconciledCondition, corev1.ConditionTrue, conditionReasonReconciled, ""))
+	if err != nil {
+		return reconcile.Result{}, jenkins, err
+	}
+	// check plugin security warnings periodically
+	baseRequeueAfter := result.RequeueAfter
 
 	if jenkins.Status.BaseConfigurationCompletedTime == nil {
 		now := metav1.Now()
 		jenkins.Status.BaseConfigurationCompletedTime = &now
-		err = r.client.Update(context.TODO(), jenkins)
+		err = r.client.Status().Update(context.TODO(), jenkins)
 		if err != nil {
 			return reconcile.Result{}, jenkins, errors.WithStack(err)
 		}
@@ -285,31 +380,47 @@ func (r *ReconcileJenkins) reconcile(request reconcile.Request) (reconcile.Resul
 		for _, msg := range messages {
 			logger.V(log.VWarn).Info(msg)
 		}
-		return reconcile.Result{}, jenkins, nil // don't requeue
+		conditions := notReadyConditions(v1alpha2.UserConfigurationReconciledCondition, conditionReasonValidationFailed, strings.Join(messages, "; "))
+		return reconcile.Result{}, jenkins, r.updateConditions(jenkins, conditions...) // don't requeue
 	}
 
 	// Reconcile casc
+	started = time.Now()
 	result, err = userConfiguration.ReconcileCasc()
+	observeReconcilePhase(jenkins, reconcilePhaseCasc, started)
 	if err != nil {
+		r.updateConditionsAfterError(jenkins, v1alpha2.UserConfigurationReconciledCondition, err)
 		return reconcile.Result{}, jenkins, err
 	}
 	if result.Requeue {
-		return result, jenkins, nil
+		return result, jenkins, r.updateUserConfigurationInProgress(jenkins)
 	}
+	// poll Configuration as Code Git repositories
+	cascRequeueAfter := result.RequeueAfter
 
 	// Reconcile seedjobs, backups
+	started = time.Now()
 	result, err = userConfiguration.ReconcileOthers()
+	observeReconcilePhase(jenkins, reconcilePhaseUser, started)
 	if err != nil {
+		r.updateConditionsAfterError(jenkins, v1alpha2.UserConfigurationReconciledCondition, err)
 		return reconcile.Result{}, jenkins, err
 	}
 	if result.Requeue {
-		return result, jenkins, nil
+		return result, jenkins, r.updateUserConfigurationInProgress(jenkins)
+	}
+	err = r.updateConditions(jenkins,
+		newCondition(v1alpha2.UserConfigurationReconciledCondition, corev1.ConditionTrue, conditionReasonReconciled, ""),
+		newCondition(v1alpha2.ReadyCondition, corev1.ConditionTrue, conditionReasonReconciled, ""),
+	)
+	if err != nil {
+		return reconcile.Result{}, jenkins, err
 	}
 
 	if jenkins.Status.UserConfigurationCompletedTime == nil {
 		now := metav1.Now()
 		jenkins.Status.UserConfigurationCompletedTime = &now
-		err = r.client.Update(context.TODO(), jenkins)
+		err = r.client.Status().Update(context.TODO(), jenkins)
 		if err != nil {
 			return reconcile.Result{}, jenkins, errors.WithStack(err)
 		}
@@ -323,176 +434,52 @@ func (r *ReconcileJenkins) reconcile(request reconcile.Request) (reconcile.Resul
 		}
 		logger.Info(message)
 	}
-	return reconcile.Result{}, jenkins, nil
+	return reconcile.Result{RequeueAfter: minRequeueAfter(baseRequeueAfter, cascRequeueAfter, r.getReconcileInterval(jenkins))}, jenkins, nil
 }
 
-func (r *ReconcileJenkins) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
-	changed := false
-	logger := logx.WithValues("cr", jenkins.Name)
-
-	var jenkinsContainer v1alpha2.Container
-
-	if len(jenkins.Spec.Master.Containers) == 0 {
-		changed = true
-		jenkinsContainer = v1alpha2.Container{Name: resources.JenkinsMasterContainerName}
-	} else {
-		if jenkins.Spec.Master.Containers[0].Name != resources.JenkinsMasterContainerName {
-			return false, errors.Errorf("first container in spec.master.containers must be Jenkins container with name '%s', please correct CR", resources.JenkinsMasterContainerName)
-		}
-		jenkinsContainer = jenkins.Spec.Master.Containers[0]
+// getReconcileInterval returns the interval of periodic reconciliation of the Jenkins CR
+func (r *ReconcileJenkins) getReconcileInterval(jenkins *v1alpha2.Jenkins) time.Duration {
+	if jenkins.Spec.ReconcileInterval != nil {
+		return jenkins.Spec.ReconcileInterval.Duration
 	}
 
-	if len(jenkinsContainer.Image) == 0 {
-		logger.Info("Setting default Jenkins master image: " + constants.DefaultJenkinsMasterImage)
-		changed = true
-		jenkinsContainer.Image = constants.DefaultJenkinsMasterImage
-		jenkinsContainer.ImagePullPolicy = corev1.PullAlways
-	}
-	if len(jenkinsContainer.ImagePullPolicy) == 0 {
-		logger.Info(fmt.Sprintf("Setting default Jenkins master image pull policy: %s", corev1.PullAlways))
-		changed = true
-		jenkinsContainer.ImagePullPolicy = corev1.PullAlways
-	}
+	return r.intervals.Reconcile
+}
 
-	if jenkinsContainer.ReadinessProbe == nil {
-		logger.Info("Setting default Jenkins readinessProbe")
-		changed = true
-		jenkinsContainer.ReadinessProbe = resources.NewSimpleProbe(containerProbeURI, containerProbePortName, corev1.URISchemeHTTP, 30)
-	}
-	if jenkinsContainer.LivenessProbe == nil {
-		logger.Info("Setting default Jenkins livenessProbe")
-		changed = true
-		jenkinsContainer.LivenessProbe = resources.NewProbe(containerProbeURI, containerProbePortName, corev1.URISchemeHTTP, 80, 5, 12)
-	}
-	if len(jenkinsContainer.Command) == 0 {
-		logger.Info("Setting default Jenkins container command")
-		changed = true
-		jenkinsContainer.Command = resources.GetJenkinsMasterContainerBaseCommand()
-	}
-	if isJavaOpsVariableNotSet(jenkinsContainer) {
-		logger.Info("Setting default Jenkins container JAVA_OPTS environment variable")
-		changed = true
-		jenkinsContainer.Env = append(jenkinsContainer.Env, corev1.EnvVar{
-			Name:  constants.JavaOpsVariableName,
-			Value: "-XX:+UnlockExperimentalVMOptions -XX:+UseCGroupMemoryLimitForHeap -XX:MaxRAMFraction=1 -Djenkins.install.runSetupWizard=false -Djava.awt.headless=true",
-		})
-	}
-	if len(jenkins.Spec.Master.BasePlugins) == 0 {
-		logger.Info("Setting default operator plugins")
-		changed = true
-		jenkins.Spec.Master.BasePlugins = basePlugins()
-	}
-	if isResourceRequirementsNotSet(jenkinsContainer.Resources) {
-		logger.Info("Setting default Jenkins master container resource requirements")
-		changed = true
-		jenkinsContainer.Resources = resources.NewResourceRequirements("1", "500Mi", "1500m", "3Gi")
-	}
-	if reflect.DeepEqual(jenkins.Spec.Service, v1alpha2.Service{}) {
-		logger.Info("Setting default Jenkins master service")
-		changed = true
-		var serviceType = corev1.ServiceTypeClusterIP
-		if r.jenkinsAPIConnectionSettings.UseNodePort {
-			serviceType = corev1.ServiceTypeNodePort
-		}
-		jenkins.Spec.Service = v1alpha2.Service{
-			Type: serviceType,
-			Port: constants.DefaultHTTPPortInt32,
-		}
-	}
-	if reflect.DeepEqual(jenkins.Spec.SlaveService, v1alpha2.Service{}) {
-		logger.Info("Setting default Jenkins slave service")
-		changed = true
-		jenkins.Spec.SlaveService = v1alpha2.Service{
-			Type: corev1.ServiceTypeClusterIP,
-			Port: constants.DefaultSlavePortInt32,
-		}
-	}
-	if len(jenkins.Spec.Master.Containers) > 1 {
-		for i, container := range jenkins.Spec.Master.Containers[1:] {
-			if r.setDefaultsForContainer(jenkins, container.Name, i+1) {
-				changed = true
-			}
+// ensurePaused updates the Paused condition and returns true when the reconciliation of Jenkins is paused
+func (r *ReconcileJenkins) ensurePaused(jenkins *v1alpha2.Jenkins) (bool, error) {
+	if jenkins.Annotations[PausedAnnotation] == "true" {
+		message := fmt.Sprintf("Reconciliation is paused by the '%s' annotation", PausedAnnotation)
+		if !configuration.IsConditionTrue(jenkins.Status, v1alpha2.PausedCondition) {
+			logx.WithValues("cr", jenkins.Name).Info(message)
 		}
-	}
-	if len(jenkins.Spec.Backup.ContainerName) > 0 && jenkins.Spec.Backup.Interval == 0 {
-		logger.Info("Setting default backup interval")
-		changed = true
-		jenkins.Spec.Backup.Interval = 30
-	}
-
-	if len(jenkins.Spec.Master.Containers) == 0 || len(jenkins.Spec.Master.Containers) == 1 {
-		jenkins.Spec.Master.Containers = []v1alpha2.Container{jenkinsContainer}
-	} else {
-		noJenkinsContainers := jenkins.Spec.Master.Containers[1:]
-		containers := []v1alpha2.Container{jenkinsContainer}
-		containers = append(containers, noJenkinsContainers...)
-		jenkins.Spec.Master.Containers = containers
+		return true, r.updateConditions(jenkins, newCondition(v1alpha2.PausedCondition, corev1.ConditionTrue, conditionReasonPaused, message))
 	}
 
-	if reflect.DeepEqual(jenkins.Spec.JenkinsAPISettings, v1alpha2.JenkinsAPISettings{}) {
-		logger.Info("Setting default Jenkins API settings")
-		changed = true
-		jenkins.Spec.JenkinsAPISettings = v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy}
-	}
-
-	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy == "" {
-		logger.Info("Setting default Jenkins API settings authorization strategy")
-		changed = true
-		jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy = v1alpha2.CreateUserAuthorizationStrategy
-	}
-
-	if len(jenkins.Spec.SeedJobs) > 0 && len(jenkins.Spec.SeedAgent.Image) == 0 {
-		logger.Info("Setting default Agent image: " + constants.DefaultJenkinsAgentImage)
-		changed = true
-		jenkins.Spec.SeedAgent.Image = constants.DefaultJenkinsAgentImage
+	if configuration.IsConditionTrue(jenkins.Status, v1alpha2.PausedCondition) {
+		logx.WithValues("cr", jenkins.Name).Info("Reconciliation is resumed")
+		return false, r.updateConditions(jenkins, newCondition(v1alpha2.PausedCondition, corev1.ConditionFalse, conditionReasonResumed, ""))
 	}
 
-	if changed {
-		return changed, errors.WithStack(r.client.Update(context.TODO(), jenkins))
-	}
-	return changed, nil
+	return false, nil
 }
 
-func isJavaOpsVariableNotSet(container v1alpha2.Container) bool {
-	for _, env := range container.Env {
-		if env.Name == constants.JavaOpsVariableName {
-			return false
+// minRequeueAfter returns the shortest non-zero requeue interval
+func minRequeueAfter(intervals ...time.Duration) time.Duration {
+	var result time.Duration
+	for _, interval := range intervals {
+		if interval > 0 && (result == 0 || interval < result) {
+			result = interval
 		}
 	}
-	return true
-}
 
-func (r *ReconcileJenkins) setDefaultsForContainer(jenkins *v1alpha2.Jenkins, containerName string, containerIndex int) bool {
-	changed := false
-	logger := logx.WithValues("cr", jenkins.Name, "container", containerName)
-
-	if len(jenkins.Spec.Master.Containers[containerIndex].ImagePullPolicy) == 0 {
-		logger.Info(fmt.Sprintf("Setting default container image pull policy: %s", corev1.PullAlways))
-		changed = true
-		jenkins.Spec.Master.Containers[containerIndex].ImagePullPolicy = corev1.PullAlways
-	}
-	if isResourceRequirementsNotSet(jenkins.Spec.Master.Containers[containerIndex].Resources) {
-		logger.Info("Setting default container resource requirements")
-		changed = true
-		jenkins.Spec.Master.Containers[containerIndex].Resources = resources.NewResourceRequirements("50m", "50Mi", "100m", "100Mi")
-	}
-	return changed
-}
-
-func isResourceRequirementsNotSet(requirements corev1.ResourceRequirements) bool {
-	return reflect.DeepEqual(requirements, corev1.ResourceRequirements{})
-}
-
-func basePlugins() (result []v1alpha2.Plugin) {
-	for _, value := range plugins.BasePlugins() {
-		result = append(result, v1alpha2.Plugin{Name: value.Name, Version: value.Version})
-	}
-	return
+	return result
 }
 
 func (r *ReconcileJenkins) handleDeprecatedData(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
 	changed := false
 	logger := logx.WithValues("cr", jenkins.Name)
+	before := jenkins.DeepCopy()
 	if len(jenkins.Spec.Master.AnnotationsDeprecated) > 0 {
 		changed = true
 		jenkins.Spec.Master.Annotations = jenkins.Spec.Master.AnnotationsDeprecated
@@ -500,7 +487,7 @@ func (r *ReconcileJenkins) handleDeprecatedData(jenkins *v1alpha2.Jenkins) (requ
 		logger.V(log.VWarn).Info("spec.master.masterAnnotations is deprecated, the annotations have been moved to spec.master.annotations")
 	}
 	if changed {
-		return changed, errors.WithStack(r.client.Update(context.TODO(), jenkins))
+		return changed, errors.WithStack(r.client.Patch(context.TODO(), jenkins, client.MergeFrom(before)))
 	}
 	return changed, nil
 }
@@ -0,0 +1,35 @@
+package jenkins
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMinRequeueAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), minRequeueAfter())
+	assert.Equal(t, time.Duration(0), minRequeueAfter(0, 0))
+	assert.Equal(t, time.Minute, minRequeueAfter(0, time.Hour, time.Minute))
+}
+
+func TestGetReconcileInterval(t *testing.T) {
+	r := &ReconcileJenkins{intervals: ReconcileIntervals{Reconcile: 10 * time.Minute}}
+
+	t.Run("operator flag", func(t *testing.T) {
+		assert.Equal(t, 10*time.Minute, r.getReconcileInterval(&v1alpha2.Jenkins{}))
+	})
+	t.Run("Jenkins CR", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{ReconcileInterval: &metav1.Duration{Duration: time.Minute}}}
+
+		assert.Equal(t, time.Minute, r.getReconcileInterval(jenkins))
+	})
+	t.Run("disabled in Jenkins CR", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{ReconcileInterval: &metav1.Duration{}}}
+
+		assert.Equal(t, time.Duration(0), r.getReconcileInterval(jenkins))
+	})
+}
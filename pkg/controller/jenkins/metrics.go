@@ -0,0 +1,102 @@
+package jenkins
+
+import (
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "jenkins_operator"
+	metricsSubsystem = "reconcile"
+
+	reconcilePhaseBase = "base"
+	reconcilePhaseCasc = "casc"
+	reconcilePhaseUser = "user"
+
+	reconcileErrorConflict           = "conflict"
+	reconcileErrorJenkinsUnavailable = "jenkins_unavailable"
+	reconcileErrorGroovyScript       = "groovy_script"
+	reconcileErrorKubernetesAPI      = "kubernetes_api"
+	reconcileErrorOther              = "other"
+)
+
+var (
+	reconcileConsecutiveFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "consecutive_failures",
+		Help:      "Number of consecutive failed reconcile loops of the Jenkins CR.",
+	}, []string{"namespace", "jenkins"})
+
+	reconcileBackoffSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "backoff_seconds",
+		Help:      "Delay of the next reconcile loop of the Jenkins CR after failures in seconds.",
+	}, []string{"namespace", "jenkins"})
+
+	reconcilePhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "phase_duration_seconds",
+		Help:      "Duration of reconcile phases of the Jenkins CR in seconds partitioned by phase (base, casc, user).",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"namespace", "jenkins", "phase"})
+
+	reconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "errors_total",
+		Help:      "Number of failed reconcile loops of the Jenkins CR partitioned by the reason type of the error.",
+	}, []string{"namespace", "jenkins", "reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileConsecutiveFailures, reconcileBackoffSeconds, reconcilePhaseDuration, reconcileErrorsTotal)
+}
+
+func observeReconcilePhase(jenkins *v1alpha2.Jenkins, phase string, started time.Time) {
+	reconcilePhaseDuration.WithLabelValues(jenkins.Namespace, jenkins.Name, phase).Observe(time.Since(started).Seconds())
+}
+
+func observeReconcileError(name types.NamespacedName, err error) {
+	reconcileErrorsTotal.WithLabelValues(name.Namespace, name.Name, getReconcileErrorReason(err)).Inc()
+}
+
+// getReconcileErrorReason returns the reason type of the reconcile loop error, it keeps the cardinality of metrics low
+func getReconcileErrorReason(err error) string {
+	cause := errors.Cause(err)
+	switch {
+	case apierrors.IsConflict(cause):
+		return reconcileErrorConflict
+	case jenkinsclient.IsTransientError(err):
+		return reconcileErrorJenkinsUnavailable
+	}
+	if _, ok := cause.(*jenkinsclient.GroovyScriptExecutionFailed); ok {
+		return reconcileErrorGroovyScript
+	}
+	if _, ok := cause.(apierrors.APIStatus); ok {
+		return reconcileErrorKubernetesAPI
+	}
+	return reconcileErrorOther
+}
+
+func observeReconcileBackoff(name types.NamespacedName, failures uint64, delay time.Duration) {
+	labels := prometheus.Labels{"namespace": name.Namespace, "jenkins": name.Name}
+	reconcileConsecutiveFailures.With(labels).Set(float64(failures))
+	reconcileBackoffSeconds.With(labels).Set(delay.Seconds())
+}
+
+func resetReconcileBackoff(name types.NamespacedName) {
+	labels := prometheus.Labels{"namespace": name.Namespace, "jenkins": name.Name}
+	reconcileConsecutiveFailures.Delete(labels)
+	reconcileBackoffSeconds.Delete(labels)
+}
@@ -0,0 +1,45 @@
+package jenkins
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetReconcileErrorReason(t *testing.T) {
+	resource := schema.GroupResource{Group: "jenkins.io", Resource: "jenkins"}
+
+	assert.Equal(t, reconcileErrorConflict, getReconcileErrorReason(errors.WithStack(apierrors.NewConflict(resource, "jenkins", errors.New("modified")))))
+	assert.Equal(t, reconcileErrorJenkinsUnavailable, getReconcileErrorReason(errors.Wrap(errors.New("503"), "couldn't get plugins")))
+	assert.Equal(t, reconcileErrorGroovyScript, getReconcileErrorReason(&jenkinsclient.GroovyScriptExecutionFailed{}))
+	assert.Equal(t, reconcileErrorKubernetesAPI, getReconcileErrorReason(errors.WithStack(apierrors.NewForbidden(resource, "jenkins", errors.New("forbidden")))))
+	assert.Equal(t, reconcileErrorOther, getReconcileErrorReason(errors.New("error")))
+}
+
+func TestGrafanaDashboardsUseReconcileMetrics(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"}}
+	configMap, err := resources.NewGrafanaDashboardsConfigMap(resources.NewResourceObjectMeta(jenkins), jenkins)
+	require.NoError(t, err)
+	dashboard := configMap.Data["jenkins-operator-default-example.json"]
+
+	descs := make(chan *prometheus.Desc, 10)
+	for _, collector := range []prometheus.Collector{reconcileConsecutiveFailures, reconcileBackoffSeconds, reconcilePhaseDuration, reconcileErrorsTotal} {
+		collector.Describe(descs)
+	}
+	close(descs)
+	for desc := range descs {
+		name := regexp.MustCompile(`fqName: "([a-z_]+)"`).FindStringSubmatch(desc.String())[1]
+		assert.Contains(t, dashboard, name)
+	}
+}
@@ -0,0 +1,90 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8sevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// NamespaceFilter selects namespaces where Jenkins CRs are reconciled by the operator, all watched namespaces
+// are selected when it's empty.
+type NamespaceFilter struct {
+	// Excluded are namespaces ignored by the operator
+	Excluded []string
+	// Selector selects namespaces by their labels, it requires get, list and watch permissions for namespaces
+	Selector labels.Selector
+}
+
+// NewNamespaceFilter returns filter of namespaces with given comma separated list of excluded namespaces
+// and label selector of namespaces.
+func NewNamespaceFilter(excluded, selector string) (NamespaceFilter, error) {
+	filter := NamespaceFilter{}
+	for _, namespace := range strings.Split(excluded, ",") {
+		if namespace = strings.TrimSpace(namespace); len(namespace) > 0 {
+			filter.Excluded = append(filter.Excluded, namespace)
+		}
+	}
+
+	if len(selector) > 0 {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return filter, errors.Wrapf(err, "invalid namespace selector '%s'", selector)
+		}
+		filter.Selector = parsed
+	}
+
+	return filter, nil
+}
+
+// IsEmpty returns true if the filter selects all namespaces.
+func (f NamespaceFilter) IsEmpty() bool {
+	return len(f.Excluded) == 0 && f.Selector == nil
+}
+
+// Allows returns true if Jenkins CRs from the namespace are reconciled by the operator.
+func (f NamespaceFilter) Allows(k8sClient client.Reader, namespace string) bool {
+	for _, excluded := range f.Excluded {
+		if namespace == excluded {
+			return false
+		}
+	}
+	if f.Selector == nil {
+		return true
+	}
+
+	ns := &corev1.Namespace{}
+	if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		log.Log.V(log.VWarn).Info(fmt.Sprintf("Failed to get namespace '%s', ignoring its resources: %s", namespace, err))
+		return false
+	}
+
+	return f.Selector.Matches(labels.Set(ns.Labels))
+}
+
+// predicate returns predicate which filters out events of resources from namespaces not allowed by the filter.
+func (f NamespaceFilter) predicate(k8sClient client.Reader) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e k8sevent.CreateEvent) bool {
+			return f.Allows(k8sClient, e.Meta.GetNamespace())
+		},
+		UpdateFunc: func(e k8sevent.UpdateEvent) bool {
+			return f.Allows(k8sClient, e.MetaNew.GetNamespace())
+		},
+		DeleteFunc: func(e k8sevent.DeleteEvent) bool {
+			return f.Allows(k8sClient, e.Meta.GetNamespace())
+		},
+		GenericFunc: func(e k8sevent.GenericEvent) bool {
+			return f.Allows(k8sClient, e.Meta.GetNamespace())
+		},
+	}
+}
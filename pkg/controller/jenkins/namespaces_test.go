@@ -0,0 +1,53 @@
+package jenkins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNamespaceFilter(t *testing.T) {
+	fakeClient := fake.NewFakeClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"jenkins": "enabled"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+	)
+
+	t.Run("empty", func(t *testing.T) {
+		filter, err := NewNamespaceFilter("", "")
+		require.NoError(t, err)
+
+		assert.True(t, filter.IsEmpty())
+		assert.True(t, filter.Allows(fakeClient, "team-b"))
+	})
+	t.Run("excluded namespaces", func(t *testing.T) {
+		filter, err := NewNamespaceFilter("kube-system, team-b,", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"kube-system", "team-b"}, filter.Excluded)
+		assert.True(t, filter.Allows(fakeClient, "team-a"))
+		assert.False(t, filter.Allows(fakeClient, "team-b"))
+	})
+	t.Run("namespace selector", func(t *testing.T) {
+		filter, err := NewNamespaceFilter("", "jenkins=enabled")
+		require.NoError(t, err)
+
+		assert.True(t, filter.Allows(fakeClient, "team-a"))
+		assert.False(t, filter.Allows(fakeClient, "team-b"))
+		assert.False(t, filter.Allows(fakeClient, "missing"))
+	})
+	t.Run("excluded namespace matching selector", func(t *testing.T) {
+		filter, err := NewNamespaceFilter("team-a", "jenkins=enabled")
+		require.NoError(t, err)
+
+		assert.False(t, filter.Allows(fakeClient, "team-a"))
+	})
+	t.Run("invalid selector", func(t *testing.T) {
+		_, err := NewNamespaceFilter("", "jenkins in (")
+
+		assert.Error(t, err)
+	})
+}
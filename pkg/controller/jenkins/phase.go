@@ -0,0 +1,135 @@
+package jenkins
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// phaseEvent is the observation of the reconcile loop which drives the phase state machine
+type phaseEvent string
+
+const (
+	// eventProvisioning - the resources of Jenkins master are being created and its pod isn't ready yet
+	eventProvisioning phaseEvent = "Provisioning"
+	// eventBaseInProgress - the base configuration is being applied in running Jenkins
+	eventBaseInProgress phaseEvent = "BaseInProgress"
+	// eventRestoring - the base configuration is applied and the backup is waiting to be restored
+	eventRestoring phaseEvent = "Restoring"
+	// eventUserInProgress - the user configuration is being applied
+	eventUserInProgress phaseEvent = "UserInProgress"
+	// eventReconciled - the base and the user configuration are applied and backups are healthy
+	eventReconciled phaseEvent = "Reconciled"
+	// eventUnhealthy - the base and the user configuration are applied but backups fail
+	eventUnhealthy phaseEvent = "Unhealthy"
+	// eventFailed - the validation or the reconciliation of the configuration has failed
+	eventFailed phaseEvent = "Failed"
+)
+
+// reasonPhaseChanged is the reason of Kubernetes events emitted on phase transitions
+const reasonPhaseChanged k8sevent.Reason = "PhaseChanged"
+
+// phaseTransitions defines the phase state machine, the transition of the event not listed for the current phase
+// is the phase of the event from the initial state (the empty phase)
+var phaseTransitions = map[v1alpha2.JenkinsPhase]map[phaseEvent]v1alpha2.JenkinsPhase{
+	"": {
+		eventProvisioning:   v1alpha2.JenkinsPhaseProvisioning,
+		eventBaseInProgress: v1alpha2.JenkinsPhaseConfiguringBase,
+		eventRestoring:      v1alpha2.JenkinsPhaseRestoringBackup,
+		eventUserInProgress: v1alpha2.JenkinsPhaseConfiguringUser,
+		eventReconciled:     v1alpha2.JenkinsPhaseReady,
+		eventUnhealthy:      v1alpha2.JenkinsPhaseDegraded,
+		eventFailed:         v1alpha2.JenkinsPhaseFailed,
+	},
+	// failures of Jenkins which has been configured don't make it unusable
+	v1alpha2.JenkinsPhaseReady: {
+		eventFailed: v1alpha2.JenkinsPhaseDegraded,
+	},
+	v1alpha2.JenkinsPhaseDegraded: {
+		eventFailed: v1alpha2.JenkinsPhaseDegraded,
+	},
+}
+
+// nextPhase returns the phase after the event observed in the current phase
+func nextPhase(current v1alpha2.JenkinsPhase, event phaseEvent) v1alpha2.JenkinsPhase {
+	if next, found := phaseTransitions[current][event]; found {
+		return next
+	}
+
+	return phaseTransitions[""][event]
+}
+
+// observePhaseEvent returns the event of the phase state machine derived from the conditions of Jenkins
+func observePhaseEvent(jenkins *v1alpha2.Jenkins) phaseEvent {
+	status := jenkins.Status
+	if configuration.IsConditionTrue(status, v1alpha2.ReadyCondition) {
+		backupHealthy := configuration.GetCondition(status, v1alpha2.BackupHealthyCondition)
+		if backupHealthy != nil && backupHealthy.Status == corev1.ConditionFalse {
+			return eventUnhealthy
+		}
+		return eventReconciled
+	}
+
+	for _, conditionType := range []v1alpha2.JenkinsConditionType{v1alpha2.BaseConfigurationReconciledCondition, v1alpha2.UserConfigurationReconciledCondition} {
+		condition := configuration.GetCondition(status, conditionType)
+		if condition != nil && condition.Status == corev1.ConditionFalse &&
+			(condition.Reason == conditionReasonReconcileFailed || condition.Reason == conditionReasonValidationFailed) {
+			return eventFailed
+		}
+	}
+
+	baseCondition := configuration.GetCondition(status, v1alpha2.BaseConfigurationReconciledCondition)
+	switch {
+	case baseCondition == nil || baseCondition.Reason == conditionReasonProvisioning:
+		return eventProvisioning
+	case baseCondition.Status != corev1.ConditionTrue:
+		return eventBaseInProgress
+	case isRestorePending(jenkins):
+		return eventRestoring
+	default:
+		return eventUserInProgress
+	}
+}
+
+// isRestorePending returns true when the restore is configured and the latest backup hasn't been restored yet
+func isRestorePending(jenkins *v1alpha2.Jenkins) bool {
+	restore := jenkins.Spec.Restore
+	return len(restore.ContainerName) > 0 && restore.Action.Exec != nil &&
+		jenkins.Status.RestoredBackup == 0 && jenkins.Status.LastBackup != 0
+}
+
+// updatePhase moves the phase of Jenkins according to its conditions, it returns true when the phase has changed
+func (r *ReconcileJenkins) updatePhase(jenkins *v1alpha2.Jenkins) bool {
+	event := observePhaseEvent(jenkins)
+	next := nextPhase(jenkins.Status.Phase, event)
+	if next == jenkins.Status.Phase {
+		return false
+	}
+
+	logx.WithValues("cr", jenkins.Name).Info(fmt.Sprintf("Phase changed from '%s' to '%s' after '%s'", jenkins.Status.Phase, next, event))
+	r.emitPhaseChangedEvent(jenkins, next)
+	jenkins.Status.Phase = next
+	return true
+}
+
+// emitPhaseChangedEvent records the phase transition on the Jenkins CR, so it's visible by kubectl describe jenkins,
+// transitions to Degraded and Failed are warnings
+func (r *ReconcileJenkins) emitPhaseChangedEvent(jenkins *v1alpha2.Jenkins, next v1alpha2.JenkinsPhase) {
+	if r.events == nil {
+		return
+	}
+
+	eventType := k8sevent.TypeNormal
+	if next == v1alpha2.JenkinsPhaseDegraded || next == v1alpha2.JenkinsPhaseFailed {
+		eventType = k8sevent.TypeWarning
+	}
+	previous := jenkins.Status.Phase
+	if len(previous) == 0 {
+		previous = "None"
+	}
+	r.events.Emitf(jenkins, eventType, reasonPhaseChanged, "Phase changed from '%s' to '%s'", previous, next)
+}
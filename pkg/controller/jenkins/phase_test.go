@@ -0,0 +1,151 @@
+package jenkins
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type fakeRecorder struct {
+	messages []string
+}
+
+func (r *fakeRecorder) Emit(_ runtime.Object, eventType k8sevent.Type, reason k8sevent.Reason, message string) {
+	r.messages = append(r.messages, fmt.Sprintf("%s %s %s", eventType, reason, message))
+}
+
+func (r *fakeRecorder) Emitf(object runtime.Object, eventType k8sevent.Type, reason k8sevent.Reason, format string, args ...interface{}) {
+	r.Emit(object, eventType, reason, fmt.Sprintf(format, args...))
+}
+
+func TestObservePhaseEvent(t *testing.T) {
+	baseReconciled := newCondition(v1alpha2.BaseConfigurationReconciledCondition, corev1.ConditionTrue, conditionReasonReconciled, "")
+	userReconciled := newCondition(v1alpha2.UserConfigurationReconciledCondition, corev1.ConditionTrue, conditionReasonReconciled, "")
+	ready := newCondition(v1alpha2.ReadyCondition, corev1.ConditionTrue, conditionReasonReconciled, "")
+	restore := v1alpha2.Restore{ContainerName: "backup", Action: v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"restore"}}}}
+
+	tests := []struct {
+		name       string
+		conditions []v1alpha2.JenkinsCondition
+		restore    v1alpha2.Restore
+		status     v1alpha2.JenkinsStatus
+		want       phaseEvent
+	}{
+		{
+			name: "no conditions",
+			want: eventProvisioning,
+		},
+		{
+			name:       "master pod is being provisioned",
+			conditions: notReadyConditions(v1alpha2.BaseConfigurationReconciledCondition, conditionReasonProvisioning, ""),
+			want:       eventProvisioning,
+		},
+		{
+			name:       "base configuration in progress",
+			conditions: notReadyConditions(v1alpha2.BaseConfigurationReconciledCondition, conditionReasonInProgress, ""),
+			want:       eventBaseInProgress,
+		},
+		{
+			name:       "base configuration reconcile failed",
+			conditions: notReadyConditions(v1alpha2.BaseConfigurationReconciledCondition, conditionReasonReconcileFailed, "error"),
+			want:       eventFailed,
+		},
+		{
+			name:       "backup waits for restore",
+			conditions: append(notReadyConditions(v1alpha2.UserConfigurationReconciledCondition, conditionReasonInProgress, ""), baseReconciled),
+			restore:    restore,
+			status:     v1alpha2.JenkinsStatus{LastBackup: 3},
+			want:       eventRestoring,
+		},
+		{
+			name:       "backup already restored",
+			conditions: append(notReadyConditions(v1alpha2.UserConfigurationReconciledCondition, conditionReasonInProgress, ""), baseReconciled),
+			restore:    restore,
+			status:     v1alpha2.JenkinsStatus{LastBackup: 3, RestoredBackup: 3},
+			want:       eventUserInProgress,
+		},
+		{
+			name:       "user configuration validation failed",
+			conditions: append(notReadyConditions(v1alpha2.UserConfigurationReconciledCondition, conditionReasonValidationFailed, "invalid"), baseReconciled),
+			want:       eventFailed,
+		},
+		{
+			name:       "ready",
+			conditions: []v1alpha2.JenkinsCondition{baseReconciled, userReconciled, ready},
+			want:       eventReconciled,
+		},
+		{
+			name: "ready with failed backup",
+			conditions: []v1alpha2.JenkinsCondition{baseReconciled, userReconciled, ready,
+				newCondition(v1alpha2.BackupHealthyCondition, corev1.ConditionFalse, "BackupFailed", "error")},
+			want: eventUnhealthy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Restore: tt.restore}, Status: tt.status}
+			jenkins.Status.Conditions = tt.conditions
+
+			assert.Equal(t, tt.want, observePhaseEvent(jenkins))
+		})
+	}
+}
+
+func TestNextPhase(t *testing.T) {
+	tests := []struct {
+		current v1alpha2.JenkinsPhase
+		event   phaseEvent
+		want    v1alpha2.JenkinsPhase
+	}{
+		{"", eventProvisioning, v1alpha2.JenkinsPhaseProvisioning},
+		{v1alpha2.JenkinsPhaseProvisioning, eventBaseInProgress, v1alpha2.JenkinsPhaseConfiguringBase},
+		{v1alpha2.JenkinsPhaseConfiguringBase, eventRestoring, v1alpha2.JenkinsPhaseRestoringBackup},
+		{v1alpha2.JenkinsPhaseRestoringBackup, eventUserInProgress, v1alpha2.JenkinsPhaseConfiguringUser},
+		{v1alpha2.JenkinsPhaseConfiguringUser, eventReconciled, v1alpha2.JenkinsPhaseReady},
+		{v1alpha2.JenkinsPhaseConfiguringBase, eventFailed, v1alpha2.JenkinsPhaseFailed},
+		{v1alpha2.JenkinsPhaseFailed, eventFailed, v1alpha2.JenkinsPhaseFailed},
+		{v1alpha2.JenkinsPhaseReady, eventFailed, v1alpha2.JenkinsPhaseDegraded},
+		{v1alpha2.JenkinsPhaseReady, eventUnhealthy, v1alpha2.JenkinsPhaseDegraded},
+		{v1alpha2.JenkinsPhaseDegraded, eventFailed, v1alpha2.JenkinsPhaseDegraded},
+		{v1alpha2.JenkinsPhaseDegraded, eventReconciled, v1alpha2.JenkinsPhaseReady},
+		{v1alpha2.JenkinsPhaseReady, eventUserInProgress, v1alpha2.JenkinsPhaseConfiguringUser},
+		{v1alpha2.JenkinsPhaseReady, eventProvisioning, v1alpha2.JenkinsPhaseProvisioning},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.current)+"+"+string(tt.event), func(t *testing.T) {
+			assert.Equal(t, tt.want, nextPhase(tt.current, tt.event))
+		})
+	}
+}
+
+func TestUpdatePhase(t *testing.T) {
+	recorder := &fakeRecorder{}
+	r := &ReconcileJenkins{events: recorder}
+	jenkins := &v1alpha2.Jenkins{}
+
+	require.True(t, r.updatePhase(jenkins))
+	assert.Equal(t, v1alpha2.JenkinsPhaseProvisioning, jenkins.Status.Phase)
+	require.False(t, r.updatePhase(jenkins))
+
+	jenkins.Status.Conditions = []v1alpha2.JenkinsCondition{
+		newCondition(v1alpha2.ReadyCondition, corev1.ConditionTrue, conditionReasonReconciled, ""),
+	}
+	require.True(t, r.updatePhase(jenkins))
+	jenkins.Status.Conditions = []v1alpha2.JenkinsCondition{
+		newCondition(v1alpha2.BaseConfigurationReconciledCondition, corev1.ConditionFalse, conditionReasonReconcileFailed, "failed"),
+	}
+	require.True(t, r.updatePhase(jenkins))
+
+	assert.Equal(t, []string{
+		"Normal PhaseChanged Phase changed from 'None' to 'Provisioning'",
+		"Normal PhaseChanged Phase changed from 'Provisioning' to 'Ready'",
+		"Warning PhaseChanged Phase changed from 'Ready' to 'Degraded'",
+	}, recorder.messages)
+}
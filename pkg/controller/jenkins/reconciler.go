@@ -1,18 +1,46 @@
 package jenkins
 
 import (
+	"time"
+
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/health"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// ReconcileIntervals defines how often Jenkins CRs are reconciled.
+type ReconcileIntervals struct {
+	// Reconcile is the interval of periodic reconciliation of successfully reconciled Jenkins CRs,
+	// zero disables it and CRs are reconciled only on changes
+	Reconcile time.Duration
+	// MaxRequeueDelay is the maximal random delay of immediate requeue
+	MaxRequeueDelay time.Duration
+}
+
+// DefaultReconcileIntervals are intervals used when they're not set by operator flags.
+var DefaultReconcileIntervals = ReconcileIntervals{
+	MaxRequeueDelay: 10 * time.Millisecond,
+}
+
+// Options are settings of the Jenkins controller.
+type Options struct {
+	Intervals       ReconcileIntervals
+	NamespaceFilter NamespaceFilter
+	Shard           Shard
+	// Instances is updated with the health of Jenkins CRs after every reconcile loop
+	Instances *health.Instances
+	// Events records Kubernetes events of phase transitions on Jenkins CRs, nil disables them
+	Events k8sevent.Recorder
+}
+
 // ReconcileJenkins reconciles a Jenkins object.
 type ReconcileJenkins struct {
 	client                       client.Client
@@ -21,6 +49,12 @@ type ReconcileJenkins struct {
 	clientSet                    kubernetes.Clientset
 	config                       rest.Config
 	notificationEvents           *chan event.Event
+	reconcileBackoff             *reconcileBackoff
+	intervals                    ReconcileIntervals
+	shard                        Shard
+	resourceDrift                *resourceDrift
+	instances                    *health.Instances
+	events                       k8sevent.Recorder
 }
 
 func (r *ReconcileJenkins) newReconcilierConfiguration(jenkins *v1alpha2.Jenkins) configuration.Configuration {
@@ -37,7 +71,11 @@ func (r *ReconcileJenkins) newReconcilierConfiguration(jenkins *v1alpha2.Jenkins
 }
 
 // newReconciler returns a newReconcilierConfiguration reconcile.Reconciler.
-func newReconciler(mgr manager.Manager, jenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings, clientSet kubernetes.Clientset, config rest.Config, notificationEvents *chan event.Event) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, jenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings, clientSet kubernetes.Clientset, config rest.Config, notificationEvents *chan event.Event, options Options) *ReconcileJenkins {
+	instances := options.Instances
+	if instances == nil {
+		instances = health.NewInstances()
+	}
 	return &ReconcileJenkins{
 		client:                       mgr.GetClient(),
 		scheme:                       mgr.GetScheme(),
@@ -45,5 +83,11 @@ func newReconciler(mgr manager.Manager, jenkinsAPIConnectionSettings jenkinsclie
 		clientSet:                    clientSet,
 		config:                       config,
 		notificationEvents:           notificationEvents,
+		reconcileBackoff:             newReconcileBackoff(reconcileBackoffBaseDelay, reconcileBackoffMaxDelay, reconcileBackoffJitter),
+		intervals:                    options.Intervals,
+		shard:                        options.Shard,
+		resourceDrift:                newResourceDrift(),
+		instances:                    instances,
+		events:                       options.Events,
 	}
 }
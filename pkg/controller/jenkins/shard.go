@@ -0,0 +1,61 @@
+package jenkins
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/pkg/errors"
+)
+
+// ShardLabel is the label of Jenkins CR which assigns it to the operator instance started with the same --shard-name
+const ShardLabel = "jenkins.io/shard"
+
+// Shard selects Jenkins CRs reconciled by the operator instance when Jenkins CRs are split between multiple
+// operator instances.
+type Shard struct {
+	// Name is the value of the jenkins.io/shard label of Jenkins CRs reconciled by the instance,
+	// instances without the name reconcile Jenkins CRs without the label
+	Name string
+	// Count is the number of shards which split Jenkins CRs by the hash of their namespace and name,
+	// zero disables sharding by the hash
+	Count uint32
+	// Index is the index of the shard of the instance, from 0 to Count-1
+	Index uint32
+}
+
+// Validate returns an error if the shard settings are invalid.
+func (s Shard) Validate() error {
+	if s.Count > 0 && s.Index >= s.Count {
+		return errors.Errorf("shard index %d must be lower than the shard count %d", s.Index, s.Count)
+	}
+
+	return nil
+}
+
+// LeaderLockName returns the name of the leader election lock of the instance, every shard has its own leader.
+func (s Shard) LeaderLockName(name string) string {
+	if len(s.Name) > 0 {
+		name = fmt.Sprintf("%s-%s", name, s.Name)
+	}
+	if s.Count > 0 {
+		name = fmt.Sprintf("%s-%d-of-%d", name, s.Index, s.Count)
+	}
+
+	return name
+}
+
+// Owns returns true if the Jenkins CR is reconciled by the instance.
+func (s Shard) Owns(jenkins *v1alpha2.Jenkins) bool {
+	if jenkins.Labels[ShardLabel] != s.Name {
+		return false
+	}
+	if s.Count == 0 {
+		return true
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(jenkins.Namespace + "/" + jenkins.Name))
+	return hash.Sum32()%s.Count == s.Index
+}
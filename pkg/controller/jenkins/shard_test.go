@@ -0,0 +1,56 @@
+package jenkins
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestShard(t *testing.T) {
+	newJenkins := func(name string, labels map[string]string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: labels}}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		shard := Shard{}
+
+		assert.NoError(t, shard.Validate())
+		assert.True(t, shard.Owns(newJenkins("jenkins", nil)))
+		assert.False(t, shard.Owns(newJenkins("jenkins", map[string]string{ShardLabel: "team-a"})))
+		assert.Equal(t, "jenkins-operator-lock", shard.LeaderLockName("jenkins-operator-lock"))
+	})
+	t.Run("shard label", func(t *testing.T) {
+		shard := Shard{Name: "team-a"}
+
+		assert.NoError(t, shard.Validate())
+		assert.False(t, shard.Owns(newJenkins("jenkins", nil)))
+		assert.True(t, shard.Owns(newJenkins("jenkins", map[string]string{ShardLabel: "team-a"})))
+		assert.Equal(t, "jenkins-operator-lock-team-a", shard.LeaderLockName("jenkins-operator-lock"))
+	})
+	t.Run("hash", func(t *testing.T) {
+		shards := []Shard{{Count: 3, Index: 0}, {Count: 3, Index: 1}, {Count: 3, Index: 2}}
+		owned := make([]int, len(shards))
+		for i := 0; i < 300; i++ {
+			jenkins := newJenkins(fmt.Sprintf("jenkins-%d", i), nil)
+			owners := 0
+			for index, shard := range shards {
+				if shard.Owns(jenkins) {
+					owners++
+					owned[index]++
+				}
+			}
+			assert.Equal(t, 1, owners, jenkins.Name)
+		}
+		for index := range shards {
+			assert.NotZero(t, owned[index])
+		}
+		assert.Equal(t, "jenkins-operator-lock-1-of-3", shards[1].LeaderLockName("jenkins-operator-lock"))
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		assert.Error(t, Shard{Count: 2, Index: 2}.Validate())
+	})
+}
@@ -0,0 +1,47 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/supportbundle"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reasonSupportBundleCollected is the reason of Kubernetes events emitted when the support bundle is stored
+const reasonSupportBundleCollected k8sevent.Reason = "SupportBundleCollected"
+
+// ensureSupportBundle collects the support bundle of Jenkins requested by the SupportBundleAnnotation, stores it in
+// the Secret and removes the annotation, so the next support bundle can be requested by setting it again
+func (r *ReconcileJenkins) ensureSupportBundle(jenkins *v1alpha2.Jenkins) error {
+	if jenkins.Annotations[SupportBundleAnnotation] != "true" {
+		return nil
+	}
+
+	config := r.newReconcilierConfiguration(jenkins)
+	bundle, err := supportbundle.Collect(jenkins, supportbundle.Sources{
+		Client:        r.client,
+		Events:        r.clientSet.CoreV1(),
+		JenkinsClient: config.GetJenkinsClient,
+	})
+	if err != nil {
+		return err
+	}
+	if err = supportbundle.Store(r.client, jenkins, bundle); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Support bundle has been stored in the '%s' Secret", supportbundle.GetSecretName(jenkins))
+	logx.WithValues("cr", jenkins.Name).Info(message)
+	if r.events != nil {
+		r.events.Emit(jenkins, k8sevent.TypeNormal, reasonSupportBundleCollected, message)
+	}
+
+	before := jenkins.DeepCopy()
+	delete(jenkins.Annotations, SupportBundleAnnotation)
+	return errors.WithStack(r.client.Patch(context.TODO(), jenkins, client.MergeFrom(before)))
+}
@@ -0,0 +1,187 @@
+package groovy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "jenkins_operator"
+	metricsSubsystem = "groovy_script"
+
+	resultSuccess = "success"
+	resultFailure = "failure"
+
+	// MaxScriptLogSize is the maximal size of logs of single script execution kept in the ConfigMap, the beginning of
+	// longer logs is dropped
+	MaxScriptLogSize = 64 * 1024
+
+	scriptLogTimeFormat = "20060102T150405.000Z"
+)
+
+var (
+	executionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "execution_duration_seconds",
+		Help:      "Duration of groovy scripts executed in Jenkins in seconds partitioned by the configuration type, source, name and result (success, failure).",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"namespace", "jenkins", "configuration_type", "source", "name", "result"})
+
+	outputBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "output_bytes",
+		Help:      "Size of the output of the latest execution of groovy scripts in bytes partitioned by the configuration type, source and name.",
+	}, []string{"namespace", "jenkins", "configuration_type", "source", "name"})
+
+	invalidScriptLogKeyCharacters = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+)
+
+func init() {
+	metrics.Registry.MustRegister(executionDuration, outputBytes)
+}
+
+// Execution is the groovy script executed in Jenkins by the operator
+type Execution struct {
+	ConfigurationType string
+	Source            string
+	Name              string
+	Duration          time.Duration
+	Logs              string
+	Err               error
+}
+
+// ObserveExecution records metrics of the groovy script execution
+func ObserveExecution(jenkins *v1alpha2.Jenkins, execution Execution) {
+	result := resultSuccess
+	if execution.Err != nil {
+		result = resultFailure
+	}
+	executionDuration.WithLabelValues(jenkins.Namespace, jenkins.Name, execution.ConfigurationType, execution.Source, execution.Name, result).
+		Observe(execution.Duration.Seconds())
+	outputBytes.WithLabelValues(jenkins.Namespace, jenkins.Name, execution.ConfigurationType, execution.Source, execution.Name).
+		Set(float64(len(execution.Logs)))
+
+	log.Log.WithValues("cr", jenkins.Name).V(log.VDebug).Info(fmt.Sprintf("%s Source '%s' Name '%s' groovy script executed in %s with %s, output %d bytes",
+		execution.ConfigurationType, execution.Source, execution.Name, execution.Duration.Round(time.Millisecond), result, len(execution.Logs)))
+}
+
+// RecordExecution records metrics of the groovy script execution and keeps its logs in the ConfigMap of the Jenkins CR
+// when spec.scriptLogs is set, the failure of storing logs is logged only
+func RecordExecution(k8sClient k8s.Client, jenkins *v1alpha2.Jenkins, execution Execution) {
+	ObserveExecution(jenkins, execution)
+
+	if jenkins.Spec.ScriptLogs == nil || jenkins.Spec.ScriptLogs.Limit <= 0 {
+		return
+	}
+	if err := storeScriptLogs(k8sClient, jenkins, execution, time.Now().UTC()); err != nil {
+		log.Log.WithValues("cr", jenkins.Name).V(log.VWarn).Info(fmt.Sprintf("Couldn't store logs of groovy script: %s", err))
+	}
+}
+
+// GetScriptLogsConfigMapName returns name of the ConfigMap with logs of groovy scripts executed in Jenkins
+func GetScriptLogsConfigMapName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-script-logs-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+func storeScriptLogs(k8sClient k8s.Client, jenkins *v1alpha2.Jenkins, execution Execution, now time.Time) error {
+	key := scriptLogKey(execution, now)
+	content := scriptLogContent(execution, now)
+	limit := int(jenkins.Spec.ScriptLogs.Limit)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: GetScriptLogsConfigMapName(jenkins)}, configMap)
+		if apierrors.IsNotFound(err) {
+			return k8sClient.Create(context.TODO(), newScriptLogsConfigMap(jenkins, key, content))
+		} else if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[key] = content
+		dropOldestScriptLogs(configMap.Data, limit)
+		return k8sClient.Update(context.TODO(), configMap)
+	})
+}
+
+// scriptLogKey returns the ConfigMap key of the execution, keys of the executions are sorted by time
+func scriptLogKey(execution Execution, now time.Time) string {
+	name := invalidScriptLogKeyCharacters.ReplaceAllString(execution.Name, "_")
+	return fmt.Sprintf("%s.%s.%s.log", now.Format(scriptLogTimeFormat), execution.ConfigurationType, name)
+}
+
+func scriptLogContent(execution Execution, now time.Time) string {
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("# configurationType: %s\n", execution.ConfigurationType))
+	content.WriteString(fmt.Sprintf("# source: %s\n", execution.Source))
+	content.WriteString(fmt.Sprintf("# name: %s\n", execution.Name))
+	content.WriteString(fmt.Sprintf("# time: %s\n", now.Format(time.RFC3339)))
+	content.WriteString(fmt.Sprintf("# duration: %s\n", execution.Duration.Round(time.Millisecond)))
+	if execution.Err != nil {
+		content.WriteString(fmt.Sprintf("# result: %s\n", resultFailure))
+		content.WriteString(fmt.Sprintf("# error: %s\n", strings.ReplaceAll(execution.Err.Error(), "\n", " ")))
+	} else {
+		content.WriteString(fmt.Sprintf("# result: %s\n", resultSuccess))
+	}
+
+	logs := execution.Logs
+	if len(logs) > MaxScriptLogSize {
+		content.WriteString(fmt.Sprintf("# truncated: the first %d bytes have been dropped\n", len(logs)-MaxScriptLogSize))
+		logs = logs[len(logs)-MaxScriptLogSize:]
+	}
+	content.WriteString(logs)
+	return content.String()
+}
+
+// dropOldestScriptLogs keeps only the latest limit executions
+func dropOldestScriptLogs(data map[string]string, limit int) {
+	if len(data) <= limit {
+		return
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys[:len(keys)-limit] {
+		delete(data, key)
+	}
+}
+
+func newScriptLogsConfigMap(jenkins *v1alpha2.Jenkins, key, content string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetScriptLogsConfigMapName(jenkins),
+			Namespace: jenkins.Namespace,
+			Labels: map[string]string{
+				constants.LabelAppKey:       constants.LabelAppValue,
+				constants.LabelJenkinsCRKey: jenkins.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(jenkins, v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.Kind)),
+			},
+		},
+		Data: map[string]string{key: content},
+	}
+}
@@ -0,0 +1,75 @@
+package groovy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRecordExecution(t *testing.T) {
+	t.Run("metrics only", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "default"}}
+		fakeClient := fake.NewFakeClient()
+
+		RecordExecution(fakeClient, jenkins, Execution{ConfigurationType: "user-groovy", Source: "scripts", Name: "1.groovy", Logs: "output"})
+
+		assert.Equal(t, float64(6), testutil.ToFloat64(outputBytes.WithLabelValues("default", "metrics", "user-groovy", "scripts", "1.groovy")))
+		configMap := &corev1.ConfigMap{}
+		err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: GetScriptLogsConfigMapName(jenkins)}, configMap)
+		assert.Error(t, err, "logs shouldn't be stored without spec.scriptLogs")
+	})
+	t.Run("keeps the latest logs", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec:       v1alpha2.JenkinsSpec{ScriptLogs: &v1alpha2.ScriptLogs{Limit: 2}},
+		}
+		fakeClient := fake.NewFakeClient()
+		now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+		for i, name := range []string{"1.groovy", "2.groovy", "3 groovy"} {
+			execution := Execution{ConfigurationType: "user-groovy", Source: "scripts", Name: name, Duration: time.Second, Logs: "output"}
+			if i == 2 {
+				execution.Err = errors.New("script failed")
+			}
+			require.NoError(t, storeScriptLogs(fakeClient, jenkins, execution, now.Add(time.Duration(i)*time.Minute)))
+		}
+
+		configMap := &corev1.ConfigMap{}
+		err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "jenkins-operator-script-logs-jenkins"}, configMap)
+		require.NoError(t, err)
+		require.Len(t, configMap.Data, 2)
+		assert.Contains(t, configMap.Data, "20200601T120100.000Z.user-groovy.2.groovy.log")
+		assert.Equal(t, `# configurationType: user-groovy
+# source: scripts
+# name: 3 groovy
+# time: 2020-06-01T12:02:00Z
+# duration: 1s
+# result: failure
+# error: script failed
+output`, configMap.Data["20200601T120200.000Z.user-groovy.3_groovy.log"])
+		require.Len(t, configMap.OwnerReferences, 1)
+		assert.Equal(t, "jenkins", configMap.OwnerReferences[0].Name)
+	})
+}
+
+func TestScriptLogContent_Truncated(t *testing.T) {
+	logs := strings.Repeat("a", MaxScriptLogSize) + "end"
+
+	content := scriptLogContent(Execution{Logs: logs}, time.Now())
+
+	assert.Contains(t, content, "# truncated: the first 3 bytes have been dropped\n")
+	assert.True(t, strings.HasSuffix(content, "end"))
+	assert.Less(t, len(content), MaxScriptLogSize+512)
+}
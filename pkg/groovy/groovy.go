@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/audit"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 
@@ -27,6 +29,7 @@ type Groovy struct {
 	jenkinsClient     jenkinsclient.Jenkins
 	configurationType string
 	customization     v1alpha2.Customization
+	resolvers         []func(content string) (string, error)
 }
 
 // New creates new instance of Groovy
@@ -41,13 +44,32 @@ func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, jenkins *v1a
 	}
 }
 
+// WithPlaceholderResolver adds function which resolves placeholders in ConfigMap content before it's applied
+func (g *Groovy) WithPlaceholderResolver(resolve func(content string) (string, error)) *Groovy {
+	g.resolvers = append(g.resolvers, resolve)
+	return g
+}
+
 // EnsureSingle runs single groovy script
 func (g *Groovy) EnsureSingle(source, name, hash, groovyScript string) (requeue bool, err error) {
+	return g.ensureSingle(source, name, hash, groovyScript, g.customization.Execution)
+}
+
+func (g *Groovy) ensureSingle(source, name, hash, groovyScript string, execution *v1alpha2.ScriptExecution) (requeue bool, err error) {
 	if g.isGroovyScriptAlreadyApplied(source, name, hash) {
 		return false, nil
 	}
 
-	logs, err := g.jenkinsClient.ExecuteScript(groovyScript)
+	start := time.Now()
+	logs, err := g.executeScript(groovyScript, execution)
+	RecordExecution(g.k8sClient, g.jenkins, Execution{
+		ConfigurationType: g.configurationType,
+		Source:            source,
+		Name:              name,
+		Duration:          time.Since(start),
+		Logs:              logs,
+		Err:               err,
+	})
 	if err != nil {
 		if groovyErr, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed); ok {
 			groovyErr.ConfigurationType = g.configurationType
@@ -58,6 +80,8 @@ func (g *Groovy) EnsureSingle(source, name, hash, groovyScript string) (requeue
 		}
 		return true, err
 	}
+	audit.Add(g.k8sClient, g.jenkins, audit.ActionGroovyScriptExecuted,
+		fmt.Sprintf("%s Source '%s' Name '%s' groovy script has been executed", g.configurationType, source, name), hash)
 
 	var appliedGroovyScripts []v1alpha2.AppliedGroovyScript
 
@@ -77,7 +101,25 @@ func (g *Groovy) EnsureSingle(source, name, hash, groovyScript string) (requeue
 
 	g.jenkins.Status.AppliedGroovyScripts = appliedGroovyScripts
 
-	return true, g.k8sClient.Update(context.TODO(), g.jenkins)
+	return true, g.k8sClient.Status().Update(context.TODO(), g.jenkins)
+}
+
+func (g *Groovy) executeScript(groovyScript string, execution *v1alpha2.ScriptExecution) (string, error) {
+	if execution == nil {
+		return g.jenkinsClient.ExecuteScript(groovyScript)
+	}
+
+	options := jenkinsclient.ScriptExecutionOptions{
+		Retries:       int(execution.Retries),
+		RetryInterval: defaultRetryInterval,
+	}
+	if execution.Timeout != nil {
+		options.Timeout = execution.Timeout.Duration
+	}
+	if execution.RetryInterval != nil {
+		options.RetryInterval = execution.RetryInterval.Duration
+	}
+	return g.jenkinsClient.ExecuteScriptWithOptions(groovyScript, options)
 }
 
 // WaitForSecretSynchronization runs groovy script which waits to synchronize secrets in pod by k8s
@@ -98,7 +140,7 @@ func (g *Groovy) WaitForSecretSynchronization(secretsPath string) (requeue bool,
 	}
 	hash := g.calculateHash(toCalculate)
 
-	name := "synchronizing-secret.groovy"
+	name := synchronizeSecretsGroovyScriptName
 	if g.isGroovyScriptAlreadyApplied(g.customization.Secret.Name, name, hash) {
 		return false, nil
 	}
@@ -109,19 +151,91 @@ func (g *Groovy) WaitForSecretSynchronization(secretsPath string) (requeue bool,
 
 // Ensure runs all groovy scripts configured in customization structure
 func (g *Groovy) Ensure(filter func(name string) bool, updateGroovyScript func(groovyScript string) string) (requeue bool, err error) {
+	scripts, configured, err := g.customizationScripts(filter, updateGroovyScript)
+	if err != nil {
+		return true, err
+	}
+
+	for _, script := range scripts {
+		if g.isGroovyScriptAlreadyApplied(script.source, script.name, script.hash) {
+			continue
+		}
+
+		g.logger.Info(fmt.Sprintf("%s ConfigMap '%s' name '%s' running groovy script", g.configurationType, script.source, script.name))
+		requeue, err := g.ensureSingle(script.source, script.name, script.hash, script.groovyScript, script.execution)
+		if err != nil || requeue {
+			return requeue, err
+		}
+	}
+
+	return false, g.removeStaleAppliedGroovyScripts(configured)
+}
+
+// Changes returns messages about already applied groovy scripts configured in customization structure
+// which content has changed since they were applied
+func (g *Groovy) Changes(filter func(name string) bool, updateGroovyScript func(groovyScript string) string) ([]string, error) {
+	scripts, _, err := g.customizationScripts(filter, updateGroovyScript)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+	for _, script := range scripts {
+		if g.isGroovyScriptAlreadyApplied(script.source, script.name, script.hash) {
+			continue
+		}
+		for _, ags := range g.jenkins.Status.AppliedGroovyScripts {
+			if ags.ConfigurationType == g.configurationType && ags.Source == script.source && ags.Name == script.name {
+				messages = append(messages, fmt.Sprintf("%s ConfigMap '%s' name '%s' has changed", g.configurationType, script.source, script.name))
+				break
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+// customizationScript is a groovy script from the customization ConfigMap
+type customizationScript struct {
+	source       string
+	name         string
+	hash         string
+	groovyScript string
+	execution    *v1alpha2.ScriptExecution
+}
+
+// customizationScripts returns groovy scripts in the order they should be applied and names of all configured scripts
+// grouped by ConfigMap name
+func (g *Groovy) customizationScripts(filter func(name string) bool, updateGroovyScript func(groovyScript string) string) ([]customizationScript, map[string]map[string]bool, error) {
 	secret := &corev1.Secret{}
 	if len(g.customization.Secret.Name) > 0 {
 		err := g.k8sClient.Get(context.TODO(), types.NamespacedName{Name: g.customization.Secret.Name, Namespace: g.jenkins.ObjectMeta.Namespace}, secret)
 		if err != nil {
-			return true, err
+			return nil, nil, err
+		}
+	}
+
+	configurations, err := SortConfigurations(g.customization.Configurations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var values map[string]string
+	if g.customization.Template != nil {
+		if values, err = g.templateValues(); err != nil {
+			return nil, nil, err
 		}
 	}
 
-	for _, configMapRef := range g.customization.Configurations {
+	var scripts []customizationScript
+	configured := map[string]map[string]bool{
+		g.customization.Secret.Name: {synchronizeSecretsGroovyScriptName: true},
+	}
+	for _, configMapRef := range configurations {
 		configMap := &corev1.ConfigMap{}
 		err := g.k8sClient.Get(context.TODO(), types.NamespacedName{Name: configMapRef.Name, Namespace: g.jenkins.ObjectMeta.Namespace}, configMap)
 		if err != nil {
-			return true, errors.WithStack(err)
+			return nil, nil, errors.WithStack(err)
 		}
 
 		var names []string
@@ -130,27 +244,110 @@ func (g *Groovy) Ensure(filter func(name string) bool, updateGroovyScript func(g
 		}
 		sort.Strings(names)
 
+		execution := configMapRef.Execution
+		if execution == nil {
+			execution = g.customization.Execution
+		}
+		configured[configMap.Name] = map[string]bool{}
 		for _, name := range names {
-			groovyScript := updateGroovyScript(configMap.Data[name])
 			if !filter(name) {
 				g.logger.V(log.VDebug).Info(fmt.Sprintf("Skipping %s ConfigMap '%s' name '%s'", g.configurationType, configMap.Name, name))
 				continue
 			}
+			configured[configMap.Name][name] = true
 
-			hash := g.calculateCustomizationHash(*secret, name, groovyScript)
-			if g.isGroovyScriptAlreadyApplied(configMap.Name, name, hash) {
-				continue
+			content := configMap.Data[name]
+			if g.customization.Template != nil {
+				if content, err = renderTemplate(name, content, values, g.jenkins); err != nil {
+					return nil, nil, errors.Wrapf(err, "couldn't render template %s ConfigMap '%s' name '%s'", g.configurationType, configMap.Name, name)
+				}
+			}
+			for _, resolve := range g.resolvers {
+				if content, err = resolve(content); err != nil {
+					return nil, nil, errors.Wrapf(err, "couldn't resolve placeholders in %s ConfigMap '%s' name '%s'", g.configurationType, configMap.Name, name)
+				}
 			}
+			groovyScript := updateGroovyScript(content)
+
+			scripts = append(scripts, customizationScript{
+				source:       configMap.Name,
+				name:         name,
+				hash:         g.calculateCustomizationHash(*secret, name, groovyScript),
+				groovyScript: groovyScript,
+				execution:    execution,
+			})
+		}
+	}
+
+	return scripts, configured, nil
+}
 
-			g.logger.Info(fmt.Sprintf("%s ConfigMap '%s' name '%s' running groovy script", g.configurationType, configMap.Name, name))
-			requeue, err := g.EnsureSingle(configMap.Name, name, hash, groovyScript)
-			if err != nil || requeue {
-				return requeue, err
+// removeStaleAppliedGroovyScripts removes hashes of groovy scripts which are no longer configured from the status,
+// so status reflects only the current configuration and the script is applied again when it's configured back
+func (g *Groovy) removeStaleAppliedGroovyScripts(configured map[string]map[string]bool) error {
+	var appliedGroovyScripts []v1alpha2.AppliedGroovyScript
+	for _, ags := range g.jenkins.Status.AppliedGroovyScripts {
+		if ags.ConfigurationType == g.configurationType && !configured[ags.Source][ags.Name] {
+			g.logger.V(log.VDebug).Info(fmt.Sprintf("%s Source '%s' Name '%s' is no longer configured", g.configurationType, ags.Source, ags.Name))
+			continue
+		}
+		appliedGroovyScripts = append(appliedGroovyScripts, ags)
+	}
+
+	if len(appliedGroovyScripts) == len(g.jenkins.Status.AppliedGroovyScripts) {
+		return nil
+	}
+
+	g.jenkins.Status.AppliedGroovyScripts = appliedGroovyScripts
+	return g.k8sClient.Status().Update(context.TODO(), g.jenkins)
+}
+
+// SortConfigurations returns configurations in the order they should be applied, a ConfigMap is applied
+// after all ConfigMaps it depends on, otherwise ConfigMaps are ordered by priority and declaration order
+func SortConfigurations(configurations []v1alpha2.ConfigMapRef) ([]v1alpha2.ConfigMapRef, error) {
+	declared := map[string]bool{}
+	for _, configMapRef := range configurations {
+		declared[configMapRef.Name] = true
+	}
+	for _, configMapRef := range configurations {
+		for _, dependency := range configMapRef.DependsOn {
+			if !declared[dependency] {
+				return nil, errors.Errorf("ConfigMap '%s' depends on ConfigMap '%s' which is not configured", configMapRef.Name, dependency)
 			}
 		}
 	}
 
-	return false, nil
+	var sorted []v1alpha2.ConfigMapRef
+	applied := map[string]bool{}
+	remaining := append([]v1alpha2.ConfigMapRef{}, configurations...)
+	for len(remaining) > 0 {
+		next := -1
+		for index, configMapRef := range remaining {
+			ready := true
+			for _, dependency := range configMapRef.DependsOn {
+				if !applied[dependency] {
+					ready = false
+					break
+				}
+			}
+			if ready && (next == -1 || configMapRef.Priority < remaining[next].Priority) {
+				next = index
+			}
+		}
+		if next == -1 {
+			var names []string
+			for _, configMapRef := range remaining {
+				names = append(names, configMapRef.Name)
+			}
+			return nil, errors.Errorf("circular dependency between ConfigMaps: %s", strings.Join(names, ", "))
+		}
+
+		sorted = append(sorted, remaining[next])
+		applied[remaining[next].Name] = true
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+
+	return sorted, nil
 }
 
 func (g *Groovy) calculateCustomizationHash(secret corev1.Secret, key, groovyScript string) string {
@@ -214,6 +411,10 @@ const secretsLoaderGroovyScriptFmt = `def secretsPath = '%s'
 def secrets = [:]
 "ls ${secretsPath}".execute().text.eachLine {secrets[it] = new File("${secretsPath}/${it}").text}`
 
+const defaultRetryInterval = 5 * time.Second
+
+const synchronizeSecretsGroovyScriptName = "synchronizing-secret.groovy"
+
 const synchronizeSecretsGroovyScriptFmt = `
 def secretsPath = '%s'
 def expectedHash = '%s'
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
@@ -609,6 +610,202 @@ func TestGroovy_Ensure(t *testing.T) {
 		assert.Equal(t, configMapName, jenkins.Status.AppliedGroovyScripts[0].Source)
 		assert.Equal(t, groovyScriptName, jenkins.Status.AppliedGroovyScripts[0].Name)
 	})
+	t.Run("execute script with timeout and retries", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      jenkinsName,
+				Namespace: namespace,
+			},
+		}
+		customization := v1alpha2.Customization{
+			Configurations: []v1alpha2.ConfigMapRef{
+				{
+					Name: configMapName,
+					Execution: &v1alpha2.ScriptExecution{
+						Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+						Retries: 3,
+					},
+				},
+			},
+			Execution: &v1alpha2.ScriptExecution{Retries: 1},
+		}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMapName,
+				Namespace: namespace,
+			},
+			Data: map[string]string{
+				groovyScriptName: groovyScript,
+			},
+		}
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		require.NoError(t, err)
+		fakeClient := fake.NewFakeClient()
+		err = fakeClient.Create(ctx, jenkins)
+		require.NoError(t, err)
+		err = fakeClient.Create(ctx, configMap)
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScriptWithOptions(groovyScript, jenkinsclient.ScriptExecutionOptions{
+			Timeout:       10 * time.Minute,
+			Retries:       3,
+			RetryInterval: 5 * time.Second,
+		}).Return("logs", nil)
+
+		groovyClient := New(jenkinsClient, fakeClient, jenkins, configurationType, customization)
+
+		// when
+		requeue, err := groovyClient.Ensure(allGroovyScriptsFunc, noUpdateGroovyScript)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, requeue)
+	})
+	t.Run("remove no longer configured scripts from status", func(t *testing.T) {
+		// given
+		appliedGroovyScript := v1alpha2.AppliedGroovyScript{
+			ConfigurationType: configurationType,
+			Source:            configMapName,
+			Name:              groovyScriptName,
+			Hash:              "qoXeeh4ia+KXhT01lYNxe+oxByDf8dfT2npP9fgzjbk=",
+		}
+		otherConfigurationType := v1alpha2.AppliedGroovyScript{
+			ConfigurationType: "other-type",
+			Source:            "other-config-map",
+			Name:              groovyScriptName,
+			Hash:              "hash",
+		}
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      jenkinsName,
+				Namespace: namespace,
+			},
+			Status: v1alpha2.JenkinsStatus{
+				AppliedGroovyScripts: []v1alpha2.AppliedGroovyScript{
+					appliedGroovyScript,
+					{ConfigurationType: configurationType, Source: configMapName, Name: "removed.groovy", Hash: "hash"},
+					{ConfigurationType: configurationType, Source: "removed-config-map", Name: groovyScriptName, Hash: "hash"},
+					otherConfigurationType,
+				},
+			},
+		}
+		customization := v1alpha2.Customization{
+			Configurations: []v1alpha2.ConfigMapRef{
+				{
+					Name: configMapName,
+				},
+			},
+		}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMapName,
+				Namespace: namespace,
+			},
+			Data: map[string]string{
+				groovyScriptName: groovyScript,
+			},
+		}
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		require.NoError(t, err)
+		fakeClient := fake.NewFakeClient()
+		err = fakeClient.Create(ctx, jenkins)
+		require.NoError(t, err)
+		err = fakeClient.Create(ctx, configMap)
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+
+		groovyClient := New(jenkinsClient, fakeClient, jenkins, configurationType, customization)
+
+		// when
+		requeue, err := groovyClient.Ensure(allGroovyScriptsFunc, noUpdateGroovyScript)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, requeue)
+		err = fakeClient.Get(ctx, types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, jenkins)
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha2.AppliedGroovyScript{appliedGroovyScript, otherConfigurationType}, jenkins.Status.AppliedGroovyScripts)
+	})
+}
+
+func TestGroovy_Changes(t *testing.T) {
+	configMapName := "config-map-name"
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Status: v1alpha2.JenkinsStatus{
+			AppliedGroovyScripts: []v1alpha2.AppliedGroovyScript{
+				{ConfigurationType: configurationType, Source: configMapName, Name: "changed.yaml", Hash: "old-hash"},
+				{ConfigurationType: configurationType, Source: configMapName, Name: "unchanged.yaml"},
+			},
+		},
+	}
+	customization := v1alpha2.Customization{Configurations: []v1alpha2.ConfigMapRef{{Name: configMapName}}}
+	fakeClient := fake.NewFakeClient(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: configMapName, Namespace: "default"},
+		Data: map[string]string{
+			"changed.yaml":   "changed",
+			"unchanged.yaml": "unchanged",
+			"new.yaml":       "new",
+		},
+	})
+	groovyClient := New(nil, fakeClient, jenkins, configurationType, customization)
+	jenkins.Status.AppliedGroovyScripts[1].Hash = groovyClient.calculateCustomizationHash(corev1.Secret{}, "unchanged.yaml", "unchanged")
+
+	changes, err := groovyClient.Changes(func(name string) bool { return true }, func(groovyScript string) string { return groovyScript })
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{configurationType + " ConfigMap 'config-map-name' name 'changed.yaml' has changed"}, changes)
+}
+
+func TestSortConfigurations(t *testing.T) {
+	names := func(configurations []v1alpha2.ConfigMapRef) []string {
+		var result []string
+		for _, configMapRef := range configurations {
+			result = append(result, configMapRef.Name)
+		}
+		return result
+	}
+
+	t.Run("declaration order", func(t *testing.T) {
+		got, err := SortConfigurations([]v1alpha2.ConfigMapRef{{Name: "b"}, {Name: "a"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b", "a"}, names(got))
+	})
+	t.Run("priority", func(t *testing.T) {
+		got, err := SortConfigurations([]v1alpha2.ConfigMapRef{{Name: "a", Priority: 10}, {Name: "b"}, {Name: "c", Priority: -1}, {Name: "d"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c", "b", "d", "a"}, names(got))
+	})
+	t.Run("dependencies", func(t *testing.T) {
+		got, err := SortConfigurations([]v1alpha2.ConfigMapRef{
+			{Name: "credentials", DependsOn: []string{"plugins", "security"}},
+			{Name: "security", Priority: 5},
+			{Name: "jobs", Priority: -10, DependsOn: []string{"credentials"}},
+			{Name: "plugins", Priority: 10},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"security", "plugins", "credentials", "jobs"}, names(got))
+	})
+	t.Run("unknown dependency", func(t *testing.T) {
+		_, err := SortConfigurations([]v1alpha2.ConfigMapRef{{Name: "a", DependsOn: []string{"b"}}})
+
+		assert.EqualError(t, err, "ConfigMap 'a' depends on ConfigMap 'b' which is not configured")
+	})
+	t.Run("circular dependency", func(t *testing.T) {
+		_, err := SortConfigurations([]v1alpha2.ConfigMapRef{{Name: "a"}, {Name: "b", DependsOn: []string{"b"}}})
+
+		assert.EqualError(t, err, "circular dependency between ConfigMaps: b")
+	})
 }
 
 func TestGroovy_isGroovyScriptAlreadyApplied(t *testing.T) {
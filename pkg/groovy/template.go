@@ -0,0 +1,124 @@
+package groovy
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// templateData is available in ConfigMaps rendered as Go templates
+type templateData struct {
+	Values  map[string]string
+	Jenkins templateJenkins
+}
+
+type templateJenkins struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// templateFuncs is a subset of Sprig functions commonly used in Helm charts
+var templateFuncs = template.FuncMap{
+	"default": func(defaultValue, value interface{}) interface{} {
+		if value == nil || value == "" {
+			return defaultValue
+		}
+		return value
+	},
+	"required": func(message string, value interface{}) (interface{}, error) {
+		if value == nil || value == "" {
+			return nil, errors.New(message)
+		}
+		return value, nil
+	},
+	"quote":   func(value interface{}) string { return fmt.Sprintf("%q", fmt.Sprint(value)) },
+	"squote":  func(value interface{}) string { return "'" + fmt.Sprint(value) + "'" },
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"trim":    strings.TrimSpace,
+	"replace": func(old, new, value string) string { return strings.Replace(value, old, new, -1) },
+	"indent":  indent,
+	"nindent": func(spaces int, value string) string { return "\n" + indent(spaces, value) },
+	"b64enc":  func(value string) string { return base64.StdEncoding.EncodeToString([]byte(value)) },
+	"b64dec": func(value string) (string, error) {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		return string(decoded), errors.WithStack(err)
+	},
+	"toJson": func(value interface{}) (string, error) {
+		encoded, err := json.Marshal(value)
+		return string(encoded), errors.WithStack(err)
+	},
+}
+
+func indent(spaces int, value string) string {
+	padding := strings.Repeat(" ", spaces)
+	return padding + strings.Replace(value, "\n", "\n"+padding, -1)
+}
+
+// templateValues reads values from Secrets and ConfigMaps configured in the customization template
+func (g *Groovy) templateValues() (map[string]string, error) {
+	values := map[string]string{}
+	for _, source := range g.customization.Template.ValuesFrom {
+		if len(source.SecretName) > 0 {
+			secret := &corev1.Secret{}
+			err := g.k8sClient.Get(context.TODO(), types.NamespacedName{Name: source.SecretName, Namespace: g.jenkins.Namespace}, secret)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			for key, value := range secret.Data {
+				values[key] = string(value)
+			}
+		}
+		if len(source.ConfigMapName) > 0 {
+			configMap := &corev1.ConfigMap{}
+			err := g.k8sClient.Get(context.TODO(), types.NamespacedName{Name: source.ConfigMapName, Namespace: g.jenkins.Namespace}, configMap)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			for key, value := range configMap.Data {
+				values[key] = value
+			}
+		}
+	}
+	for key, value := range g.customization.Template.Values {
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// renderTemplate renders ConfigMap content as Go template, missing values are reported as errors
+func renderTemplate(name, content string, values map[string]string, jenkins *v1alpha2.Jenkins) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(templateFuncs).Parse(content)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	data := templateData{
+		Values: values,
+		Jenkins: templateJenkins{
+			Name:        jenkins.Name,
+			Namespace:   jenkins.Namespace,
+			Labels:      jenkins.Labels,
+			Annotations: jenkins.Annotations,
+		},
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return buffer.String(), nil
+}
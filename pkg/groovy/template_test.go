@@ -0,0 +1,88 @@
+package groovy
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "default",
+			Labels:    map[string]string{"team": "platform"},
+		},
+	}
+
+	t.Run("values and Jenkins metadata", func(t *testing.T) {
+		content := "url: {{ .Values.url | quote }}\nname: {{ .Jenkins.Name | upper }}\nteam: {{ index .Jenkins.Labels \"team\" }}\nmode: {{ default \"NORMAL\" .Values.mode }}"
+		values := map[string]string{"url": "https://jenkins.example.com", "mode": ""}
+
+		got, err := renderTemplate("jenkins.yaml", content, values, jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, "url: \"https://jenkins.example.com\"\nname: JENKINS\nteam: platform\nmode: NORMAL", got)
+	})
+	t.Run("indent", func(t *testing.T) {
+		got, err := renderTemplate("jenkins.yaml", "message:{{ .Values.message | nindent 2 }}", map[string]string{"message": "line1\nline2"}, jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, "message:\n  line1\n  line2", got)
+	})
+	t.Run("missing value", func(t *testing.T) {
+		_, err := renderTemplate("jenkins.yaml", "url: {{ .Values.url }}", map[string]string{}, jenkins)
+
+		assert.Error(t, err)
+	})
+	t.Run("required value", func(t *testing.T) {
+		_, err := renderTemplate("jenkins.yaml", "url: {{ required \"url is required\" .Values.url }}", map[string]string{"url": ""}, jenkins)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "url is required")
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := renderTemplate("jenkins.yaml", "url: {{ .Values.url ", map[string]string{}, jenkins)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGroovy_templateValues(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+	customization := v1alpha2.Customization{
+		Template: &v1alpha2.CustomizationTemplate{
+			ValuesFrom: []v1alpha2.TemplateValuesSource{
+				{ConfigMapName: "values"},
+				{SecretName: "secret-values"},
+			},
+			Values: map[string]string{"inline": "inline"},
+		},
+	}
+	fakeClient := fake.NewFakeClient(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "values", Namespace: "default"},
+			Data:       map[string]string{"url": "https://jenkins.example.com", "password": "overridden", "inline": "overridden"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret-values", Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		},
+	)
+	groovyClient := New(nil, fakeClient, jenkins, configurationType, customization)
+
+	values, err := groovyClient.templateValues()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"url":      "https://jenkins.example.com",
+		"password": "secret",
+		"inline":   "inline",
+	}, values)
+}
@@ -0,0 +1,137 @@
+// Package health serves liveness and readiness probes of the operator and the health of managed Jenkins CRs as
+// perceived by the operator.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// LivenessPath is the path of the liveness probe
+	LivenessPath = "/healthz"
+	// ReadinessPath is the path of the readiness probe
+	ReadinessPath = "/readyz"
+	// InstancesPath is the path of the health of Jenkins CRs reconciled by the operator
+	InstancesPath = "/healthz/instances"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var logger = log.Log.WithName("health")
+
+// Instance is the health of the Jenkins CR as perceived by the operator
+type Instance struct {
+	Namespace         string                `json:"namespace"`
+	Name              string                `json:"name"`
+	Phase             v1alpha2.JenkinsPhase `json:"phase,omitempty"`
+	LastError         string                `json:"lastError,omitempty"`
+	LastReconcileTime metav1.Time           `json:"lastReconcileTime"`
+}
+
+// InstancesStatus is the response of the InstancesPath endpoint
+type InstancesStatus struct {
+	Instances []Instance `json:"instances"`
+}
+
+// Instances is thread-safe store of the health of Jenkins CRs updated after every reconcile loop
+type Instances struct {
+	mutex     sync.RWMutex
+	instances map[types.NamespacedName]Instance
+}
+
+// NewInstances returns empty store of the health of Jenkins CRs
+func NewInstances() *Instances {
+	return &Instances{instances: map[types.NamespacedName]Instance{}}
+}
+
+// Set records the result of the reconcile loop of the Jenkins CR, the error is nil when it succeeded
+func (i *Instances) Set(jenkins *v1alpha2.Jenkins, err error) {
+	instance := Instance{
+		Namespace:         jenkins.Namespace,
+		Name:              jenkins.Name,
+		Phase:             jenkins.Status.Phase,
+		LastReconcileTime: metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
+	}
+	if err != nil {
+		instance.LastError = err.Error()
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.instances[types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name}] = instance
+}
+
+// Delete forgets the Jenkins CR which has been deleted or isn't reconciled by the operator
+func (i *Instances) Delete(name types.NamespacedName) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	delete(i.instances, name)
+}
+
+// List returns the health of Jenkins CRs sorted by namespace and name
+func (i *Instances) List() []Instance {
+	i.mutex.RLock()
+	instances := make([]Instance, 0, len(i.instances))
+	for _, instance := range i.instances {
+		instances = append(instances, instance)
+	}
+	i.mutex.RUnlock()
+
+	sort.Slice(instances, func(a, b int) bool {
+		if instances[a].Namespace != instances[b].Namespace {
+			return instances[a].Namespace < instances[b].Namespace
+		}
+		return instances[a].Name < instances[b].Name
+	})
+	return instances
+}
+
+// ServeHTTP writes the health of Jenkins CRs as JSON
+func (i *Instances) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(InstancesStatus{Instances: i.List()}); err != nil {
+		logger.V(log.VWarn).Info("Couldn't write health of Jenkins CRs", "error", err.Error())
+	}
+}
+
+// NewHandler returns the handler of the liveness and readiness probes and of the health of Jenkins CRs
+func NewHandler(instances *Instances) http.Handler {
+	mux := http.NewServeMux()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.Handle(LivenessPath, ok)
+	mux.Handle(ReadinessPath, ok)
+	mux.Handle(InstancesPath, instances)
+	return mux
+}
+
+// Serve serves the probes on the address until ctx is done, it's started before the leader election so replicas
+// waiting for the leadership respond to probes and list no Jenkins CRs
+func Serve(ctx context.Context, address string, instances *Instances) error {
+	server := &http.Server{Addr: address, Handler: NewHandler(instances)}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("Serving health probes", "address", address)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return errors.Wrapf(err, "couldn't serve health probes on '%s'", address)
+	}
+	return nil
+}
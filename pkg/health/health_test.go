@@ -0,0 +1,54 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newJenkins(namespace, name string, phase v1alpha2.JenkinsPhase) *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     v1alpha2.JenkinsStatus{Phase: phase},
+	}
+}
+
+func TestInstances(t *testing.T) {
+	instances := NewInstances()
+	instances.Set(newJenkins("team-b", "jenkins", v1alpha2.JenkinsPhaseReady), nil)
+	instances.Set(newJenkins("team-a", "jenkins", v1alpha2.JenkinsPhaseDegraded), errors.New("Jenkins API is unavailable"))
+	instances.Set(newJenkins("team-a", "deleted", v1alpha2.JenkinsPhaseReady), nil)
+	instances.Delete(types.NamespacedName{Namespace: "team-a", Name: "deleted"})
+
+	handler := NewHandler(instances)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, InstancesPath, nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var status InstancesStatus
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	require.Len(t, status.Instances, 2)
+	assert.Equal(t, "team-a", status.Instances[0].Namespace)
+	assert.Equal(t, v1alpha2.JenkinsPhaseDegraded, status.Instances[0].Phase)
+	assert.Equal(t, "Jenkins API is unavailable", status.Instances[0].LastError)
+	assert.Equal(t, "team-b", status.Instances[1].Namespace)
+	assert.Empty(t, status.Instances[1].LastError)
+	assert.False(t, status.Instances[1].LastReconcileTime.IsZero())
+
+	for _, path := range []string{LivenessPath, ReadinessPath} {
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "ok", recorder.Body.String())
+	}
+}
@@ -0,0 +1,145 @@
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// DefaultConfig is the default configuration of the leader election.
+var DefaultConfig = Config{
+	Enabled:       true,
+	LeaseDuration: 15 * time.Second,
+	RenewDeadline: 10 * time.Second,
+	RetryPeriod:   2 * time.Second,
+}
+
+var logger = log.Log.WithName("leader-election")
+
+// Config is the configuration of the leader election.
+type Config struct {
+	// Enabled determines whether or not to use leader election, only one replica of the operator
+	// may run when it's disabled
+	Enabled bool
+	// LockName is the name of the Lease object used as the lock
+	LockName string
+	// Namespace is the namespace of the Lease object, the namespace of the operator is used when it's empty
+	Namespace string
+	// LeaseDuration is the duration that non-leader candidates wait to force acquire leadership
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration that the leader retries refreshing leadership before giving up
+	RenewDeadline time.Duration
+	// RetryPeriod is the duration between tries of acquiring and renewing leadership
+	RetryPeriod time.Duration
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.LockName) == 0 {
+		return errors.New("leader election lock name is empty")
+	}
+	if c.RetryPeriod <= 0 {
+		return errors.New("leader election retry period must be greater than zero")
+	}
+	if c.RenewDeadline <= time.Duration(leaderelection.JitterFactor*float64(c.RetryPeriod)) {
+		return errors.Errorf("leader election renew deadline must be greater than %.1f * retry period", leaderelection.JitterFactor)
+	}
+	if c.LeaseDuration <= c.RenewDeadline {
+		return errors.New("leader election lease duration must be greater than renew deadline")
+	}
+
+	return nil
+}
+
+// Run runs the function when the operator becomes the leader, the context of the function is cancelled
+// when the leadership is lost. It returns an error if the leadership is lost before ctx is done.
+func Run(ctx context.Context, cfg *rest.Config, config Config, run func(ctx context.Context)) error {
+	if !config.Enabled {
+		logger.Info("Leader election is disabled")
+		run(ctx)
+		return nil
+	}
+
+	lock, err := newLeaseLock(cfg, config)
+	if err != nil {
+		return err
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		Name:            config.LockName,
+		LeaseDuration:   config.LeaseDuration,
+		RenewDeadline:   config.RenewDeadline,
+		RetryPeriod:     config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				logger.Info(fmt.Sprintf("Became the leader '%s'", lock.Identity()))
+				run(ctx)
+			},
+			OnStoppedLeading: func() {
+				logger.Info(fmt.Sprintf("Stopped leading '%s'", lock.Identity()))
+			},
+			OnNewLeader: func(identity string) {
+				if identity != lock.Identity() {
+					logger.Info(fmt.Sprintf("Current leader is '%s'", identity))
+				}
+			},
+		},
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	logger.Info(fmt.Sprintf("Trying to become the leader of Lease '%s/%s'", lock.LeaseMeta.Namespace, lock.LeaseMeta.Name))
+	elector.Run(ctx)
+	if ctx.Err() == nil {
+		return errors.New("leader election lost")
+	}
+
+	return nil
+}
+
+func newLeaseLock(cfg *rest.Config, config Config) (*resourcelock.LeaseLock, error) {
+	namespace := config.Namespace
+	if len(namespace) == 0 {
+		var err error
+		namespace, err = k8sutil.GetOperatorNamespace()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the namespace of the leader election lock")
+		}
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	clientSet, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{Namespace: namespace, Name: config.LockName},
+		Client:    clientSet.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: hostname + "_" + string(uuid.NewUUID()),
+		},
+	}, nil
+}
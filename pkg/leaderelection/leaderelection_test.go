@@ -0,0 +1,51 @@
+package leaderelection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		config := DefaultConfig
+		config.LockName = "jenkins-operator-lock"
+
+		assert.NoError(t, config.Validate())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, Config{}.Validate())
+	})
+	t.Run("empty lock name", func(t *testing.T) {
+		assert.Error(t, DefaultConfig.Validate())
+	})
+	t.Run("renew deadline lower than retry period", func(t *testing.T) {
+		config := DefaultConfig
+		config.LockName = "jenkins-operator-lock"
+		config.RenewDeadline = config.RetryPeriod
+
+		assert.Error(t, config.Validate())
+	})
+	t.Run("lease duration lower than renew deadline", func(t *testing.T) {
+		config := DefaultConfig
+		config.LockName = "jenkins-operator-lock"
+		config.LeaseDuration = 5 * time.Second
+
+		assert.Error(t, config.Validate())
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		called := false
+
+		err := Run(context.TODO(), nil, Config{}, func(ctx context.Context) {
+			called = true
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// MaxCRLogLines is the number of the latest log entries of every Jenkins CR kept in memory for support bundles
+const MaxCRLogLines = 500
+
+var crLogs = struct {
+	sync.Mutex
+	lines map[string][]string
+}{lines: map[string][]string{}}
+
+// CRLogs returns the latest log entries with the "cr" key of the Jenkins CR, the oldest first
+func CRLogs(name string) []string {
+	crLogs.Lock()
+	defer crLogs.Unlock()
+	return append([]string(nil), crLogs.lines[name]...)
+}
+
+// DeleteCRLogs forgets log entries of the deleted Jenkins CR
+func DeleteCRLogs(name string) {
+	crLogs.Lock()
+	defer crLogs.Unlock()
+	delete(crLogs.lines, name)
+}
+
+func addCRLog(name string, entry zapcore.Entry, fields []zapcore.Field) {
+	line := formatCRLog(entry, fields)
+
+	crLogs.Lock()
+	defer crLogs.Unlock()
+	lines := append(crLogs.lines[name], line)
+	if len(lines) > MaxCRLogLines {
+		lines = lines[len(lines)-MaxCRLogLines:]
+	}
+	crLogs.lines[name] = lines
+}
+
+func formatCRLog(entry zapcore.Entry, fields []zapcore.Field) string {
+	line := fmt.Sprintf("%s\t%s\t%s\t%s", entry.Time.UTC().Format(time.RFC3339), entry.Level.CapitalString(), entry.LoggerName, entry.Message)
+	if len(fields) == 0 {
+		return line
+	}
+
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+	keys := make([]string, 0, len(encoder.Fields))
+	for key := range encoder.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, fmt.Sprintf("%s=%v", key, encoder.Fields[key]))
+	}
+	return line + "\t" + strings.Join(values, " ")
+}
@@ -0,0 +1,94 @@
+package log
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// LevelDebug enables debug logs of the Jenkins CR
+	LevelDebug = "debug"
+	// LevelInfo is the default log level of the Jenkins CR
+	LevelInfo = "info"
+
+	// crKey is the key of the name of the Jenkins CR in loggers
+	crKey = "cr"
+)
+
+var crLevels = struct {
+	sync.RWMutex
+	debug map[string]bool
+}{debug: map[string]bool{}}
+
+// SetCRLevel sets the log level of logs with the "cr" key of the Jenkins CR, LevelDebug or LevelInfo, an empty level
+// resets it to LevelInfo. Debug logs of all Jenkins CRs are written anyway when the operator runs with debug.
+func SetCRLevel(name, level string) error {
+	switch level {
+	case LevelDebug:
+		crLevels.Lock()
+		crLevels.debug[name] = true
+		crLevels.Unlock()
+	case LevelInfo, "":
+		crLevels.Lock()
+		delete(crLevels.debug, name)
+		crLevels.Unlock()
+	default:
+		return errors.Errorf("invalid log level '%s', it has to be '%s' or '%s'", level, LevelDebug, LevelInfo)
+	}
+	return nil
+}
+
+func isCRDebug(name string) bool {
+	crLevels.RLock()
+	defer crLevels.RUnlock()
+	return crLevels.debug[name]
+}
+
+// crLevelCore writes debug logs when the operator runs with debug or when they belong to the Jenkins CR with
+// debug level set by SetCRLevel
+type crLevelCore struct {
+	zapcore.Core
+	debug bool
+	cr    string
+}
+
+func wrapCRLevelCore(debug bool) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &crLevelCore{Core: core, debug: debug}
+	})
+}
+
+func (c *crLevelCore) Enabled(level zapcore.Level) bool {
+	if level >= zapcore.InfoLevel || c.debug || (len(c.cr) > 0 && isCRDebug(c.cr)) {
+		return c.Core.Enabled(level)
+	}
+	return false
+}
+
+func (c *crLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	cr := c.cr
+	for _, field := range fields {
+		if field.Key == crKey && field.Type == zapcore.StringType {
+			cr = field.String
+		}
+	}
+	return &crLevelCore{Core: c.Core.With(fields), debug: c.debug, cr: cr}
+}
+
+// Write keeps the entry of the Jenkins CR in memory for support bundles and writes it
+func (c *crLevelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if len(c.cr) > 0 {
+		addCRLog(c.cr, entry, fields)
+	}
+	return c.Core.Write(entry, fields)
+}
+
+func (c *crLevelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
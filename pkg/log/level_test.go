@@ -0,0 +1,86 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCRLevelCore(t *testing.T) {
+	newLogger := func(debug bool) (*zap.Logger, *observer.ObservedLogs) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		return zap.New(core, wrapCRLevelCore(debug)), logs
+	}
+
+	t.Run("info", func(t *testing.T) {
+		logger, logs := newLogger(false)
+
+		logger.With(zap.String(crKey, "example")).Debug("debug")
+		logger.With(zap.String(crKey, "example")).Info("info")
+
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, "info", logs.All()[0].Message)
+	})
+	t.Run("global debug", func(t *testing.T) {
+		logger, logs := newLogger(true)
+
+		logger.Debug("debug")
+		logger.With(zap.String(crKey, "example")).Debug("debug")
+
+		assert.Equal(t, 2, logs.Len())
+	})
+	t.Run("debug of single CR", func(t *testing.T) {
+		logger, logs := newLogger(false)
+		require.NoError(t, SetCRLevel("debugged", LevelDebug))
+		defer func() {
+			require.NoError(t, SetCRLevel("debugged", ""))
+		}()
+
+		logger.Debug("debug")
+		logger.With(zap.String(crKey, "example")).Debug("debug")
+		debugged := logger.With(zap.String(crKey, "debugged"))
+		debugged.Debug("debugged")
+		debugged.With(zap.String("key", "value")).Debug("debugged with values")
+		require.NoError(t, SetCRLevel("debugged", LevelInfo))
+		debugged.Debug("not debugged")
+
+		require.Equal(t, 2, logs.Len())
+		assert.Equal(t, "debugged", logs.All()[0].Message)
+		assert.Equal(t, "debugged with values", logs.All()[1].Message)
+	})
+	t.Run("invalid level", func(t *testing.T) {
+		assert.Error(t, SetCRLevel("example", "trace"))
+	})
+}
+
+func TestSetupLoggerWithEncoding(t *testing.T) {
+	assert.NoError(t, SetupLoggerWithEncoding(false, EncodingJSON))
+	assert.Error(t, SetupLoggerWithEncoding(false, "xml"))
+}
+
+func TestCRLogs(t *testing.T) {
+	core, _ := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core, wrapCRLevelCore(false)).With(zap.String(crKey, "logged"))
+	defer DeleteCRLogs("logged")
+
+	logger.Info("first", zap.String("key", "value"))
+	logger.Debug("disabled")
+	for i := 0; i < MaxCRLogLines; i++ {
+		logger.Info("next")
+	}
+
+	lines := CRLogs("logged")
+	require.Len(t, lines, MaxCRLogLines)
+	assert.Contains(t, lines[len(lines)-1], "INFO\t\tnext")
+	assert.Empty(t, CRLogs("other"))
+
+	DeleteCRLogs("logged")
+	logger.Warn("warning", zap.String("key", "value"))
+	lines = CRLogs("logged")
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], "WARN\t\twarning\tkey=value")
+}
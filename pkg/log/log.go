@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -22,16 +23,26 @@ const (
 	VDebug = 1
 )
 
-func zapLogger(debug bool) logr.Logger {
+const (
+	// EncodingConsole is the human readable log encoding
+	EncodingConsole = "console"
+	// EncodingJSON is the structured log encoding, one JSON object per line
+	EncodingJSON = "json"
+)
+
+func zapLogger(debug bool, encoding string) logr.Logger {
 	var zapLog *zap.Logger
 	var err error
-	zapLogCfg := zap.NewDevelopmentConfig()
-	if debug {
-		zapLogCfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	var zapLogCfg zap.Config
+	if encoding == EncodingJSON {
+		zapLogCfg = zap.NewProductionConfig()
+		zapLogCfg.Sampling = nil
 	} else {
-		zapLogCfg.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+		zapLogCfg = zap.NewDevelopmentConfig()
 	}
-	zapLog, err = zapLogCfg.Build(zap.AddStacktrace(zap.DPanicLevel), zap.AddCallerSkip(1))
+	// the level is checked by crLevelCore, so debug logs of single Jenkins CRs can be enabled at runtime
+	zapLogCfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	zapLog, err = zapLogCfg.Build(zap.AddStacktrace(zap.DPanicLevel), zap.AddCallerSkip(1), wrapCRLevelCore(debug))
 	// who watches the watchmen?
 	fatalIfErr(err, log.Fatalf)
 	return zapr.NewLogger(zapLog)
@@ -45,7 +56,20 @@ func fatalIfErr(err error, f func(format string, v ...interface{})) {
 
 // SetupLogger setups global logger.
 func SetupLogger(debug bool) {
+	setupLogger(debug, EncodingConsole)
+}
+
+// SetupLoggerWithEncoding setups global logger which writes logs with the encoding, EncodingConsole or EncodingJSON.
+func SetupLoggerWithEncoding(debug bool, encoding string) error {
+	if encoding != EncodingConsole && encoding != EncodingJSON {
+		return errors.Errorf("invalid log encoding '%s', it has to be '%s' or '%s'", encoding, EncodingConsole, EncodingJSON)
+	}
+	setupLogger(debug, encoding)
+	return nil
+}
+
+func setupLogger(debug bool, encoding string) {
 	Debug = debug
-	logf.SetLogger(zapLogger(debug))
+	logf.SetLogger(zapLogger(debug, encoding))
 	Log = logf.Log.WithName("controller-jenkins")
 }
@@ -0,0 +1,116 @@
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	specVersion = "1.0"
+	contentType = "application/cloudevents+json; charset=utf-8"
+	typePrefix  = "io.jenkins.operator."
+)
+
+// CloudEvents sends events as CloudEvents 1.0 in HTTP structured content mode.
+type CloudEvents struct {
+	httpClient http.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of CloudEvents.
+func New(config v1alpha2.Notification, httpClient http.Client) *CloudEvents {
+	return &CloudEvents{config: config, httpClient: httpClient}
+}
+
+// Message is representation of CloudEvent in JSON format.
+type Message struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject"`
+	Time            string `json:"time"`
+	DataContentType string `json:"datacontenttype"`
+	Data            Data   `json:"data"`
+}
+
+// Data is the event payload.
+type Data struct {
+	Title     string                     `json:"title"`
+	Level     v1alpha2.NotificationLevel `json:"level"`
+	Phase     event.Phase                `json:"phase"`
+	Namespace string                     `json:"namespace"`
+	CrName    string                     `json:"crName"`
+	Reason    string                     `json:"reason"`
+	Messages  []string                   `json:"messages"`
+}
+
+func (c CloudEvents) getSource(e event.Event) string {
+	if len(c.config.CloudEvents.Source) > 0 {
+		return c.config.CloudEvents.Source
+	}
+
+	return fmt.Sprintf("/apis/%s/namespaces/%s/jenkins/%s", v1alpha2.SchemeGroupVersion.String(), e.Jenkins.Namespace, e.Jenkins.Name)
+}
+
+func (c CloudEvents) generateMessage(e event.Event) Message {
+	messages := provider.Messages(c.config, e)
+	reasonName := reflect.Indirect(reflect.ValueOf(e.Reason)).Type().Name()
+
+	return Message{
+		SpecVersion:     specVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          c.getSource(e),
+		Type:            typePrefix + strings.ToLower(reasonName),
+		Subject:         e.Jenkins.Name,
+		Time:            time.Now().UTC().Format(time.RFC3339),
+		DataContentType: "application/json",
+		Data: Data{
+			Title:     provider.NotificationTitle(e),
+			Level:     e.Level,
+			Phase:     e.Phase,
+			Namespace: e.Jenkins.Namespace,
+			CrName:    e.Jenkins.Name,
+			Reason:    reasonName,
+			Messages:  messages,
+		},
+	}
+}
+
+// Send is function for sending directly to the sink.
+func (c CloudEvents) Send(e event.Event) error {
+	message, err := json.Marshal(c.generateMessage(e))
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", c.config.CloudEvents.SinkURL, bytes.NewBuffer(message))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", contentType)
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("CloudEvents sink '%s' responded with invalid status code %d", c.config.CloudEvents.SinkURL, resp.StatusCode)
+	}
+
+	return nil
+}
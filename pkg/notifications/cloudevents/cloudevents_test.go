@@ -0,0 +1,113 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	testPhase     = event.PhaseUser
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testReason    = reason.NewPodRestart(
+		reason.KubernetesSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	)
+	testLevel = v1alpha2.NotificationLevelWarning
+)
+
+func TestCloudEvents_Send(t *testing.T) {
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, contentType, r.Header.Get("Content-Type"))
+
+		var message Message
+		err := json.NewDecoder(r.Body).Decode(&message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "1.0", message.SpecVersion)
+		assert.NotEmpty(t, message.ID)
+		assert.Equal(t, "/apis/jenkins.io/v1alpha2/namespaces/default/jenkins/test-cr", message.Source)
+		assert.Equal(t, "io.jenkins.operator.podrestart", message.Type)
+		assert.Equal(t, testCrName, message.Subject)
+		_, err = time.Parse(time.RFC3339, message.Time)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/json", message.DataContentType)
+		assert.Equal(t, Data{
+			Title:     provider.NotificationTitle(e),
+			Level:     testLevel,
+			Phase:     testPhase,
+			Namespace: testNamespace,
+			CrName:    testCrName,
+			Reason:    "PodRestart",
+			Messages:  testReason.Short(),
+		}, message.Data)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	cloudEvents := New(v1alpha2.Notification{CloudEvents: &v1alpha2.CloudEvents{SinkURL: server.URL}}, http.Client{})
+
+	err := cloudEvents.Send(e)
+	assert.NoError(t, err)
+}
+
+func TestCloudEvents_Send_CustomSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message Message
+		err := json.NewDecoder(r.Body).Decode(&message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "https://jenkins.example.com", message.Source)
+	}))
+	defer server.Close()
+
+	cloudEvents := New(v1alpha2.Notification{CloudEvents: &v1alpha2.CloudEvents{
+		SinkURL: server.URL,
+		Source:  "https://jenkins.example.com",
+	}}, http.Client{})
+
+	err := cloudEvents.Send(event.Event{Level: testLevel, Phase: testPhase, Reason: testReason})
+	assert.NoError(t, err)
+}
+
+func TestCloudEvents_Send_InvalidStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cloudEvents := New(v1alpha2.Notification{CloudEvents: &v1alpha2.CloudEvents{SinkURL: server.URL}}, http.Client{})
+
+	err := cloudEvents.Send(event.Event{Level: testLevel, Phase: testPhase, Reason: testReason})
+
+	assert.EqualError(t, err, "CloudEvents sink '"+server.URL+"' responded with invalid status code 400")
+}
@@ -0,0 +1,158 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	infoColor    = 0x439FE0
+	warningColor = 0xE01E5A
+	defaultColor = 0xC8C8C8
+
+	username = "Jenkins Operator"
+	// reasonFieldName is field title for the reason of the event
+	reasonFieldName = "Reason"
+)
+
+// Discord is a Discord notification service.
+type Discord struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of Discord.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *Discord {
+	return &Discord{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Message is representation of json message.
+type Message struct {
+	Username string  `json:"username"`
+	Embeds   []Embed `json:"embeds"`
+}
+
+// Embed is representation of json embed.
+type Embed struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Color       int     `json:"color"`
+	Fields      []Field `json:"fields"`
+}
+
+// Field is representation of json embed field.
+type Field struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func (d Discord) getStatusColor(logLevel v1alpha2.NotificationLevel) int {
+	switch logLevel {
+	case v1alpha2.NotificationLevelInfo:
+		return infoColor
+	case v1alpha2.NotificationLevelWarning:
+		return warningColor
+	default:
+		return defaultColor
+	}
+}
+
+func (d Discord) generateMessage(e event.Event) Message {
+	var messageStringBuilder strings.Builder
+	messages := provider.Messages(d.config, e)
+	for _, msg := range messages {
+		messageStringBuilder.WriteString(" - " + msg + "\n")
+	}
+
+	return Message{
+		Username: username,
+		Embeds: []Embed{
+			{
+				Title:       provider.NotificationTitle(e),
+				Description: messageStringBuilder.String(),
+				Color:       d.getStatusColor(e.Level),
+				Fields: []Field{
+					{
+						Name:   provider.NamespaceFieldName,
+						Value:  e.Jenkins.Namespace,
+						Inline: true,
+					},
+					{
+						Name:   provider.CrNameFieldName,
+						Value:  e.Jenkins.Name,
+						Inline: true,
+					},
+					{
+						Name:   provider.PhaseFieldName,
+						Value:  string(e.Phase),
+						Inline: true,
+					},
+					{
+						Name:   provider.LevelFieldName,
+						Value:  string(e.Level),
+						Inline: true,
+					},
+					{
+						Name:   reasonFieldName,
+						Value:  reflect.Indirect(reflect.ValueOf(e.Reason)).Type().Name(),
+						Inline: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Send is function for sending directly to API.
+func (d Discord) Send(e event.Event) error {
+	secret := &corev1.Secret{}
+	selector := d.config.Discord.WebHookURLSecretKeySelector
+
+	err := d.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return err
+	}
+
+	discordMessage, err := json.Marshal(d.generateMessage(e))
+	if err != nil {
+		return err
+	}
+
+	secretValue := string(secret.Data[selector.Key])
+	if secretValue == "" {
+		return errors.Errorf("Discord WebHook URL is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	request, err := http.NewRequest("POST", secretValue, bytes.NewBuffer(discordMessage))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("Discord responded with invalid status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
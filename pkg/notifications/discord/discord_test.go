@@ -0,0 +1,108 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testPhase     = event.PhaseUser
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testReason    = reason.NewPodRestart(
+		reason.KubernetesSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	)
+	testLevel = v1alpha2.NotificationLevelWarning
+)
+
+func TestDiscord_Send(t *testing.T) {
+	fakeClient := fake.NewFakeClient()
+	testURLSelectorKeyName := "test-url-selector"
+	testSecretName := "test-secret"
+
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+
+	discord := Discord{k8sClient: fakeClient, config: v1alpha2.Notification{
+		Discord: &v1alpha2.Discord{
+			WebHookURLSecretKeySelector: v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: testSecretName,
+				},
+				Key: testURLSelectorKeyName,
+			},
+		},
+	}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message Message
+		err := json.NewDecoder(r.Body).Decode(&message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		embed := message.Embeds[0]
+		assert.Equal(t, provider.NotificationTitle(e), embed.Title)
+		assert.Equal(t, warningColor, embed.Color)
+		assert.Equal(t, " - "+testReason.Short()[0]+"\n", embed.Description)
+		for _, field := range embed.Fields {
+			switch field.Name {
+			case provider.PhaseFieldName:
+				assert.Equal(t, string(e.Phase), field.Value)
+			case provider.CrNameFieldName:
+				assert.Equal(t, e.Jenkins.Name, field.Value)
+			case provider.NamespaceFieldName:
+				assert.Equal(t, e.Jenkins.Namespace, field.Value)
+			case provider.LevelFieldName:
+				assert.Equal(t, string(e.Level), field.Value)
+			case reasonFieldName:
+				assert.Equal(t, "PodRestart", field.Value)
+			default:
+				t.Errorf("unexpected field %+v", field)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecretName,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			testURLSelectorKeyName: []byte(server.URL),
+		},
+	}
+
+	err := fakeClient.Create(context.TODO(), secret)
+	require.NoError(t, err)
+
+	err = discord.Send(e)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,49 @@
+package notifications
+
+import (
+	"reflect"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// matchesFilter returns true if the event is selected by the filter of the notification service.
+func matchesFilter(filter *v1alpha2.NotificationFilter, e event.Event) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	if len(filter.Phases) > 0 && !contains(filter.Phases, string(e.Phase)) {
+		return false, nil
+	}
+
+	if len(filter.Reasons) > 0 && !contains(filter.Reasons, reflect.Indirect(reflect.ValueOf(e.Reason)).Type().Name()) {
+		return false, nil
+	}
+
+	if filter.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(filter.Selector)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		if !selector.Matches(labels.Set(e.Jenkins.Labels)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package notifications
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchesFilter(t *testing.T) {
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{
+			Name:   "jenkins",
+			Labels: map[string]string{"environment": "production"},
+		}},
+		Phase:  event.PhaseBase,
+		Level:  v1alpha2.NotificationLevelWarning,
+		Reason: reason.NewPodRestart(reason.KubernetesSource, []string{"restart"}),
+	}
+
+	tests := []struct {
+		name    string
+		filter  *v1alpha2.NotificationFilter
+		matches bool
+	}{
+		{name: "no filter", filter: nil, matches: true},
+		{name: "empty filter", filter: &v1alpha2.NotificationFilter{}, matches: true},
+		{name: "matching phase", filter: &v1alpha2.NotificationFilter{Phases: []string{"user", "base"}}, matches: true},
+		{name: "other phase", filter: &v1alpha2.NotificationFilter{Phases: []string{"user"}}, matches: false},
+		{name: "matching reason", filter: &v1alpha2.NotificationFilter{Reasons: []string{"PodRestart"}}, matches: true},
+		{name: "other reason", filter: &v1alpha2.NotificationFilter{Reasons: []string{"BaseConfigurationFailed"}}, matches: false},
+		{
+			name: "matching selector",
+			filter: &v1alpha2.NotificationFilter{Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"environment": "production"},
+			}},
+			matches: true,
+		},
+		{
+			name: "other selector",
+			filter: &v1alpha2.NotificationFilter{Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"environment": "staging"},
+			}},
+			matches: false,
+		},
+		{
+			name: "all fields",
+			filter: &v1alpha2.NotificationFilter{
+				Phases:  []string{"base"},
+				Reasons: []string{"PodRestart"},
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "environment", Operator: metav1.LabelSelectorOpIn, Values: []string{"production"}},
+				}},
+			},
+			matches: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := matchesFilter(tt.filter, e)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.matches, matches)
+		})
+	}
+
+	t.Run("invalid selector", func(t *testing.T) {
+		filter := &v1alpha2.NotificationFilter{Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "environment", Operator: "invalid"},
+		}}}
+
+		_, err := matchesFilter(filter, e)
+
+		assert.Error(t, err)
+	})
+}
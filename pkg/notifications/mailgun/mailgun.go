@@ -71,11 +71,7 @@ func (m MailGun) generateMessage(event event.Event) string {
 	var statusMessage strings.Builder
 	var reasons string
 
-	if m.config.Verbose {
-		reasons = strings.TrimRight(strings.Join(event.Reason.Verbose(), "</li><li>"), "<li>")
-	} else {
-		reasons = strings.TrimRight(strings.Join(event.Reason.Short(), "</li><li>"), "<li>")
-	}
+	reasons = strings.TrimRight(strings.Join(provider.Messages(m.config, event), "</li><li>"), "<li>")
 
 	statusMessage.WriteString("<ul><li>")
 	statusMessage.WriteString(reasons)
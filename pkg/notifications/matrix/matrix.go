@@ -0,0 +1,125 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	noticeMessageType = "m.notice"
+	htmlFormat        = "org.matrix.custom.html"
+)
+
+// transactionCounter makes transaction IDs unique within the operator process
+var transactionCounter uint64
+
+// Matrix is a Matrix notification service.
+type Matrix struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of Matrix.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *Matrix {
+	return &Matrix{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Message is representation of json m.room.message event content.
+type Message struct {
+	MessageType   string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+func (m Matrix) generateMessage(e event.Event) Message {
+	messages := provider.Messages(m.config, e)
+
+	fields := [][2]string{
+		{provider.NamespaceFieldName, e.Jenkins.Namespace},
+		{provider.CrNameFieldName, e.Jenkins.Name},
+		{provider.PhaseFieldName, string(e.Phase)},
+		{provider.LevelFieldName, string(e.Level)},
+	}
+
+	var body, formattedBody strings.Builder
+	body.WriteString(provider.NotificationTitle(e) + "\n")
+	formattedBody.WriteString("<strong>" + html.EscapeString(provider.NotificationTitle(e)) + "</strong><ul>")
+	for _, msg := range messages {
+		body.WriteString(" - " + msg + "\n")
+		formattedBody.WriteString("<li>" + html.EscapeString(msg) + "</li>")
+	}
+	formattedBody.WriteString("</ul>")
+	for _, field := range fields {
+		body.WriteString(fmt.Sprintf("%s: %s\n", field[0], field[1]))
+		formattedBody.WriteString(fmt.Sprintf("<b>%s:</b> %s<br>", html.EscapeString(field[0]), html.EscapeString(field[1])))
+	}
+
+	return Message{
+		MessageType:   noticeMessageType,
+		Body:          body.String(),
+		Format:        htmlFormat,
+		FormattedBody: formattedBody.String(),
+	}
+}
+
+// Send is function for sending directly to API.
+func (m Matrix) Send(e event.Event) error {
+	secret := &corev1.Secret{}
+	selector := m.config.Matrix.AccessTokenSecretKeySelector
+
+	err := m.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return err
+	}
+
+	accessToken := string(secret.Data[selector.Key])
+	if accessToken == "" {
+		return errors.Errorf("Matrix access token is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	matrixMessage, err := json.Marshal(m.generateMessage(e))
+	if err != nil {
+		return err
+	}
+
+	transactionID := fmt.Sprintf("jenkins-operator-%d-%d", time.Now().UnixNano(), atomic.AddUint64(&transactionCounter, 1))
+	endpoint := fmt.Sprintf("%s/_matrix/client/r0/rooms/%s/send/m.room.message/%s",
+		strings.TrimSuffix(m.config.Matrix.HomeserverURL, "/"), url.PathEscape(m.config.Matrix.RoomID), transactionID)
+	request, err := http.NewRequest("PUT", endpoint, bytes.NewBuffer(matrixMessage))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := m.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Matrix homeserver responded with invalid status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package matrix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testPhase     = event.PhaseUser
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testReason    = reason.NewPodRestart(
+		reason.KubernetesSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	)
+	testLevel = v1alpha2.NotificationLevelWarning
+)
+
+func TestMatrix_Send(t *testing.T) {
+	fakeClient := fake.NewFakeClient()
+	testTokenSelectorKeyName := "test-token-selector"
+	testSecretName := "test-secret"
+	testAccessToken := "test-access-token"
+	testRoomID := "!room:example.com"
+
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.True(t, strings.HasPrefix(r.URL.EscapedPath(), "/_matrix/client/r0/rooms/%21room:example.com/send/m.room.message/"), r.URL.EscapedPath())
+		assert.Equal(t, "Bearer "+testAccessToken, r.Header.Get("Authorization"))
+
+		var message Message
+		err := json.NewDecoder(r.Body).Decode(&message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "m.notice", message.MessageType)
+		assert.Contains(t, message.Body, provider.NotificationTitle(e))
+		assert.Contains(t, message.Body, provider.CrNameFieldName+": "+testCrName)
+		assert.Contains(t, message.FormattedBody, "<li>"+testReason.Short()[0]+"</li>")
+	}))
+	defer server.Close()
+
+	matrix := Matrix{k8sClient: fakeClient, config: v1alpha2.Notification{
+		Matrix: &v1alpha2.Matrix{
+			HomeserverURL: server.URL,
+			RoomID:        testRoomID,
+			AccessTokenSecretKeySelector: v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: testSecretName,
+				},
+				Key: testTokenSelectorKeyName,
+			},
+		},
+	}}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecretName,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			testTokenSelectorKeyName: []byte(testAccessToken),
+		},
+	}
+
+	err := fakeClient.Create(context.TODO(), secret)
+	require.NoError(t, err)
+
+	err = matrix.Send(e)
+	assert.NoError(t, err)
+}
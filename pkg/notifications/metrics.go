@@ -0,0 +1,17 @@
+package notifications
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var deliveryFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "jenkins_operator",
+	Subsystem: "notification",
+	Name:      "delivery_failures_total",
+	Help:      "Number of notifications which couldn't be delivered partitioned by the notification name.",
+}, []string{"namespace", "jenkins", "notification"})
+
+func init() {
+	metrics.Registry.MustRegister(deliveryFailuresTotal)
+}
@@ -70,12 +70,7 @@ func (t Teams) getStatusColor(logLevel v1alpha2.NotificationLevel) event.StatusC
 }
 
 func (t Teams) generateMessage(e event.Event) Message {
-	var reason string
-	if t.config.Verbose {
-		reason = strings.Join(e.Reason.Verbose(), "\n\n - ")
-	} else {
-		reason = strings.Join(e.Reason.Short(), "\n\n - ")
-	}
+	reason := strings.Join(provider.Messages(t.config, e), "\n\n - ")
 
 	tm := Message{
 		Title:      provider.NotificationTitle(e),
@@ -0,0 +1,179 @@
+package opsgenie
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultURL is the Opsgenie API endpoint
+	DefaultURL = "https://api.opsgenie.com"
+
+	infoPriority    = "P5"
+	warningPriority = "P2"
+	defaultPriority = "P3"
+	source          = "jenkins-operator"
+	// maxMessageLength is the maximum length of alert message accepted by Opsgenie
+	maxMessageLength = 130
+)
+
+// Opsgenie is an Opsgenie notification service.
+type Opsgenie struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of Opsgenie.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *Opsgenie {
+	return &Opsgenie{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Alert is representation of json alert.
+type Alert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags"`
+	Details     map[string]string `json:"details"`
+	Source      string            `json:"source"`
+	Priority    string            `json:"priority"`
+}
+
+// CloseRequest is representation of json request which closes an alert.
+type CloseRequest struct {
+	Source string `json:"source"`
+	Note   string `json:"note"`
+}
+
+func (o Opsgenie) getPriority(logLevel v1alpha2.NotificationLevel) string {
+	switch logLevel {
+	case v1alpha2.NotificationLevelInfo:
+		return infoPriority
+	case v1alpha2.NotificationLevelWarning:
+		return warningPriority
+	default:
+		return defaultPriority
+	}
+}
+
+// getAlias returns the alias of alerts for failures in the event phase, the success event of the phase closes them
+func getAlias(e event.Event) string {
+	return fmt.Sprintf("%s/%s/%s", e.Jenkins.Namespace, e.Jenkins.Name, e.Phase)
+}
+
+// Resolves tells whether the event closes alerts raised in its phase
+func (o Opsgenie) Resolves(e event.Event) bool {
+	switch e.Reason.(type) {
+	case *reason.BaseConfigurationComplete, *reason.UserConfigurationComplete:
+		return true
+	default:
+		return false
+	}
+}
+
+func (o Opsgenie) generateAlert(e event.Event) Alert {
+	messages := provider.Messages(o.config, e)
+	reasonName := reflect.Indirect(reflect.ValueOf(e.Reason)).Type().Name()
+
+	alias := getAlias(e)
+	if e.Level != v1alpha2.NotificationLevelWarning {
+		// info events must not be merged with alerts for failures
+		alias = fmt.Sprintf("%s/%s", alias, reasonName)
+	}
+
+	message := fmt.Sprintf("%s: %s", provider.NotificationTitle(e), strings.Join(e.Reason.Short(), "; "))
+	if len(message) > maxMessageLength {
+		message = message[:maxMessageLength-3] + "..."
+	}
+
+	return Alert{
+		Message:     message,
+		Alias:       alias,
+		Description: strings.Join(messages, "\n"),
+		Tags: append([]string{
+			"namespace:" + e.Jenkins.Namespace,
+			"cr:" + e.Jenkins.Name,
+			"phase:" + string(e.Phase),
+		}, o.config.Opsgenie.Tags...),
+		Details: map[string]string{
+			provider.NamespaceFieldName: e.Jenkins.Namespace,
+			provider.CrNameFieldName:    e.Jenkins.Name,
+			provider.PhaseFieldName:     string(e.Phase),
+			provider.LevelFieldName:     string(e.Level),
+		},
+		Source:   source,
+		Priority: o.getPriority(e.Level),
+	}
+}
+
+// Send is function for sending directly to API.
+func (o Opsgenie) Send(e event.Event) error {
+	secret := &corev1.Secret{}
+	selector := o.config.Opsgenie.APIKeySecretKeySelector
+
+	err := o.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return err
+	}
+
+	apiKey := string(secret.Data[selector.Key])
+	if apiKey == "" {
+		return errors.Errorf("Opsgenie API key is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	baseURL := strings.TrimSuffix(o.config.Opsgenie.URL, "/")
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+
+	var endpoint string
+	var body interface{}
+	if o.Resolves(e) {
+		endpoint = fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", baseURL, url.PathEscape(getAlias(e)))
+		body = CloseRequest{Source: source, Note: strings.Join(e.Reason.Short(), "\n")}
+	} else {
+		endpoint = baseURL + "/v2/alerts"
+		body = o.generateAlert(e)
+	}
+
+	message, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(message))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "GenieKey "+apiKey)
+
+	resp, err := o.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return errors.Errorf("Opsgenie responded with invalid status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testSecretKey = "test-api-key-selector"
+	testSecret    = "test-secret"
+	testAPIKey    = "test-api-key"
+)
+
+func newTestOpsgenie(t *testing.T, url string) Opsgenie {
+	fakeClient := fake.NewFakeClient()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecret,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			testSecretKey: []byte(testAPIKey),
+		},
+	}
+	err := fakeClient.Create(context.TODO(), secret)
+	require.NoError(t, err)
+
+	return Opsgenie{k8sClient: fakeClient, config: v1alpha2.Notification{
+		Opsgenie: &v1alpha2.Opsgenie{
+			URL:  url,
+			Tags: []string{"team:platform"},
+			APIKeySecretKeySelector: v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: testSecret,
+				},
+				Key: testSecretKey,
+			},
+		},
+	}}
+}
+
+func newTestEvent(level v1alpha2.NotificationLevel, r reason.Reason) event.Event {
+	return event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  event.PhaseBase,
+		Level:  level,
+		Reason: r,
+	}
+}
+
+func TestOpsgenie_Send(t *testing.T) {
+	t.Run("alert", func(t *testing.T) {
+		e := newTestEvent(v1alpha2.NotificationLevelWarning, reason.NewBaseConfigurationFailed(reason.OperatorSource, []string{"test-reason-1"}))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v2/alerts", r.URL.Path)
+			assert.Equal(t, "GenieKey "+testAPIKey, r.Header.Get("Authorization"))
+
+			var alert Alert
+			err := json.NewDecoder(r.Body).Decode(&alert)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, "default/test-cr/base", alert.Alias)
+			assert.Equal(t, "P2", alert.Priority)
+			assert.Equal(t, []string{"namespace:default", "cr:test-cr", "phase:base", "team:platform"}, alert.Tags)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := newTestOpsgenie(t, server.URL).Send(e)
+
+		assert.NoError(t, err)
+	})
+	t.Run("close alert on success", func(t *testing.T) {
+		e := newTestEvent(v1alpha2.NotificationLevelInfo, reason.NewBaseConfigurationComplete(reason.OperatorSource, []string{"test-reason-1"}))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v2/alerts/default%2Ftest-cr%2Fbase/close", r.URL.EscapedPath())
+			assert.Equal(t, "alias", r.URL.Query().Get("identifierType"))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := newTestOpsgenie(t, server.URL).Send(e)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestOpsgenie_generateAlert(t *testing.T) {
+	e := newTestEvent(v1alpha2.NotificationLevelInfo, reason.NewPodCreation(reason.OperatorSource, []string{"test-reason-1"}))
+
+	alert := newTestOpsgenie(t, "").generateAlert(e)
+
+	assert.Equal(t, "default/test-cr/base/PodCreation", alert.Alias)
+	assert.Equal(t, "P5", alert.Priority)
+}
@@ -0,0 +1,142 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultURL is the PagerDuty Events API v2 endpoint
+	DefaultURL = "https://events.pagerduty.com/v2/enqueue"
+
+	triggerEventAction = "trigger"
+	infoSeverity       = "info"
+	warningSeverity    = "error"
+	defaultSeverity    = "warning"
+	source             = "jenkins-operator"
+)
+
+// PagerDuty is a PagerDuty notification service.
+type PagerDuty struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of PagerDuty.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *PagerDuty {
+	return &PagerDuty{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Message is representation of Events API v2 json message.
+type Message struct {
+	RoutingKey  string  `json:"routing_key"`
+	EventAction string  `json:"event_action"`
+	DedupKey    string  `json:"dedup_key"`
+	Payload     Payload `json:"payload"`
+}
+
+// Payload is representation of json event payload.
+type Payload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component"`
+	Group         string            `json:"group"`
+	Class         string            `json:"class"`
+	CustomDetails map[string]string `json:"custom_details"`
+}
+
+func (p PagerDuty) getSeverity(logLevel v1alpha2.NotificationLevel) string {
+	switch logLevel {
+	case v1alpha2.NotificationLevelInfo:
+		return infoSeverity
+	case v1alpha2.NotificationLevelWarning:
+		return warningSeverity
+	default:
+		return defaultSeverity
+	}
+}
+
+func (p PagerDuty) generateMessage(e event.Event, routingKey string) Message {
+	messages := provider.Messages(p.config, e)
+	reasonName := reflect.Indirect(reflect.ValueOf(e.Reason)).Type().Name()
+
+	return Message{
+		RoutingKey:  routingKey,
+		EventAction: triggerEventAction,
+		// events with the same reason are grouped into a single incident
+		DedupKey: fmt.Sprintf("%s/%s/%s", e.Jenkins.Namespace, e.Jenkins.Name, reasonName),
+		Payload: Payload{
+			Summary:   fmt.Sprintf("%s: %s", provider.NotificationTitle(e), strings.Join(e.Reason.Short(), "; ")),
+			Source:    source,
+			Severity:  p.getSeverity(e.Level),
+			Component: fmt.Sprintf("%s/%s", e.Jenkins.Namespace, e.Jenkins.Name),
+			Group:     string(e.Phase),
+			Class:     reasonName,
+			CustomDetails: map[string]string{
+				provider.MessageFieldName:   strings.Join(messages, "\n"),
+				provider.NamespaceFieldName: e.Jenkins.Namespace,
+				provider.CrNameFieldName:    e.Jenkins.Name,
+				provider.PhaseFieldName:     string(e.Phase),
+			},
+		},
+	}
+}
+
+// Send is function for sending directly to API.
+func (p PagerDuty) Send(e event.Event) error {
+	secret := &corev1.Secret{}
+	selector := p.config.PagerDuty.RoutingKeySecretKeySelector
+
+	err := p.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return err
+	}
+
+	routingKey := string(secret.Data[selector.Key])
+	if routingKey == "" {
+		return errors.Errorf("PagerDuty routing key is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	message, err := json.Marshal(p.generateMessage(e, routingKey))
+	if err != nil {
+		return err
+	}
+
+	url := p.config.PagerDuty.URL
+	if url == "" {
+		url = DefaultURL
+	}
+	request, err := http.NewRequest("POST", url, bytes.NewBuffer(message))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return errors.Errorf("PagerDuty responded with invalid status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testPhase     = event.PhaseBase
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testReason    = reason.NewReconcileLoopFailed(
+		reason.OperatorSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	)
+	testLevel = v1alpha2.NotificationLevelWarning
+)
+
+func TestPagerDuty_Send(t *testing.T) {
+	fakeClient := fake.NewFakeClient()
+	testRoutingKeySelectorKeyName := "test-routing-key-selector"
+	testSecretName := "test-secret"
+	testRoutingKey := "test-routing-key"
+
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message Message
+		err := json.NewDecoder(r.Body).Decode(&message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, testRoutingKey, message.RoutingKey)
+		assert.Equal(t, "trigger", message.EventAction)
+		assert.Equal(t, "default/test-cr/ReconcileLoopFailed", message.DedupKey)
+		assert.Equal(t, "error", message.Payload.Severity)
+		assert.Equal(t, "ReconcileLoopFailed", message.Payload.Class)
+		assert.Equal(t, string(testPhase), message.Payload.Group)
+		assert.Contains(t, message.Payload.Summary, provider.NotificationTitle(e))
+		assert.Equal(t, testCrName, message.Payload.CustomDetails[provider.CrNameFieldName])
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	pagerDuty := PagerDuty{k8sClient: fakeClient, config: v1alpha2.Notification{
+		PagerDuty: &v1alpha2.PagerDuty{
+			URL: server.URL,
+			RoutingKeySecretKeySelector: v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: testSecretName,
+				},
+				Key: testRoutingKeySelectorKeyName,
+			},
+		},
+	}}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecretName,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			testRoutingKeySelectorKeyName: []byte(testRoutingKey),
+		},
+	}
+
+	err := fakeClient.Create(context.TODO(), secret)
+	require.NoError(t, err)
+
+	err = pagerDuty.Send(e)
+	assert.NoError(t, err)
+}
+
+func TestPagerDuty_getSeverity(t *testing.T) {
+	pagerDuty := PagerDuty{}
+
+	assert.Equal(t, "info", pagerDuty.getSeverity(v1alpha2.NotificationLevelInfo))
+	assert.Equal(t, "error", pagerDuty.getSeverity(v1alpha2.NotificationLevelWarning))
+	assert.Equal(t, "warning", pagerDuty.getSeverity("unknown"))
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/pkg/errors"
+)
+
+// TemplateData is the data available in the template of notification message.
+type TemplateData struct {
+	Title           string
+	Level           v1alpha2.NotificationLevel
+	Phase           event.Phase
+	Namespace       string
+	Name            string
+	Labels          map[string]string
+	Annotations     map[string]string
+	Reason          string
+	Messages        []string
+	VerboseMessages []string
+	JenkinsURL      string
+}
+
+// ParseTemplate parses the template of notification message.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("notification").Funcs(template.FuncMap{"join": strings.Join}).Parse(text)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return tmpl, nil
+}
+
+// NewTemplateData returns the data of the event available in the template of notification message.
+func NewTemplateData(e event.Event) TemplateData {
+	data := TemplateData{
+		Title:           NotificationTitle(e),
+		Level:           e.Level,
+		Phase:           e.Phase,
+		Namespace:       e.Jenkins.Namespace,
+		Name:            e.Jenkins.Name,
+		Labels:          e.Jenkins.Labels,
+		Annotations:     e.Jenkins.Annotations,
+		Reason:          reflect.Indirect(reflect.ValueOf(e.Reason)).Type().Name(),
+		Messages:        e.Reason.Short(),
+		VerboseMessages: e.Reason.Verbose(),
+	}
+	if fqdn, err := resources.GetJenkinsHTTPServiceFQDN(&e.Jenkins); err == nil {
+		data.JenkinsURL = fmt.Sprintf("http://%s:%d", fqdn, e.Jenkins.Spec.Service.Port)
+	}
+
+	return data
+}
+
+// Messages returns messages of the event sent by the notification service, messages are rendered by the template
+// of the notification when it's set.
+func Messages(config v1alpha2.Notification, e event.Event) []string {
+	messages := e.Reason.Short()
+	if config.Verbose {
+		messages = e.Reason.Verbose()
+	}
+	if len(config.Template) == 0 {
+		return messages
+	}
+
+	rendered, err := renderTemplate(config.Template, e)
+	if err != nil {
+		log.Log.V(log.VWarn).Info(fmt.Sprintf("Failed to render template of notification '%s', using default message: %s", config.Name, err))
+		return messages
+	}
+
+	return []string{rendered}
+}
+
+func renderTemplate(text string, e event.Event) (string, error) {
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := render.Render(tmpl, NewTemplateData(e))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(rendered), nil
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMessages(t *testing.T) {
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "jenkins",
+				Namespace: "default",
+				Labels:    map[string]string{"team": "platform"},
+			},
+			Spec: v1alpha2.JenkinsSpec{Service: v1alpha2.Service{Port: 8080}},
+		},
+		Phase:  event.PhaseBase,
+		Level:  v1alpha2.NotificationLevelWarning,
+		Reason: reason.NewBaseConfigurationFailed(reason.OperatorSource, []string{"short"}, "verbose"),
+	}
+
+	t.Run("short", func(t *testing.T) {
+		assert.Equal(t, e.Reason.Short(), Messages(v1alpha2.Notification{}, e))
+	})
+	t.Run("verbose", func(t *testing.T) {
+		assert.Equal(t, e.Reason.Verbose(), Messages(v1alpha2.Notification{Verbose: true}, e))
+	})
+	t.Run("template", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			Template: `
+{{ .Reason }} of {{ .Namespace }}/{{ .Name }} ({{ .Phase }}, {{ .Level }}) owned by {{ index .Labels "team" }}: {{ join .Messages ", " }}
+Jenkins: {{ .JenkinsURL }}, runbook: https://runbooks.example.com/{{ .Reason }}
+`,
+		}
+
+		assert.Equal(t, []string{
+			"BaseConfigurationFailed of default/jenkins (base, warning) owned by platform: short\n" +
+				"Jenkins: http://jenkins-operator-http-jenkins.default.svc.cluster.local:8080, runbook: https://runbooks.example.com/BaseConfigurationFailed",
+		}, Messages(config, e))
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		config := v1alpha2.Notification{Template: "{{ .Unknown }}"}
+
+		assert.Equal(t, e.Reason.Short(), Messages(config, e))
+	})
+}
+
+func TestParseTemplate(t *testing.T) {
+	_, err := ParseTemplate("{{ .Name }}")
+	assert.NoError(t, err)
+
+	_, err = ParseTemplate("{{ .Name ")
+	assert.Error(t, err)
+}
@@ -65,6 +65,26 @@ type UserConfigurationComplete struct {
 	Undefined
 }
 
+// BackupHookFailed defines the reason why the backup hook failed.
+type BackupHookFailed struct {
+	Undefined
+}
+
+// PluginSecurityWarnings informs that installed plugins are affected by security warnings.
+type PluginSecurityWarnings struct {
+	Undefined
+}
+
+// ResourceDrift informs that resources managed by the operator have been changed or deleted manually.
+type ResourceDrift struct {
+	Undefined
+}
+
+// AdminCredentialsRotated informs that credentials of the operator user have been rotated.
+type AdminCredentialsRotated struct {
+	Undefined
+}
+
 // NewUndefined returns new instance of Undefined.
 func NewUndefined(source Source, short []string, verbose ...string) *Undefined {
 	return &Undefined{source: source, short: short, verbose: checkIfVerboseEmpty(short, verbose)}
@@ -171,6 +191,50 @@ func NewUserConfigurationComplete(source Source, short []string, verbose ...stri
 	}
 }
 
+// NewBackupHookFailed returns new instance of BackupHookFailed.
+func NewBackupHookFailed(source Source, short []string, verbose ...string) *BackupHookFailed {
+	return &BackupHookFailed{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
+// NewPluginSecurityWarnings returns new instance of PluginSecurityWarnings.
+func NewPluginSecurityWarnings(source Source, short []string, verbose ...string) *PluginSecurityWarnings {
+	return &PluginSecurityWarnings{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
+// NewResourceDrift returns new instance of ResourceDrift.
+func NewResourceDrift(source Source, short []string, verbose ...string) *ResourceDrift {
+	return &ResourceDrift{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
+// NewAdminCredentialsRotated returns new instance of AdminCredentialsRotated.
+func NewAdminCredentialsRotated(source Source, short []string, verbose ...string) *AdminCredentialsRotated {
+	return &AdminCredentialsRotated{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
 // Source is enum type that informs us what triggered notification.
 type Source string
 
@@ -9,11 +9,17 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
 	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/cloudevents"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/discord"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/mailgun"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/matrix"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/msteams"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/opsgenie"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/pagerduty"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/slack"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/smtp"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/webhook"
 
 	"github.com/pkg/errors"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,6 +30,12 @@ type Provider interface {
 	Send(event event.Event) error
 }
 
+// Resolver is implemented by providers which resolve previously sent warnings on success events,
+// they receive success events even when only warnings are requested.
+type Resolver interface {
+	Resolves(event event.Event) bool
+}
+
 // Listen listens for incoming events and send it as notifications.
 func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8sclient.Client) {
 	httpClient := http.Client{}
@@ -35,14 +47,9 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 			continue // skip empty messages
 		}
 
-		k8sEvent.Emit(&e.Jenkins,
-			eventLevelToKubernetesEventType(e.Level),
-			k8sevent.Reason(reflect.TypeOf(e.Reason).Name()),
-			strings.Join(e.Reason.Short(), "; "),
-		)
+		emitKubernetesEvent(k8sEvent, e)
 
 		for _, notificationConfig := range e.Jenkins.Spec.Notifications {
-			var err error
 			var provider Provider
 			switch {
 			case notificationConfig.Slack != nil:
@@ -53,6 +60,18 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 				provider = mailgun.New(k8sClient, notificationConfig)
 			case notificationConfig.SMTP != nil:
 				provider = smtp.New(k8sClient, notificationConfig)
+			case notificationConfig.Webhook != nil:
+				provider = webhook.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.PagerDuty != nil:
+				provider = pagerduty.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.Opsgenie != nil:
+				provider = opsgenie.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.Discord != nil:
+				provider = discord.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.Matrix != nil:
+				provider = matrix.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.CloudEvents != nil:
+				provider = cloudevents.New(notificationConfig, httpClient)
 			default:
 				logger.V(log.VWarn).Info(fmt.Sprintf("Unknown notification service `%+v`", notificationConfig))
 				continue
@@ -60,13 +79,24 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 
 			isInfoEvent := e.Level == v1alpha2.NotificationLevelInfo
 			wantsWarning := notificationConfig.LoggingLevel == v1alpha2.NotificationLevelWarning
-			if isInfoEvent && wantsWarning {
+			resolver, isResolver := provider.(Resolver)
+			if isInfoEvent && wantsWarning && !(isResolver && resolver.Resolves(e)) {
 				continue // skip the event
 			}
 
-			go func(notificationConfig v1alpha2.Notification) {
-				err = provider.Send(e)
+			matches, err := matchesFilter(notificationConfig.Filter, e)
+			if err != nil {
+				logger.V(log.VWarn).Info(fmt.Sprintf("Invalid filter of notification '%s': %s", notificationConfig.Name, err))
+				continue
+			}
+			if !matches {
+				continue // skip the event
+			}
+
+			go func(notificationConfig v1alpha2.Notification, provider Provider, e event.Event) {
+				err := provider.Send(e)
 				if err != nil {
+					deliveryFailuresTotal.WithLabelValues(e.Jenkins.Namespace, e.Jenkins.Name, notificationConfig.Name).Inc()
 					wrapped := errors.WithMessage(err,
 						fmt.Sprintf("failed to send notification '%s'", notificationConfig.Name))
 					if log.Debug {
@@ -75,11 +105,21 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 						logger.Error(nil, fmt.Sprintf("%s", wrapped))
 					}
 				}
-			}(notificationConfig)
+			}(notificationConfig, provider, e)
 		}
 	}
 }
 
+// emitKubernetesEvent records the event on the Jenkins CR, so it's visible by kubectl describe jenkins
+// without any notification provider.
+func emitKubernetesEvent(k8sEvent k8sevent.Recorder, e event.Event) {
+	k8sEvent.Emit(&e.Jenkins,
+		eventLevelToKubernetesEventType(e.Level),
+		k8sevent.Reason(reflect.Indirect(reflect.ValueOf(e.Reason)).Type().Name()),
+		fmt.Sprintf("[%s] %s", e.Phase, strings.Join(e.Reason.Short(), "; ")),
+	)
+}
+
 func eventLevelToKubernetesEventType(level v1alpha2.NotificationLevel) k8sevent.Type {
 	switch level {
 	case v1alpha2.NotificationLevelWarning:
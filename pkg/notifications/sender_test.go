@@ -0,0 +1,71 @@
+package notifications
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type emittedEvent struct {
+	object    runtime.Object
+	eventType k8sevent.Type
+	reason    k8sevent.Reason
+	message   string
+}
+
+type fakeRecorder struct {
+	events []emittedEvent
+}
+
+func (r *fakeRecorder) Emit(object runtime.Object, eventType k8sevent.Type, reason k8sevent.Reason, message string) {
+	r.events = append(r.events, emittedEvent{object: object, eventType: eventType, reason: reason, message: message})
+}
+
+func (r *fakeRecorder) Emitf(object runtime.Object, eventType k8sevent.Type, reason k8sevent.Reason, format string, args ...interface{}) {
+	r.Emit(object, eventType, reason, fmt.Sprintf(format, args...))
+}
+
+func TestEmitKubernetesEvent(t *testing.T) {
+	jenkins := v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+
+	t.Run("warning", func(t *testing.T) {
+		recorder := &fakeRecorder{}
+
+		emitKubernetesEvent(recorder, event.Event{
+			Jenkins: jenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelWarning,
+			Reason:  reason.NewPodRestart(reason.KubernetesSource, []string{"first", "second"}, "verbose"),
+		})
+
+		require.Len(t, recorder.events, 1)
+		assert.Equal(t, k8sevent.TypeWarning, recorder.events[0].eventType)
+		assert.Equal(t, k8sevent.Reason("PodRestart"), recorder.events[0].reason)
+		assert.Equal(t, "[base] Jenkins master pod restarted by kubernetes:; first; second", recorder.events[0].message)
+		assert.Equal(t, "jenkins", recorder.events[0].object.(*v1alpha2.Jenkins).Name)
+	})
+	t.Run("info", func(t *testing.T) {
+		recorder := &fakeRecorder{}
+
+		emitKubernetesEvent(recorder, event.Event{
+			Jenkins: jenkins,
+			Phase:   event.PhaseUser,
+			Level:   v1alpha2.NotificationLevelInfo,
+			Reason:  reason.NewUserConfigurationComplete(reason.OperatorSource, []string{"done"}),
+		})
+
+		require.Len(t, recorder.events, 1)
+		assert.Equal(t, k8sevent.TypeNormal, recorder.events[0].eventType)
+		assert.Equal(t, k8sevent.Reason("UserConfigurationComplete"), recorder.events[0].reason)
+		assert.Equal(t, "[user] done", recorder.events[0].message)
+	})
+}
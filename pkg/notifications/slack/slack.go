@@ -72,14 +72,8 @@ func (s Slack) getStatusColor(logLevel v1alpha2.NotificationLevel) event.StatusC
 
 func (s Slack) generateMessage(e event.Event) Message {
 	var messageStringBuilder strings.Builder
-	if s.config.Verbose {
-		for _, msg := range e.Reason.Verbose() {
-			messageStringBuilder.WriteString("\n - " + msg + "\n")
-		}
-	} else {
-		for _, msg := range e.Reason.Short() {
-			messageStringBuilder.WriteString("\n - " + msg + "\n")
-		}
+	for _, msg := range provider.Messages(s.config, e) {
+		messageStringBuilder.WriteString("\n - " + msg + "\n")
 	}
 
 	sm := Message{
@@ -62,11 +62,7 @@ func (s SMTP) generateMessage(e event.Event) *gomail.Message {
 	var statusMessage strings.Builder
 	var reasons string
 
-	if s.config.Verbose {
-		reasons = strings.TrimRight(strings.Join(e.Reason.Verbose(), "</li><li>"), "<li>")
-	} else {
-		reasons = strings.TrimRight(strings.Join(e.Reason.Short(), "</li><li>"), "<li>")
-	}
+	reasons = strings.TrimRight(strings.Join(provider.Messages(s.config, e), "</li><li>"), "<li>")
 
 	statusMessage.WriteString("<ul><li>")
 	statusMessage.WriteString(reasons)
@@ -76,20 +72,49 @@ func (s SMTP) generateMessage(e event.Event) *gomail.Message {
 	message := gomail.NewMessage()
 
 	message.SetHeader("From", s.config.SMTP.From)
-	message.SetHeader("To", s.config.SMTP.To)
+	message.SetHeader("To", s.getRecipients(e.Level)...)
 	message.SetHeader("Subject", mailSubject)
 	message.SetBody("text/html", htmlMessage)
 
 	return message
 }
 
+// getRecipients returns recipients of the event with the given level
+func (s SMTP) getRecipients(logLevel v1alpha2.NotificationLevel) []string {
+	to := s.config.SMTP.To
+	switch {
+	case logLevel == v1alpha2.NotificationLevelInfo && len(s.config.SMTP.InfoTo) > 0:
+		to = s.config.SMTP.InfoTo
+	case logLevel == v1alpha2.NotificationLevelWarning && len(s.config.SMTP.WarningTo) > 0:
+		to = s.config.SMTP.WarningTo
+	}
+
+	var recipients []string
+	for _, recipient := range strings.Split(to, ",") {
+		if recipient = strings.TrimSpace(recipient); len(recipient) > 0 {
+			recipients = append(recipients, recipient)
+		}
+	}
+
+	return recipients
+}
+
 // Send is function for sending notification by SMTP server.
 func (s SMTP) Send(e event.Event) error {
-	usernameSecret := &corev1.Secret{}
-	passwordSecret := &corev1.Secret{}
+	if len(s.getRecipients(e.Level)) == 0 {
+		return errors.Errorf("SMTP has no recipients of %s events", e.Level)
+	}
 
 	usernameSelector := s.config.SMTP.UsernameSecretKeySelector
 	passwordSelector := s.config.SMTP.PasswordSecretKeySelector
+	if usernameSelector.Name == "" {
+		mailer := &gomail.Dialer{Host: s.config.SMTP.Server, Port: s.config.SMTP.Port, SSL: s.config.SMTP.SSL}
+		mailer.TLSConfig = &tls.Config{InsecureSkipVerify: s.config.SMTP.TLSInsecureSkipVerify, ServerName: s.config.SMTP.Server}
+		return mailer.DialAndSend(s.generateMessage(e))
+	}
+
+	usernameSecret := &corev1.Secret{}
+	passwordSecret := &corev1.Secret{}
 
 	err := s.k8sClient.Get(context.TODO(), types.NamespacedName{Name: usernameSelector.Name, Namespace: e.Jenkins.Namespace}, usernameSecret)
 	if err != nil {
@@ -112,7 +137,8 @@ func (s SMTP) Send(e event.Event) error {
 	}
 
 	mailer := gomail.NewDialer(s.config.SMTP.Server, s.config.SMTP.Port, usernameSecretValue, passwordSecretValue)
-	mailer.TLSConfig = &tls.Config{InsecureSkipVerify: s.config.SMTP.TLSInsecureSkipVerify}
+	mailer.SSL = s.config.SMTP.SSL
+	mailer.TLSConfig = &tls.Config{InsecureSkipVerify: s.config.SMTP.TLSInsecureSkipVerify, ServerName: s.config.SMTP.Server}
 
 	message := s.generateMessage(e)
 	if err := mailer.DialAndSend(message); err != nil {
@@ -310,3 +310,23 @@ func TestGenerateMessage(t *testing.T) {
 		assert.NotNil(t, message)
 	})
 }
+
+func TestGetRecipients(t *testing.T) {
+	s := SMTP{
+		config: v1alpha2.Notification{
+			SMTP: &v1alpha2.SMTP{
+				To:        "to@jenkins.local, other@jenkins.local",
+				WarningTo: "oncall@jenkins.local",
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"to@jenkins.local", "other@jenkins.local"}, s.getRecipients(v1alpha2.NotificationLevelInfo))
+	assert.Equal(t, []string{"oncall@jenkins.local"}, s.getRecipients(v1alpha2.NotificationLevelWarning))
+
+	s.config.SMTP.InfoTo = "info@jenkins.local,"
+	assert.Equal(t, []string{"info@jenkins.local"}, s.getRecipients(v1alpha2.NotificationLevelInfo))
+
+	s.config.SMTP.To, s.config.SMTP.WarningTo = "", ""
+	assert.Empty(t, s.getRecipients(v1alpha2.NotificationLevelWarning))
+}
@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Webhook is a generic outgoing webhook notification service.
+type Webhook struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of Webhook.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *Webhook {
+	return &Webhook{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Message is representation of json message.
+type Message struct {
+	Title     string                     `json:"title"`
+	Level     v1alpha2.NotificationLevel `json:"level"`
+	Phase     event.Phase                `json:"phase"`
+	Namespace string                     `json:"namespace"`
+	CrName    string                     `json:"crName"`
+	Reason    string                     `json:"reason"`
+	Messages  []string                   `json:"messages"`
+}
+
+func (w Webhook) generateMessage(e event.Event) Message {
+	messages := provider.Messages(w.config, e)
+
+	return Message{
+		Title:     provider.NotificationTitle(e),
+		Level:     e.Level,
+		Phase:     e.Phase,
+		Namespace: e.Jenkins.Namespace,
+		CrName:    e.Jenkins.Name,
+		Reason:    reflect.Indirect(reflect.ValueOf(e.Reason)).Type().Name(),
+		Messages:  messages,
+	}
+}
+
+func (w Webhook) getToken(e event.Event) (string, error) {
+	selector := w.config.Webhook.TokenSecretKeySelector
+	if selector == nil {
+		return "", nil
+	}
+
+	secret := &corev1.Secret{}
+	err := w.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return "", err
+	}
+
+	token := string(secret.Data[selector.Key])
+	if token == "" {
+		return "", errors.Errorf("Webhook token is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	return token, nil
+}
+
+// Send is function for sending directly to API.
+func (w Webhook) Send(e event.Event) error {
+	token, err := w.getToken(e)
+	if err != nil {
+		return err
+	}
+
+	message, err := json.Marshal(w.generateMessage(e))
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", w.config.Webhook.URL, bytes.NewBuffer(message))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range w.config.Webhook.Headers {
+		request.Header.Set(name, value)
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := w.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("Webhook '%s' responded with invalid status code %d", w.config.Webhook.URL, resp.StatusCode)
+	}
+
+	return nil
+}
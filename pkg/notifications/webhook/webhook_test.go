@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testPhase     = event.PhaseUser
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testReason    = reason.NewPodRestart(
+		reason.KubernetesSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	)
+	testLevel = v1alpha2.NotificationLevelWarning
+)
+
+func TestWebhook_Send(t *testing.T) {
+	fakeClient := fake.NewFakeClient()
+	testTokenSelectorKeyName := "test-token-selector"
+	testSecretName := "test-secret"
+	testToken := "test-token"
+
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer "+testToken, r.Header.Get("Authorization"))
+		assert.Equal(t, "jenkins", r.Header.Get("X-Source"))
+
+		var message Message
+		err := json.NewDecoder(r.Body).Decode(&message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Message{
+			Title:     provider.NotificationTitle(e),
+			Level:     testLevel,
+			Phase:     testPhase,
+			Namespace: testNamespace,
+			CrName:    testCrName,
+			Reason:    "PodRestart",
+			Messages:  testReason.Short(),
+		}, message)
+	}))
+	defer server.Close()
+
+	webhook := Webhook{k8sClient: fakeClient, config: v1alpha2.Notification{
+		Webhook: &v1alpha2.Webhook{
+			URL:     server.URL,
+			Headers: map[string]string{"X-Source": "jenkins"},
+			TokenSecretKeySelector: &v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: testSecretName,
+				},
+				Key: testTokenSelectorKeyName,
+			},
+		},
+	}}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecretName,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			testTokenSelectorKeyName: []byte(testToken),
+		},
+	}
+
+	err := fakeClient.Create(context.TODO(), secret)
+	require.NoError(t, err)
+
+	err = webhook.Send(e)
+	assert.NoError(t, err)
+}
+
+func TestWebhook_Send_InvalidStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook := New(fake.NewFakeClient(), v1alpha2.Notification{Webhook: &v1alpha2.Webhook{URL: server.URL}}, http.Client{})
+
+	err := webhook.Send(event.Event{Level: testLevel, Phase: testPhase, Reason: testReason})
+
+	assert.EqualError(t, err, "Webhook '"+server.URL+"' responded with invalid status code 500")
+}
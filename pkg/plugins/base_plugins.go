@@ -1,11 +1,35 @@
 package plugins
 
 const (
+	// BitbucketBranchSourcePluginName is the name of plugin required by seed jobs with Bitbucket credentials
+	BitbucketBranchSourcePluginName = "cloudbees-bitbucket-branch-source"
+
+	// GitHubBranchSourcePluginName is the name of plugin required by seed jobs with GitHub App credentials
+	GitHubBranchSourcePluginName = "github-branch-source"
+
+	// PrometheusPluginName is the name of plugin required by monitoring of Jenkins
+	PrometheusPluginName = "prometheus"
+
+	// LDAPPluginName is the name of plugin required by the LDAP security realm
+	LDAPPluginName = "ldap"
+
+	// OIDCPluginName is the name of plugin required by the OpenID Connect security realm
+	OIDCPluginName = "oic-auth"
+
+	// RoleStrategyPluginName is the name of plugin required by the role-based authorization strategy
+	RoleStrategyPluginName = "role-strategy"
+
+	bitbucketBranchSourcePlugin         = BitbucketBranchSourcePluginName + ":2.7.0"
+	gitHubBranchSourcePlugin            = GitHubBranchSourcePluginName + ":2.7.1"
 	configurationAsCodePlugin           = "configuration-as-code:1.38"
 	gitPlugin                           = "git:4.2.2"
 	jobDslPlugin                        = "job-dsl:1.77"
 	kubernetesCredentialsProviderPlugin = "kubernetes-credentials-provider:0.13"
 	kubernetesPlugin                    = "kubernetes:1.25.2"
+	ldapPlugin                          = LDAPPluginName + ":1.24"
+	oidcPlugin                          = OIDCPluginName + ":1.8"
+	prometheusPlugin                    = PrometheusPluginName + ":2.0.7"
+	roleStrategyPlugin                  = RoleStrategyPluginName + ":3.1"
 	workflowAggregatorPlugin            = "workflow-aggregator:2.6"
 	workflowJobPlugin                   = "workflow-job:2.39"
 )
@@ -25,3 +49,33 @@ var basePluginsList = []Plugin{
 func BasePlugins() []Plugin {
 	return basePluginsList
 }
+
+// BitbucketBranchSourcePlugin returns plugin installed by operator when seed jobs use Bitbucket credentials.
+func BitbucketBranchSourcePlugin() Plugin {
+	return Must(New(bitbucketBranchSourcePlugin))
+}
+
+// GitHubBranchSourcePlugin returns plugin installed by operator when seed jobs use GitHub App credentials.
+func GitHubBranchSourcePlugin() Plugin {
+	return Must(New(gitHubBranchSourcePlugin))
+}
+
+// PrometheusPlugin returns plugin installed by operator when monitoring of Jenkins is enabled.
+func PrometheusPlugin() Plugin {
+	return Must(New(prometheusPlugin))
+}
+
+// LDAPPlugin returns plugin installed by operator when the LDAP security realm is configured.
+func LDAPPlugin() Plugin {
+	return Must(New(ldapPlugin))
+}
+
+// OIDCPlugin returns plugin installed by operator when the OpenID Connect security realm is configured.
+func OIDCPlugin() Plugin {
+	return Must(New(oidcPlugin))
+}
+
+// RoleStrategyPlugin returns plugin installed by operator when the role-based authorization strategy is configured.
+func RoleStrategyPlugin() Plugin {
+	return Must(New(roleStrategyPlugin))
+}
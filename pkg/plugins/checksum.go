@@ -0,0 +1,32 @@
+package plugins
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// NormalizeSHA256 converts hex or base64 (used by the update center) encoded SHA-256 checksum to lowercase hex.
+func NormalizeSHA256(checksum string) (string, error) {
+	if decoded, err := hex.DecodeString(checksum); err == nil && len(decoded) == sha256.Size {
+		return strings.ToLower(checksum), nil
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(checksum); err == nil && len(decoded) == sha256.Size {
+		return hex.EncodeToString(decoded), nil
+	}
+
+	return "", errors.Errorf("'%s' isn't a hex or base64 encoded SHA-256 checksum", checksum)
+}
+
+// Checksum returns SHA-256 checksum of the plugin version published in the update center.
+func (u *UpdateCenter) Checksum(name, version string) (string, bool) {
+	pluginVersion, ok := u.Plugins[name][version]
+	if !ok || len(pluginVersion.SHA256) == 0 {
+		return "", false
+	}
+
+	return pluginVersion.SHA256, true
+}
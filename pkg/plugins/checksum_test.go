@@ -0,0 +1,47 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSHA256(t *testing.T) {
+	const hexChecksum = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	t.Run("hex", func(t *testing.T) {
+		got, err := NormalizeSHA256("9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08")
+
+		require.NoError(t, err)
+		assert.Equal(t, hexChecksum, got)
+	})
+	t.Run("base64", func(t *testing.T) {
+		got, err := NormalizeSHA256("n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=")
+
+		require.NoError(t, err)
+		assert.Equal(t, hexChecksum, got)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NormalizeSHA256("abc")
+
+		assert.EqualError(t, err, "'abc' isn't a hex or base64 encoded SHA-256 checksum")
+	})
+}
+
+func TestUpdateCenter_Checksum(t *testing.T) {
+	updateCenter := &UpdateCenter{Plugins: map[string]map[string]PluginVersion{
+		"git": {
+			"4.2.2": {Version: "4.2.2", SHA256: "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="},
+			"4.3.0": {Version: "4.3.0"},
+		},
+	}}
+
+	checksum, ok := updateCenter.Checksum("git", "4.2.2")
+	assert.True(t, ok)
+	assert.Equal(t, "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=", checksum)
+	_, ok = updateCenter.Checksum("git", "4.3.0")
+	assert.False(t, ok)
+	_, ok = updateCenter.Checksum("job-dsl", "1.77")
+	assert.False(t, ok)
+}
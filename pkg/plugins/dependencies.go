@@ -0,0 +1,201 @@
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultUpdateCenterURL is the URL of the official Jenkins update center
+	DefaultUpdateCenterURL = "https://updates.jenkins.io"
+	// PluginVersionsPath is the path of the update center metadata which contains dependencies of all plugin versions
+	PluginVersionsPath = "/current/plugin-versions.json"
+	// DefaultPluginVersionsURL is the URL of the update center metadata which contains dependencies of all plugin versions
+	DefaultPluginVersionsURL = DefaultUpdateCenterURL + PluginVersionsPath
+
+	updateCenterCacheTTL = time.Hour
+	updateCenterTimeout  = 2 * time.Minute
+)
+
+// UpdateCenter contains metadata of all plugin versions published in the update center.
+type UpdateCenter struct {
+	// Plugins key - plugin name, value - plugin versions
+	Plugins map[string]map[string]PluginVersion `json:"plugins"`
+}
+
+// PluginVersion represents metadata of single plugin version.
+type PluginVersion struct {
+	Version      string       `json:"version"`
+	URL          string       `json:"url"`
+	SHA256       string       `json:"sha256"`
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// Dependency represents plugin dependency.
+type Dependency struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Optional bool   `json:"optional"`
+}
+
+type cachedUpdateCenter struct {
+	updateCenter *UpdateCenter
+	fetchTime    time.Time
+}
+
+var updateCenterCache = struct {
+	sync.Mutex
+	entries map[string]cachedUpdateCenter
+}{entries: map[string]cachedUpdateCenter{}}
+
+// FetchUpdateCenter downloads update center metadata, the result is cached for an hour.
+func FetchUpdateCenter(url string, proxy client.ProxySettings) (*UpdateCenter, error) {
+	updateCenterCache.Lock()
+	defer updateCenterCache.Unlock()
+
+	if cached, ok := updateCenterCache.entries[url]; ok && time.Since(cached.fetchTime) < updateCenterCacheTTL {
+		return cached.updateCenter, nil
+	}
+
+	httpClient := newHTTPClient(proxy)
+	defer httpClient.CloseIdleConnections()
+	response, err := httpClient.Get(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't download update center metadata '%s'", url)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("couldn't download update center metadata '%s', invalid status code %d", url, response.StatusCode)
+	}
+
+	updateCenter := &UpdateCenter{}
+	if err := json.NewDecoder(response.Body).Decode(updateCenter); err != nil {
+		return nil, errors.Wrapf(err, "couldn't decode update center metadata '%s'", url)
+	}
+	updateCenterCache.entries[url] = cachedUpdateCenter{updateCenter: updateCenter, fetchTime: time.Now()}
+
+	return updateCenter, nil
+}
+
+// newHTTPClient returns the client of the update center which sends requests through the proxy
+func newHTTPClient(proxy client.ProxySettings) *http.Client {
+	return &http.Client{Timeout: updateCenterTimeout, Transport: proxy.NewHTTPTransport()}
+}
+
+// ResolveDependencies resolves transitive dependencies of the requested plugins. It returns dependencies which aren't
+// requested explicitly, pinned to the lowest versions satisfying all plugins, and messages describing conflicts.
+// Plugins with custom download URL or not pinned latest version are skipped because their dependencies are unknown.
+func (u *UpdateCenter) ResolveDependencies(requested ...[]Plugin) ([]Plugin, []string) {
+	var messages []string
+	requestedPlugins := map[string]Plugin{}
+	var queue []Plugin
+	for _, plugins := range requested {
+		for _, plugin := range plugins {
+			if _, ok := requestedPlugins[plugin.Name]; ok {
+				continue
+			}
+			requestedPlugins[plugin.Name] = plugin
+			queue = append(queue, plugin)
+		}
+	}
+
+	resolved := map[string]string{}
+	for len(queue) > 0 {
+		plugin := queue[0]
+		queue = queue[1:]
+		if len(plugin.DownloadURL) > 0 || plugin.Version == LatestVersion {
+			continue
+		}
+
+		pluginVersion, ok := u.Plugins[plugin.Name][plugin.Version]
+		if !ok {
+			messages = append(messages, fmt.Sprintf("Plugin '%s' not found in update center", plugin))
+			continue
+		}
+
+		for _, dependency := range pluginVersion.Dependencies {
+			if dependency.Optional {
+				continue
+			}
+			if requestedPlugin, ok := requestedPlugins[dependency.Name]; ok {
+				if CompareVersions(requestedPlugin.Version, dependency.Version) < 0 {
+					messages = append(messages, fmt.Sprintf("Plugin '%s' requires '%s' in version '%s' or newer but '%s' is requested",
+						plugin, dependency.Name, dependency.Version, requestedPlugin))
+				}
+				continue
+			}
+			if version, ok := resolved[dependency.Name]; ok && CompareVersions(version, dependency.Version) >= 0 {
+				continue
+			}
+			resolved[dependency.Name] = dependency.Version
+			queue = append(queue, Plugin{Name: dependency.Name, Version: dependency.Version})
+		}
+	}
+
+	var dependencies []Plugin
+	for name, version := range resolved {
+		dependencies = append(dependencies, Plugin{Name: name, Version: version})
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		return dependencies[i].Name < dependencies[j].Name
+	})
+
+	return dependencies, messages
+}
+
+// CompareVersions compares two plugin versions, it returns a negative number when first version is older than
+// the second one, zero when they're equal and a positive number otherwise.
+func CompareVersions(first, second string) int {
+	firstParts := splitVersion(first)
+	secondParts := splitVersion(second)
+	for i := 0; i < len(firstParts) && i < len(secondParts); i++ {
+		firstNumber, firstErr := strconv.Atoi(firstParts[i])
+		secondNumber, secondErr := strconv.Atoi(secondParts[i])
+		switch {
+		case firstErr == nil && secondErr == nil:
+			if firstNumber != secondNumber {
+				return firstNumber - secondNumber
+			}
+		case firstErr == nil:
+			return 1
+		case secondErr == nil:
+			return -1
+		default:
+			if result := strings.Compare(firstParts[i], secondParts[i]); result != 0 {
+				return result
+			}
+		}
+	}
+
+	// qualifiers like 1.0-beta-1 precede the release 1.0
+	switch {
+	case len(firstParts) > len(secondParts):
+		if _, err := strconv.Atoi(firstParts[len(secondParts)]); err != nil {
+			return -1
+		}
+		return 1
+	case len(firstParts) < len(secondParts):
+		if _, err := strconv.Atoi(secondParts[len(firstParts)]); err != nil {
+			return 1
+		}
+		return -1
+	}
+
+	return 0
+}
+
+func splitVersion(version string) []string {
+	return strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '-' || r == '+'
+	})
+}
@@ -0,0 +1,127 @@
+package plugins
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		first, second string
+		want          int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.2", "1.10", -1},
+		{"2.0", "1.99.1", 1},
+		{"1.0.1", "1.0", 1},
+		{"1.0-beta-1", "1.0", -1},
+		{"1.0", "1.0-beta-1", 1},
+		{"3.0-rc1", "3.0-rc2", -1},
+		{"1.25.2", "1.25.10", -1},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s", test.first, test.second), func(t *testing.T) {
+			got := CompareVersions(test.first, test.second)
+			switch {
+			case test.want < 0:
+				assert.True(t, got < 0)
+			case test.want > 0:
+				assert.True(t, got > 0)
+			default:
+				assert.Equal(t, 0, got)
+			}
+		})
+	}
+}
+
+func TestUpdateCenter_ResolveDependencies(t *testing.T) {
+	updateCenter := &UpdateCenter{
+		Plugins: map[string]map[string]PluginVersion{
+			"git": {
+				"4.2.2": {Version: "4.2.2", Dependencies: []Dependency{
+					{Name: "git-client", Version: "3.2.1"},
+					{Name: "scm-api", Version: "2.6.3"},
+					{Name: "credentials", Version: "2.3.0", Optional: true},
+				}},
+			},
+			"git-client": {
+				"3.2.1": {Version: "3.2.1", Dependencies: []Dependency{
+					{Name: "scm-api", Version: "2.2.0"},
+					{Name: "structs", Version: "1.20"},
+				}},
+			},
+			"workflow-job": {
+				"2.39": {Version: "2.39", Dependencies: []Dependency{
+					{Name: "scm-api", Version: "2.6.3"},
+					{Name: "structs", Version: "1.19"},
+				}},
+			},
+			"scm-api": {
+				"2.6.3": {Version: "2.6.3", Dependencies: []Dependency{{Name: "structs", Version: "1.20"}}},
+				"2.2.0": {Version: "2.2.0"},
+			},
+			"structs": {
+				"1.20": {Version: "1.20"},
+				"1.19": {Version: "1.19"},
+			},
+		},
+	}
+
+	t.Run("transitive dependencies", func(t *testing.T) {
+		dependencies, messages := updateCenter.ResolveDependencies(
+			[]Plugin{Must(New("git:4.2.2"))},
+			[]Plugin{Must(New("workflow-job:2.39"))},
+		)
+
+		assert.Nil(t, messages)
+		assert.Equal(t, []Plugin{
+			{Name: "git-client", Version: "3.2.1"},
+			{Name: "scm-api", Version: "2.6.3"},
+			{Name: "structs", Version: "1.20"},
+		}, dependencies)
+	})
+	t.Run("requested dependency is too old", func(t *testing.T) {
+		dependencies, messages := updateCenter.ResolveDependencies(
+			[]Plugin{Must(New("git:4.2.2")), Must(New("scm-api:2.2.0"))},
+		)
+
+		assert.Equal(t, []string{"Plugin 'git:4.2.2' requires 'scm-api' in version '2.6.3' or newer but 'scm-api:2.2.0' is requested"}, messages)
+		assert.Equal(t, []Plugin{{Name: "git-client", Version: "3.2.1"}, {Name: "structs", Version: "1.20"}}, dependencies)
+	})
+	t.Run("unknown plugin", func(t *testing.T) {
+		dependencies, messages := updateCenter.ResolveDependencies([]Plugin{Must(New("unknown:1.0"))})
+
+		assert.Equal(t, []string{"Plugin 'unknown:1.0' not found in update center"}, messages)
+		assert.Nil(t, dependencies)
+	})
+	t.Run("plugin with download URL is skipped", func(t *testing.T) {
+		dependencies, messages := updateCenter.ResolveDependencies([]Plugin{Must(NewPlugin("custom", "1.0", "https://example.com/custom.hpi"))})
+
+		assert.Nil(t, messages)
+		assert.Nil(t, dependencies)
+	})
+}
+
+func TestFetchUpdateCenter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"plugins": {"git": {"4.2.2": {"version": "4.2.2", "dependencies": [{"name": "scm-api", "version": "2.6.3", "optional": false}]}}}}`))
+	}))
+	defer server.Close()
+
+	updateCenter, err := FetchUpdateCenter(server.URL, client.ProxySettings{})
+	require.NoError(t, err)
+	_, err = FetchUpdateCenter(server.URL, client.ProxySettings{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, []Dependency{{Name: "scm-api", Version: "2.6.3"}}, updateCenter.Plugins["git"]["4.2.2"].Dependencies)
+}
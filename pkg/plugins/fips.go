@@ -0,0 +1,16 @@
+package plugins
+
+// fipsNonCompliantPlugins contains plugins known to implement cryptography by themselves instead of using the FIPS
+// validated security provider of the JVM, with the reason
+var fipsNonCompliantPlugins = map[string]string{
+	"bouncycastle-api": "it registers the Bouncy Castle provider which isn't FIPS validated",
+	"jsch":             "it bundles the JSch SSH implementation with its own cryptography",
+	"ssh-slaves":       "it connects agents with the Trilead SSH implementation with its own cryptography",
+	"trilead-api":      "it bundles the Trilead SSH implementation with its own cryptography",
+}
+
+// FIPSNonCompliantReason returns why the plugin isn't FIPS compliant, it's empty when the plugin isn't known
+// to be non-compliant
+func FIPSNonCompliantReason(name string) string {
+	return fipsNonCompliantPlugins[name]
+}
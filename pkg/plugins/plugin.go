@@ -20,6 +20,9 @@ func (p Plugin) String() string {
 	return fmt.Sprintf("%s:%s", p.Name, p.Version)
 }
 
+// LatestVersion is the plugin version resolved by the operator to the latest version published in the update center
+const LatestVersion = "latest"
+
 var (
 	// NamePattern is the plugin name regex pattern
 	NamePattern = regexp.MustCompile(`^[0-9a-zA-Z-_]+$`)
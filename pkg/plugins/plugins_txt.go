@@ -0,0 +1,40 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParsePluginsTxt parses plugins.txt file in the jenkins-plugin-cli format, every line contains plugin name, version
+// and optional download URL separated by colons, e.g. "git:4.2.2". Plugins without version have the latest version.
+// It returns valid plugins and messages describing invalid lines.
+func ParsePluginsTxt(content string) ([]Plugin, []string) {
+	var plugins []Plugin
+	var messages []string
+	for index, line := range strings.Split(content, "\n") {
+		if commentIndex := strings.Index(line, "#"); commentIndex >= 0 {
+			line = line[:commentIndex]
+		}
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		values := strings.SplitN(line, ":", 3)
+		if len(values) < 2 || len(values[1]) == 0 {
+			values = append(values[:1], LatestVersion)
+		}
+		var downloadURL string
+		if len(values) == 3 {
+			downloadURL = values[2]
+		}
+		plugin, err := NewPlugin(values[0], values[1], downloadURL)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("line %d: %s", index+1, err))
+			continue
+		}
+		plugins = append(plugins, *plugin)
+	}
+
+	return plugins, messages
+}
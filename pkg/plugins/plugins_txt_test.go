@@ -0,0 +1,47 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePluginsTxt(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		content := `# plugins required by the team
+git:4.2.2
+  job-dsl:1.77   # trailing comment
+
+custom:1.0:https://artifacts.example.com/custom.hpi
+`
+
+		plugins, messages := ParsePluginsTxt(content)
+
+		assert.Nil(t, messages)
+		assert.Equal(t, []Plugin{
+			{Name: "git", Version: "4.2.2"},
+			{Name: "job-dsl", Version: "1.77"},
+			{Name: "custom", Version: "1.0", DownloadURL: "https://artifacts.example.com/custom.hpi"},
+		}, plugins)
+	})
+	t.Run("invalid lines", func(t *testing.T) {
+		content := "invalid!:1.0\nkubernetes:1.25.2"
+
+		plugins, messages := ParsePluginsTxt(content)
+
+		assert.Equal(t, []string{
+			"line 1: invalid plugin name 'invalid!:1.0', must follow pattern '^[0-9a-zA-Z-_]+$'",
+		}, messages)
+		assert.Equal(t, []Plugin{{Name: "kubernetes", Version: "1.25.2"}}, plugins)
+	})
+	t.Run("latest version", func(t *testing.T) {
+		plugins, messages := ParsePluginsTxt("git\nworkflow-job:\njob-dsl:latest")
+
+		assert.Nil(t, messages)
+		assert.Equal(t, []Plugin{
+			{Name: "git", Version: LatestVersion},
+			{Name: "workflow-job", Version: LatestVersion},
+			{Name: "job-dsl", Version: LatestVersion},
+		}, plugins)
+	})
+}
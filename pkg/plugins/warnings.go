@@ -0,0 +1,134 @@
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// UpdateCenterJSONPath is the path of the update center metadata which contains security warnings
+	UpdateCenterJSONPath = "/update-center.actual.json"
+	// StableUpdateCenterPath is the path of the update center of the LTS release line of Jenkins core
+	StableUpdateCenterPath = "/stable"
+
+	securityWarningPluginType = "plugin"
+)
+
+// SecurityWarning represents security warning published in the update center.
+type SecurityWarning struct {
+	ID       string                   `json:"id"`
+	Message  string                   `json:"message"`
+	Name     string                   `json:"name"`
+	Type     string                   `json:"type"`
+	URL      string                   `json:"url"`
+	Versions []SecurityWarningVersion `json:"versions"`
+}
+
+// SecurityWarningVersion represents range of versions affected by security warning.
+type SecurityWarningVersion struct {
+	LastVersion string `json:"lastVersion"`
+	Pattern     string `json:"pattern"`
+}
+
+func (w SecurityWarning) String() string {
+	return fmt.Sprintf("%s %s", w.ID, w.URL)
+}
+
+// updateCenterJSON contains the update center metadata used by the operator
+type updateCenterJSON struct {
+	Core struct {
+		Version string `json:"version"`
+	} `json:"core"`
+	Plugins map[string]struct {
+		Version string `json:"version"`
+	} `json:"plugins"`
+	Warnings []SecurityWarning `json:"warnings"`
+}
+
+func fetchUpdateCenterJSON(url string, proxy client.ProxySettings) (*updateCenterJSON, error) {
+	httpClient := newHTTPClient(proxy)
+	defer httpClient.CloseIdleConnections()
+	response, err := httpClient.Get(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't download update center metadata '%s'", url)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("couldn't download update center metadata '%s', invalid status code %d", url, response.StatusCode)
+	}
+
+	updateCenter := &updateCenterJSON{}
+	if err := json.NewDecoder(response.Body).Decode(updateCenter); err != nil {
+		return nil, errors.Wrapf(err, "couldn't decode update center metadata '%s'", url)
+	}
+
+	return updateCenter, nil
+}
+
+// FetchSecurityWarnings downloads security warnings from the update center metadata.
+func FetchSecurityWarnings(url string, proxy client.ProxySettings) ([]SecurityWarning, error) {
+	updateCenter, err := fetchUpdateCenterJSON(url, proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return updateCenter.Warnings, nil
+}
+
+// FetchLatestVersions downloads the latest versions of plugins from the update center metadata,
+// key - plugin name, value - version.
+func FetchLatestVersions(url string, proxy client.ProxySettings) (map[string]string, error) {
+	updateCenter, err := fetchUpdateCenterJSON(url, proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string]string{}
+	for name, plugin := range updateCenter.Plugins {
+		versions[name] = plugin.Version
+	}
+
+	return versions, nil
+}
+
+// FetchLatestCoreVersion downloads the latest version of Jenkins core from the update center metadata.
+func FetchLatestCoreVersion(url string, proxy client.ProxySettings) (string, error) {
+	updateCenter, err := fetchUpdateCenterJSON(url, proxy)
+	if err != nil {
+		return "", err
+	}
+	if len(updateCenter.Core.Version) == 0 {
+		return "", errors.Errorf("update center metadata '%s' doesn't contain the version of Jenkins core", url)
+	}
+
+	return updateCenter.Core.Version, nil
+}
+
+// Affects returns true when the plugin version is affected by the security warning.
+func (w SecurityWarning) Affects(plugin Plugin) bool {
+	if w.Type != securityWarningPluginType || w.Name != plugin.Name {
+		return false
+	}
+
+	for _, version := range w.Versions {
+		// patterns are Java regular expressions which have to match the whole version
+		pattern, err := regexp.Compile("^(?:" + version.Pattern + ")$")
+		if err != nil {
+			if len(version.LastVersion) > 0 && CompareVersions(plugin.Version, version.LastVersion) <= 0 {
+				return true
+			}
+			continue
+		}
+		if pattern.MatchString(plugin.Version) {
+			return true
+		}
+	}
+
+	return false
+}
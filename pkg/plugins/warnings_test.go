@@ -0,0 +1,86 @@
+package plugins
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityWarning_Affects(t *testing.T) {
+	warning := SecurityWarning{
+		ID:   "SECURITY-1234",
+		Name: "git",
+		Type: "plugin",
+		Versions: []SecurityWarningVersion{
+			{LastVersion: "4.2.2", Pattern: `4[.][0-2]([.-].*|)`},
+		},
+	}
+
+	assert.True(t, warning.Affects(Plugin{Name: "git", Version: "4.2.2"}))
+	assert.True(t, warning.Affects(Plugin{Name: "git", Version: "4.0"}))
+	assert.False(t, warning.Affects(Plugin{Name: "git", Version: "4.3.0"}))
+	assert.False(t, warning.Affects(Plugin{Name: "git", Version: "14.2"}))
+	assert.False(t, warning.Affects(Plugin{Name: "git-client", Version: "4.2.2"}))
+	t.Run("core warning", func(t *testing.T) {
+		warning := SecurityWarning{Name: "core", Type: "core", Versions: []SecurityWarningVersion{{Pattern: ".*"}}}
+
+		assert.False(t, warning.Affects(Plugin{Name: "core", Version: "2.222"}))
+	})
+	t.Run("pattern not supported by Go", func(t *testing.T) {
+		warning := SecurityWarning{Name: "git", Type: "plugin", Versions: []SecurityWarningVersion{{LastVersion: "4.2.2", Pattern: `4(?!5)`}}}
+
+		assert.True(t, warning.Affects(Plugin{Name: "git", Version: "4.1"}))
+		assert.False(t, warning.Affects(Plugin{Name: "git", Version: "4.3"}))
+	})
+}
+
+func TestFetchSecurityWarnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"plugins": {}, "warnings": [{"id": "SECURITY-1234", "message": "XSS vulnerability", "name": "git", "type": "plugin", "url": "https://jenkins.io/security/advisory/", "versions": [{"lastVersion": "4.2.2", "pattern": "4[.][0-2]"}]}]}`))
+	}))
+	defer server.Close()
+
+	warnings, err := FetchSecurityWarnings(server.URL, client.ProxySettings{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []SecurityWarning{{
+		ID:       "SECURITY-1234",
+		Message:  "XSS vulnerability",
+		Name:     "git",
+		Type:     "plugin",
+		URL:      "https://jenkins.io/security/advisory/",
+		Versions: []SecurityWarningVersion{{LastVersion: "4.2.2", Pattern: "4[.][0-2]"}},
+	}}, warnings)
+}
+
+func TestFetchLatestVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"plugins": {"git": {"version": "4.3.0"}, "job-dsl": {"version": "1.77"}}, "warnings": []}`))
+	}))
+	defer server.Close()
+
+	versions, err := FetchLatestVersions(server.URL, client.ProxySettings{})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"git": "4.3.0", "job-dsl": "1.77"}, versions)
+}
+
+func TestFetchLatestVersions_Proxy(t *testing.T) {
+	var requestedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURL = r.URL.String()
+		_, _ = w.Write([]byte(`{"plugins": {"git": {"version": "4.3.0"}}}`))
+	}))
+	defer proxy.Close()
+
+	versions, err := FetchLatestVersions("http://updates.example.com/update-center.actual.json", client.ProxySettings{HTTPProxy: proxy.URL})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"git": "4.3.0"}, versions)
+	assert.Equal(t, "http://updates.example.com/update-center.actual.json", requestedURL)
+}
@@ -0,0 +1,267 @@
+// Package supportbundle collects diagnostic data of the Jenkins CR into a single archive which can be attached to bug
+// reports: the CR with its status, resources generated by the operator, recent Kubernetes events, logs of the operator
+// and the system information and plugins of Jenkins.
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SecretKey is the key of the Secret data which holds the support bundle archive
+	SecretKey = "support-bundle.tar.gz"
+	// CollectedAtAnnotation is the annotation of the Secret with the time when the support bundle has been collected
+	CollectedAtAnnotation = "jenkins.io/support-bundle-collected-at"
+
+	redactedValue = "REDACTED"
+	fetchPlugins  = 1
+
+	systemInfoGroovyScript = `import jenkins.model.Jenkins
+
+def instance = Jenkins.get()
+def runtime = Runtime.getRuntime()
+println "Jenkins version: ${Jenkins.VERSION}"
+println "Java version: ${System.getProperty('java.version')} (${System.getProperty('java.vendor')})"
+println "OS: ${System.getProperty('os.name')} ${System.getProperty('os.version')} ${System.getProperty('os.arch')}"
+println "Available processors: ${runtime.availableProcessors()}"
+println "Memory: max ${runtime.maxMemory()}, total ${runtime.totalMemory()}, free ${runtime.freeMemory()}"
+println "Quieting down: ${instance.isQuietingDown()}"
+println "Nodes: ${instance.nodes.size()}"
+println "Executors: ${instance.numExecutors}"
+println "Queue size: ${instance.queue.items.size()}"
+`
+)
+
+// Sources are the sources of diagnostic data of the Jenkins CR
+type Sources struct {
+	Client k8s.Client
+	Events typedcorev1.EventsGetter
+	// JenkinsClient returns the client of running Jenkins, the system information and plugins are skipped when it fails
+	JenkinsClient func() (jenkinsclient.Jenkins, error)
+}
+
+// archive is the content of the support bundle, the failure of collecting single file is stored in errors.txt
+type archive struct {
+	files  map[string][]byte
+	errors []string
+}
+
+func (a *archive) add(name string, content []byte) {
+	a.files[name] = content
+}
+
+func (a *archive) addJSON(name string, object interface{}) {
+	content, err := json.MarshalIndent(object, "", "  ")
+	if err != nil {
+		a.failed(name, err)
+		return
+	}
+	a.add(name, content)
+}
+
+func (a *archive) failed(name string, err error) {
+	a.errors = append(a.errors, fmt.Sprintf("%s: %s", name, err))
+}
+
+// GetSecretName returns name of the Secret with the support bundle of the Jenkins CR
+func GetSecretName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-support-bundle-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// Collect collects diagnostic data of the Jenkins CR into the gzipped tar archive, data which can't be collected is
+// skipped and the error is stored in errors.txt of the archive
+func Collect(jenkins *v1alpha2.Jenkins, sources Sources) ([]byte, error) {
+	bundle := &archive{files: map[string][]byte{}}
+
+	bundle.addJSON("jenkins.json", jenkins)
+	collectResources(bundle, jenkins, sources.Client)
+	collectEvents(bundle, jenkins, sources.Events)
+	bundle.add("operator.log", []byte(strings.Join(log.CRLogs(jenkins.Name), "\n")+"\n"))
+	collectJenkins(bundle, sources.JenkinsClient)
+	if len(bundle.errors) > 0 {
+		bundle.add("errors.txt", []byte(strings.Join(bundle.errors, "\n")+"\n"))
+	}
+
+	return bundle.compress()
+}
+
+func collectResources(bundle *archive, jenkins *v1alpha2.Jenkins, k8sClient k8s.Client) {
+	lists := map[string]runtime.Object{
+		"configmaps": &corev1.ConfigMapList{},
+		"secrets":    &corev1.SecretList{},
+		"services":   &corev1.ServiceList{},
+		"pods":       &corev1.PodList{},
+	}
+	for kind, list := range lists {
+		name := fmt.Sprintf("resources/%s.json", kind)
+		err := k8sClient.List(context.TODO(), list, k8s.InNamespace(jenkins.Namespace), k8s.MatchingLabels(resources.BuildResourceLabels(jenkins)))
+		if err != nil {
+			bundle.failed(name, errors.WithStack(err))
+			continue
+		}
+		if secrets, ok := list.(*corev1.SecretList); ok {
+			redactSecrets(secrets)
+		}
+		bundle.addJSON(name, list)
+	}
+}
+
+// redactSecrets keeps only keys of the Secret data
+func redactSecrets(secrets *corev1.SecretList) {
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		redacted := map[string]string{}
+		for key := range secret.Data {
+			redacted[key] = redactedValue
+		}
+		for key := range secret.StringData {
+			redacted[key] = redactedValue
+		}
+		secret.Data = nil
+		secret.StringData = redacted
+		delete(secret.Annotations, corev1.LastAppliedConfigAnnotation)
+	}
+}
+
+// collectEvents collects events of the Jenkins CR and the Jenkins master pod
+func collectEvents(bundle *archive, jenkins *v1alpha2.Jenkins, events typedcorev1.EventsGetter) {
+	var collected []corev1.Event
+	for _, name := range []string{jenkins.Name, resources.GetJenkinsMasterPodName(jenkins)} {
+		list, err := events.Events(jenkins.Namespace).List(metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.name", name).String(),
+		})
+		if err != nil {
+			bundle.failed("events.json", errors.WithStack(err))
+			continue
+		}
+		collected = append(collected, list.Items...)
+	}
+	sort.SliceStable(collected, func(i, j int) bool {
+		return collected[i].LastTimestamp.Before(&collected[j].LastTimestamp)
+	})
+	bundle.addJSON("events.json", collected)
+}
+
+func collectJenkins(bundle *archive, getJenkinsClient func() (jenkinsclient.Jenkins, error)) {
+	if getJenkinsClient == nil {
+		return
+	}
+	jenkinsClient, err := getJenkinsClient()
+	if err != nil {
+		bundle.failed("jenkins", err)
+		return
+	}
+
+	systemInfo, err := jenkinsClient.ExecuteScript(systemInfoGroovyScript)
+	if err != nil {
+		bundle.failed("jenkins/system-info.txt", err)
+	} else {
+		bundle.add("jenkins/system-info.txt", []byte(systemInfo))
+	}
+
+	plugins, err := jenkinsClient.GetPlugins(fetchPlugins)
+	if err != nil {
+		bundle.failed("jenkins/plugins.txt", errors.WithStack(err))
+		return
+	}
+	var lines []string
+	for _, plugin := range plugins.Raw.Plugins {
+		line := fmt.Sprintf("%s:%s", plugin.ShortName, plugin.Version)
+		if !plugin.Active || !plugin.Enabled {
+			line += " (inactive)"
+		}
+		if plugin.HasUpdate {
+			line += " (update available)"
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	bundle.add("jenkins/plugins.txt", []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+func (a *archive) compress() ([]byte, error) {
+	names := make([]string, 0, len(a.files))
+	for name := range a.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	now := time.Now()
+	for _, name := range names {
+		content := a.files[name]
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), ModTime: now}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Store stores the support bundle in the Secret owned by the Jenkins CR, the previous support bundle is replaced
+func Store(k8sClient k8s.Client, jenkins *v1alpha2.Jenkins, bundle []byte) error {
+	annotations := map[string]string{CollectedAtAnnotation: time.Now().UTC().Format(time.RFC3339)}
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: GetSecretName(jenkins)}, secret)
+	if apierrors.IsNotFound(err) {
+		// the Secret is deleted with the Jenkins CR, but it isn't controlled by the operator, so its manual deletion
+		// isn't reported as drift
+		ownerReference := metav1.NewControllerRef(jenkins, v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.Kind))
+		ownerReference.Controller = nil
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            GetSecretName(jenkins),
+				Namespace:       jenkins.Namespace,
+				Labels:          resources.BuildResourceLabels(jenkins),
+				Annotations:     annotations,
+				OwnerReferences: []metav1.OwnerReference{*ownerReference},
+			},
+			Data: map[string][]byte{SecretKey: bundle},
+		}
+		return errors.WithStack(k8sClient.Create(context.TODO(), secret))
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[CollectedAtAnnotation] = annotations[CollectedAtAnnotation]
+	secret.Data = map[string][]byte{SecretKey: bundle}
+	return errors.WithStack(k8sClient.Update(context.TODO(), secret))
+}
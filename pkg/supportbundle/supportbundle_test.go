@@ -0,0 +1,127 @@
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func extract(t *testing.T, bundle []byte) map[string]string {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(bundle))
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	files := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(tarReader)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func TestCollect(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Status:     v1alpha2.JenkinsStatus{Phase: v1alpha2.JenkinsPhaseDegraded},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins-operator-credentials-example", Namespace: "default", Labels: resources.BuildResourceLabels(jenkins)},
+		Data:       map[string][]byte{"password": []byte("secret-password")},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins-operator-scripts-example", Namespace: "default", Labels: resources.BuildResourceLabels(jenkins)},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "example.1", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Name: "example"},
+		Reason:         "PhaseChanged",
+	}
+
+	t.Run("Jenkins is running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(systemInfoGroovyScript).Return("Jenkins version: 2.235.1\n", nil)
+		jenkinsClient.EXPECT().GetPlugins(fetchPlugins).Return(&gojenkins.Plugins{
+			Raw: &gojenkins.PluginResponse{Plugins: []gojenkins.Plugin{
+				{ShortName: "git", Version: "4.3.0", Active: true, Enabled: true, HasUpdate: true},
+				{ShortName: "blueocean", Version: "1.23.2"},
+			}},
+		}, nil)
+
+		bundle, err := Collect(jenkins, Sources{
+			Client:        fake.NewFakeClient(secret, configMap),
+			Events:        k8sfake.NewSimpleClientset(event).CoreV1(),
+			JenkinsClient: func() (jenkinsclient.Jenkins, error) { return jenkinsClient, nil },
+		})
+
+		require.NoError(t, err)
+		files := extract(t, bundle)
+		assert.Contains(t, files["jenkins.json"], `"phase": "Degraded"`)
+		assert.Contains(t, files["resources/configmaps.json"], "jenkins-operator-scripts-example")
+		assert.Contains(t, files["resources/secrets.json"], `"password": "REDACTED"`)
+		assert.NotContains(t, files["resources/secrets.json"], "secret-password")
+		assert.NotContains(t, files["resources/secrets.json"], "c2VjcmV0LXBhc3N3b3Jk")
+		assert.Contains(t, files["events.json"], "PhaseChanged")
+		assert.Contains(t, files, "operator.log")
+		assert.Equal(t, "Jenkins version: 2.235.1\n", files["jenkins/system-info.txt"])
+		assert.Equal(t, "blueocean:1.23.2 (inactive)\ngit:4.3.0 (update available)\n", files["jenkins/plugins.txt"])
+		assert.NotContains(t, files, "errors.txt")
+	})
+	t.Run("Jenkins is unavailable", func(t *testing.T) {
+		bundle, err := Collect(jenkins, Sources{
+			Client:        fake.NewFakeClient(),
+			Events:        k8sfake.NewSimpleClientset().CoreV1(),
+			JenkinsClient: func() (jenkinsclient.Jenkins, error) { return nil, errors.New("connection refused") },
+		})
+
+		require.NoError(t, err)
+		files := extract(t, bundle)
+		assert.Contains(t, files, "jenkins.json")
+		assert.NotContains(t, files, "jenkins/plugins.txt")
+		assert.Equal(t, "jenkins: connection refused\n", files["errors.txt"])
+	})
+}
+
+func TestStore(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+	fakeClient := fake.NewFakeClient()
+
+	require.NoError(t, Store(fakeClient, jenkins, []byte("first")))
+	require.NoError(t, Store(fakeClient, jenkins, []byte("second")))
+
+	secret := &corev1.Secret{}
+	err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "jenkins-operator-support-bundle-example"}, secret)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), secret.Data[SecretKey])
+	assert.NotEmpty(t, secret.Annotations[CollectedAtAnnotation])
+	require.Len(t, secret.OwnerReferences, 1)
+	assert.Nil(t, secret.OwnerReferences[0].Controller, "the Secret shouldn't be controlled by the operator")
+}
@@ -0,0 +1,2 @@
+// Package vault resolves HashiCorp Vault placeholders in Groovy scripts and Configuration as Code
+package vault
@@ -0,0 +1,271 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TokenSecretKey is key for Vault token in the token authentication method secret
+	TokenSecretKey = "token"
+	// RoleIDSecretKey is key for role ID in the approle authentication method secret
+	RoleIDSecretKey = "roleId"
+	// SecretIDSecretKey is key for secret ID in the approle authentication method secret
+	SecretIDSecretKey = "secretId"
+
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	requestTimeout          = 10 * time.Second
+)
+
+// placeholderRegex matches ${vault:path#key} placeholder, for example ${vault:secret/data/jenkins#password}
+var placeholderRegex = regexp.MustCompile(`\$\{vault:([^#}]+)#([^}]+)\}`)
+
+// Resolver resolves Vault placeholders
+type Resolver interface {
+	Resolve(content string) (string, error)
+}
+
+type resolver struct {
+	k8sClient               k8s.Client
+	jenkins                 *v1alpha2.Jenkins
+	httpClient              *http.Client
+	serviceAccountTokenPath string
+	token                   string
+	secrets                 map[string]map[string]interface{}
+}
+
+// New creates new instance of Resolver
+func New(k8sClient k8s.Client, jenkins *v1alpha2.Jenkins) Resolver {
+	return &resolver{
+		k8sClient:               k8sClient,
+		jenkins:                 jenkins,
+		httpClient:              &http.Client{Timeout: requestTimeout},
+		serviceAccountTokenPath: serviceAccountTokenPath,
+		secrets:                 map[string]map[string]interface{}{},
+	}
+}
+
+// HasPlaceholders returns true if content contains Vault placeholders
+func HasPlaceholders(content string) bool {
+	return placeholderRegex.MatchString(content)
+}
+
+// Resolve replaces all ${vault:path#key} placeholders in content with values read from Vault,
+// every secret is read from Vault only once by the Resolver instance
+func (r *resolver) Resolve(content string) (string, error) {
+	if !HasPlaceholders(content) {
+		return content, nil
+	}
+	if r.jenkins.Spec.Vault == nil {
+		return "", errors.New("found Vault placeholders but spec.vault is not configured")
+	}
+
+	var resolveErr error
+	resolved := placeholderRegex.ReplaceAllStringFunc(content, func(placeholder string) string {
+		if resolveErr != nil {
+			return placeholder
+		}
+		matches := placeholderRegex.FindStringSubmatch(placeholder)
+		value, err := r.read(strings.Trim(matches[1], "/"), matches[2])
+		if err != nil {
+			resolveErr = err
+			return placeholder
+		}
+		return value
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	return resolved, nil
+}
+
+func (r *resolver) read(path, key string) (string, error) {
+	data, found := r.secrets[path]
+	if !found {
+		if err := r.login(); err != nil {
+			return "", err
+		}
+
+		response := struct {
+			Data map[string]interface{} `json:"data"`
+		}{}
+		if err := r.do(http.MethodGet, "/v1/"+path, nil, &response); err != nil {
+			return "", errors.Wrapf(err, "couldn't read Vault secret '%s'", path)
+		}
+		data = response.Data
+		// KV secrets engine version 2 nests secret data
+		if nested, ok := data["data"].(map[string]interface{}); ok {
+			if _, hasMetadata := data["metadata"]; hasMetadata {
+				data = nested
+			}
+		}
+		r.secrets[path] = data
+	}
+
+	value, found := data[key]
+	if !found {
+		return "", errors.Errorf("key '%s' not found in Vault secret '%s'", key, path)
+	}
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(encoded), nil
+}
+
+func (r *resolver) login() error {
+	if len(r.token) > 0 {
+		return nil
+	}
+
+	auth := r.jenkins.Spec.Vault.Auth
+	mountPath := auth.MountPath
+	if len(mountPath) == 0 {
+		mountPath = string(auth.Method)
+	}
+
+	var body map[string]string
+	switch auth.Method {
+	case v1alpha2.VaultTokenAuthMethod:
+		secret, err := r.getSecret(auth.Secret.Name)
+		if err != nil {
+			return err
+		}
+		r.token = string(secret.Data[TokenSecretKey])
+		return nil
+	case v1alpha2.VaultKubernetesAuthMethod:
+		jwt, err := ioutil.ReadFile(r.serviceAccountTokenPath)
+		if err != nil {
+			return errors.Wrap(err, "couldn't read the operator service account token")
+		}
+		body = map[string]string{"role": auth.Role, "jwt": string(jwt)}
+	case v1alpha2.VaultAppRoleAuthMethod:
+		secret, err := r.getSecret(auth.Secret.Name)
+		if err != nil {
+			return err
+		}
+		body = map[string]string{"role_id": string(secret.Data[RoleIDSecretKey]), "secret_id": string(secret.Data[SecretIDSecretKey])}
+	default:
+		return errors.Errorf("unsupported Vault auth method '%s'", auth.Method)
+	}
+
+	response := struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}{}
+	if err := r.do(http.MethodPost, fmt.Sprintf("/v1/auth/%s/login", strings.Trim(mountPath, "/")), body, &response); err != nil {
+		return errors.Wrapf(err, "couldn't log in to Vault using '%s' auth method", auth.Method)
+	}
+	r.token = response.Auth.ClientToken
+	return nil
+}
+
+func (r *resolver) do(method, path string, body interface{}, result interface{}) error {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		if requestBody, err = json.Marshal(body); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	request, err := http.NewRequest(method, strings.TrimSuffix(r.jenkins.Spec.Vault.Address, "/")+path, bytes.NewReader(requestBody))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if len(r.token) > 0 {
+		request.Header.Set("X-Vault-Token", r.token)
+	}
+	if len(r.jenkins.Spec.Vault.Namespace) > 0 {
+		request.Header.Set("X-Vault-Namespace", r.jenkins.Spec.Vault.Namespace)
+	}
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("invalid response status code '%d'", response.StatusCode)
+	}
+
+	return errors.WithStack(json.NewDecoder(response.Body).Decode(result))
+}
+
+func (r *resolver) getSecret(name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := r.k8sClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.jenkins.Namespace}, secret)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return secret, nil
+}
+
+// Validate verifies Vault configuration
+func Validate(k8sClient k8s.Client, jenkins v1alpha2.Jenkins) ([]string, error) {
+	var messages []string
+	vault := jenkins.Spec.Vault
+	if vault == nil {
+		return messages, nil
+	}
+
+	if !strings.HasPrefix(vault.Address, "http://") && !strings.HasPrefix(vault.Address, "https://") {
+		messages = append(messages, fmt.Sprintf("spec.vault.address '%s' must start with http:// or https://", vault.Address))
+	}
+
+	var requiredKeys []string
+	switch vault.Auth.Method {
+	case v1alpha2.VaultTokenAuthMethod:
+		requiredKeys = []string{TokenSecretKey}
+	case v1alpha2.VaultAppRoleAuthMethod:
+		requiredKeys = []string{RoleIDSecretKey, SecretIDSecretKey}
+	case v1alpha2.VaultKubernetesAuthMethod:
+		if len(vault.Auth.Role) == 0 {
+			messages = append(messages, "spec.vault.auth.role can't be empty for kubernetes auth method")
+		}
+		return messages, nil
+	default:
+		return append(messages, fmt.Sprintf("spec.vault.auth.method '%s' is invalid, supported methods: %s, %s, %s", vault.Auth.Method,
+			v1alpha2.VaultTokenAuthMethod, v1alpha2.VaultKubernetesAuthMethod, v1alpha2.VaultAppRoleAuthMethod)), nil
+	}
+
+	if len(vault.Auth.Secret.Name) == 0 {
+		return append(messages, fmt.Sprintf("spec.vault.auth.secret can't be empty for %s auth method", vault.Auth.Method)), nil
+	}
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: vault.Auth.Secret.Name, Namespace: jenkins.Namespace}, secret)
+	if err != nil && apierrors.IsNotFound(err) {
+		return append(messages, fmt.Sprintf("Secret '%s' defined in spec.vault.auth.secret not found", vault.Auth.Secret.Name)), nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, key := range requiredKeys {
+		if len(secret.Data[key]) == 0 {
+			messages = append(messages, fmt.Sprintf("required data '%s' not found in secret '%s'", key, secret.Name))
+		}
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,168 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func vaultServer(t *testing.T, reads *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login", "/v1/auth/approle/login":
+			body := map[string]string{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body["jwt"] != "service-account-token" && body["secret_id"] != "secret-id" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth": {"client_token": "client-token"}}`))
+		case "/v1/secret/data/jenkins":
+			*reads++
+			if r.Header.Get("X-Vault-Token") != "client-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": {"data": {"password": "p4ssw0rd", "port": 8080}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/jenkins":
+			_, _ = w.Write([]byte(`{"data": {"user": "admin"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func jenkinsWithVault(address string, auth v1alpha2.VaultAuth) *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Vault: &v1alpha2.Vault{Address: address, Auth: auth},
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("token auth method and KV version 2", func(t *testing.T) {
+		reads := 0
+		server := vaultServer(t, &reads)
+		defer server.Close()
+		fakeClient := fake.NewFakeClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+			Data:       map[string][]byte{TokenSecretKey: []byte("client-token")},
+		})
+		jenkins := jenkinsWithVault(server.URL, v1alpha2.VaultAuth{Method: v1alpha2.VaultTokenAuthMethod, Secret: v1alpha2.SecretRef{Name: "vault"}})
+
+		resolved, err := New(fakeClient, jenkins).Resolve("password: ${vault:secret/data/jenkins#password}\nport: ${vault:secret/data/jenkins#port}")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "password: p4ssw0rd\nport: 8080", resolved)
+		assert.Equal(t, 1, reads)
+	})
+	t.Run("kubernetes auth method", func(t *testing.T) {
+		reads := 0
+		server := vaultServer(t, &reads)
+		defer server.Close()
+		tokenFile, err := ioutil.TempFile("", "token")
+		require.NoError(t, err)
+		defer func() { _ = os.Remove(tokenFile.Name()) }()
+		_, err = tokenFile.WriteString("service-account-token")
+		require.NoError(t, err)
+		jenkins := jenkinsWithVault(server.URL, v1alpha2.VaultAuth{Method: v1alpha2.VaultKubernetesAuthMethod, Role: "jenkins"})
+
+		r := New(fake.NewFakeClient(), jenkins).(*resolver)
+		r.serviceAccountTokenPath = tokenFile.Name()
+		resolved, err := r.Resolve("${vault:secret/data/jenkins#password}")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "p4ssw0rd", resolved)
+	})
+	t.Run("approle auth method and KV version 1", func(t *testing.T) {
+		reads := 0
+		server := vaultServer(t, &reads)
+		defer server.Close()
+		fakeClient := fake.NewFakeClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+			Data:       map[string][]byte{RoleIDSecretKey: []byte("role-id"), SecretIDSecretKey: []byte("secret-id")},
+		})
+		jenkins := jenkinsWithVault(server.URL, v1alpha2.VaultAuth{Method: v1alpha2.VaultAppRoleAuthMethod, Secret: v1alpha2.SecretRef{Name: "vault"}})
+
+		resolved, err := New(fakeClient, jenkins).Resolve("${vault:/kv/jenkins#user}")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "admin", resolved)
+	})
+	t.Run("key not found", func(t *testing.T) {
+		reads := 0
+		server := vaultServer(t, &reads)
+		defer server.Close()
+		fakeClient := fake.NewFakeClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+			Data:       map[string][]byte{TokenSecretKey: []byte("client-token")},
+		})
+		jenkins := jenkinsWithVault(server.URL, v1alpha2.VaultAuth{Method: v1alpha2.VaultTokenAuthMethod, Secret: v1alpha2.SecretRef{Name: "vault"}})
+
+		_, err := New(fakeClient, jenkins).Resolve("${vault:secret/data/jenkins#missing}")
+
+		assert.EqualError(t, err, "key 'missing' not found in Vault secret 'secret/data/jenkins'")
+	})
+	t.Run("without placeholders", func(t *testing.T) {
+		resolved, err := New(fake.NewFakeClient(), &v1alpha2.Jenkins{}).Resolve("jenkins:\n  systemMessage: ${SYSTEM_MESSAGE}")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jenkins:\n  systemMessage: ${SYSTEM_MESSAGE}", resolved)
+	})
+	t.Run("Vault not configured", func(t *testing.T) {
+		_, err := New(fake.NewFakeClient(), &v1alpha2.Jenkins{}).Resolve("${vault:secret/data/jenkins#password}")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		fakeClient := fake.NewFakeClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+			Data:       map[string][]byte{RoleIDSecretKey: []byte("role-id"), SecretIDSecretKey: []byte("secret-id")},
+		})
+		jenkins := jenkinsWithVault("https://vault:8200", v1alpha2.VaultAuth{Method: v1alpha2.VaultAppRoleAuthMethod, Secret: v1alpha2.SecretRef{Name: "vault"}})
+
+		messages, err := Validate(fakeClient, *jenkins)
+
+		assert.NoError(t, err)
+		assert.Nil(t, messages)
+	})
+	t.Run("invalid address and missing role", func(t *testing.T) {
+		jenkins := jenkinsWithVault("vault:8200", v1alpha2.VaultAuth{Method: v1alpha2.VaultKubernetesAuthMethod})
+
+		messages, err := Validate(fake.NewFakeClient(), *jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"spec.vault.address 'vault:8200' must start with http:// or https://",
+			"spec.vault.auth.role can't be empty for kubernetes auth method",
+		}, messages)
+	})
+	t.Run("missing secret key", func(t *testing.T) {
+		fakeClient := fake.NewFakeClient()
+		err := fakeClient.Create(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"}})
+		require.NoError(t, err)
+		jenkins := jenkinsWithVault("https://vault:8200", v1alpha2.VaultAuth{Method: v1alpha2.VaultTokenAuthMethod, Secret: v1alpha2.SecretRef{Name: "vault"}})
+
+		messages, err := Validate(fakeClient, *jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"required data 'token' not found in secret 'vault'"}, messages)
+	})
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ConversionPath is the path of the conversion webhook between API versions of Jenkins CRs
+const ConversionPath = "/convert"
+
+// legacyAPIVersion is the deprecated version of Jenkins CRs, it has the same schema as v1alpha2
+const legacyAPIVersion = "jenkins.io/v1alpha1"
+
+// NewConversionWebhook returns the webhook which converts Jenkins CRs between v1alpha1, v1alpha2 and v1beta1,
+// v1alpha2 is the hub version. v1alpha1 CRs are converted by changing the API version only, as before the webhook
+// conversion strategy of the CRD.
+func NewConversionWebhook() http.Handler {
+	return &conversionWebhook{}
+}
+
+type conversionWebhook struct{}
+
+// conversionReview is the apiextensions.k8s.io/v1beta1 ConversionReview sent by the API server
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+func (wh *conversionWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &conversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil || review.Request == nil {
+		logger.Error(err, "failed to read conversion request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	review.Response = convert(review.Request)
+	review.Request = nil
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		logger.Error(err, "failed to write conversion response")
+	}
+}
+
+func convert(request *conversionRequest) *conversionResponse {
+	response := &conversionResponse{UID: request.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+	for _, object := range request.Objects {
+		converted, err := convertJenkins(object.Raw, request.DesiredAPIVersion)
+		if err != nil {
+			logger.Error(err, "failed to convert Jenkins CR", "request", request.UID)
+			return &conversionResponse{UID: request.UID, Result: metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}}
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+
+	return response
+}
+
+// convertJenkins converts the Jenkins CR to the desired API version through the v1alpha2 hub version
+func convertJenkins(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+	apiVersion, _ := object["apiVersion"].(string)
+	if isHubSchema(apiVersion) && isHubSchema(desiredAPIVersion) {
+		object["apiVersion"] = desiredAPIVersion
+		return json.Marshal(object)
+	}
+
+	hub := &v1alpha2.Jenkins{}
+	switch {
+	case isHubSchema(apiVersion):
+		if err := json.Unmarshal(raw, hub); err != nil {
+			return nil, err
+		}
+	case apiVersion == v1beta1.SchemeGroupVersion.String():
+		jenkins := &v1beta1.Jenkins{}
+		if err := json.Unmarshal(raw, jenkins); err != nil {
+			return nil, err
+		}
+		if err := jenkins.ConvertTo(hub); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported API version '%s' of Jenkins CR", apiVersion)
+	}
+
+	switch {
+	case isHubSchema(desiredAPIVersion):
+		hub.APIVersion = desiredAPIVersion
+		return json.Marshal(hub)
+	case desiredAPIVersion == v1beta1.SchemeGroupVersion.String():
+		jenkins := &v1beta1.Jenkins{}
+		if err := jenkins.ConvertFrom(hub); err != nil {
+			return nil, err
+		}
+		return json.Marshal(jenkins)
+	default:
+		return nil, fmt.Errorf("unsupported desired API version '%s' of Jenkins CR", desiredAPIVersion)
+	}
+}
+
+// isHubSchema returns true for API versions which have the same schema as the v1alpha2 hub version
+func isHubSchema(apiVersion string) bool {
+	return apiVersion == v1alpha2.SchemeGroupVersion.String() || apiVersion == legacyAPIVersion
+}
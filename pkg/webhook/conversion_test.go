@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func serveConversion(t *testing.T, desiredAPIVersion string, objects ...interface{}) *conversionResponse {
+	request := &conversionRequest{UID: "uid", DesiredAPIVersion: desiredAPIVersion}
+	for _, object := range objects {
+		raw, err := json.Marshal(object)
+		require.NoError(t, err)
+		request.Objects = append(request.Objects, runtime.RawExtension{Raw: raw})
+	}
+	body, err := json.Marshal(&conversionReview{TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1beta1", Kind: "ConversionReview"}, Request: request})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	NewConversionWebhook().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ConversionPath, bytes.NewReader(body)))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	review := &conversionReview{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), review))
+	require.NotNil(t, review.Response)
+	assert.Nil(t, review.Request)
+	assert.Equal(t, request.UID, review.Response.UID)
+	return review.Response
+}
+
+func TestConversionWebhook(t *testing.T) {
+	hub := &v1alpha2.Jenkins{
+		TypeMeta:   v1alpha2.JenkinsTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master:  v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: "jenkins-master"}}},
+			Service: v1alpha2.Service{Type: corev1.ServiceTypeNodePort, Port: 8080},
+		},
+	}
+
+	t.Run("v1alpha2 to v1beta1 and back", func(t *testing.T) {
+		response := serveConversion(t, v1beta1.SchemeGroupVersion.String(), hub)
+
+		require.Equal(t, metav1.StatusSuccess, response.Result.Status, response.Result.Message)
+		require.Len(t, response.ConvertedObjects, 1)
+		jenkins := &v1beta1.Jenkins{}
+		require.NoError(t, json.Unmarshal(response.ConvertedObjects[0].Raw, jenkins))
+		assert.Equal(t, v1beta1.SchemeGroupVersion.String(), jenkins.APIVersion)
+		assert.Equal(t, hub.Spec.Service, jenkins.Spec.Ingress.Service)
+
+		response = serveConversion(t, v1alpha2.SchemeGroupVersion.String(), jenkins)
+
+		require.Equal(t, metav1.StatusSuccess, response.Result.Status, response.Result.Message)
+		converted := &v1alpha2.Jenkins{}
+		require.NoError(t, json.Unmarshal(response.ConvertedObjects[0].Raw, converted))
+		assert.Equal(t, hub, converted)
+	})
+	t.Run("v1alpha1 to v1alpha2 changes only the API version", func(t *testing.T) {
+		legacy := map[string]interface{}{
+			"apiVersion": legacyAPIVersion,
+			"kind":       "Jenkins",
+			"metadata":   map[string]interface{}{"name": "example"},
+			"spec":       map[string]interface{}{"unknownField": "value"},
+		}
+
+		response := serveConversion(t, v1alpha2.SchemeGroupVersion.String(), legacy)
+
+		require.Equal(t, metav1.StatusSuccess, response.Result.Status, response.Result.Message)
+		converted := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(response.ConvertedObjects[0].Raw, &converted))
+		legacy["apiVersion"] = v1alpha2.SchemeGroupVersion.String()
+		assert.Equal(t, legacy, converted)
+	})
+	t.Run("v1beta1 to v1alpha1", func(t *testing.T) {
+		jenkins := &v1beta1.Jenkins{}
+		require.NoError(t, jenkins.ConvertFrom(hub))
+
+		response := serveConversion(t, legacyAPIVersion, jenkins)
+
+		require.Equal(t, metav1.StatusSuccess, response.Result.Status, response.Result.Message)
+		converted := &v1alpha2.Jenkins{}
+		require.NoError(t, json.Unmarshal(response.ConvertedObjects[0].Raw, converted))
+		assert.Equal(t, legacyAPIVersion, converted.APIVersion)
+		assert.Equal(t, hub.Spec, converted.Spec)
+	})
+	t.Run("unsupported version", func(t *testing.T) {
+		response := serveConversion(t, "jenkins.io/v2", hub)
+
+		assert.Equal(t, metav1.StatusFailure, response.Result.Status)
+		assert.Contains(t, response.Result.Message, "unsupported desired API version 'jenkins.io/v2'")
+		assert.Empty(t, response.ConvertedObjects)
+	})
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/defaults"
+
+	"k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// MutatingPath is the path of the mutating admission webhook of Jenkins CRs
+const MutatingPath = "/mutate-jenkins-io-v1alpha2-jenkins"
+
+// NewMutatingWebhook returns the mutating admission webhook which sets default values of Jenkins CRs.
+func NewMutatingWebhook(useNodePort bool) *admission.Webhook {
+	return &admission.Webhook{Handler: &jenkinsDefaulter{useNodePort: useNodePort}}
+}
+
+type jenkinsDefaulter struct {
+	decoder     *admission.Decoder
+	useNodePort bool
+}
+
+var _ admission.DecoderInjector = &jenkinsDefaulter{}
+
+// InjectDecoder injects the decoder.
+func (d *jenkinsDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.decoder = decoder
+	return nil
+}
+
+// Handle sets default values of Jenkins CR from the admission request.
+func (d *jenkinsDefaulter) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != v1beta1.Create && req.Operation != v1beta1.Update {
+		return admission.Allowed("")
+	}
+
+	jenkins := &v1alpha2.Jenkins{}
+	if err := d.decoder.Decode(req, jenkins); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	changed, err := defaults.SetDefaults(jenkins, d.useNodePort)
+	if err != nil {
+		// invalid CR is rejected by the validating webhook
+		return admission.Allowed("")
+	}
+	if !changed {
+		return admission.Allowed("")
+	}
+
+	marshaled, err := json.Marshal(jenkins)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/defaults"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestJenkinsDefaulter_Handle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(scheme))
+	decoder, err := admission.NewDecoder(scheme)
+	require.NoError(t, err)
+	defaulter := NewMutatingWebhook(false).Handler.(*jenkinsDefaulter)
+	require.NoError(t, defaulter.InjectDecoder(decoder))
+
+	newRequest := func(jenkins *v1alpha2.Jenkins) admission.Request {
+		jenkins.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha2.SchemeGroupVersion.String(), Kind: v1alpha2.Kind}
+		raw, err := json.Marshal(jenkins)
+		require.NoError(t, err)
+		return admission.Request{AdmissionRequest: v1beta1.AdmissionRequest{
+			Operation: v1beta1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+	}
+
+	t.Run("defaults are set", func(t *testing.T) {
+		response := defaulter.Handle(context.TODO(), newRequest(&v1alpha2.Jenkins{}))
+
+		assert.True(t, response.Allowed)
+		assert.NotEmpty(t, response.Patches)
+	})
+	t.Run("defaults are already set", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{}
+		_, err := defaults.SetDefaults(jenkins, false)
+		require.NoError(t, err)
+
+		response := defaulter.Handle(context.TODO(), newRequest(jenkins))
+
+		assert.True(t, response.Allowed)
+		assert.Empty(t, response.Patches)
+	})
+	t.Run("invalid CR", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: "backup"}}},
+			},
+		}
+
+		response := defaulter.Handle(context.TODO(), newRequest(jenkins))
+
+		assert.True(t, response.Allowed)
+		assert.Empty(t, response.Patches)
+	})
+}
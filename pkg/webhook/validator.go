@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ValidatingPath is the path of the validating admission webhook of Jenkins CRs
+const ValidatingPath = "/validate-jenkins-io-v1alpha2-jenkins"
+
+var logger = log.Log.WithName("webhook")
+
+// NewValidatingWebhook returns the validating admission webhook which rejects invalid Jenkins CRs.
+func NewValidatingWebhook() *admission.Webhook {
+	return &admission.Webhook{Handler: &jenkinsValidator{}}
+}
+
+type jenkinsValidator struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &jenkinsValidator{}
+
+// InjectDecoder injects the decoder.
+func (v *jenkinsValidator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+// Handle validates Jenkins CR from the admission request.
+func (v *jenkinsValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != v1beta1.Create && req.Operation != v1beta1.Update {
+		return admission.Allowed("")
+	}
+
+	jenkins := &v1alpha2.Jenkins{}
+	if err := v.decoder.Decode(req, jenkins); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if messages := Validate(jenkins); len(messages) > 0 {
+		logger.V(log.VDebug).Info(fmt.Sprintf("Jenkins '%s/%s' has been rejected: %s", req.Namespace, jenkins.Name, strings.Join(messages, "; ")))
+		return admission.Denied(strings.Join(messages, "; "))
+	}
+
+	return admission.Allowed("")
+}
+
+// Validate validates Jenkins CR without access to the Kubernetes API, references to other resources
+// are validated later by the reconcile loop.
+func Validate(jenkins *v1alpha2.Jenkins) []string {
+	var messages []string
+
+	containers := jenkins.Spec.Master.Containers
+	if len(containers) > 0 && containers[0].Name != resources.JenkinsMasterContainerName {
+		messages = append(messages, fmt.Sprintf("first container in spec.master.containers must be Jenkins container with name '%s'", resources.JenkinsMasterContainerName))
+	}
+
+	for _, plugin := range append(jenkins.Spec.Master.BasePlugins, jenkins.Spec.Master.Plugins...) {
+		if _, err := plugins.NewPlugin(plugin.Name, plugin.Version, plugin.DownloadURL); err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+
+	ids := map[string]bool{}
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		if len(seedJob.ID) == 0 {
+			messages = append(messages, "seed job id can't be empty")
+		} else if ids[seedJob.ID] {
+			messages = append(messages, fmt.Sprintf("'%s' seed job ID is not unique", seedJob.ID))
+		}
+		ids[seedJob.ID] = true
+	}
+
+	// the backup interval is defaulted by the operator
+	backupJenkins := jenkins.DeepCopy()
+	if backupJenkins.Spec.Backup.Interval == 0 {
+		backupJenkins.Spec.Backup.Interval = 30
+	}
+	backupAndRestore := backuprestore.New(configuration.Configuration{Jenkins: backupJenkins}, logger)
+	messages = append(messages, backupAndRestore.Validate()...)
+
+	if jenkins.Spec.ReconcileInterval != nil && jenkins.Spec.ReconcileInterval.Duration < 0 {
+		messages = append(messages, fmt.Sprintf("spec.reconcileInterval '%s' must not be negative", jenkins.Spec.ReconcileInterval.Duration))
+	}
+
+	return messages
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis"
+	"github.com/jenkinsci/kubernetes-operator/pkg/apis/jenkins/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName}},
+					Plugins:    []v1alpha2.Plugin{{Name: "simple-theme-plugin", Version: "0.5.1"}},
+				},
+				SeedJobs: []v1alpha2.SeedJob{{ID: "jenkins-operator"}},
+			},
+		}
+
+		assert.Empty(t, Validate(jenkins))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: "backup"}},
+					Plugins:    []v1alpha2.Plugin{{Name: "simple-theme-plugin", Version: "latest!"}},
+				},
+				SeedJobs: []v1alpha2.SeedJob{{ID: "jenkins-operator"}, {ID: "jenkins-operator"}},
+				Backup:   v1alpha2.Backup{ContainerName: "backup"},
+			},
+		}
+
+		messages := Validate(jenkins)
+
+		assert.Contains(t, messages, "first container in spec.master.containers must be Jenkins container with name 'jenkins-master'")
+		assert.Contains(t, messages, "'jenkins-operator' seed job ID is not unique")
+		assert.Contains(t, messages, "spec.backup.action.exec is not configured")
+		assert.Len(t, messages, 4)
+	})
+}
+
+func TestJenkinsValidator_Handle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(scheme))
+	decoder, err := admission.NewDecoder(scheme)
+	require.NoError(t, err)
+	validator := &jenkinsValidator{}
+	require.NoError(t, validator.InjectDecoder(decoder))
+
+	newRequest := func(operation v1beta1.Operation, jenkins *v1alpha2.Jenkins) admission.Request {
+		jenkins.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha2.SchemeGroupVersion.String(), Kind: v1alpha2.Kind}
+		raw, err := json.Marshal(jenkins)
+		require.NoError(t, err)
+		return admission.Request{AdmissionRequest: v1beta1.AdmissionRequest{
+			Operation: operation,
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		response := validator.Handle(context.TODO(), newRequest(v1beta1.Create, &v1alpha2.Jenkins{}))
+
+		assert.True(t, response.Allowed)
+	})
+	t.Run("denied", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{SeedJobs: []v1alpha2.SeedJob{{}}}}
+
+		response := validator.Handle(context.TODO(), newRequest(v1beta1.Update, jenkins))
+
+		assert.False(t, response.Allowed)
+		assert.Equal(t, metav1.StatusReason("seed job id can't be empty"), response.Result.Reason)
+	})
+	t.Run("delete", func(t *testing.T) {
+		response := validator.Handle(context.TODO(), admission.Request{AdmissionRequest: v1beta1.AdmissionRequest{Operation: v1beta1.Delete}})
+
+		assert.True(t, response.Allowed)
+	})
+}
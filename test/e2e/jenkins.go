@@ -170,7 +170,7 @@ func createJenkinsAPIClientFromServiceAccount(t *testing.T, jenkins *v1alpha2.Je
 		return nil, err
 	}
 
-	return jenkinsclient.NewBearerTokenAuthorization(jenkinsAPIURL, token.String())
+	return jenkinsclient.NewBearerTokenAuthorization(jenkinsAPIURL, token.String(), jenkinsclient.JenkinsAPIConnectionSettings{})
 }
 
 func createJenkinsAPIClientFromSecret(t *testing.T, jenkins *v1alpha2.Jenkins, jenkinsAPIURL string) (jenkinsclient.Jenkins, error) {
@@ -186,6 +186,7 @@ func createJenkinsAPIClientFromSecret(t *testing.T, jenkins *v1alpha2.Jenkins, j
 		jenkinsAPIURL,
 		string(adminSecret.Data[resources.OperatorCredentialsSecretUserNameKey]),
 		string(adminSecret.Data[resources.OperatorCredentialsSecretTokenKey]),
+		jenkinsclient.JenkinsAPIConnectionSettings{},
 	)
 }
 